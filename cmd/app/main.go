@@ -15,7 +15,10 @@ func run(ctx context.Context) error {
 	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt)
 	defer cancel()
 
-	server := app.NewServer()
+	server, jobRunner, eventHub := app.NewServer()
+
+	jobRunner.Start()
+	eventHub.Start()
 
 	go func() {
 		log.Printf("listening on %s\n", server.Addr)
@@ -32,6 +35,15 @@ func run(ctx context.Context) error {
 	if err := server.Shutdown(shutdownCtx); err != nil {
 		log.Fatalf("failed to shutdown app: %s\n", err)
 	}
+
+	// Running jobs get their own grace period to finish, the ones that don't
+	// are cancelled and run again after the next start.
+	drainCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := jobRunner.Shutdown(drainCtx); err != nil {
+		log.Printf("failed to drain jobs: %s\n", err)
+	}
 	log.Println("app shutdown complete")
 
 	return nil
@@ -0,0 +1,51 @@
+// Command reconcile compares the stored assets against the files in the
+// database and prints the orphaned objects and the files missing their
+// asset as JSON. It's a dry run unless -delete is given.
+package main
+
+import (
+	"app/pkg/app"
+	"app/pkg/database"
+	"app/pkg/platform/config"
+	"app/pkg/reconcile"
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+)
+
+func run(ctx context.Context, deleteOrphans bool) error {
+	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt)
+	defer cancel()
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	fileStorage := app.NewFileStorage(cfg.Storage)
+	pool := app.NewDatabase(cfg.Database)
+	defer pool.Close()
+
+	service := reconcile.NewService(reconcile.NewRepository(database.New(pool)), fileStorage, cfg.Storage.OrphanGracePeriod, deleteOrphans)
+
+	report, err := service.Reconcile(ctx, !deleteOrphans)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+func main() {
+	deleteOrphans := flag.Bool("delete", false, "delete orphaned objects instead of only reporting them")
+	flag.Parse()
+
+	if err := run(context.Background(), *deleteOrphans); err != nil {
+		log.Fatalf("%s\n", err)
+	}
+}
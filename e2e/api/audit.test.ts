@@ -0,0 +1,44 @@
+import { expect, test } from "../src/fixtures";
+
+// The test user isn't an instance admin, the audit log is closed to them.
+test.describe("Audit", () => {
+  test.describe("List audit events", () => {
+    test("should return 403 for a non-admin", async ({ request }) => {
+      const response = await request.get("/api/v1/audit");
+
+      expect(response.status()).toBe(403);
+    });
+
+    test("should return 403 for a CSV export by a non-admin", async ({ request }) => {
+      const response = await request.get("/api/v1/audit?format=csv");
+
+      expect(response.status()).toBe(403);
+    });
+
+    test("should return 401 without a token", async ({ anonymousRequest }) => {
+      const response = await anonymousRequest.get("/api/v1/audit");
+
+      expect(response.status()).toBe(401);
+    });
+
+    test("should return 400 for an invalid resource type", async ({ request }) => {
+      const response = await request.get("/api/v1/audit?resourceType=planet");
+
+      expect(response.status()).toBe(400);
+    });
+
+    test("should accept the membership and link resource types", async ({ request }) => {
+      for (const resourceType of ["membership", "share_link", "short_link"]) {
+        const response = await request.get(`/api/v1/audit?resourceType=${resourceType}&action=revoke`);
+
+        expect(response.status()).toBe(403);
+      }
+    });
+
+    test("should return 400 for an invalid time", async ({ request }) => {
+      const response = await request.get("/api/v1/audit?from=yesterday");
+
+      expect(response.status()).toBe(400);
+    });
+  });
+});
@@ -11,8 +11,8 @@ test.describe("Auth", () => {
     expect(response.status()).toBe(401);
   });
 
-  test("should return 401 for missing token", async ({ request }) => {
-    const response = await request.get("/api/v1/users/me/token-info");
+  test("should return 401 for missing token", async ({ anonymousRequest }) => {
+    const response = await anonymousRequest.get("/api/v1/users/me/token-info");
 
     expect(response.status()).toBe(401);
   });
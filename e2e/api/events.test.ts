@@ -0,0 +1,155 @@
+import { expect, test } from "../src/fixtures";
+
+type StreamedEvent = {
+  id: string;
+  event: string;
+  data: {
+    id: string;
+    event: string;
+    occurredAt: string;
+    projectIds: number[];
+    data?: any;
+  };
+};
+
+type Stream = {
+  status: number;
+  events: StreamedEvent[];
+  comments: string[];
+  close: () => void;
+};
+
+// The API request context buffers whole responses, the endless stream is read
+// with fetch instead.
+const openStream = async (baseURL: string, token: string, lastEventId?: string): Promise<Stream> => {
+  const controller = new AbortController();
+  const headers: Record<string, string> = { Authorization: `Bearer ${token}` };
+  if (lastEventId) {
+    headers["Last-Event-ID"] = lastEventId;
+  }
+  const response = await fetch(`${baseURL}/api/v1/events`, { headers, signal: controller.signal });
+
+  const stream: Stream = { status: response.status, events: [], comments: [], close: () => controller.abort() };
+  if (!response.ok || !response.body) {
+    return stream;
+  }
+
+  (async () => {
+    const decoder = new TextDecoder();
+    let buffer = "";
+    try {
+      for await (const chunk of response.body as any) {
+        buffer += decoder.decode(chunk, { stream: true });
+        let end: number;
+        while ((end = buffer.indexOf("\n\n")) >= 0) {
+          const block = buffer.slice(0, end);
+          buffer = buffer.slice(end + 2);
+
+          const fields: Record<string, string> = {};
+          for (const line of block.split("\n")) {
+            if (line.startsWith(":")) {
+              stream.comments.push(line.slice(1).trim());
+              continue;
+            }
+            const colon = line.indexOf(":");
+            fields[line.slice(0, colon)] = line.slice(colon + 1).trim();
+          }
+          if (fields.data) {
+            stream.events.push({ id: fields.id, event: fields.event, data: JSON.parse(fields.data) });
+          }
+        }
+      }
+    } catch {
+      // Aborted by close.
+    }
+  })();
+
+  return stream;
+};
+
+// Deliveries take a round trip through Postgres, they arrive shortly after the
+// change.
+const eventTimeout = 10_000;
+
+test.describe("Events", () => {
+  test("should stream the events of own projects", async ({ baseURL, defaultToken, createProject, createVersion }) => {
+    const stream = await openStream(baseURL, defaultToken);
+    expect(stream.status).toBe(200);
+
+    try {
+      const project = await createProject();
+      const version = await createVersion({ projectId: project.id });
+
+      const ofProject = () => stream.events.filter((e) => e.data.projectIds.includes(project.id));
+      await expect.poll(() => ofProject().map((e) => e.event), { timeout: eventTimeout }).toEqual([
+        "project.created",
+        "version.created",
+      ]);
+
+      const [created, versionCreated] = ofProject();
+      expect(created.id).toBe(created.data.id);
+      expect(created.data).toMatchObject({ event: "project.created", data: { id: project.id, slug: project.slug } });
+      expect(versionCreated.data.data).toMatchObject({ id: version.id, projectId: project.id });
+    } finally {
+      stream.close();
+    }
+  });
+
+  test("should send heartbeats", async ({ baseURL, defaultToken }) => {
+    const stream = await openStream(baseURL, defaultToken);
+    expect(stream.status).toBe(200);
+
+    try {
+      await expect.poll(() => stream.comments, { timeout: eventTimeout }).toContain("heartbeat");
+    } finally {
+      stream.close();
+    }
+  });
+
+  test("should replay the events after the last event ID", async ({ baseURL, defaultToken, createProject, createVersion }) => {
+    const stream = await openStream(baseURL, defaultToken);
+    expect(stream.status).toBe(200);
+
+    const project = await createProject();
+    const ofProject = (events: StreamedEvent[]) => events.filter((e) => e.data.projectIds.includes(project.id));
+    let created: StreamedEvent;
+    try {
+      await expect.poll(() => ofProject(stream.events).length, { timeout: eventTimeout }).toBe(1);
+      [created] = ofProject(stream.events);
+    } finally {
+      stream.close();
+    }
+
+    // Missed while disconnected.
+    const version = await createVersion({ projectId: project.id });
+
+    const resumed = await openStream(baseURL, defaultToken, created.id);
+    expect(resumed.status).toBe(200);
+    try {
+      await expect.poll(() => ofProject(resumed.events).map((e) => e.event), { timeout: eventTimeout }).toEqual([
+        "version.created",
+      ]);
+      expect(ofProject(resumed.events)[0].data.data).toMatchObject({ id: version.id });
+    } finally {
+      resumed.close();
+    }
+  });
+
+  test("should reset instead of replaying for an unknown last event ID", async ({ baseURL, defaultToken }) => {
+    const stream = await openStream(baseURL, defaultToken, "unknown-event-id");
+    expect(stream.status).toBe(200);
+
+    try {
+      await expect.poll(() => stream.events.length, { timeout: eventTimeout }).toBeGreaterThan(0);
+      expect(stream.events[0]).toMatchObject({ id: "", event: "reset", data: {} });
+    } finally {
+      stream.close();
+    }
+  });
+
+  test("should return 401 without a token", async ({ anonymousRequest }) => {
+    const response = await anonymousRequest.get("/api/v1/events");
+
+    expect(response.status()).toBe(401);
+  });
+});
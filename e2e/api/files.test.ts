@@ -1,4 +1,8 @@
+import { APIRequestContext } from "@playwright/test";
 import { expect, test } from "../src/fixtures";
+import { createHash } from "node:crypto";
+
+const sha256Digest = (buffer: Buffer) => `sha-256=:${createHash("sha256").update(buffer).digest("base64")}:`;
 
 test.describe("Files", () => {
   test.describe("List files", () => {
@@ -79,6 +83,60 @@ test.describe("Files", () => {
       }));
     });
 
+    test("should record the checksum", async ({ createFile }) => {
+      const file = await createFile({
+        name: "example.txt",
+        mimeType: "text/plain",
+        buffer: Buffer.from("Hello, world!")
+      });
+
+      expect(file.checksum).toBe(createHash("sha256").update("Hello, world!").digest("hex"));
+    });
+
+    test("should return 201 for matching digest", async ({ createFile, request }) => {
+      const file = await createFile({ name: "example.txt" });
+
+      const response = await request.post(`/api/v1/files/${file.id}/upload`, {
+        headers: {
+          "Repr-Digest": sha256Digest(Buffer.from("Hello, world!")),
+        },
+        multipart: {
+          file: {
+            name: "example.txt",
+            mimeType: "text/plain",
+            buffer: Buffer.from("Hello, world!")
+          }
+        }
+      });
+
+      expect(response.status()).toBe(201);
+    });
+
+    test("should return 422 for mismatching digest", async ({ createFile, request }) => {
+      const file = await createFile({ name: "example.txt" });
+
+      const response = await request.post(`/api/v1/files/${file.id}/upload`, {
+        headers: {
+          "Repr-Digest": sha256Digest(Buffer.from("Goodbye, world!")),
+        },
+        multipart: {
+          file: {
+            name: "example.txt",
+            mimeType: "text/plain",
+            buffer: Buffer.from("Hello, world!")
+          }
+        }
+      });
+
+      expect(response.status()).toBe(422);
+
+      const getFileResponse = await request.get(`/api/v1/files/${file.id}`);
+
+      await expect(getFileResponse.json()).resolves.toEqual(expect.objectContaining({
+        isComplete: false
+      }));
+    });
+
     test("should return 400 for missing file", async ({ createFile, request }) => {
       const file = await createFile({ name: "example.txt" });
 
@@ -89,7 +147,7 @@ test.describe("Files", () => {
       expect(response.status()).toBe(400);
     });
 
-    test("should return 409 for already uploaded file", async ({ createFile, request }) => {
+    test("should add a revision to an already uploaded file", async ({ createFile, request }) => {
       const file = await createFile({ name: "example.txt" });
 
       const firstResponse = await request.post(`/api/v1/files/${file.id}/upload`, {
@@ -109,12 +167,22 @@ test.describe("Files", () => {
           file: {
             name: "example.txt",
             mimeType: "text/plain",
-            buffer: Buffer.from("Hello, world!")
+            buffer: Buffer.from("Hello, revised world!")
           }
         }
       });
 
-      expect(secondResponse.status()).toBe(409);
+      expect(secondResponse.status()).toBe(201);
+
+      const first = await firstResponse.json();
+      const second = await secondResponse.json();
+
+      expect(second.revisionId).not.toBe(first.revisionId);
+      expect(second.size).toBe(21);
+
+      const downloadResponse = await request.get(`/api/v1/files/${file.id}/download`);
+
+      await expect(downloadResponse.text()).resolves.toBe("Hello, revised world!");
     });
 
     test("should return 400 for invalid file ID", async ({ request }) => {
@@ -130,6 +198,177 @@ test.describe("Files", () => {
     });
   });
 
+  test.describe("Resumable upload", () => {
+    test("should complete the file after the last chunk", async ({ createFile, request }) => {
+      const file = await createFile({ name: "example.txt" });
+
+      const createResponse = await request.post(`/api/v1/files/${file.id}/uploads`, {
+        data: {
+          size: 13,
+        },
+      });
+
+      expect(createResponse.status()).toBe(201);
+
+      const session = await createResponse.json();
+
+      expect(session.offset).toBe(0);
+
+      const firstChunkResponse = await request.patch(`/api/v1/files/${file.id}/uploads/${session.id}`, {
+        headers: {
+          "Content-Type": "application/octet-stream",
+          "Upload-Offset": "0",
+        },
+        data: Buffer.from("Hello, "),
+      });
+
+      expect(firstChunkResponse.status()).toBe(200);
+      expect(firstChunkResponse.headers()["upload-offset"]).toBe("7");
+
+      const progressResponse = await request.get(`/api/v1/files/${file.id}/uploads/${session.id}`);
+
+      expect(progressResponse.status()).toBe(200);
+      await expect(progressResponse.json()).resolves.toEqual(expect.objectContaining({
+        offset: 7,
+        isComplete: false,
+      }));
+
+      const lastChunkResponse = await request.patch(`/api/v1/files/${file.id}/uploads/${session.id}`, {
+        headers: {
+          "Content-Type": "application/octet-stream",
+          "Upload-Offset": "7",
+        },
+        data: Buffer.from("world!"),
+      });
+
+      expect(lastChunkResponse.status()).toBe(200);
+      await expect(lastChunkResponse.json()).resolves.toEqual(expect.objectContaining({
+        offset: 13,
+        isComplete: true,
+      }));
+
+      const downloadResponse = await request.get(`/api/v1/files/${file.id}/download`);
+
+      expect(downloadResponse.status()).toBe(200);
+      await expect(downloadResponse.text()).resolves.toBe("Hello, world!");
+    });
+
+    test("should return 422 for mismatching chunk digest", async ({ createFile, request }) => {
+      const file = await createFile({ name: "example.txt" });
+
+      const createResponse = await request.post(`/api/v1/files/${file.id}/uploads`, {
+        data: {
+          size: 13,
+        },
+      });
+      const session = await createResponse.json();
+
+      const response = await request.patch(`/api/v1/files/${file.id}/uploads/${session.id}`, {
+        headers: {
+          "Content-Type": "application/octet-stream",
+          "Content-Digest": sha256Digest(Buffer.from("Goodbye")),
+          "Upload-Offset": "0",
+        },
+        data: Buffer.from("Hello, "),
+      });
+
+      expect(response.status()).toBe(422);
+    });
+
+    test("should return 409 for offset mismatch", async ({ createFile, request }) => {
+      const file = await createFile({ name: "example.txt" });
+
+      const createResponse = await request.post(`/api/v1/files/${file.id}/uploads`, {
+        data: {
+          size: 13,
+        },
+      });
+      const session = await createResponse.json();
+
+      const response = await request.patch(`/api/v1/files/${file.id}/uploads/${session.id}`, {
+        headers: {
+          "Content-Type": "application/octet-stream",
+          "Upload-Offset": "5",
+        },
+        data: Buffer.from("world!"),
+      });
+
+      expect(response.status()).toBe(409);
+    });
+
+    test("should return 413 for chunk exceeding the session size", async ({ createFile, request }) => {
+      const file = await createFile({ name: "example.txt" });
+
+      const createResponse = await request.post(`/api/v1/files/${file.id}/uploads`, {
+        data: {
+          size: 5,
+        },
+      });
+      const session = await createResponse.json();
+
+      const response = await request.patch(`/api/v1/files/${file.id}/uploads/${session.id}`, {
+        headers: {
+          "Content-Type": "application/octet-stream",
+          "Upload-Offset": "0",
+        },
+        data: Buffer.from("Hello, world!"),
+      });
+
+      expect(response.status()).toBe(413);
+    });
+
+    test("should add a revision to an already uploaded file", async ({ createFile, request }) => {
+      const file = await createFile({
+        name: "example.txt",
+        mimeType: "text/plain",
+        buffer: Buffer.from("Hello, world!")
+      });
+
+      const createResponse = await request.post(`/api/v1/files/${file.id}/uploads`, {
+        data: {
+          size: 7,
+        },
+      });
+
+      expect(createResponse.status()).toBe(201);
+
+      const session = await createResponse.json();
+
+      const chunkResponse = await request.patch(`/api/v1/files/${file.id}/uploads/${session.id}`, {
+        headers: {
+          "Content-Type": "application/octet-stream",
+          "Upload-Offset": "0",
+        },
+        data: Buffer.from("Revised"),
+      });
+
+      expect(chunkResponse.status()).toBe(200);
+
+      const downloadResponse = await request.get(`/api/v1/files/${file.id}/download`);
+
+      await expect(downloadResponse.text()).resolves.toBe("Revised");
+    });
+
+    test("should return 204 for cancelled upload", async ({ createFile, request }) => {
+      const file = await createFile({ name: "example.txt" });
+
+      const createResponse = await request.post(`/api/v1/files/${file.id}/uploads`, {
+        data: {
+          size: 13,
+        },
+      });
+      const session = await createResponse.json();
+
+      const response = await request.delete(`/api/v1/files/${file.id}/uploads/${session.id}`);
+
+      expect(response.status()).toBe(204);
+
+      const progressResponse = await request.get(`/api/v1/files/${file.id}/uploads/${session.id}`);
+
+      expect(progressResponse.status()).toBe(404);
+    });
+  });
+
   test.describe("Download file", () => {
     test("should return 200", async ({ createFile, request }) => {
       const file = await createFile({
@@ -142,6 +381,7 @@ test.describe("Files", () => {
 
       expect(response.status()).toBe(200);
       expect(response.headers()['content-type']).toBe('text/plain; charset=utf-8');
+      expect(response.headers()['repr-digest']).toBe(sha256Digest(Buffer.from('Hello, world!')));
       await expect(response.text()).resolves.toBe('Hello, world!');
     });
 
@@ -166,6 +406,34 @@ test.describe("Files", () => {
     });
   });
 
+  // The test user isn't an instance admin, verification is closed to them
+  // even for their own files.
+  test.describe("Verify files", () => {
+    test("should return 403 for a non-admin", async ({ createFile, request }) => {
+      const file = await createFile({
+        name: "example.txt",
+        mimeType: "text/plain",
+        buffer: Buffer.from("Hello, world!")
+      });
+
+      const response = await request.post(`/api/v1/admin/files/${file.id}/verify`);
+
+      expect(response.status()).toBe(403);
+    });
+
+    test("should return 403 for a page of files for a non-admin", async ({ request }) => {
+      const response = await request.post("/api/v1/admin/files/verify");
+
+      expect(response.status()).toBe(403);
+    });
+
+    test("should return 401 without a token", async ({ anonymousRequest }) => {
+      const response = await anonymousRequest.post("/api/v1/admin/files/verify");
+
+      expect(response.status()).toBe(401);
+    });
+  });
+
   test.describe("Get file", () => {
     test("should return 200", async ({ createFile, request }) => {
       const file = await createFile({ name: "example.txt" });
@@ -209,4 +477,219 @@ test.describe("Files", () => {
       expect(response.status()).toBe(404);
     });
   });
+
+  test.describe("File revisions", () => {
+    const upload = (request: APIRequestContext, id: number, contents: string) =>
+      request.post(`/api/v1/files/${id}/upload`, {
+        multipart: {
+          file: {
+            name: "example.txt",
+            mimeType: "text/plain",
+            buffer: Buffer.from(contents)
+          }
+        }
+      });
+
+    test("should list the revisions newest first", async ({ createFile, request }) => {
+      const file = await createFile({
+        name: "example.txt",
+        mimeType: "text/plain",
+        buffer: Buffer.from("First")
+      });
+      await upload(request, file.id, "Second");
+
+      const response = await request.get(`/api/v1/files/${file.id}/revisions`);
+
+      expect(response.status()).toBe(200);
+
+      const { revisions } = await response.json();
+
+      expect(revisions.map((r: any) => r.number)).toEqual([2, 1]);
+      expect(revisions[0]).toEqual(expect.objectContaining({
+        fileId: file.id,
+        size: 6,
+        checksum: createHash("sha256").update("Second").digest("hex"),
+      }));
+      expect(revisions[1].id).toBe(file.revisionId);
+    });
+
+    test("should download an earlier revision", async ({ createFile, request }) => {
+      const file = await createFile({
+        name: "example.txt",
+        mimeType: "text/plain",
+        buffer: Buffer.from("First")
+      });
+      await upload(request, file.id, "Second");
+
+      const response = await request.get(`/api/v1/files/${file.id}/revisions/${file.revisionId}/download`);
+
+      expect(response.status()).toBe(200);
+      expect(response.headers()['repr-digest']).toBe(sha256Digest(Buffer.from('First')));
+      await expect(response.text()).resolves.toBe("First");
+    });
+
+    test("should restore an earlier revision", async ({ createFile, request }) => {
+      const file = await createFile({
+        name: "example.txt",
+        mimeType: "text/plain",
+        buffer: Buffer.from("First")
+      });
+      await upload(request, file.id, "Second");
+
+      const response = await request.post(`/api/v1/files/${file.id}/revisions/${file.revisionId}/restore`);
+
+      expect(response.status()).toBe(200);
+      await expect(response.json()).resolves.toEqual(expect.objectContaining({
+        revisionId: file.revisionId,
+        size: 5,
+      }));
+
+      const downloadResponse = await request.get(`/api/v1/files/${file.id}/download`);
+
+      await expect(downloadResponse.text()).resolves.toBe("First");
+
+      const listResponse = await request.get(`/api/v1/files/${file.id}/revisions`);
+
+      expect((await listResponse.json()).revisions).toHaveLength(2);
+    });
+
+    test("should keep the revision of a released version", async ({ createProject, createVersion, releaseVersion, createFile, request }) => {
+      const project = await createProject();
+      const version = await createVersion({ projectId: project.id });
+      const file = await createFile({
+        name: "example.txt",
+        mimeType: "text/plain",
+        buffer: Buffer.from("Released")
+      });
+      await request.patch(`/api/v1/versions/${version.id}/attach-file`, { data: { fileId: file.id } });
+      await releaseVersion(version.id);
+
+      await upload(request, file.id, "Changed later");
+
+      const response = await request.get(`/api/v1/files`, { params: { versionId: version.id } });
+
+      expect(response.status()).toBe(200);
+      await expect(response.json()).resolves.toEqual(expect.objectContaining({
+        files: [expect.objectContaining({ id: file.id, revisionId: file.revisionId, size: 8 })],
+      }));
+    });
+
+    test("should pin the revision given when attaching", async ({ createProject, createVersion, createFile, request }) => {
+      const project = await createProject();
+      const version = await createVersion({ projectId: project.id });
+      const file = await createFile({
+        name: "example.txt",
+        mimeType: "text/plain",
+        buffer: Buffer.from("First")
+      });
+      await upload(request, file.id, "Second");
+
+      const attachResponse = await request.patch(`/api/v1/versions/${version.id}/attach-file`, {
+        data: { fileId: file.id, revisionId: file.revisionId },
+      });
+
+      expect(attachResponse.status()).toBe(204);
+
+      const response = await request.get(`/api/v1/files`, { params: { versionId: version.id } });
+
+      await expect(response.json()).resolves.toEqual(expect.objectContaining({
+        files: [expect.objectContaining({ id: file.id, revisionId: file.revisionId, size: 5 })],
+      }));
+    });
+
+    test("should return 400 for a revision of another file", async ({ createProject, createVersion, createFile, request }) => {
+      const project = await createProject();
+      const version = await createVersion({ projectId: project.id });
+      const file = await createFile({ name: "example.txt" });
+      const other = await createFile({
+        name: "other.txt",
+        mimeType: "text/plain",
+        buffer: Buffer.from("Other")
+      });
+
+      const response = await request.patch(`/api/v1/versions/${version.id}/attach-file`, {
+        data: { fileId: file.id, revisionId: other.revisionId },
+      });
+
+      expect(response.status()).toBe(400);
+    });
+
+    test("should return 404 for a revision of another file", async ({ createFile, request }) => {
+      const file = await createFile({
+        name: "example.txt",
+        mimeType: "text/plain",
+        buffer: Buffer.from("Example")
+      });
+      const other = await createFile({
+        name: "other.txt",
+        mimeType: "text/plain",
+        buffer: Buffer.from("Other")
+      });
+
+      const response = await request.get(`/api/v1/files/${file.id}/revisions/${other.revisionId}/download`);
+
+      expect(response.status()).toBe(404);
+    });
+
+    test("should return 400 for invalid revision ID", async ({ createFile, request }) => {
+      const file = await createFile({ name: "example.txt" });
+
+      const response = await request.post(`/api/v1/files/${file.id}/revisions/invalid/restore`);
+
+      expect(response.status()).toBe(400);
+    });
+  });
+
+  test.describe("Latest file by name", () => {
+    test("should return the copy from the latest released version", async ({ createProject, createVersion, releaseVersion, createFile, request }) => {
+      const project = await createProject();
+      const versions = [];
+      for (const name of ["1.0", "2.0", "3.0"]) {
+        const version = await createVersion({ projectId: project.id, name });
+        const file = await createFile({
+          name: "manual.pdf",
+          mimeType: "application/pdf",
+          buffer: Buffer.from(`Manual ${name}`)
+        });
+        await request.patch(`/api/v1/versions/${version.id}/attach-file`, { data: { fileId: file.id } });
+        versions.push({ version, file });
+      }
+      await releaseVersion(versions[0].version.id);
+      await releaseVersion(versions[1].version.id);
+
+      const response = await request.get(`/api/v1/projects/${project.id}/files/latest`, {
+        params: { name: "manual.pdf" },
+      });
+
+      expect(response.status()).toBe(200);
+      expect((await response.json()).id).toBe(versions[1].file.id);
+    });
+
+    test("should return 404 for a name that was never released", async ({ createProject, request }) => {
+      const project = await createProject();
+
+      const response = await request.get(`/api/v1/projects/${project.id}/files/latest`, {
+        params: { name: "missing.pdf" },
+      });
+
+      expect(response.status()).toBe(404);
+    });
+  });
+
+  test.describe("File QR code", () => {
+    test("should return 200", async ({ createFile, request }) => {
+      const file = await createFile({ name: "example.txt" });
+
+      const response = await request.get(`/api/v1/files/${file.id}/qr`);
+
+      expect(response.status()).toBe(200);
+      expect(response.headers()["content-type"]).toBe("image/png");
+    });
+
+    test("should return 404 for non-existing file", async ({ request }) => {
+      const response = await request.get(`/api/v1/files/-1/qr`);
+
+      expect(response.status()).toBe(404);
+    });
+  });
 });
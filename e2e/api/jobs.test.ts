@@ -0,0 +1,41 @@
+import { expect, test } from "../src/fixtures";
+
+// The test user isn't an instance admin, the job administration is closed to
+// them.
+test.describe("Jobs", () => {
+  test.describe("List jobs", () => {
+    test("should return 403 for a non-admin", async ({ request }) => {
+      const response = await request.get("/api/v1/admin/jobs");
+
+      expect(response.status()).toBe(403);
+    });
+
+    test("should return 401 without a token", async ({ anonymousRequest }) => {
+      const response = await anonymousRequest.get("/api/v1/admin/jobs");
+
+      expect(response.status()).toBe(401);
+    });
+  });
+
+  test.describe("Get job", () => {
+    test("should return 403 for a non-admin", async ({ request }) => {
+      const response = await request.get("/api/v1/admin/jobs/1");
+
+      expect(response.status()).toBe(403);
+    });
+
+    test("should return 400 for an invalid id", async ({ request }) => {
+      const response = await request.get("/api/v1/admin/jobs/invalid-id");
+
+      expect(response.status()).toBe(400);
+    });
+  });
+
+  test.describe("Retry job", () => {
+    test("should return 403 for a non-admin", async ({ request }) => {
+      const response = await request.post("/api/v1/admin/jobs/1/retry");
+
+      expect(response.status()).toBe(403);
+    });
+  });
+});
@@ -0,0 +1,243 @@
+import { expect, test } from "../src/fixtures";
+import * as uuid from "uuid";
+
+test.describe("Locations", () => {
+  test.describe("List locations", () => {
+    test("should return 200", async ({ request }) => {
+      const response = await request.get("/api/v1/locations");
+
+      expect(response.status()).toBe(200);
+    });
+  });
+
+  test.describe("Create location", () => {
+    test("should return 201", async ({ request }) => {
+      const response = await request.post("/api/v1/locations", {
+        data: {
+          name: "Test location",
+          address: "Industrieweg 1, 9000 Ghent, Belgium",
+          lat: 51.0543,
+          lon: 3.7174,
+        },
+      });
+
+      expect(response.status()).toBe(201);
+
+      const responseBody = await response.json();
+      expect(responseBody.name).toBe("Test location");
+      expect(responseBody.address).toBe("Industrieweg 1, 9000 Ghent, Belgium");
+      expect(responseBody.lat).toBe(51.0543);
+      expect(responseBody.lon).toBe(3.7174);
+    });
+
+    test("should return 201 without coordinates", async ({ request }) => {
+      const response = await request.post("/api/v1/locations", {
+        data: {
+          name: "Test location",
+        },
+      });
+
+      expect(response.status()).toBe(201);
+
+      const responseBody = await response.json();
+      expect(responseBody.address).toBeNull();
+      expect(responseBody.lat).toBeNull();
+      expect(responseBody.lon).toBeNull();
+    });
+
+    test("should return 400 for missing name", async ({ request }) => {
+      const response = await request.post("/api/v1/locations", {
+        data: {
+          lat: 51.0543,
+          lon: 3.7174,
+        },
+      });
+
+      expect(response.status()).toBe(400);
+    });
+
+    test("should return 400 for out of range latitude", async ({ request }) => {
+      const response = await request.post("/api/v1/locations", {
+        data: {
+          name: "Test location",
+          lat: 91,
+          lon: 3.7174,
+        },
+      });
+
+      expect(response.status()).toBe(400);
+    });
+
+    test("should return 400 for incomplete coordinates", async ({ request }) => {
+      const response = await request.post("/api/v1/locations", {
+        data: {
+          name: "Test location",
+          lat: 51.0543,
+        },
+      });
+
+      expect(response.status()).toBe(400);
+    });
+  });
+
+  test.describe("Get location", () => {
+    test("should return 200", async ({ createLocation, request }) => {
+      const location = await createLocation();
+
+      const response = await request.get(`/api/v1/locations/${location.id}`);
+
+      expect(response.status()).toBe(200);
+    });
+
+    test("should return 400 for invalid location ID", async ({ request }) => {
+      const response = await request.get(`/api/v1/locations/invalid-id`);
+
+      expect(response.status()).toBe(400);
+    });
+
+    test("should return 404 for non-existing location", async ({ request }) => {
+      const response = await request.get(`/api/v1/locations/-1`);
+
+      expect(response.status()).toBe(404);
+    });
+  });
+
+  test.describe("Locations of other users", () => {
+    test.skip(!process.env.KEYCLOAK_OTHER_USERNAME, "KEYCLOAK_OTHER_USERNAME isn't set");
+
+    test("should hide a location of another user", async ({ otherRequest, request }) => {
+      const createResponse = await otherRequest.post("/api/v1/locations", { data: { name: "Other location" } });
+      const location = await createResponse.json();
+
+      const response = await request.get(`/api/v1/locations/${location.id}`);
+      expect(response.status()).toBe(403);
+
+      const listResponse = await request.get("/api/v1/locations?limit=100");
+      const { locations } = await listResponse.json();
+      expect(locations.map((l: { id: number }) => l.id)).not.toContain(location.id);
+    });
+
+    test("should show the location of a project the user is a member of", async ({ otherRequest, request }) => {
+      const createResponse = await otherRequest.post("/api/v1/locations", { data: { name: "Other location" } });
+      const location = await createResponse.json();
+      const projectResponse = await otherRequest.post("/api/v1/projects", {
+        data: { slug: uuid.v4(), name: "Other project", locationId: location.id }
+      });
+      const project = await projectResponse.json();
+      const meResponse = await request.get("/api/v1/users/me");
+      const { id: userId } = await meResponse.json();
+      await otherRequest.post(`/api/v1/projects/${project.id}/members`, { data: { userId, role: "viewer" } });
+
+      const response = await request.get(`/api/v1/locations/${location.id}`);
+
+      expect(response.status()).toBe(200);
+    });
+
+    test("should return 403 for assigning a location of another user", async ({ otherRequest, request }) => {
+      const createResponse = await otherRequest.post("/api/v1/locations", { data: { name: "Other location" } });
+      const location = await createResponse.json();
+
+      const response = await request.post("/api/v1/projects", {
+        data: { slug: uuid.v4(), name: "Test project", locationId: location.id }
+      });
+
+      expect(response.status()).toBe(403);
+    });
+  });
+
+  test.describe("Update location", () => {
+    test("should return 200", async ({ createLocation, request }) => {
+      const location = await createLocation();
+
+      const response = await request.put(`/api/v1/locations/${location.id}`, {
+        data: {
+          name: "Updated location name",
+          lat: 50.8503,
+          lon: 4.3517,
+        },
+      });
+
+      expect(response.status()).toBe(200);
+
+      const responseBody = await response.json();
+      expect(responseBody.name).toBe("Updated location name");
+      expect(responseBody.lat).toBe(50.8503);
+      expect(responseBody.lon).toBe(4.3517);
+    });
+
+    test("should return 400 for missing name", async ({ createLocation, request }) => {
+      const location = await createLocation();
+
+      const response = await request.put(`/api/v1/locations/${location.id}`, {
+        data: {},
+      });
+
+      expect(response.status()).toBe(400);
+    });
+
+    test("should return 403 for a location of a project the user doesn't administer", async ({ createLocation, createProject, handOverProject, request }) => {
+      const location = await createLocation();
+      const project = await createProject({ locationId: location.id });
+      await handOverProject(project.id, "editor");
+
+      const response = await request.put(`/api/v1/locations/${location.id}`, {
+        data: {
+          name: "Updated location name",
+        },
+      });
+
+      expect(response.status()).toBe(403);
+    });
+
+    test("should return 404 for non-existing location", async ({ request }) => {
+      const response = await request.put(`/api/v1/locations/-1`, {
+        data: {
+          name: "Updated location name",
+        },
+      });
+
+      expect(response.status()).toBe(404);
+    });
+  });
+
+  test.describe("Delete location", () => {
+    test("should return 204", async ({ createLocation, request }) => {
+      const location = await createLocation();
+
+      const response = await request.delete(`/api/v1/locations/${location.id}`);
+
+      expect(response.status()).toBe(204);
+    });
+
+    test("should return 400 for invalid location ID", async ({ request }) => {
+      const response = await request.delete(`/api/v1/locations/invalid-id`);
+
+      expect(response.status()).toBe(400);
+    });
+
+    test("should return 404 for non-existing location", async ({ request }) => {
+      const response = await request.delete(`/api/v1/locations/-1`);
+
+      expect(response.status()).toBe(404);
+    });
+
+    test("should return 403 for a location of a project the user left", async ({ createLocation, createProject, handOverProject, request }) => {
+      const location = await createLocation();
+      const project = await createProject({ locationId: location.id });
+      await handOverProject(project.id);
+
+      const response = await request.delete(`/api/v1/locations/${location.id}`);
+
+      expect(response.status()).toBe(403);
+    });
+
+    test("should return 409 for location assigned to a project", async ({ createLocation, createProject, request }) => {
+      const location = await createLocation();
+      await createProject({ locationId: location.id });
+
+      const response = await request.delete(`/api/v1/locations/${location.id}`);
+
+      expect(response.status()).toBe(409);
+    });
+  });
+});
@@ -0,0 +1,220 @@
+import { APIRequestContext } from "@playwright/test";
+import { expect, test } from "../src/fixtures";
+import * as uuid from "uuid";
+
+const createUser = async (request: APIRequestContext): Promise<number> => {
+  const response = await request.post("/api/v1/users", {
+    data: {
+      name: `John - ${uuid.v4()}`,
+      email: `john-${uuid.v4()}@example.com`,
+      emailVerified: true
+    }
+  });
+
+  expect(response.status()).toBe(200);
+
+  const responseBody = await response.json();
+  return responseBody.id;
+};
+
+const getMe = async (request: APIRequestContext): Promise<number> => {
+  const response = await request.get("/api/v1/users/me");
+
+  expect(response.status()).toBe(200);
+
+  const responseBody = await response.json();
+  return responseBody.id;
+};
+
+test.describe("Members", () => {
+  test.describe("List members", () => {
+    test("should list the creator as admin", async ({ createProject, request }) => {
+      const project = await createProject();
+      const me = await getMe(request);
+
+      const response = await request.get(`/api/v1/projects/${project.id}/members`);
+
+      expect(response.status()).toBe(200);
+
+      const responseBody = await response.json();
+      expect(responseBody.members).toEqual([
+        expect.objectContaining({ projectId: project.id, userId: me, role: "admin" })
+      ]);
+    });
+
+    test("should return 401 for missing token", async ({ createProject, anonymousRequest }) => {
+      const project = await createProject();
+
+      const response = await anonymousRequest.get(`/api/v1/projects/${project.id}/members`);
+
+      expect(response.status()).toBe(401);
+    });
+  });
+
+  test.describe("Create member", () => {
+    test("should return 201", async ({ createProject, request }) => {
+      const project = await createProject();
+      const userId = await createUser(request);
+
+      const response = await request.post(`/api/v1/projects/${project.id}/members`, {
+        data: { userId, role: "viewer" }
+      });
+
+      expect(response.status()).toBe(201);
+      await expect(response.json()).resolves.toEqual(expect.objectContaining({
+        projectId: project.id,
+        userId,
+        role: "viewer"
+      }));
+    });
+
+    test("should return 409 for existing member", async ({ createProject, request }) => {
+      const project = await createProject();
+      const userId = await createUser(request);
+
+      await request.post(`/api/v1/projects/${project.id}/members`, { data: { userId, role: "viewer" } });
+      const response = await request.post(`/api/v1/projects/${project.id}/members`, {
+        data: { userId, role: "editor" }
+      });
+
+      expect(response.status()).toBe(409);
+    });
+
+    test("should return 400 for invalid role", async ({ createProject, request }) => {
+      const project = await createProject();
+      const userId = await createUser(request);
+
+      const response = await request.post(`/api/v1/projects/${project.id}/members`, {
+        data: { userId, role: "owner" }
+      });
+
+      expect(response.status()).toBe(400);
+    });
+
+    test("should return 400 for unknown user", async ({ createProject, request }) => {
+      const project = await createProject();
+
+      const response = await request.post(`/api/v1/projects/${project.id}/members`, {
+        data: { userId: -1, role: "viewer" }
+      });
+
+      expect(response.status()).toBe(400);
+    });
+  });
+
+  test.describe("Update member", () => {
+    test("should return 200", async ({ createProject, request }) => {
+      const project = await createProject();
+      const userId = await createUser(request);
+      await request.post(`/api/v1/projects/${project.id}/members`, { data: { userId, role: "viewer" } });
+
+      const response = await request.put(`/api/v1/projects/${project.id}/members/${userId}`, {
+        data: { role: "editor" }
+      });
+
+      expect(response.status()).toBe(200);
+      await expect(response.json()).resolves.toEqual(expect.objectContaining({ userId, role: "editor" }));
+    });
+
+    test("should return 409 when demoting the last admin", async ({ createProject, request }) => {
+      const project = await createProject();
+      const me = await getMe(request);
+
+      const response = await request.put(`/api/v1/projects/${project.id}/members/${me}`, {
+        data: { role: "viewer" }
+      });
+
+      expect(response.status()).toBe(409);
+    });
+
+    test("should return 404 for unknown member", async ({ createProject, request }) => {
+      const project = await createProject();
+      const userId = await createUser(request);
+
+      const response = await request.put(`/api/v1/projects/${project.id}/members/${userId}`, {
+        data: { role: "editor" }
+      });
+
+      expect(response.status()).toBe(404);
+    });
+  });
+
+  test.describe("Access", () => {
+    test("should return 403 for viewers updating the project", async ({ createProject, request }) => {
+      const project = await createProject();
+      const me = await getMe(request);
+      const userId = await createUser(request);
+      await request.post(`/api/v1/projects/${project.id}/members`, { data: { userId, role: "admin" } });
+      await request.put(`/api/v1/projects/${project.id}/members/${me}`, { data: { role: "viewer" } });
+
+      const getResponse = await request.get(`/api/v1/projects/${project.id}`);
+      expect(getResponse.status()).toBe(200);
+
+      const updateResponse = await request.put(`/api/v1/projects/${project.id}`, {
+        data: { slug: project.slug, name: "Renamed project" }
+      });
+      expect(updateResponse.status()).toBe(403);
+
+      const versionResponse = await request.post("/api/v1/versions", {
+        data: { name: "v1", description: "Test description", projectId: project.id }
+      });
+      expect(versionResponse.status()).toBe(403);
+    });
+
+    test("should hide projects after leaving them", async ({ createProject, request }) => {
+      const project = await createProject();
+      const me = await getMe(request);
+      const userId = await createUser(request);
+      await request.post(`/api/v1/projects/${project.id}/members`, { data: { userId, role: "admin" } });
+
+      const deleteResponse = await request.delete(`/api/v1/projects/${project.id}/members/${me}`);
+      expect(deleteResponse.status()).toBe(204);
+
+      const getResponse = await request.get(`/api/v1/projects/${project.id}`);
+      expect(getResponse.status()).toBe(403);
+
+      const listResponse = await request.get("/api/v1/projects?limit=100");
+      const listResponseBody = await listResponse.json();
+      expect(listResponseBody.projects.map((p) => p.id)).not.toContain(project.id);
+    });
+
+    test.describe("Files of other users", () => {
+      test.skip(!process.env.KEYCLOAK_OTHER_USERNAME, "KEYCLOAK_OTHER_USERNAME isn't set");
+
+      test("should return 403 for attaching a file the user can't view", async ({ createProject, createVersion, createOtherFile, request }) => {
+        const project = await createProject();
+        const version = await createVersion({ projectId: project.id });
+        const file = await createOtherFile();
+
+        const response = await request.patch(`/api/v1/versions/${version.id}/attach-file`, {
+          data: { fileId: file.id }
+        });
+        expect(response.status()).toBe(403);
+
+        const filesResponse = await request.get(`/api/v1/files`, { params: { versionId: version.id } });
+        const filesResponseBody = await filesResponse.json();
+        expect(filesResponseBody.files).toHaveLength(0);
+      });
+
+      test("should allow attaching a file included in a version of the user's project", async ({ createProject, createVersion, createOtherFile, otherRequest, request }) => {
+        const file = await createOtherFile();
+        const sharedProject = await createProject();
+        const sharedVersion = await createVersion({ projectId: sharedProject.id });
+        const otherMeResponse = await otherRequest.get("/api/v1/users/me");
+        const { id: otherUserId } = await otherMeResponse.json();
+        await request.post(`/api/v1/projects/${sharedProject.id}/members`, { data: { userId: otherUserId, role: "editor" } });
+        const sharedResponse = await otherRequest.patch(`/api/v1/versions/${sharedVersion.id}/attach-file`, {
+          data: { fileId: file.id }
+        });
+        expect(sharedResponse.status()).toBe(204);
+
+        const project = await createProject();
+        const version = await createVersion({ projectId: project.id });
+        const response = await request.patch(`/api/v1/versions/${version.id}/attach-file`, {
+          data: { fileId: file.id }
+        });
+        expect(response.status()).toBe(204);
+      });
+    });
+  });
+});
@@ -8,6 +8,90 @@ test.describe("Projects", () => {
 
       expect(response.status()).toBe(200);
     });
+
+    test("should filter by location", async ({ createLocation, createProject, request }) => {
+      const location = await createLocation();
+      const project = await createProject({ locationId: location.id });
+      await createProject();
+
+      const response = await request.get(`/api/v1/projects?locationId=${location.id}`);
+
+      expect(response.status()).toBe(200);
+
+      const responseBody = await response.json();
+      expect(responseBody.projects.map((p) => p.id)).toEqual([project.id]);
+    });
+
+    test("should sort projects near a point by distance", async ({ createLocation, createProject, request }) => {
+      const nearby = await createLocation({ lat: -33.8688, lon: 151.2093 });
+      const further = await createLocation({ lat: -33.9, lon: 151.25 });
+      const outside = await createLocation({ lat: -34.4278, lon: 150.8931 });
+      const furtherProject = await createProject({ locationId: further.id });
+      const nearbyProject = await createProject({ locationId: nearby.id });
+      const outsideProject = await createProject({ locationId: outside.id });
+
+      const response = await request.get("/api/v1/projects?near=-33.8688,151.2093&radiusKm=10");
+
+      expect(response.status()).toBe(200);
+
+      const responseBody = await response.json();
+      const ids = responseBody.projects.map((p) => p.id);
+      expect(ids.indexOf(nearbyProject.id)).toBeGreaterThanOrEqual(0);
+      expect(ids.indexOf(nearbyProject.id)).toBeLessThan(ids.indexOf(furtherProject.id));
+      expect(ids).not.toContain(outsideProject.id);
+
+      const nearbyResult = responseBody.projects.find((p) => p.id === nearbyProject.id);
+      expect(nearbyResult.distanceKm).toBeCloseTo(0, 3);
+      const furtherResult = responseBody.projects.find((p) => p.id === furtherProject.id);
+      expect(furtherResult.distanceKm).toBeGreaterThan(4);
+      expect(furtherResult.distanceKm).toBeLessThan(6);
+    });
+
+    test("should filter by bounding box", async ({ createLocation, createProject, request }) => {
+      const inside = await createLocation({ lat: 64.1466, lon: -21.9426 });
+      const outside = await createLocation({ lat: 65.6835, lon: -18.0878 });
+      const insideProject = await createProject({ locationId: inside.id });
+      const outsideProject = await createProject({ locationId: outside.id });
+
+      const response = await request.get("/api/v1/projects?bbox=64,-22.5,64.5,-21.5");
+
+      expect(response.status()).toBe(200);
+
+      const responseBody = await response.json();
+      const ids = responseBody.projects.map((p) => p.id);
+      expect(ids).toContain(insideProject.id);
+      expect(ids).not.toContain(outsideProject.id);
+    });
+
+    test("should filter by bounding box crossing the antimeridian", async ({ createLocation, createProject, request }) => {
+      const location = await createLocation({ lat: -17.7134, lon: 178.065 });
+      const project = await createProject({ locationId: location.id });
+
+      const response = await request.get("/api/v1/projects?bbox=-20,175,-15,-175");
+
+      expect(response.status()).toBe(200);
+
+      const responseBody = await response.json();
+      expect(responseBody.projects.map((p) => p.id)).toContain(project.id);
+    });
+
+    test("should return 400 for invalid near", async ({ request }) => {
+      const response = await request.get("/api/v1/projects?near=91,0");
+
+      expect(response.status()).toBe(400);
+    });
+
+    test("should return 400 for radius without near", async ({ request }) => {
+      const response = await request.get("/api/v1/projects?radiusKm=10");
+
+      expect(response.status()).toBe(400);
+    });
+
+    test("should return 400 for invalid bounding box", async ({ request }) => {
+      const response = await request.get("/api/v1/projects?bbox=1,2,3");
+
+      expect(response.status()).toBe(400);
+    });
   });
 
   test.describe("Create project", () => {
@@ -22,6 +106,36 @@ test.describe("Projects", () => {
       expect(response.status()).toBe(201);
     });
 
+    test("should return 201 with location", async ({ createLocation, request }) => {
+      const location = await createLocation({ lat: 51.0543, lon: 3.7174 });
+
+      const response = await request.post("/api/v1/projects", {
+        data: {
+          slug: uuid.v4(),
+          name: "Test project",
+          locationId: location.id,
+        },
+      });
+
+      expect(response.status()).toBe(201);
+
+      const responseBody = await response.json();
+      expect(responseBody.locationId).toBe(location.id);
+      expect(responseBody.location).toEqual(location);
+    });
+
+    test("should return 400 for non-existing location", async ({ request }) => {
+      const response = await request.post("/api/v1/projects", {
+        data: {
+          slug: uuid.v4(),
+          name: "Test project",
+          locationId: 2147483647,
+        },
+      });
+
+      expect(response.status()).toBe(400);
+    });
+
     test("should return 400 for missing name", async ({ request }) => {
       const response = await request.post("/api/v1/projects", {
         data: {
@@ -160,4 +274,72 @@ test.describe("Projects", () => {
       expect(response.status()).toBe(404);
     });
   });
+
+  test.describe("Project QR code", () => {
+    test("should return a PNG", async ({ createProject, request }) => {
+      const project = await createProject();
+
+      const response = await request.get(`/api/v1/projects/${project.id}/qr`);
+
+      expect(response.status()).toBe(200);
+      expect(response.headers()["content-type"]).toBe("image/png");
+
+      const body = await response.body();
+      expect(body.subarray(0, 8)).toEqual(Buffer.from([0x89, 0x50, 0x4e, 0x47, 0x0d, 0x0a, 0x1a, 0x0a]));
+    });
+
+    test("should return an SVG with label", async ({ createProject, request }) => {
+      const project = await createProject();
+
+      const response = await request.get(`/api/v1/projects/${project.id}/qr?format=svg&size=512&level=H&label=Pump%20station%20%3C7%3E`);
+
+      expect(response.status()).toBe(200);
+      expect(response.headers()["content-type"]).toBe("image/svg+xml");
+
+      const body = await response.text();
+      expect(body).toMatch(/^<svg /);
+      expect(body).toContain('width="512"');
+      expect(body).toContain("Pump station &lt;7&gt;");
+    });
+
+    test("should be deterministic", async ({ createProject, request }) => {
+      const project = await createProject();
+
+      const first = await request.get(`/api/v1/projects/${project.id}/qr?size=300&label=Site`);
+      const second = await request.get(`/api/v1/projects/${project.id}/qr?size=300&label=Site`);
+
+      expect(first.status()).toBe(200);
+      expect(await first.body()).toEqual(await second.body());
+    });
+
+    test("should return 400 for invalid format", async ({ createProject, request }) => {
+      const project = await createProject();
+
+      const response = await request.get(`/api/v1/projects/${project.id}/qr?format=gif`);
+
+      expect(response.status()).toBe(400);
+    });
+
+    test("should return 400 for invalid size", async ({ createProject, request }) => {
+      const project = await createProject();
+
+      const response = await request.get(`/api/v1/projects/${project.id}/qr?size=10`);
+
+      expect(response.status()).toBe(400);
+    });
+
+    test("should return 400 for invalid level", async ({ createProject, request }) => {
+      const project = await createProject();
+
+      const response = await request.get(`/api/v1/projects/${project.id}/qr?level=X`);
+
+      expect(response.status()).toBe(400);
+    });
+
+    test("should return 404 for non-existing project", async ({ request }) => {
+      const response = await request.get(`/api/v1/projects/-1/qr`);
+
+      expect(response.status()).toBe(404);
+    });
+  });
 });
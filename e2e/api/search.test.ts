@@ -0,0 +1,176 @@
+import { APIRequestContext } from "@playwright/test";
+import * as uuid from "uuid";
+import { expect, test } from "../src/fixtures";
+
+// Every test searches for a term of its own, the database is shared between
+// tests.
+const uniqueTerm = () => `term${uuid.v4().replaceAll("-", "")}`;
+
+test.describe("Search", () => {
+  test("should return typed hits for projects, versions and files", async ({ createProject, createVersion, createFile, request }) => {
+    const term = uniqueTerm();
+    const project = await createProject({ name: `Pump station ${term}` });
+    const version = await createVersion({ projectId: project.id, name: "1.0", description: `Manuals for ${term}` });
+    const file = await createFile({ name: `${term}-wiring.pdf` });
+
+    const response = await request.get("/api/v1/search", { params: { q: term } });
+
+    expect(response.status()).toBe(200);
+
+    const responseBody = await response.json();
+    expect(responseBody.limit).toBe(100);
+    expect(responseBody.offset).toBe(0);
+    expect(responseBody.hits).toHaveLength(3);
+    expect(responseBody.hits).toContainEqual(expect.objectContaining({ type: "project", id: project.id, projectId: project.id }));
+    expect(responseBody.hits).toContainEqual(expect.objectContaining({ type: "version", id: version.id, projectId: project.id }));
+    expect(responseBody.hits).toContainEqual(expect.objectContaining({ type: "file", id: file.id, title: `${term}-wiring.pdf` }));
+  });
+
+  test("should highlight the matching words", async ({ createProject, request }) => {
+    const term = uniqueTerm();
+    await createProject({ name: `Pump station ${term}` });
+
+    const response = await request.get("/api/v1/search", { params: { q: term } });
+
+    expect(response.status()).toBe(200);
+    expect((await response.json()).hits[0].highlight).toContain(`<b>${term}</b>`);
+  });
+
+  test("should rank name matches above description matches", async ({ createProject, createVersion, request }) => {
+    const term = uniqueTerm();
+    const project = await createProject();
+    const described = await createVersion({ projectId: project.id, name: "1.0", description: term });
+    const named = await createVersion({ projectId: project.id, name: term });
+
+    const response = await request.get("/api/v1/search", { params: { q: term } });
+
+    expect(response.status()).toBe(200);
+    const ids = (await response.json()).hits.map((hit: { id: number }) => hit.id);
+    expect(ids).toEqual([named.id, described.id]);
+  });
+
+  test("should find files by the parts of their name", async ({ createFile, request }) => {
+    const term = uniqueTerm();
+    const file = await createFile({ name: `${term}_maintenance-manual.pdf` });
+
+    const response = await request.get("/api/v1/search", { params: { q: `${term} maintenance` } });
+
+    expect(response.status()).toBe(200);
+    expect((await response.json()).hits.map((hit: { id: number }) => hit.id)).toEqual([file.id]);
+  });
+
+  test("should reflect renamed projects", async ({ createProject, request }) => {
+    const term = uniqueTerm();
+    const project = await createProject({ name: "Old name" });
+    await request.put(`/api/v1/projects/${project.id}`, { data: { slug: project.slug, name: term, locationId: null } });
+
+    const response = await request.get("/api/v1/search", { params: { q: term } });
+
+    expect(response.status()).toBe(200);
+    expect((await response.json()).hits).toHaveLength(1);
+  });
+
+  test("should filter by type", async ({ createProject, createFile, request }) => {
+    const term = uniqueTerm();
+    await createProject({ name: term });
+    const file = await createFile({ name: `${term}.pdf` });
+
+    const response = await request.get("/api/v1/search", { params: { q: term, types: "file" } });
+
+    expect(response.status()).toBe(200);
+    const responseBody = await response.json();
+    expect(responseBody.hits).toHaveLength(1);
+    expect(responseBody.hits[0]).toMatchObject({ type: "file", id: file.id, projectId: null });
+  });
+
+  test("should paginate", async ({ createProject, request }) => {
+    const term = uniqueTerm();
+    for (let i = 0; i < 3; i++) {
+      await createProject({ name: term });
+    }
+
+    const response = await request.get("/api/v1/search", { params: { q: term, limit: 2, offset: 2 } });
+
+    expect(response.status()).toBe(200);
+    const responseBody = await response.json();
+    expect(responseBody.limit).toBe(2);
+    expect(responseBody.offset).toBe(2);
+    expect(responseBody.hits).toHaveLength(1);
+  });
+
+  test("should return 400 for a missing query", async ({ request }) => {
+    const response = await request.get("/api/v1/search");
+
+    expect(response.status()).toBe(400);
+  });
+
+  test("should return 400 for an unknown type", async ({ request }) => {
+    const response = await request.get("/api/v1/search", { params: { q: "manual", types: "location" } });
+
+    expect(response.status()).toBe(400);
+  });
+
+  test("should return 401 without a token", async ({ anonymousRequest }) => {
+    const response = await anonymousRequest.get("/api/v1/search", { params: { q: "manual" } });
+
+    expect(response.status()).toBe(401);
+  });
+
+  test.describe("Content search", () => {
+    // Text is extracted by a background job, which workers poll for.
+    const extractionTimeout = 20_000;
+
+    const searchContents = async (request: APIRequestContext, term: string) => {
+      const response = await request.get("/api/v1/search", { params: { q: term, mode: "content" } });
+      expect(response.status()).toBe(200);
+      return (await response.json()).hits;
+    };
+
+    test("should find a plain text file by a phrase in it", async ({ createFile, request }) => {
+      const term = uniqueTerm();
+      const file = await createFile({
+        name: "notes.txt",
+        mimeType: "text/plain",
+        buffer: Buffer.from(`Check the ${term} before every start.`),
+      });
+
+      await expect.poll(() => searchContents(request, `"the ${term}"`), { timeout: extractionTimeout }).toEqual([
+        expect.objectContaining({ type: "file", id: file.id, title: "notes.txt", projectId: null }),
+      ]);
+      const hits = await searchContents(request, term);
+      expect(hits[0].highlight).toContain(`<b>${term}</b>`);
+    });
+
+    test("should find a Markdown file", async ({ createFile, request }) => {
+      const term = uniqueTerm();
+      const file = await createFile({
+        name: "README.md",
+        mimeType: "text/markdown",
+        buffer: Buffer.from(`# Maintenance\n\n- Replace the **${term}** yearly\n`),
+      });
+
+      await expect.poll(async () => (await searchContents(request, term)).map((hit: { id: number }) => hit.id), {
+        timeout: extractionTimeout,
+      }).toEqual([file.id]);
+    });
+
+    test("should not match file names", async ({ createFile, request }) => {
+      const term = uniqueTerm();
+      await createFile({
+        name: `${term}.txt`,
+        mimeType: "text/plain",
+        buffer: Buffer.from("Nothing to see here"),
+      });
+
+      const response = await request.get("/api/v1/search", { params: { q: term } });
+      expect((await response.json()).hits).toHaveLength(1);
+      expect(await searchContents(request, term)).toHaveLength(0);
+    });
+
+    test("should return 400 for an unknown mode", async ({ request }) => {
+      const response = await request.get("/api/v1/search", { params: { q: "manual", mode: "everything" } });
+
+      expect(response.status()).toBe(400);
+    });
+  });
+});
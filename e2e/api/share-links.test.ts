@@ -0,0 +1,202 @@
+import { expect, test } from "../src/fixtures";
+
+const inOneHour = () => new Date(Date.now() + 60 * 60 * 1000).toISOString();
+
+const createShareLink = async (request, data) => {
+  const response = await request.post("/api/v1/share-links", { data: { expiresAt: inOneHour(), ...data } });
+
+  expect(response.status()).toBe(201);
+
+  return await response.json();
+};
+
+const toPath = (url: string) => new URL(url).pathname;
+
+test.describe("Share links", () => {
+  test.describe("Create share link", () => {
+    test("should return 201 for a file", async ({ createFile, request }) => {
+      const file = await createFile({ mimeType: "text/plain", buffer: Buffer.from("Hello, world!") });
+
+      const response = await request.post("/api/v1/share-links", {
+        data: {
+          fileId: file.id,
+          expiresAt: inOneHour(),
+          maxDownloads: 2,
+        },
+      });
+
+      expect(response.status()).toBe(201);
+
+      const responseBody = await response.json();
+      expect(responseBody.url).toMatch(new RegExp(`/s/${responseBody.id}\\.\\d+\\.[\\w-]+$`));
+      expect(responseBody.fileId).toBe(file.id);
+      expect(responseBody.versionId).toBeNull();
+      expect(responseBody.maxDownloads).toBe(2);
+      expect(responseBody.downloadCount).toBe(0);
+      expect(responseBody.hasPassword).toBe(false);
+      expect(responseBody.revokedAt).toBeNull();
+    });
+
+    test("should return 400 for an expiry in the past", async ({ createFile, request }) => {
+      const file = await createFile({ mimeType: "text/plain", buffer: Buffer.from("Hello, world!") });
+
+      const response = await request.post("/api/v1/share-links", {
+        data: {
+          fileId: file.id,
+          expiresAt: new Date(Date.now() - 1000).toISOString(),
+        },
+      });
+
+      expect(response.status()).toBe(400);
+    });
+
+    test("should return 400 for both a file and a version", async ({ createProject, createVersion, createFile, request }) => {
+      const project = await createProject();
+      const version = await createVersion({ projectId: project.id });
+      const file = await createFile({ mimeType: "text/plain", buffer: Buffer.from("Hello, world!") });
+
+      const response = await request.post("/api/v1/share-links", {
+        data: {
+          fileId: file.id,
+          versionId: version.id,
+          expiresAt: inOneHour(),
+        },
+      });
+
+      expect(response.status()).toBe(400);
+    });
+  });
+
+  test.describe("Open share link", () => {
+    test("should download a shared file without a token", async ({ createFile, request, anonymousRequest }) => {
+      const file = await createFile({ mimeType: "text/plain", buffer: Buffer.from("Hello, world!") });
+      const shareLink = await createShareLink(request, { fileId: file.id });
+
+      const response = await anonymousRequest.get(toPath(shareLink.url));
+
+      expect(response.status()).toBe(200);
+      expect(response.headers()["cache-control"]).toBe("no-store");
+      await expect(response.text()).resolves.toBe("Hello, world!");
+    });
+
+    test("should return 404 for a forged signature", async ({ createFile, request, anonymousRequest }) => {
+      const file = await createFile({ mimeType: "text/plain", buffer: Buffer.from("Hello, world!") });
+      const shareLink = await createShareLink(request, { fileId: file.id });
+
+      const response = await anonymousRequest.get(`${toPath(shareLink.url)}x`);
+
+      expect(response.status()).toBe(404);
+    });
+
+    test("should return 410 once the download limit is reached", async ({ createFile, request, anonymousRequest }) => {
+      const file = await createFile({ mimeType: "text/plain", buffer: Buffer.from("Hello, world!") });
+      const shareLink = await createShareLink(request, { fileId: file.id, maxDownloads: 1 });
+
+      const first = await anonymousRequest.get(toPath(shareLink.url));
+      const second = await anonymousRequest.get(toPath(shareLink.url));
+
+      expect(first.status()).toBe(200);
+      expect(second.status()).toBe(410);
+    });
+
+    test("should not count HEAD requests", async ({ createFile, request, anonymousRequest }) => {
+      const file = await createFile({ mimeType: "text/plain", buffer: Buffer.from("Hello, world!") });
+      const shareLink = await createShareLink(request, { fileId: file.id, maxDownloads: 1 });
+
+      const headResponse = await anonymousRequest.head(toPath(shareLink.url));
+      expect(headResponse.status()).toBe(200);
+
+      const first = await anonymousRequest.get(toPath(shareLink.url));
+      expect(first.status()).toBe(200);
+
+      const second = await anonymousRequest.get(toPath(shareLink.url));
+      expect(second.status()).toBe(410);
+    });
+
+    test("should count range requests", async ({ createFile, request, anonymousRequest }) => {
+      const file = await createFile({ mimeType: "text/plain", buffer: Buffer.from("Hello, world!") });
+      const shareLink = await createShareLink(request, { fileId: file.id, maxDownloads: 1 });
+
+      const suffixResponse = await anonymousRequest.get(toPath(shareLink.url), { headers: { "Range": "bytes=-999999999" } });
+      expect(suffixResponse.ok()).toBe(true);
+
+      const rangeResponse = await anonymousRequest.get(toPath(shareLink.url), { headers: { "Range": "bytes=1-" } });
+      expect(rangeResponse.status()).toBe(410);
+    });
+
+    test("should return 410 after revoking", async ({ createFile, request, anonymousRequest }) => {
+      const file = await createFile({ mimeType: "text/plain", buffer: Buffer.from("Hello, world!") });
+      const shareLink = await createShareLink(request, { fileId: file.id });
+
+      const revokeResponse = await request.post(`/api/v1/share-links/${shareLink.id}/revoke`);
+      expect(revokeResponse.status()).toBe(200);
+
+      const response = await anonymousRequest.get(toPath(shareLink.url));
+
+      expect(response.status()).toBe(410);
+    });
+
+    test("should ask for the password", async ({ createFile, request, anonymousRequest }) => {
+      const file = await createFile({ mimeType: "text/plain", buffer: Buffer.from("Hello, world!") });
+      const shareLink = await createShareLink(request, { fileId: file.id, password: "s3cret" });
+
+      const withoutPassword = await anonymousRequest.get(toPath(shareLink.url));
+      expect(withoutPassword.status()).toBe(401);
+      expect(withoutPassword.headers()["www-authenticate"]).toContain("Basic");
+
+      const wrongPassword = await anonymousRequest.get(toPath(shareLink.url), {
+        headers: { "Authorization": `Basic ${Buffer.from("guest:wrong").toString("base64")}` }
+      });
+      expect(wrongPassword.status()).toBe(401);
+
+      const response = await anonymousRequest.get(toPath(shareLink.url), {
+        headers: { "Authorization": `Basic ${Buffer.from("guest:s3cret").toString("base64")}` }
+      });
+      expect(response.status()).toBe(200);
+    });
+
+    test("should list and download the files of a shared version", async ({ createProject, createVersion, createFile, request, anonymousRequest }) => {
+      const project = await createProject();
+      const version = await createVersion({ projectId: project.id });
+      const file = await createFile({ mimeType: "text/plain", buffer: Buffer.from("Hello, world!") });
+      const otherFile = await createFile({ mimeType: "text/plain", buffer: Buffer.from("Other") });
+      await request.patch(`/api/v1/versions/${version.id}/attach-file`, { data: { fileId: file.id } });
+      const shareLink = await createShareLink(request, { versionId: version.id });
+
+      const listResponse = await anonymousRequest.get(toPath(shareLink.url));
+      expect(listResponse.status()).toBe(200);
+
+      const listResponseBody = await listResponse.json();
+      expect(listResponseBody.versionId).toBe(version.id);
+      expect(listResponseBody.files.map((f) => f.id)).toEqual([file.id]);
+
+      const downloadResponse = await anonymousRequest.get(toPath(listResponseBody.files[0].url));
+      expect(downloadResponse.status()).toBe(200);
+      await expect(downloadResponse.text()).resolves.toBe("Hello, world!");
+
+      const otherResponse = await anonymousRequest.get(`${toPath(shareLink.url)}/files/${otherFile.id}`);
+      expect(otherResponse.status()).toBe(404);
+    });
+  });
+
+  test.describe("Revoke share link", () => {
+    test.skip(!process.env.KEYCLOAK_OTHER_USERNAME, "KEYCLOAK_OTHER_USERNAME isn't set");
+
+    test("should only let editors revoke links of others", async ({ createProject, createVersion, handOverProject, otherRequest, request }) => {
+      const project = await createProject();
+      const version = await createVersion({ projectId: project.id });
+      const otherMeResponse = await otherRequest.get("/api/v1/users/me");
+      const { id: otherUserId } = await otherMeResponse.json();
+      await request.post(`/api/v1/projects/${project.id}/members`, { data: { userId: otherUserId, role: "editor" } });
+      const shareLink = await createShareLink(otherRequest, { versionId: version.id });
+
+      await handOverProject(project.id, "viewer");
+
+      const getResponse = await request.get(`/api/v1/share-links/${shareLink.id}`);
+      expect(getResponse.status()).toBe(200);
+
+      const revokeResponse = await request.post(`/api/v1/share-links/${shareLink.id}/revoke`);
+      expect(revokeResponse.status()).toBe(403);
+    });
+  });
+});
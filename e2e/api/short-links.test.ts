@@ -0,0 +1,248 @@
+import { expect, test } from "../src/fixtures";
+
+test.describe("Short links", () => {
+  test.describe("Create short link", () => {
+    test("should return 201 for a project", async ({ createProject, request }) => {
+      const project = await createProject();
+
+      const response = await request.post("/api/v1/short-links", {
+        data: {
+          targetType: "project",
+          projectId: project.id,
+        },
+      });
+
+      expect(response.status()).toBe(201);
+
+      const responseBody = await response.json();
+      expect(responseBody.code).toMatch(/^[0-9a-z]{10}$/);
+      expect(responseBody.url).toMatch(new RegExp(`/q/${responseBody.code}$`));
+      expect(responseBody.targetType).toBe("project");
+      expect(responseBody.projectId).toBe(project.id);
+      expect(responseBody.versionId).toBeNull();
+      expect(responseBody.revokedAt).toBeNull();
+      expect(responseBody.hitCount).toBe(0);
+    });
+
+    test("should issue distinct codes", async ({ createProject, request }) => {
+      const project = await createProject();
+
+      const first = await request.post("/api/v1/short-links", {
+        data: { targetType: "project", projectId: project.id },
+      });
+      const second = await request.post("/api/v1/short-links", {
+        data: { targetType: "project", projectId: project.id },
+      });
+
+      expect((await first.json()).code).not.toBe((await second.json()).code);
+    });
+
+    test("should return 400 for missing target ID", async ({ request }) => {
+      const response = await request.post("/api/v1/short-links", {
+        data: {
+          targetType: "version",
+        },
+      });
+
+      expect(response.status()).toBe(400);
+    });
+
+    test("should return 400 for invalid target type", async ({ createProject, request }) => {
+      const project = await createProject();
+
+      const response = await request.post("/api/v1/short-links", {
+        data: {
+          targetType: "file",
+          projectId: project.id,
+        },
+      });
+
+      expect(response.status()).toBe(400);
+    });
+
+    test("should return 403 for non-existing project", async ({ request }) => {
+      const response = await request.post("/api/v1/short-links", {
+        data: {
+          targetType: "project",
+          projectId: 2147483647,
+        },
+      });
+
+      expect(response.status()).toBe(403);
+    });
+
+    test("should return 403 for viewers", async ({ createProject, handOverProject, request }) => {
+      const project = await createProject();
+      await handOverProject(project.id, "viewer");
+
+      const response = await request.post("/api/v1/short-links", {
+        data: { targetType: "project", projectId: project.id },
+      });
+
+      expect(response.status()).toBe(403);
+    });
+  });
+
+  test.describe("Access", () => {
+    test("should let viewers read but not change short links", async ({ createProject, handOverProject, request }) => {
+      const project = await createProject();
+      const otherProject = await createProject();
+      const createResponse = await request.post("/api/v1/short-links", {
+        data: { targetType: "project", projectId: project.id },
+      });
+      const shortLink = await createResponse.json();
+      await handOverProject(project.id, "viewer");
+
+      const getResponse = await request.get(`/api/v1/short-links/${shortLink.id}`);
+      expect(getResponse.status()).toBe(200);
+
+      const updateResponse = await request.put(`/api/v1/short-links/${shortLink.id}`, {
+        data: { targetType: "project", projectId: otherProject.id },
+      });
+      expect(updateResponse.status()).toBe(403);
+
+      const revokeResponse = await request.post(`/api/v1/short-links/${shortLink.id}/revoke`);
+      expect(revokeResponse.status()).toBe(403);
+    });
+
+    test("should hide short links of projects the user left", async ({ createProject, createVersion, handOverProject, request }) => {
+      const project = await createProject();
+      const version = await createVersion({ projectId: project.id });
+      const projectResponse = await request.post("/api/v1/short-links", {
+        data: { targetType: "project", projectId: project.id },
+      });
+      const projectShortLink = await projectResponse.json();
+      const versionResponse = await request.post("/api/v1/short-links", {
+        data: { targetType: "version", versionId: version.id },
+      });
+      const versionShortLink = await versionResponse.json();
+      await handOverProject(project.id);
+
+      const getResponse = await request.get(`/api/v1/short-links/${versionShortLink.id}`);
+      expect(getResponse.status()).toBe(403);
+
+      const listResponse = await request.get("/api/v1/short-links", { params: { limit: 100 } });
+      const listResponseBody = await listResponse.json();
+      const ids = listResponseBody.shortLinks.map((s) => s.id);
+      expect(ids).not.toContain(projectShortLink.id);
+      expect(ids).not.toContain(versionShortLink.id);
+    });
+  });
+
+  test.describe("Resolve short link", () => {
+    test("should redirect to the project and count the hit", async ({ createProject, request }) => {
+      const project = await createProject();
+      const createResponse = await request.post("/api/v1/short-links", {
+        data: { targetType: "project", projectId: project.id },
+      });
+      const shortLink = await createResponse.json();
+
+      const response = await request.get(`/q/${shortLink.code}`, { maxRedirects: 0 });
+
+      expect(response.status()).toBe(302);
+      expect(response.headers()["location"]).toMatch(new RegExp(`/projects/${project.id}$`));
+      expect(response.headers()["cache-control"]).toBe("no-store");
+
+      const getResponse = await request.get(`/api/v1/short-links/${shortLink.id}`);
+      const responseBody = await getResponse.json();
+      expect(responseBody.hitCount).toBe(1);
+      expect(responseBody.lastHitAt).not.toBeNull();
+    });
+
+    test("should redirect to the latest released version of a project", async ({ createProject, createVersion, releaseVersion, request }) => {
+      const project = await createProject();
+      const first = await createVersion({ projectId: project.id, name: "1.0" });
+      await releaseVersion(first.id);
+      const createResponse = await request.post("/api/v1/short-links", {
+        data: { targetType: "latest_version", projectId: project.id },
+      });
+      const shortLink = await createResponse.json();
+      const latest = await createVersion({ projectId: project.id, name: "2.0" });
+      await releaseVersion(latest.id);
+      await createVersion({ projectId: project.id, name: "3.0" });
+
+      const response = await request.get(`/q/${shortLink.code}`, { maxRedirects: 0 });
+
+      expect(response.status()).toBe(302);
+      expect(response.headers()["location"]).toMatch(new RegExp(`/versions/${latest.id}$`));
+    });
+
+    test("should follow a re-pointed short link", async ({ createProject, createVersion, request }) => {
+      const project = await createProject();
+      const version = await createVersion({ projectId: project.id });
+      const createResponse = await request.post("/api/v1/short-links", {
+        data: { targetType: "project", projectId: project.id },
+      });
+      const shortLink = await createResponse.json();
+
+      const updateResponse = await request.put(`/api/v1/short-links/${shortLink.id}`, {
+        data: { targetType: "version", versionId: version.id },
+      });
+
+      expect(updateResponse.status()).toBe(200);
+      expect((await updateResponse.json()).code).toBe(shortLink.code);
+
+      const response = await request.get(`/q/${shortLink.code}`, { maxRedirects: 0 });
+
+      expect(response.status()).toBe(302);
+      expect(response.headers()["location"]).toMatch(new RegExp(`/versions/${version.id}$`));
+    });
+
+    test("should return 410 for a revoked short link", async ({ createProject, request }) => {
+      const project = await createProject();
+      const createResponse = await request.post("/api/v1/short-links", {
+        data: { targetType: "project", projectId: project.id },
+      });
+      const shortLink = await createResponse.json();
+
+      const revokeResponse = await request.post(`/api/v1/short-links/${shortLink.id}/revoke`);
+
+      expect(revokeResponse.status()).toBe(200);
+      expect((await revokeResponse.json()).revokedAt).not.toBeNull();
+
+      const response = await request.get(`/q/${shortLink.code}`, { maxRedirects: 0 });
+
+      expect(response.status()).toBe(410);
+    });
+
+    test("should return 404 when the target was deleted", async ({ createProject, request }) => {
+      const project = await createProject();
+      const createResponse = await request.post("/api/v1/short-links", {
+        data: { targetType: "project", projectId: project.id },
+      });
+      const shortLink = await createResponse.json();
+      await request.delete(`/api/v1/projects/${project.id}`);
+
+      const response = await request.get(`/q/${shortLink.code}`, { maxRedirects: 0 });
+
+      expect(response.status()).toBe(404);
+    });
+
+    test("should return 404 for unknown code", async ({ request }) => {
+      const response = await request.get(`/q/unknown`, { maxRedirects: 0 });
+
+      expect(response.status()).toBe(404);
+    });
+  });
+
+  test.describe("Short link QR code", () => {
+    test("should return 200", async ({ createProject, request }) => {
+      const project = await createProject();
+      const createResponse = await request.post("/api/v1/short-links", {
+        data: { targetType: "project", projectId: project.id },
+      });
+      const shortLink = await createResponse.json();
+
+      const response = await request.get(`/api/v1/short-links/${shortLink.id}/qr?format=svg&label=${shortLink.code}`);
+
+      expect(response.status()).toBe(200);
+      expect(response.headers()["content-type"]).toBe("image/svg+xml");
+    });
+
+    test("should return 404 for non-existing short link", async ({ request }) => {
+      const response = await request.get(`/api/v1/short-links/-1/qr`);
+
+      expect(response.status()).toBe(404);
+    });
+  });
+});
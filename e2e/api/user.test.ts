@@ -4,14 +4,30 @@ import * as uuid from "uuid";
 test.describe("Users", () => {
   test.describe("Me", () => {
     test.describe("Information", () => {
-      test("should return 404 for unknown user", async ({ request, defaultToken }) => {
+      test("should return 200 for provisioned user", async ({ request, defaultToken }) => {
         const response = await request.get("/api/v1/users/me", {
           headers: {
             "Authorization": `Bearer ${defaultToken}`
           }
         });
 
-        expect(response.status()).toBe(404);
+        expect(response.status()).toBe(200);
+      });
+
+      test("should return the same user for repeated requests", async ({ request, defaultToken }) => {
+        const jwtPayload = JSON.parse(Buffer.from(defaultToken.split(".")[1], "base64url").toString());
+
+        const first = await request.get("/api/v1/users/me");
+        const second = await request.get("/api/v1/users/me");
+
+        expect(first.status()).toBe(200);
+        expect(second.status()).toBe(200);
+
+        const firstBody = await first.json();
+        const secondBody = await second.json();
+        expect(secondBody.id).toBe(firstBody.id);
+        expect(firstBody.email).toBe(jwtPayload.email);
+        expect(firstBody.name).toBe(jwtPayload.name);
       });
     });
   });
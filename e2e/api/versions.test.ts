@@ -1,4 +1,50 @@
+import { APIRequestContext } from "@playwright/test";
 import { expect, test } from "../src/fixtures";
+import { crc32, gunzipSync } from "node:zlib";
+
+// buildZip writes an uncompressed ZIP archive, enough to exercise imports
+// without pulling in an archive library.
+const buildZip = (entries: { name: string; data: Buffer }[]): Buffer => {
+  const locals: Buffer[] = [];
+  const centrals: Buffer[] = [];
+  let offset = 0;
+  for (const entry of entries) {
+    const name = Buffer.from(entry.name);
+    const crc = crc32(entry.data);
+
+    const local = Buffer.alloc(30);
+    local.writeUInt32LE(0x04034b50, 0);
+    local.writeUInt16LE(20, 4);
+    local.writeUInt32LE(crc, 14);
+    local.writeUInt32LE(entry.data.length, 18);
+    local.writeUInt32LE(entry.data.length, 22);
+    local.writeUInt16LE(name.length, 26);
+    locals.push(local, name, entry.data);
+
+    const central = Buffer.alloc(46);
+    central.writeUInt32LE(0x02014b50, 0);
+    central.writeUInt16LE(20, 4);
+    central.writeUInt16LE(20, 6);
+    central.writeUInt32LE(crc, 16);
+    central.writeUInt32LE(entry.data.length, 20);
+    central.writeUInt32LE(entry.data.length, 24);
+    central.writeUInt16LE(name.length, 28);
+    central.writeUInt32LE(offset, 42);
+    centrals.push(central, name);
+
+    offset += local.length + name.length + entry.data.length;
+  }
+
+  const centralSize = centrals.reduce((size, b) => size + b.length, 0);
+  const end = Buffer.alloc(22);
+  end.writeUInt32LE(0x06054b50, 0);
+  end.writeUInt16LE(entries.length, 8);
+  end.writeUInt16LE(entries.length, 10);
+  end.writeUInt32LE(centralSize, 12);
+  end.writeUInt32LE(offset, 16);
+
+  return Buffer.concat([...locals, ...centrals, end]);
+};
 import { CreateProjectResult } from "../src/fixtures/project";
 
 test.describe("Versions", () => {
@@ -354,4 +400,389 @@ test.describe("Versions", () => {
       expect(response.status()).toBe(400);
     });
   });
+
+  test.describe("Version status", () => {
+    const updateStatus = (request: APIRequestContext, versionId: number, status: string) =>
+      request.put(`/api/v1/versions/${versionId}/status`, { data: { status } });
+
+    test("should create versions as draft", async ({ createVersion }) => {
+      const version = await createVersion({ projectId: project.id });
+
+      expect(version.status).toBe("draft");
+      expect(version.releasedAt).toBeNull();
+    });
+
+    test("should release a reviewed version", async ({ createVersion, request }) => {
+      const version = await createVersion({ projectId: project.id });
+
+      expect((await updateStatus(request, version.id, "in_review")).status()).toBe(200);
+      const response = await updateStatus(request, version.id, "released");
+
+      expect(response.status()).toBe(200);
+      const body = await response.json();
+      expect(body.status).toBe("released");
+      expect(body.releasedAt).not.toBeNull();
+    });
+
+    test("should return 409 for a transition that isn't allowed", async ({ createVersion, request }) => {
+      const version = await createVersion({ projectId: project.id });
+
+      const response = await updateStatus(request, version.id, "released");
+
+      expect(response.status()).toBe(409);
+    });
+
+    test("should return 400 for an unknown status", async ({ createVersion, request }) => {
+      const version = await createVersion({ projectId: project.id });
+
+      const response = await updateStatus(request, version.id, "archived");
+
+      expect(response.status()).toBe(400);
+    });
+
+    test("should keep released versions immutable", async ({ createVersion, createFile, request }) => {
+      const version = await createVersion({ projectId: project.id });
+      const file = await createFile({
+        name: "example.txt",
+        mimeType: "text/plain",
+        buffer: Buffer.from("Hello, world!")
+      });
+      await request.patch(`/api/v1/versions/${version.id}/attach-file`, { data: { fileId: file.id } });
+      await updateStatus(request, version.id, "in_review");
+      expect((await updateStatus(request, version.id, "released")).status()).toBe(200);
+
+      const updateResponse = await request.put(`/api/v1/versions/${version.id}`, {
+        data: { name: "Updated version name" },
+      });
+      expect(updateResponse.status()).toBe(409);
+
+      const detachResponse = await request.patch(`/api/v1/versions/${version.id}/detach-file`, {
+        data: { fileId: file.id },
+      });
+      expect(detachResponse.status()).toBe(409);
+
+      const deleteResponse = await request.delete(`/api/v1/versions/${version.id}`);
+      expect(deleteResponse.status()).toBe(409);
+
+      const deleteFileResponse = await request.delete(`/api/v1/files/${file.id}`);
+      expect(deleteFileResponse.status()).toBe(409);
+    });
+
+    test("should filter versions by status", async ({ createVersion, request }) => {
+      const draft = await createVersion({ projectId: project.id });
+      const reviewed = await createVersion({ projectId: project.id, name: "Reviewed version" });
+      await updateStatus(request, reviewed.id, "in_review");
+
+      const response = await request.get("/api/v1/versions", {
+        params: { projectId: project.id, status: "in_review" },
+      });
+
+      expect(response.status()).toBe(200);
+      const ids = (await response.json()).versions.map((v: { id: number }) => v.id);
+      expect(ids).toContain(reviewed.id);
+      expect(ids).not.toContain(draft.id);
+    });
+  });
+
+  test.describe("Version archive", () => {
+    test("should stream a ZIP with a manifest", async ({ createVersion, createFile, request }) => {
+      const version = await createVersion({ projectId: project.id });
+      for (const content of ["first", "second"]) {
+        const file = await createFile({ name: "manual.txt", buffer: Buffer.from(content) });
+        await request.patch(`/api/v1/versions/${version.id}/attach-file`, { data: { fileId: file.id } });
+      }
+
+      const response = await request.get(`/api/v1/versions/${version.id}/archive`);
+
+      expect(response.status()).toBe(200);
+      expect(response.headers()["content-type"]).toBe("application/zip");
+      const body = await response.body();
+      expect(body.subarray(0, 4)).toEqual(Buffer.from([0x50, 0x4b, 0x03, 0x04]));
+      expect(body.includes("manifest.json")).toBe(true);
+      expect(body.includes("manual.txt")).toBe(true);
+      expect(body.includes("manual (2).txt")).toBe(true);
+    });
+
+    test("should stream a tar.gz", async ({ createVersion, createFile, request }) => {
+      const version = await createVersion({ projectId: project.id });
+      const file = await createFile({ name: "manual.txt", buffer: Buffer.from("manual") });
+      await request.patch(`/api/v1/versions/${version.id}/attach-file`, { data: { fileId: file.id } });
+
+      const response = await request.get(`/api/v1/versions/${version.id}/archive`, {
+        params: { format: "tar.gz" },
+      });
+
+      expect(response.status()).toBe(200);
+      const tar = gunzipSync(await response.body());
+      expect(tar.includes("manifest.json")).toBe(true);
+      expect(tar.includes("manual.txt")).toBe(true);
+    });
+
+    test("should return 404 for non-existing version", async ({ request }) => {
+      const response = await request.get(`/api/v1/versions/-1/archive`);
+
+      expect(response.status()).toBe(404);
+    });
+  });
+
+  test.describe("Import archive", () => {
+    const importArchive = (request: APIRequestContext, versionId: number, buffer: Buffer) =>
+      request.post(`/api/v1/versions/${versionId}/import`, {
+        multipart: {
+          file: { name: "pack.zip", mimeType: "application/zip", buffer },
+        },
+      });
+
+    test("should import each entry and report failures", async ({ createVersion, request }) => {
+      const version = await createVersion({ projectId: project.id });
+      const archive = buildZip([
+        { name: "manuals/wiring.txt", data: Buffer.from("wiring") },
+        { name: "readme.txt", data: Buffer.from("readme") },
+        { name: "../escape.txt", data: Buffer.from("escape") },
+      ]);
+
+      const response = await importArchive(request, version.id, archive);
+
+      expect(response.status()).toBe(200);
+      const body = await response.json();
+      expect(body.imported).toBe(2);
+      expect(body.failed).toBe(1);
+      expect(body.entries[2].status).toBe("failed");
+
+      const filesResponse = await request.get("/api/v1/files", { params: { versionId: version.id } });
+      const names = (await filesResponse.json()).files.map((f: { name: string }) => f.name).sort();
+      expect(names).toEqual(["readme.txt", "wiring.txt"]);
+    });
+
+    test("should return 400 for something that isn't a ZIP", async ({ createVersion, request }) => {
+      const version = await createVersion({ projectId: project.id });
+
+      const response = await importArchive(request, version.id, Buffer.from("not a zip"));
+
+      expect(response.status()).toBe(400);
+    });
+
+    test("should return 409 for a released version", async ({ createVersion, releaseVersion, request }) => {
+      const version = await createVersion({ projectId: project.id });
+      await releaseVersion(version.id);
+
+      const response = await importArchive(request, version.id, buildZip([{ name: "a.txt", data: Buffer.from("a") }]));
+
+      expect(response.status()).toBe(409);
+    });
+  });
+
+  test.describe("Clone version", () => {
+    test("should return 201 with the files attached", async ({ createVersion, createFile, releaseVersion, request }) => {
+      const source = await createVersion({ projectId: project.id, name: "1.0", description: "First revision" });
+      const file = await createFile({ name: "manual.txt", mimeType: "text/plain", buffer: Buffer.from("manual") });
+      await request.patch(`/api/v1/versions/${source.id}/attach-file`, { data: { fileId: file.id } });
+      await releaseVersion(source.id);
+
+      const response = await request.post(`/api/v1/versions/${source.id}/clone`, {
+        data: { name: "2.0" },
+      });
+
+      expect(response.status()).toBe(201);
+      const clone = await response.json();
+      expect(clone.projectId).toBe(project.id);
+      expect(clone.status).toBe("draft");
+      expect(clone.description).toBe("First revision");
+
+      const filesResponse = await request.get("/api/v1/files", { params: { versionId: clone.id } });
+      const files = (await filesResponse.json()).files;
+      expect(files.map((f: { id: number }) => f.id)).toEqual([file.id]);
+    });
+
+    test("should keep the revisions pinned in the source version", async ({ createVersion, createFile, releaseVersion, request }) => {
+      const source = await createVersion({ projectId: project.id, name: "1.0" });
+      const file = await createFile({ name: "manual.txt", mimeType: "text/plain", buffer: Buffer.from("manual v1") });
+      await request.patch(`/api/v1/versions/${source.id}/attach-file`, { data: { fileId: file.id } });
+      await releaseVersion(source.id);
+      await request.post(`/api/v1/files/${file.id}/upload`, {
+        multipart: { file: { name: "manual.txt", mimeType: "text/plain", buffer: Buffer.from("manual v2") } },
+      });
+
+      const response = await request.post(`/api/v1/versions/${source.id}/clone`, {
+        data: { name: "2.0" },
+      });
+      const clone = await response.json();
+
+      const compareResponse = await request.get(`/api/v1/versions/${source.id}/compare/${clone.id}`);
+      const body = await compareResponse.json();
+      expect(body.summary).toEqual({ added: 0, removed: 0, renamed: 0, modified: 0, unchanged: 1 });
+    });
+
+    test("should not copy the description when asked not to", async ({ createVersion, request }) => {
+      const source = await createVersion({ projectId: project.id, name: "1.0" });
+
+      const response = await request.post(`/api/v1/versions/${source.id}/clone`, {
+        data: { name: "2.0", copyDescription: false },
+      });
+
+      expect(response.status()).toBe(201);
+      expect((await response.json()).description).toBeNull();
+    });
+
+    test("should return 404 for non-existing version", async ({ request }) => {
+      const response = await request.post(`/api/v1/versions/-1/clone`, {
+        data: { name: "2.0" },
+      });
+
+      expect(response.status()).toBe(404);
+    });
+  });
+
+  test.describe("Compare versions", () => {
+    test("should report added, removed, renamed and modified files", async ({ createVersion, createFile, request }) => {
+      const base = await createVersion({ projectId: project.id, name: "1.0" });
+      const target = await createVersion({ projectId: project.id, name: "2.0" });
+      const attach = (versionId: number, fileId: number) =>
+        request.patch(`/api/v1/versions/${versionId}/attach-file`, { data: { fileId } });
+
+      const shared = await createFile({ name: "shared.txt", mimeType: "text/plain", buffer: Buffer.from("shared") });
+      const removed = await createFile({ name: "removed.txt", mimeType: "text/plain", buffer: Buffer.from("removed") });
+      const beforeRename = await createFile({ name: "before.txt", mimeType: "text/plain", buffer: Buffer.from("renamed") });
+      const afterRename = await createFile({ name: "after.txt", mimeType: "text/plain", buffer: Buffer.from("renamed") });
+      const manualV1 = await createFile({ name: "manual.txt", mimeType: "text/plain", buffer: Buffer.from("manual v1") });
+      const manualV2 = await createFile({ name: "manual.txt", mimeType: "text/plain", buffer: Buffer.from("manual v2") });
+      const added = await createFile({ name: "added.txt", mimeType: "text/plain", buffer: Buffer.from("added") });
+      for (const file of [shared, removed, beforeRename, manualV1]) {
+        await attach(base.id, file.id);
+      }
+      for (const file of [shared, afterRename, manualV2, added]) {
+        await attach(target.id, file.id);
+      }
+
+      const response = await request.get(`/api/v1/versions/${base.id}/compare/${target.id}`);
+
+      expect(response.status()).toBe(200);
+      const body = await response.json();
+      expect(body.summary).toEqual({ added: 1, removed: 1, renamed: 1, modified: 1, unchanged: 1 });
+      expect(body.added[0].id).toBe(added.id);
+      expect(body.removed[0].id).toBe(removed.id);
+      expect(body.renamed[0].from.id).toBe(beforeRename.id);
+      expect(body.renamed[0].to.id).toBe(afterRename.id);
+      expect(body.modified[0].to.id).toBe(manualV2.id);
+
+      const textResponse = await request.get(`/api/v1/versions/${base.id}/compare/${target.id}`, {
+        params: { format: "text" },
+      });
+
+      expect(textResponse.status()).toBe(200);
+      expect(textResponse.headers()["content-type"]).toContain("text/plain");
+      expect(await textResponse.text()).toContain("before.txt -> after.txt");
+    });
+
+    test("should report a new revision of a file in both versions as modified", async ({ createVersion, createFile, releaseVersion, request }) => {
+      const base = await createVersion({ projectId: project.id, name: "1.0" });
+      const file = await createFile({ name: "manual.txt", mimeType: "text/plain", buffer: Buffer.from("manual v1") });
+      await request.patch(`/api/v1/versions/${base.id}/attach-file`, { data: { fileId: file.id } });
+      await releaseVersion(base.id);
+      const uploadResponse = await request.post(`/api/v1/files/${file.id}/upload`, {
+        multipart: { file: { name: "manual.txt", mimeType: "text/plain", buffer: Buffer.from("manual v2") } },
+      });
+      const revised = await uploadResponse.json();
+      const target = await createVersion({ projectId: project.id, name: "2.0" });
+      await request.patch(`/api/v1/versions/${target.id}/attach-file`, { data: { fileId: file.id } });
+
+      const response = await request.get(`/api/v1/versions/${base.id}/compare/${target.id}`);
+
+      expect(response.status()).toBe(200);
+      const body = await response.json();
+      expect(body.summary).toEqual({ added: 0, removed: 0, renamed: 0, modified: 1, unchanged: 0 });
+      expect(body.modified[0].from.revisionId).toBe(file.revisionId);
+      expect(body.modified[0].to.revisionId).toBe(revised.revisionId);
+    });
+
+    test("should return 404 for non-existing version", async ({ createVersion, request }) => {
+      const version = await createVersion({ projectId: project.id });
+
+      const response = await request.get(`/api/v1/versions/${version.id}/compare/-1`);
+
+      expect(response.status()).toBe(404);
+    });
+  });
+
+  test.describe("Latest version", () => {
+    test("should return 404 when no version is released", async ({ createVersion, request }) => {
+      await createVersion({ projectId: project.id });
+
+      const response = await request.get(`/api/v1/projects/${project.id}/versions/latest`);
+
+      expect(response.status()).toBe(404);
+    });
+
+    test("should return the most recently released version", async ({ createVersion, releaseVersion, request }) => {
+      const older = await createVersion({ projectId: project.id, name: "2.0.0" });
+      const newer = await createVersion({ projectId: project.id, name: "1.1.0" });
+      await releaseVersion(older.id);
+      await releaseVersion(newer.id);
+      await createVersion({ projectId: project.id, name: "3.0.0" });
+
+      const response = await request.get(`/api/v1/projects/${project.id}/versions/latest`);
+
+      expect(response.status()).toBe(200);
+      expect((await response.json()).id).toBe(newer.id);
+    });
+
+    test("should return the highest semantic version", async ({ createVersion, releaseVersion, request }) => {
+      const highest = await createVersion({ projectId: project.id, name: "v2.0.0" });
+      const lower = await createVersion({ projectId: project.id, name: "1.10.0" });
+      await releaseVersion(highest.id);
+      await releaseVersion(lower.id);
+
+      const response = await request.get(`/api/v1/projects/${project.id}/versions/latest`, {
+        params: { strategy: "semver" },
+      });
+
+      expect(response.status()).toBe(200);
+      expect((await response.json()).id).toBe(highest.id);
+    });
+
+    test("should return the pinned version", async ({ createVersion, releaseVersion, request }) => {
+      const pinned = await createVersion({ projectId: project.id, name: "1.0.0" });
+      const newer = await createVersion({ projectId: project.id, name: "2.0.0" });
+      await releaseVersion(pinned.id);
+      await releaseVersion(newer.id);
+
+      const policyResponse = await request.put(`/api/v1/projects/${project.id}/latest-version-policy`, {
+        data: { strategy: "pinned", pinnedVersionId: pinned.id },
+      });
+      expect(policyResponse.status()).toBe(200);
+
+      const response = await request.get(`/api/v1/projects/${project.id}/versions/latest`);
+
+      expect(response.status()).toBe(200);
+      expect((await response.json()).id).toBe(pinned.id);
+    });
+
+    test("should return 400 for pinning a draft", async ({ createVersion, request }) => {
+      const draft = await createVersion({ projectId: project.id });
+
+      const response = await request.put(`/api/v1/projects/${project.id}/latest-version-policy`, {
+        data: { strategy: "pinned", pinnedVersionId: draft.id },
+      });
+
+      expect(response.status()).toBe(400);
+    });
+  });
+
+  test.describe("Version QR code", () => {
+    test("should return 200", async ({ createProject, createVersion, request }) => {
+      const project = await createProject();
+      const version = await createVersion({ projectId: project.id });
+
+      const response = await request.get(`/api/v1/versions/${version.id}/qr?format=svg`);
+
+      expect(response.status()).toBe(200);
+      expect(response.headers()["content-type"]).toBe("image/svg+xml");
+    });
+
+    test("should return 404 for non-existing version", async ({ request }) => {
+      const response = await request.get(`/api/v1/versions/-1/qr`);
+
+      expect(response.status()).toBe(404);
+    });
+  });
 });
@@ -0,0 +1,167 @@
+import { createHmac } from "node:crypto";
+import { createServer, IncomingHttpHeaders, Server } from "node:http";
+import { AddressInfo } from "node:net";
+import { expect, test } from "../src/fixtures";
+
+type ReceivedRequest = {
+  headers: IncomingHttpHeaders;
+  body: string;
+};
+
+// Deliveries are sent by the job runner, they arrive a few seconds after the
+// event.
+const deliveryTimeout = 20_000;
+
+test.describe("Webhooks", () => {
+  let server: Server;
+  let received: ReceivedRequest[];
+  let receiverUrl: string;
+
+  test.beforeEach(async () => {
+    received = [];
+    server = createServer((req, res) => {
+      const chunks: Buffer[] = [];
+      req.on("data", (chunk) => chunks.push(chunk));
+      req.on("end", () => {
+        received.push({ headers: req.headers, body: Buffer.concat(chunks).toString() });
+        res.writeHead(204).end();
+      });
+    });
+    await new Promise<void>((resolve) => server.listen(0, "127.0.0.1", resolve));
+    receiverUrl = `http://127.0.0.1:${(server.address() as AddressInfo).port}/hook`;
+  });
+
+  test.afterEach(async () => {
+    await new Promise((resolve) => server.close(resolve));
+  });
+
+  test.describe("Create webhook", () => {
+    test("should create a project webhook with a secret", async ({ createProject, request }) => {
+      const project = await createProject();
+
+      const response = await request.post("/api/v1/webhooks", {
+        data: { projectId: project.id, url: receiverUrl, events: ["version.created"] },
+      });
+
+      expect(response.status()).toBe(201);
+      const webhook = await response.json();
+      expect(webhook).toMatchObject({ projectId: project.id, url: receiverUrl, events: ["version.created"], isActive: true });
+      expect(webhook.secret).toMatch(/^[0-9a-f]{64}$/);
+
+      const getResponse = await request.get(`/api/v1/webhooks/${webhook.id}`);
+      expect(getResponse.status()).toBe(200);
+      expect((await getResponse.json()).secret).toBeUndefined();
+    });
+
+    test("should return 403 for a global webhook by a non-admin", async ({ request }) => {
+      const response = await request.post("/api/v1/webhooks", {
+        data: { projectId: null, url: receiverUrl, events: ["project.created"] },
+      });
+
+      expect(response.status()).toBe(403);
+    });
+
+    test("should return 400 for an invalid URL", async ({ createProject, request }) => {
+      const project = await createProject();
+
+      const response = await request.post("/api/v1/webhooks", {
+        data: { projectId: project.id, url: "ftp://example.com/hook", events: ["version.created"] },
+      });
+
+      expect(response.status()).toBe(400);
+    });
+
+    test("should return 400 without events", async ({ createProject, request }) => {
+      const project = await createProject();
+
+      const response = await request.post("/api/v1/webhooks", {
+        data: { projectId: project.id, url: receiverUrl, events: [] },
+      });
+
+      expect(response.status()).toBe(400);
+    });
+
+    test("should return 401 without a token", async ({ anonymousRequest }) => {
+      const response = await anonymousRequest.post("/api/v1/webhooks", {
+        data: { projectId: null, url: receiverUrl, events: ["project.created"] },
+      });
+
+      expect(response.status()).toBe(401);
+    });
+  });
+
+  test.describe("Get webhook", () => {
+    test("should return 404 for an unknown webhook", async ({ request }) => {
+      const response = await request.get("/api/v1/webhooks/999999999");
+
+      expect(response.status()).toBe(404);
+    });
+  });
+
+  test.describe("Deliveries", () => {
+    test("should deliver a signed event and redeliver it", async ({ createProject, createVersion, request }) => {
+      const project = await createProject();
+      const createResponse = await request.post("/api/v1/webhooks", {
+        data: { projectId: project.id, url: receiverUrl, events: ["version.created"] },
+      });
+      expect(createResponse.status()).toBe(201);
+      const webhook = await createResponse.json();
+
+      const version = await createVersion({ projectId: project.id });
+
+      await expect.poll(() => received.length, { timeout: deliveryTimeout }).toBe(1);
+      const [delivery] = received;
+      expect(delivery.headers["x-docport-event"]).toBe("version.created");
+      const timestamp = delivery.headers["x-docport-timestamp"];
+      const signature = createHmac("sha256", webhook.secret).update(`${timestamp}.${delivery.body}`).digest("hex");
+      expect(delivery.headers["x-docport-signature"]).toBe(`sha256=${signature}`);
+
+      const payload = JSON.parse(delivery.body);
+      expect(payload).toMatchObject({ event: "version.created", data: { id: version.id, projectId: project.id } });
+
+      const listDeliveries = async () => {
+        const response = await request.get(`/api/v1/webhooks/${webhook.id}/deliveries`);
+        expect(response.status()).toBe(200);
+        return (await response.json()).deliveries;
+      };
+      await expect.poll(async () => (await listDeliveries()).map((d: { status: string }) => d.status), {
+        timeout: deliveryTimeout,
+      }).toEqual(["succeeded"]);
+      const [logged] = await listDeliveries();
+      expect(logged).toMatchObject({ webhookId: webhook.id, event: "version.created", attempts: 1, responseStatus: 204 });
+
+      const redeliverResponse = await request.post(`/api/v1/webhooks/${webhook.id}/deliveries/${logged.id}/redeliver`);
+      expect(redeliverResponse.status()).toBe(202);
+
+      await expect.poll(() => received.length, { timeout: deliveryTimeout }).toBe(2);
+      expect(JSON.parse(received[1].body).id).toBe(payload.id);
+    });
+
+    test("should not deliver events the webhook isn't subscribed to", async ({ createProject, createVersion, request }) => {
+      const project = await createProject();
+      const createResponse = await request.post("/api/v1/webhooks", {
+        data: { projectId: project.id, url: receiverUrl, events: ["version.deleted"] },
+      });
+      expect(createResponse.status()).toBe(201);
+      const webhook = await createResponse.json();
+
+      await createVersion({ projectId: project.id });
+
+      const response = await request.get(`/api/v1/webhooks/${webhook.id}/deliveries`);
+      expect(response.status()).toBe(200);
+      expect((await response.json()).deliveries).toEqual([]);
+    });
+
+    test("should return 404 for an unknown delivery", async ({ createProject, request }) => {
+      const project = await createProject();
+      const createResponse = await request.post("/api/v1/webhooks", {
+        data: { projectId: project.id, url: receiverUrl, events: ["version.created"] },
+      });
+      const webhook = await createResponse.json();
+
+      const response = await request.post(`/api/v1/webhooks/${webhook.id}/deliveries/999999999/redeliver`);
+
+      expect(response.status()).toBe(404);
+    });
+  });
+});
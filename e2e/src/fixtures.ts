@@ -1,7 +1,8 @@
-import { mergeExpects, test as baseTest } from "@playwright/test";
+import { APIRequestContext, mergeExpects, test as baseTest } from "@playwright/test";
 import { createCreateProjectFixture, CreateProjectParams, CreateProjectResult, } from "./fixtures/project";
 import { createCreateVersionFixture, CreateVersionParams, CreateVersionResult } from "./fixtures/version";
 import { createCreateFileFixture, CreateFileParams, CreateFileResult } from "./fixtures/file";
+import { createCreateLocationFixture, CreateLocationParams, CreateLocationResult } from "./fixtures/location";
 import { AuthenticateParams, AuthenticateResult, createAuthenticateFixture } from "./fixtures/auth";
 
 type TestFixtures = {
@@ -10,8 +11,16 @@ type TestFixtures = {
   defaultUsername: string;
   defaultPassword: string;
   defaultToken: string;
+  anonymousRequest: APIRequestContext;
+  // otherRequest acts as a second user, tests using it skip unless
+  // KEYCLOAK_OTHER_USERNAME is set.
+  otherRequest: APIRequestContext;
+  createOtherFile: (params?: CreateFileParams) => Promise<CreateFileResult>;
+  createLocation: (params?: CreateLocationParams) => Promise<CreateLocationResult>;
   createProject: (params?: CreateProjectParams) => Promise<CreateProjectResult>;
+  handOverProject: (projectId: number, role?: "viewer" | "editor") => Promise<void>;
   createVersion: (params: CreateVersionParams) => Promise<CreateVersionResult>;
+  releaseVersion: (id: number) => Promise<CreateVersionResult>;
   createFile: (params?: CreateFileParams) => Promise<CreateFileResult>;
   authenticate: (params: AuthenticateParams) => Promise<AuthenticateResult>;
 };
@@ -37,20 +46,66 @@ export const test = baseTest.extend<TestFixtures>({
     });
     await use(result.accessToken);
   },
+  request: async ({ playwright, baseURL, defaultToken }, use) => {
+    const request = await playwright.request.newContext({
+      baseURL,
+      extraHTTPHeaders: {
+        "Authorization": `Bearer ${defaultToken}`
+      }
+    });
+    await use(request);
+    await request.dispose();
+  },
+  anonymousRequest: async ({ playwright, baseURL }, use) => {
+    const request = await playwright.request.newContext({ baseURL });
+    await use(request);
+    await request.dispose();
+  },
+  otherRequest: async ({ playwright, baseURL, authenticate, defaultClientId }, use) => {
+    const result = await authenticate({
+      clientId: defaultClientId,
+      username: process.env.KEYCLOAK_OTHER_USERNAME,
+      password: process.env.KEYCLOAK_OTHER_PASSWORD,
+    });
+    const request = await playwright.request.newContext({
+      baseURL,
+      extraHTTPHeaders: {
+        "Authorization": `Bearer ${result.accessToken}`
+      }
+    });
+    await use(request);
+    await request.dispose();
+  },
+  createLocation: async ({ request }, use) => {
+    const { createLocation } = createCreateLocationFixture(request);
+    await use(createLocation);
+  },
   createProject: async ({ request }, use) => {
     const { createProject } = createCreateProjectFixture(request);
     await use(createProject);
   },
+  handOverProject: async ({ request }, use) => {
+    const { handOverProject } = createCreateProjectFixture(request);
+    await use(handOverProject);
+  },
   createVersion: async ({ request }, use) => {
     const { createVersion } = createCreateVersionFixture(request);
     await use(createVersion);
   },
+  releaseVersion: async ({ request }, use) => {
+    const { releaseVersion } = createCreateVersionFixture(request);
+    await use(releaseVersion);
+  },
   createFile: async ({ request }, use) => {
     const { createFile } = createCreateFileFixture(request);
     await use(createFile);
   },
-  authenticate: async ({ request }, use) => {
-    const { authenticate } = createAuthenticateFixture(request);
+  createOtherFile: async ({ otherRequest }, use) => {
+    const { createFile } = createCreateFileFixture(otherRequest);
+    await use(createFile);
+  },
+  authenticate: async ({ anonymousRequest }, use) => {
+    const { authenticate } = createAuthenticateFixture(anonymousRequest);
     await use(authenticate);
   }
 });
@@ -9,6 +9,8 @@ export type CreateFileResult = {
   size: string | null;
   mimeType: string | null;
   isComplete: boolean;
+  checksum: string | null;
+  revisionId: number | null;
 };
 
 export type CreateFileParams = {
@@ -0,0 +1,41 @@
+import { APIRequestContext } from "@playwright/test";
+import { expect } from "../fixtures";
+
+export type CreateLocationResult = {
+  id: number;
+  createdAt: string;
+  updatedAt: string;
+  name: string;
+  address: string | null;
+  lat: number | null;
+  lon: number | null;
+};
+
+export type CreateLocationParams = {
+  name?: string;
+  address?: string | null;
+  lat?: number | null;
+  lon?: number | null;
+};
+
+export const createCreateLocationFixture = (request: APIRequestContext) => {
+  const createLocation = async (params: CreateLocationParams = {}): Promise<CreateLocationResult> => {
+    const response = await request.post("/api/v1/locations", {
+      data: {
+        name: params.name ?? "Test location",
+        address: params.address ?? null,
+        lat: params.lat ?? null,
+        lon: params.lon ?? null,
+      },
+    });
+
+    expect(response.status()).toBe(201);
+
+    const responseBody = await response.json();
+    return responseBody;
+  };
+
+  return {
+    createLocation,
+  };
+};
@@ -1,6 +1,7 @@
 import { APIRequestContext } from "@playwright/test";
 import * as uuid from "uuid";
 import { expect } from "../fixtures";
+import { CreateLocationResult } from "./location";
 
 export type CreateProjectResult = {
   id: number;
@@ -8,11 +9,14 @@ export type CreateProjectResult = {
   updatedAt: string;
   slug: string;
   name: string;
+  locationId: number | null;
+  location: CreateLocationResult | null;
 };
 
 export type CreateProjectParams = {
   slug?: string;
   name?: string;
+  locationId?: number | null;
 };
 
 export const createCreateProjectFixture = (request: APIRequestContext) => {
@@ -21,6 +25,7 @@ export const createCreateProjectFixture = (request: APIRequestContext) => {
       data: {
         slug: params.slug ?? uuid.v4(),
         name: params.name ?? "Test project",
+        locationId: params.locationId ?? null,
       },
     });
 
@@ -30,7 +35,36 @@ export const createCreateProjectFixture = (request: APIRequestContext) => {
     return responseBody;
   };
 
+  // handOverProject makes a new user admin of the project and drops the test
+  // user to the role, or out of the project without one, so the test user
+  // has no say over it anymore.
+  const handOverProject = async (projectId: number, role?: "viewer" | "editor"): Promise<void> => {
+    const userResponse = await request.post("/api/v1/users", {
+      data: {
+        name: `Jane - ${uuid.v4()}`,
+        email: `jane-${uuid.v4()}@example.com`,
+        emailVerified: true
+      }
+    });
+    expect(userResponse.status()).toBe(200);
+    const { id: userId } = await userResponse.json();
+
+    const memberResponse = await request.post(`/api/v1/projects/${projectId}/members`, {
+      data: { userId, role: "admin" }
+    });
+    expect(memberResponse.status()).toBe(201);
+
+    const meResponse = await request.get("/api/v1/users/me");
+    const { id: me } = await meResponse.json();
+
+    const response = role
+      ? await request.put(`/api/v1/projects/${projectId}/members/${me}`, { data: { role } })
+      : await request.delete(`/api/v1/projects/${projectId}/members/${me}`);
+    expect(response.ok()).toBeTruthy();
+  };
+
   return {
     createProject,
+    handOverProject,
   };
 };
@@ -9,6 +9,8 @@ export type CreateVersionResult = {
   name: string;
   description: string;
   projectId: number;
+  status: "draft" | "in_review" | "released" | "superseded" | "withdrawn";
+  releasedAt: string | null;
 };
 
 export type CreateVersionParams = {
@@ -33,7 +35,21 @@ export const createCreateVersionFixture = (request: APIRequestContext) => {
     return responseBody;
   };
 
+  const releaseVersion = async (id: number): Promise<CreateVersionResult> => {
+    for (const status of ["in_review", "released"]) {
+      const response = await request.put(`/api/v1/versions/${id}/status`, {
+        data: { status },
+      });
+
+      expect(response.status()).toBe(200);
+    }
+
+    const response = await request.get(`/api/v1/versions/${id}`);
+    return response.json();
+  };
+
   return {
     createVersion,
+    releaseVersion,
   };
 };
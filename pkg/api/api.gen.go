@@ -21,9 +21,757 @@ const (
 	OpenIdConnectScopes = "OpenIdConnect.Scopes"
 )
 
+// Defines values for AuditAction.
+const (
+	AuditActionAttach             AuditAction = "attach"
+	AuditActionClone              AuditAction = "clone"
+	AuditActionCreate             AuditAction = "create"
+	AuditActionDelete             AuditAction = "delete"
+	AuditActionDetach             AuditAction = "detach"
+	AuditActionRestore            AuditAction = "restore"
+	AuditActionRevoke             AuditAction = "revoke"
+	AuditActionStatusChange       AuditAction = "status_change"
+	AuditActionUpdate             AuditAction = "update"
+	AuditActionUpdateLatestPolicy AuditAction = "update_latest_policy"
+	AuditActionUpload             AuditAction = "upload"
+)
+
+// Valid indicates whether the value is a known member of the AuditAction enum.
+func (e AuditAction) Valid() bool {
+	switch e {
+	case AuditActionAttach:
+		return true
+	case AuditActionClone:
+		return true
+	case AuditActionCreate:
+		return true
+	case AuditActionDelete:
+		return true
+	case AuditActionDetach:
+		return true
+	case AuditActionRestore:
+		return true
+	case AuditActionRevoke:
+		return true
+	case AuditActionStatusChange:
+		return true
+	case AuditActionUpdate:
+		return true
+	case AuditActionUpdateLatestPolicy:
+		return true
+	case AuditActionUpload:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for AuditResourceType.
+const (
+	AuditResourceTypeFile       AuditResourceType = "file"
+	AuditResourceTypeLocation   AuditResourceType = "location"
+	AuditResourceTypeMembership AuditResourceType = "membership"
+	AuditResourceTypeProject    AuditResourceType = "project"
+	AuditResourceTypeShareLink  AuditResourceType = "share_link"
+	AuditResourceTypeShortLink  AuditResourceType = "short_link"
+	AuditResourceTypeUser       AuditResourceType = "user"
+	AuditResourceTypeVersion    AuditResourceType = "version"
+)
+
+// Valid indicates whether the value is a known member of the AuditResourceType enum.
+func (e AuditResourceType) Valid() bool {
+	switch e {
+	case AuditResourceTypeFile:
+		return true
+	case AuditResourceTypeLocation:
+		return true
+	case AuditResourceTypeMembership:
+		return true
+	case AuditResourceTypeProject:
+		return true
+	case AuditResourceTypeShareLink:
+		return true
+	case AuditResourceTypeShortLink:
+		return true
+	case AuditResourceTypeUser:
+		return true
+	case AuditResourceTypeVersion:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for FileVerificationStatus.
+const (
+	FileVerificationStatusBackfilled FileVerificationStatus = "backfilled"
+	FileVerificationStatusMismatch   FileVerificationStatus = "mismatch"
+	FileVerificationStatusMissing    FileVerificationStatus = "missing"
+	FileVerificationStatusOk         FileVerificationStatus = "ok"
+	FileVerificationStatusUnreadable FileVerificationStatus = "unreadable"
+)
+
+// Valid indicates whether the value is a known member of the FileVerificationStatus enum.
+func (e FileVerificationStatus) Valid() bool {
+	switch e {
+	case FileVerificationStatusBackfilled:
+		return true
+	case FileVerificationStatusMismatch:
+		return true
+	case FileVerificationStatusMissing:
+		return true
+	case FileVerificationStatusOk:
+		return true
+	case FileVerificationStatusUnreadable:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for ImportEntryStatus.
+const (
+	ImportEntryStatusFailed   ImportEntryStatus = "failed"
+	ImportEntryStatusImported ImportEntryStatus = "imported"
+)
+
+// Valid indicates whether the value is a known member of the ImportEntryStatus enum.
+func (e ImportEntryStatus) Valid() bool {
+	switch e {
+	case ImportEntryStatusFailed:
+		return true
+	case ImportEntryStatusImported:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for JobStatus.
+const (
+	JobStatusCompleted JobStatus = "completed"
+	JobStatusFailed    JobStatus = "failed"
+	JobStatusPending   JobStatus = "pending"
+	JobStatusRunning   JobStatus = "running"
+)
+
+// Valid indicates whether the value is a known member of the JobStatus enum.
+func (e JobStatus) Valid() bool {
+	switch e {
+	case JobStatusCompleted:
+		return true
+	case JobStatusFailed:
+		return true
+	case JobStatusPending:
+		return true
+	case JobStatusRunning:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for LatestVersionStrategy.
+const (
+	LatestVersionStrategyPinned   LatestVersionStrategy = "pinned"
+	LatestVersionStrategyReleased LatestVersionStrategy = "released"
+	LatestVersionStrategySemver   LatestVersionStrategy = "semver"
+)
+
+// Valid indicates whether the value is a known member of the LatestVersionStrategy enum.
+func (e LatestVersionStrategy) Valid() bool {
+	switch e {
+	case LatestVersionStrategyPinned:
+		return true
+	case LatestVersionStrategyReleased:
+		return true
+	case LatestVersionStrategySemver:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for MemberRole.
+const (
+	MemberRoleAdmin  MemberRole = "admin"
+	MemberRoleEditor MemberRole = "editor"
+	MemberRoleViewer MemberRole = "viewer"
+)
+
+// Valid indicates whether the value is a known member of the MemberRole enum.
+func (e MemberRole) Valid() bool {
+	switch e {
+	case MemberRoleAdmin:
+		return true
+	case MemberRoleEditor:
+		return true
+	case MemberRoleViewer:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for SearchHitType.
+const (
+	SearchHitTypeFile    SearchHitType = "file"
+	SearchHitTypeProject SearchHitType = "project"
+	SearchHitTypeVersion SearchHitType = "version"
+)
+
+// Valid indicates whether the value is a known member of the SearchHitType enum.
+func (e SearchHitType) Valid() bool {
+	switch e {
+	case SearchHitTypeFile:
+		return true
+	case SearchHitTypeProject:
+		return true
+	case SearchHitTypeVersion:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for ShortLinkTargetType.
+const (
+	ShortLinkTargetTypeLatestVersion ShortLinkTargetType = "latest_version"
+	ShortLinkTargetTypeProject       ShortLinkTargetType = "project"
+	ShortLinkTargetTypeVersion       ShortLinkTargetType = "version"
+)
+
+// Valid indicates whether the value is a known member of the ShortLinkTargetType enum.
+func (e ShortLinkTargetType) Valid() bool {
+	switch e {
+	case ShortLinkTargetTypeLatestVersion:
+		return true
+	case ShortLinkTargetTypeProject:
+		return true
+	case ShortLinkTargetTypeVersion:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for VersionStatus.
+const (
+	VersionStatusDraft      VersionStatus = "draft"
+	VersionStatusInReview   VersionStatus = "in_review"
+	VersionStatusReleased   VersionStatus = "released"
+	VersionStatusSuperseded VersionStatus = "superseded"
+	VersionStatusWithdrawn  VersionStatus = "withdrawn"
+)
+
+// Valid indicates whether the value is a known member of the VersionStatus enum.
+func (e VersionStatus) Valid() bool {
+	switch e {
+	case VersionStatusDraft:
+		return true
+	case VersionStatusInReview:
+		return true
+	case VersionStatusReleased:
+		return true
+	case VersionStatusSuperseded:
+		return true
+	case VersionStatusWithdrawn:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for WebhookDeliveryStatus.
+const (
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "failed"
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "succeeded"
+)
+
+// Valid indicates whether the value is a known member of the WebhookDeliveryStatus enum.
+func (e WebhookDeliveryStatus) Valid() bool {
+	switch e {
+	case WebhookDeliveryStatusFailed:
+		return true
+	case WebhookDeliveryStatusPending:
+		return true
+	case WebhookDeliveryStatusSucceeded:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for WebhookEvent.
+const (
+	WebhookEventFileAttached         WebhookEvent = "file.attached"
+	WebhookEventFileDeleted          WebhookEvent = "file.deleted"
+	WebhookEventFileDetached         WebhookEvent = "file.detached"
+	WebhookEventFileRestored         WebhookEvent = "file.restored"
+	WebhookEventFileUploaded         WebhookEvent = "file.uploaded"
+	WebhookEventProjectCreated       WebhookEvent = "project.created"
+	WebhookEventProjectDeleted       WebhookEvent = "project.deleted"
+	WebhookEventProjectUpdated       WebhookEvent = "project.updated"
+	WebhookEventVersionCreated       WebhookEvent = "version.created"
+	WebhookEventVersionDeleted       WebhookEvent = "version.deleted"
+	WebhookEventVersionReleased      WebhookEvent = "version.released"
+	WebhookEventVersionStatusChanged WebhookEvent = "version.status_changed"
+	WebhookEventVersionSuperseded    WebhookEvent = "version.superseded"
+	WebhookEventVersionUpdated       WebhookEvent = "version.updated"
+	WebhookEventVersionWithdrawn     WebhookEvent = "version.withdrawn"
+)
+
+// Valid indicates whether the value is a known member of the WebhookEvent enum.
+func (e WebhookEvent) Valid() bool {
+	switch e {
+	case WebhookEventFileAttached:
+		return true
+	case WebhookEventFileDeleted:
+		return true
+	case WebhookEventFileDetached:
+		return true
+	case WebhookEventFileRestored:
+		return true
+	case WebhookEventFileUploaded:
+		return true
+	case WebhookEventProjectCreated:
+		return true
+	case WebhookEventProjectDeleted:
+		return true
+	case WebhookEventProjectUpdated:
+		return true
+	case WebhookEventVersionCreated:
+		return true
+	case WebhookEventVersionDeleted:
+		return true
+	case WebhookEventVersionReleased:
+		return true
+	case WebhookEventVersionStatusChanged:
+		return true
+	case WebhookEventVersionSuperseded:
+		return true
+	case WebhookEventVersionUpdated:
+		return true
+	case WebhookEventVersionWithdrawn:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for QueryArchiveFormat.
+const (
+	QueryArchiveFormatTarGz QueryArchiveFormat = "tar.gz"
+	QueryArchiveFormatZip   QueryArchiveFormat = "zip"
+)
+
+// Valid indicates whether the value is a known member of the QueryArchiveFormat enum.
+func (e QueryArchiveFormat) Valid() bool {
+	switch e {
+	case QueryArchiveFormatTarGz:
+		return true
+	case QueryArchiveFormatZip:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for QueryAuditFormat.
+const (
+	QueryAuditFormatCsv  QueryAuditFormat = "csv"
+	QueryAuditFormatJson QueryAuditFormat = "json"
+)
+
+// Valid indicates whether the value is a known member of the QueryAuditFormat enum.
+func (e QueryAuditFormat) Valid() bool {
+	switch e {
+	case QueryAuditFormatCsv:
+		return true
+	case QueryAuditFormatJson:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for QueryComparisonFormat.
+const (
+	QueryComparisonFormatJson QueryComparisonFormat = "json"
+	QueryComparisonFormatText QueryComparisonFormat = "text"
+)
+
+// Valid indicates whether the value is a known member of the QueryComparisonFormat enum.
+func (e QueryComparisonFormat) Valid() bool {
+	switch e {
+	case QueryComparisonFormatJson:
+		return true
+	case QueryComparisonFormatText:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for QueryQRCodeFormat.
+const (
+	QueryQRCodeFormatPng QueryQRCodeFormat = "png"
+	QueryQRCodeFormatSvg QueryQRCodeFormat = "svg"
+)
+
+// Valid indicates whether the value is a known member of the QueryQRCodeFormat enum.
+func (e QueryQRCodeFormat) Valid() bool {
+	switch e {
+	case QueryQRCodeFormatPng:
+		return true
+	case QueryQRCodeFormatSvg:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for QueryQRCodeLevel.
+const (
+	QueryQRCodeLevelH QueryQRCodeLevel = "H"
+	QueryQRCodeLevelL QueryQRCodeLevel = "L"
+	QueryQRCodeLevelM QueryQRCodeLevel = "M"
+	QueryQRCodeLevelQ QueryQRCodeLevel = "Q"
+)
+
+// Valid indicates whether the value is a known member of the QueryQRCodeLevel enum.
+func (e QueryQRCodeLevel) Valid() bool {
+	switch e {
+	case QueryQRCodeLevelH:
+		return true
+	case QueryQRCodeLevelL:
+		return true
+	case QueryQRCodeLevelM:
+		return true
+	case QueryQRCodeLevelQ:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for QuerySearchMode.
+const (
+	QuerySearchModeContent  QuerySearchMode = "content"
+	QuerySearchModeMetadata QuerySearchMode = "metadata"
+)
+
+// Valid indicates whether the value is a known member of the QuerySearchMode enum.
+func (e QuerySearchMode) Valid() bool {
+	switch e {
+	case QuerySearchModeContent:
+		return true
+	case QuerySearchModeMetadata:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for ListAuditEventsParamsFormat.
+const (
+	ListAuditEventsParamsFormatCsv  ListAuditEventsParamsFormat = "csv"
+	ListAuditEventsParamsFormatJson ListAuditEventsParamsFormat = "json"
+)
+
+// Valid indicates whether the value is a known member of the ListAuditEventsParamsFormat enum.
+func (e ListAuditEventsParamsFormat) Valid() bool {
+	switch e {
+	case ListAuditEventsParamsFormatCsv:
+		return true
+	case ListAuditEventsParamsFormatJson:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for GetFileQRCodeParamsFormat.
+const (
+	GetFileQRCodeParamsFormatPng GetFileQRCodeParamsFormat = "png"
+	GetFileQRCodeParamsFormatSvg GetFileQRCodeParamsFormat = "svg"
+)
+
+// Valid indicates whether the value is a known member of the GetFileQRCodeParamsFormat enum.
+func (e GetFileQRCodeParamsFormat) Valid() bool {
+	switch e {
+	case GetFileQRCodeParamsFormatPng:
+		return true
+	case GetFileQRCodeParamsFormatSvg:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for GetFileQRCodeParamsLevel.
+const (
+	GetFileQRCodeParamsLevelH GetFileQRCodeParamsLevel = "H"
+	GetFileQRCodeParamsLevelL GetFileQRCodeParamsLevel = "L"
+	GetFileQRCodeParamsLevelM GetFileQRCodeParamsLevel = "M"
+	GetFileQRCodeParamsLevelQ GetFileQRCodeParamsLevel = "Q"
+)
+
+// Valid indicates whether the value is a known member of the GetFileQRCodeParamsLevel enum.
+func (e GetFileQRCodeParamsLevel) Valid() bool {
+	switch e {
+	case GetFileQRCodeParamsLevelH:
+		return true
+	case GetFileQRCodeParamsLevelL:
+		return true
+	case GetFileQRCodeParamsLevelM:
+		return true
+	case GetFileQRCodeParamsLevelQ:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for GetProjectQRCodeParamsFormat.
+const (
+	GetProjectQRCodeParamsFormatPng GetProjectQRCodeParamsFormat = "png"
+	GetProjectQRCodeParamsFormatSvg GetProjectQRCodeParamsFormat = "svg"
+)
+
+// Valid indicates whether the value is a known member of the GetProjectQRCodeParamsFormat enum.
+func (e GetProjectQRCodeParamsFormat) Valid() bool {
+	switch e {
+	case GetProjectQRCodeParamsFormatPng:
+		return true
+	case GetProjectQRCodeParamsFormatSvg:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for GetProjectQRCodeParamsLevel.
+const (
+	GetProjectQRCodeParamsLevelH GetProjectQRCodeParamsLevel = "H"
+	GetProjectQRCodeParamsLevelL GetProjectQRCodeParamsLevel = "L"
+	GetProjectQRCodeParamsLevelM GetProjectQRCodeParamsLevel = "M"
+	GetProjectQRCodeParamsLevelQ GetProjectQRCodeParamsLevel = "Q"
+)
+
+// Valid indicates whether the value is a known member of the GetProjectQRCodeParamsLevel enum.
+func (e GetProjectQRCodeParamsLevel) Valid() bool {
+	switch e {
+	case GetProjectQRCodeParamsLevelH:
+		return true
+	case GetProjectQRCodeParamsLevelL:
+		return true
+	case GetProjectQRCodeParamsLevelM:
+		return true
+	case GetProjectQRCodeParamsLevelQ:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for SearchParamsMode.
+const (
+	SearchParamsModeContent  SearchParamsMode = "content"
+	SearchParamsModeMetadata SearchParamsMode = "metadata"
+)
+
+// Valid indicates whether the value is a known member of the SearchParamsMode enum.
+func (e SearchParamsMode) Valid() bool {
+	switch e {
+	case SearchParamsModeContent:
+		return true
+	case SearchParamsModeMetadata:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for GetShortLinkQRCodeParamsFormat.
+const (
+	GetShortLinkQRCodeParamsFormatPng GetShortLinkQRCodeParamsFormat = "png"
+	GetShortLinkQRCodeParamsFormatSvg GetShortLinkQRCodeParamsFormat = "svg"
+)
+
+// Valid indicates whether the value is a known member of the GetShortLinkQRCodeParamsFormat enum.
+func (e GetShortLinkQRCodeParamsFormat) Valid() bool {
+	switch e {
+	case GetShortLinkQRCodeParamsFormatPng:
+		return true
+	case GetShortLinkQRCodeParamsFormatSvg:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for GetShortLinkQRCodeParamsLevel.
+const (
+	GetShortLinkQRCodeParamsLevelH GetShortLinkQRCodeParamsLevel = "H"
+	GetShortLinkQRCodeParamsLevelL GetShortLinkQRCodeParamsLevel = "L"
+	GetShortLinkQRCodeParamsLevelM GetShortLinkQRCodeParamsLevel = "M"
+	GetShortLinkQRCodeParamsLevelQ GetShortLinkQRCodeParamsLevel = "Q"
+)
+
+// Valid indicates whether the value is a known member of the GetShortLinkQRCodeParamsLevel enum.
+func (e GetShortLinkQRCodeParamsLevel) Valid() bool {
+	switch e {
+	case GetShortLinkQRCodeParamsLevelH:
+		return true
+	case GetShortLinkQRCodeParamsLevelL:
+		return true
+	case GetShortLinkQRCodeParamsLevelM:
+		return true
+	case GetShortLinkQRCodeParamsLevelQ:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for DownloadVersionArchiveParamsFormat.
+const (
+	DownloadVersionArchiveParamsFormatTarGz DownloadVersionArchiveParamsFormat = "tar.gz"
+	DownloadVersionArchiveParamsFormatZip   DownloadVersionArchiveParamsFormat = "zip"
+)
+
+// Valid indicates whether the value is a known member of the DownloadVersionArchiveParamsFormat enum.
+func (e DownloadVersionArchiveParamsFormat) Valid() bool {
+	switch e {
+	case DownloadVersionArchiveParamsFormatTarGz:
+		return true
+	case DownloadVersionArchiveParamsFormatZip:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for CompareVersionsParamsFormat.
+const (
+	CompareVersionsParamsFormatJson CompareVersionsParamsFormat = "json"
+	CompareVersionsParamsFormatText CompareVersionsParamsFormat = "text"
+)
+
+// Valid indicates whether the value is a known member of the CompareVersionsParamsFormat enum.
+func (e CompareVersionsParamsFormat) Valid() bool {
+	switch e {
+	case CompareVersionsParamsFormatJson:
+		return true
+	case CompareVersionsParamsFormatText:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for GetVersionQRCodeParamsFormat.
+const (
+	GetVersionQRCodeParamsFormatPng GetVersionQRCodeParamsFormat = "png"
+	GetVersionQRCodeParamsFormatSvg GetVersionQRCodeParamsFormat = "svg"
+)
+
+// Valid indicates whether the value is a known member of the GetVersionQRCodeParamsFormat enum.
+func (e GetVersionQRCodeParamsFormat) Valid() bool {
+	switch e {
+	case GetVersionQRCodeParamsFormatPng:
+		return true
+	case GetVersionQRCodeParamsFormatSvg:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for GetVersionQRCodeParamsLevel.
+const (
+	GetVersionQRCodeParamsLevelH GetVersionQRCodeParamsLevel = "H"
+	GetVersionQRCodeParamsLevelL GetVersionQRCodeParamsLevel = "L"
+	GetVersionQRCodeParamsLevelM GetVersionQRCodeParamsLevel = "M"
+	GetVersionQRCodeParamsLevelQ GetVersionQRCodeParamsLevel = "Q"
+)
+
+// Valid indicates whether the value is a known member of the GetVersionQRCodeParamsLevel enum.
+func (e GetVersionQRCodeParamsLevel) Valid() bool {
+	switch e {
+	case GetVersionQRCodeParamsLevelH:
+		return true
+	case GetVersionQRCodeParamsLevelL:
+		return true
+	case GetVersionQRCodeParamsLevelM:
+		return true
+	case GetVersionQRCodeParamsLevelQ:
+		return true
+	default:
+		return false
+	}
+}
+
 // AttachFileToVersionRequest defines model for AttachFileToVersionRequest.
 type AttachFileToVersionRequest struct {
 	FileId int64 `json:"fileId"`
+
+	// RevisionId Pins the version to this revision of the file. Without it the version follows the current revision until it's released.
+	RevisionId *int64 `json:"revisionId,omitempty"`
+}
+
+// AuditAction Attach and detach are recorded on the file with the version in the after or before state, clone on the new version, revoke on share and short links
+type AuditAction string
+
+// AuditEventResponse defines model for AuditEventResponse.
+type AuditEventResponse struct {
+	// Action Attach and detach are recorded on the file with the version in the after or before state, clone on the new version, revoke on share and short links
+	Action AuditAction `json:"action"`
+
+	// Actor Token subject of the caller, null for changes the application made on its own
+	Actor   *string `json:"actor"`
+	ActorId *int64  `json:"actorId"`
+
+	// After State of the resource after the change
+	After interface{} `json:"after"`
+
+	// Before State of the resource before the change
+	Before     interface{} `json:"before"`
+	ClientIp   *string     `json:"clientIp"`
+	Id         int64       `json:"id"`
+	OccurredAt time.Time   `json:"occurredAt"`
+	RequestId  *string     `json:"requestId"`
+	ResourceId int64       `json:"resourceId"`
+
+	// ResourceType Membership events use the project ID as resource ID
+	ResourceType AuditResourceType `json:"resourceType"`
+}
+
+// AuditResourceType Membership events use the project ID as resource ID
+type AuditResourceType string
+
+// CloneVersionRequest defines model for CloneVersionRequest.
+type CloneVersionRequest struct {
+	// CopyDescription Copy the description of the cloned version when no description is given
+	CopyDescription *bool `json:"copyDescription,omitempty"`
+
+	// Description Description of the new version, defaults to the one of the cloned version
+	Description *string `json:"description,omitempty"`
+	Name        string  `json:"name"`
+}
+
+// ComparedFileResponse defines model for ComparedFileResponse.
+type ComparedFileResponse struct {
+	Checksum *string `json:"checksum"`
+	Id       int64   `json:"id"`
+	MimeType *string `json:"mimeType"`
+	Name     string  `json:"name"`
+
+	// RevisionId The revision the version pins or, when it pins none, the current one
+	RevisionId *int64 `json:"revisionId"`
+	Size       *int64 `json:"size"`
 }
 
 // CreateFileRequest defines model for CreateFileRequest.
@@ -31,10 +779,47 @@ type CreateFileRequest struct {
 	Name string `json:"name"`
 }
 
+// CreateLocationRequest defines model for CreateLocationRequest.
+type CreateLocationRequest struct {
+	Address *string  `json:"address,omitempty"`
+	Lat     *float64 `json:"lat,omitempty"`
+	Lon     *float64 `json:"lon,omitempty"`
+	Name    string   `json:"name"`
+}
+
+// CreateMemberRequest defines model for CreateMemberRequest.
+type CreateMemberRequest struct {
+	// Role Viewers can read the project and its versions and files, editors can also change them and admins can additionally manage the members and delete the project.
+	Role   MemberRole `json:"role"`
+	UserId int64      `json:"userId"`
+}
+
 // CreateProjectRequest defines model for CreateProjectRequest.
 type CreateProjectRequest struct {
-	Name string `json:"name"`
-	Slug string `json:"slug"`
+	LocationId *int64 `json:"locationId,omitempty"`
+	Name       string `json:"name"`
+	Slug       string `json:"slug"`
+}
+
+// CreateShareLinkRequest defines model for CreateShareLinkRequest.
+type CreateShareLinkRequest struct {
+	ExpiresAt    time.Time `json:"expiresAt"`
+	FileId       *int64    `json:"fileId,omitempty"`
+	MaxDownloads *int64    `json:"maxDownloads,omitempty"`
+	Password     *string   `json:"password,omitempty"`
+	VersionId    *int64    `json:"versionId,omitempty"`
+}
+
+// CreateShortLinkRequest defines model for CreateShortLinkRequest.
+type CreateShortLinkRequest struct {
+	ProjectId  *int64              `json:"projectId,omitempty"`
+	TargetType ShortLinkTargetType `json:"targetType"`
+	VersionId  *int64              `json:"versionId,omitempty"`
+}
+
+// CreateUploadSessionRequest defines model for CreateUploadSessionRequest.
+type CreateUploadSessionRequest struct {
+	Size int64 `json:"size"`
 }
 
 // CreateUserRequest defines model for CreateUserRequest.
@@ -51,6 +836,13 @@ type CreateVersionRequest struct {
 	ProjectId   int64   `json:"projectId"`
 }
 
+// CreateWebhookRequest defines model for CreateWebhookRequest.
+type CreateWebhookRequest struct {
+	Events    []WebhookEvent `json:"events"`
+	ProjectId *int64         `json:"projectId,omitempty"`
+	Url       string         `json:"url"`
+}
+
 // DetachFileFromVersionRequest defines model for DetachFileFromVersionRequest.
 type DetachFileFromVersionRequest struct {
 	FileId int64 `json:"fileId"`
@@ -61,17 +853,143 @@ type ErrorResponse struct {
 	Message string `json:"message"`
 }
 
+// EventResponse defines model for EventResponse.
+type EventResponse struct {
+	// Data API representation of the resource, as in webhook payloads. It's left out when it's too large to pass between instances, fetch the resource instead then.
+	Data interface{} `json:"data,omitempty"`
+
+	// Event Subscriptions of a project are deleted with it, only webhooks without a project receive project.deleted. version.status_changed covers submitting a draft for review and sending it back.
+	Event WebhookEvent `json:"event"`
+
+	// Id ID of the event, the same as in webhook payloads
+	Id         string    `json:"id"`
+	OccurredAt time.Time `json:"occurredAt"`
+
+	// ProjectIds Projects the event belongs to, empty for files not in any version
+	ProjectIds []int64 `json:"projectIds"`
+}
+
+// FileChangeResponse defines model for FileChangeResponse.
+type FileChangeResponse struct {
+	From ComparedFileResponse `json:"from"`
+	To   ComparedFileResponse `json:"to"`
+}
+
 // FileResponse defines model for FileResponse.
 type FileResponse struct {
+	// Checksum Hex encoded SHA-256 digest of the file contents
+	Checksum   *string   `json:"checksum"`
 	CreatedAt  time.Time `json:"createdAt"`
 	Id         int64     `json:"id"`
 	IsComplete bool      `json:"isComplete"`
 	MimeType   *string   `json:"mimeType"`
 	Name       string    `json:"name"`
+
+	// RevisionId Revision the contents are from, the current one unless the file is listed as part of a version that pins another
+	RevisionId *int64    `json:"revisionId,omitempty"`
 	Size       *int64    `json:"size"`
 	UpdatedAt  time.Time `json:"updatedAt"`
 }
 
+// FileRevisionResponse defines model for FileRevisionResponse.
+type FileRevisionResponse struct {
+	// Checksum Hex encoded SHA-256 digest of the revision contents
+	Checksum  *string   `json:"checksum"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	// CreatedBy User who uploaded the revision
+	CreatedBy *int64 `json:"createdBy"`
+	FileId    int64  `json:"fileId"`
+	Id        int64  `json:"id"`
+	MimeType  string `json:"mimeType"`
+
+	// Number Position of the revision in the history of the file, starting at 1
+	Number int32 `json:"number"`
+	Size   int64 `json:"size"`
+}
+
+// FileVerificationResponse defines model for FileVerificationResponse.
+type FileVerificationResponse struct {
+	ActualChecksum   *string                `json:"actualChecksum"`
+	ExpectedChecksum *string                `json:"expectedChecksum"`
+	FileId           int64                  `json:"fileId"`
+	Name             string                 `json:"name"`
+	Status           FileVerificationStatus `json:"status"`
+}
+
+// FileVerificationStatus defines model for FileVerificationStatus.
+type FileVerificationStatus string
+
+// ImportEntryResponse defines model for ImportEntryResponse.
+type ImportEntryResponse struct {
+	Error  *string `json:"error"`
+	FileId *int64  `json:"fileId"`
+
+	// Path Path of the entry inside the archive
+	Path   string            `json:"path"`
+	Status ImportEntryStatus `json:"status"`
+}
+
+// ImportEntryStatus defines model for ImportEntryStatus.
+type ImportEntryStatus string
+
+// ImportVersionArchiveResponse defines model for ImportVersionArchiveResponse.
+type ImportVersionArchiveResponse struct {
+	Entries  []ImportEntryResponse `json:"entries"`
+	Failed   int                   `json:"failed"`
+	Imported int                   `json:"imported"`
+}
+
+// JobResponse defines model for JobResponse.
+type JobResponse struct {
+	Attempts    int32      `json:"attempts"`
+	CompletedAt *time.Time `json:"completedAt"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	Id          int64      `json:"id"`
+	Kind        string     `json:"kind"`
+	LastError   *string    `json:"lastError"`
+	LockedAt    *time.Time `json:"lockedAt"`
+	MaxAttempts int32      `json:"maxAttempts"`
+
+	// Payload JSON payload the job was queued with
+	Payload interface{} `json:"payload"`
+
+	// RunAt When the job is due, or was due for its last attempt
+	RunAt     time.Time `json:"runAt"`
+	Status    JobStatus `json:"status"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// JobStatus defines model for JobStatus.
+type JobStatus string
+
+// LatestVersionPolicyResponse defines model for LatestVersionPolicyResponse.
+type LatestVersionPolicyResponse struct {
+	PinnedVersionId *int64 `json:"pinnedVersionId"`
+
+	// Strategy released picks the most recently released version, semver the released version with the highest
+	// semantic version as its name and pinned the pinned version while it stays released.
+	Strategy LatestVersionStrategy `json:"strategy"`
+}
+
+// LatestVersionStrategy released picks the most recently released version, semver the released version with the highest
+// semantic version as its name and pinned the pinned version while it stays released.
+type LatestVersionStrategy string
+
+// ListAuditEventsResponse defines model for ListAuditEventsResponse.
+type ListAuditEventsResponse struct {
+	Events []AuditEventResponse `json:"events"`
+	Limit  int64                `json:"limit"`
+	Offset int64                `json:"offset"`
+}
+
+// ListFileRevisionsResponse defines model for ListFileRevisionsResponse.
+type ListFileRevisionsResponse struct {
+	Limit     int64                  `json:"limit"`
+	Offset    int64                  `json:"offset"`
+	Revisions []FileRevisionResponse `json:"revisions"`
+}
+
 // ListFilesResponse defines model for ListFilesResponse.
 type ListFilesResponse struct {
 	Files  []FileResponse `json:"files"`
@@ -79,6 +997,27 @@ type ListFilesResponse struct {
 	Offset int64          `json:"offset"`
 }
 
+// ListJobsResponse defines model for ListJobsResponse.
+type ListJobsResponse struct {
+	Jobs   []JobResponse `json:"jobs"`
+	Limit  int64         `json:"limit"`
+	Offset int64         `json:"offset"`
+}
+
+// ListLocationsResponse defines model for ListLocationsResponse.
+type ListLocationsResponse struct {
+	Limit     int64              `json:"limit"`
+	Locations []LocationResponse `json:"locations"`
+	Offset    int64              `json:"offset"`
+}
+
+// ListMembersResponse defines model for ListMembersResponse.
+type ListMembersResponse struct {
+	Limit   int64            `json:"limit"`
+	Members []MemberResponse `json:"members"`
+	Offset  int64            `json:"offset"`
+}
+
 // ListProjectsResponse defines model for ListProjectsResponse.
 type ListProjectsResponse struct {
 	Limit    int64             `json:"limit"`
@@ -86,6 +1025,20 @@ type ListProjectsResponse struct {
 	Projects []ProjectResponse `json:"projects"`
 }
 
+// ListShareLinksResponse defines model for ListShareLinksResponse.
+type ListShareLinksResponse struct {
+	Limit      int64               `json:"limit"`
+	Offset     int64               `json:"offset"`
+	ShareLinks []ShareLinkResponse `json:"shareLinks"`
+}
+
+// ListShortLinksResponse defines model for ListShortLinksResponse.
+type ListShortLinksResponse struct {
+	Limit      int64               `json:"limit"`
+	Offset     int64               `json:"offset"`
+	ShortLinks []ShortLinkResponse `json:"shortLinks"`
+}
+
 // ListVersionsResponse defines model for ListVersionsResponse.
 type ListVersionsResponse struct {
 	Limit    int64             `json:"limit"`
@@ -93,24 +1046,176 @@ type ListVersionsResponse struct {
 	Versions []VersionResponse `json:"versions"`
 }
 
-// ProjectResponse defines model for ProjectResponse.
-type ProjectResponse struct {
+// ListWebhookDeliveriesResponse defines model for ListWebhookDeliveriesResponse.
+type ListWebhookDeliveriesResponse struct {
+	Deliveries []WebhookDeliveryResponse `json:"deliveries"`
+	Limit      int64                     `json:"limit"`
+	Offset     int64                     `json:"offset"`
+}
+
+// ListWebhooksResponse defines model for ListWebhooksResponse.
+type ListWebhooksResponse struct {
+	Limit    int64             `json:"limit"`
+	Offset   int64             `json:"offset"`
+	Webhooks []WebhookResponse `json:"webhooks"`
+}
+
+// LocationResponse defines model for LocationResponse.
+type LocationResponse struct {
+	Address   *string   `json:"address"`
 	CreatedAt time.Time `json:"createdAt"`
 	Id        int64     `json:"id"`
+	Lat       *float64  `json:"lat"`
+	Lon       *float64  `json:"lon"`
 	Name      string    `json:"name"`
-	Slug      string    `json:"slug"`
 	UpdatedAt time.Time `json:"updatedAt"`
 }
 
+// MemberResponse defines model for MemberResponse.
+type MemberResponse struct {
+	CreatedAt time.Time `json:"createdAt"`
+	ProjectId int64     `json:"projectId"`
+
+	// Role Viewers can read the project and its versions and files, editors can also change them and admins can additionally manage the members and delete the project.
+	Role      MemberRole `json:"role"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+	UserId    int64      `json:"userId"`
+}
+
+// MemberRole Viewers can read the project and its versions and files, editors can also change them and admins can additionally manage the members and delete the project.
+type MemberRole string
+
+// ProjectResponse defines model for ProjectResponse.
+type ProjectResponse struct {
+	CreatedAt time.Time `json:"createdAt"`
+
+	// DistanceKm Distance to the near point in kilometres, only present when searching near a point.
+	DistanceKm *float64          `json:"distanceKm,omitempty"`
+	Id         int64             `json:"id"`
+	Location   *LocationResponse `json:"location"`
+	LocationId *int64            `json:"locationId"`
+	Name       string            `json:"name"`
+	Slug       string            `json:"slug"`
+	UpdatedAt  time.Time         `json:"updatedAt"`
+}
+
+// SearchHitResponse defines model for SearchHitResponse.
+type SearchHitResponse struct {
+	// Highlight Matched text with the matching words wrapped in <b> tags, a snippet of the file text in content mode
+	Highlight string `json:"highlight"`
+	Id        int64  `json:"id"`
+
+	// ProjectId Project of the hit, files have none as they can be attached to several projects
+	ProjectId *int64        `json:"projectId,omitempty"`
+	Rank      float32       `json:"rank"`
+	Title     string        `json:"title"`
+	Type      SearchHitType `json:"type"`
+}
+
+// SearchHitType defines model for SearchHitType.
+type SearchHitType string
+
+// SearchResponse defines model for SearchResponse.
+type SearchResponse struct {
+	Hits   []SearchHitResponse `json:"hits"`
+	Limit  int64               `json:"limit"`
+	Offset int64               `json:"offset"`
+}
+
+// ShareLinkResponse defines model for ShareLinkResponse.
+type ShareLinkResponse struct {
+	CreatedAt     time.Time  `json:"createdAt"`
+	DownloadCount int64      `json:"downloadCount"`
+	ExpiresAt     time.Time  `json:"expiresAt"`
+	FileId        *int64     `json:"fileId"`
+	HasPassword   bool       `json:"hasPassword"`
+	Id            int64      `json:"id"`
+	MaxDownloads  *int64     `json:"maxDownloads"`
+	RevokedAt     *time.Time `json:"revokedAt"`
+	UpdatedAt     time.Time  `json:"updatedAt"`
+	Url           string     `json:"url"`
+	VersionId     *int64     `json:"versionId"`
+}
+
+// SharedFileResponse defines model for SharedFileResponse.
+type SharedFileResponse struct {
+	// Checksum Hex encoded SHA-256 digest of the file contents
+	Checksum *string `json:"checksum"`
+	Id       int64   `json:"id"`
+	MimeType *string `json:"mimeType"`
+	Name     string  `json:"name"`
+	Size     *int64  `json:"size"`
+	Url      string  `json:"url"`
+}
+
+// SharedVersionResponse defines model for SharedVersionResponse.
+type SharedVersionResponse struct {
+	ExpiresAt time.Time            `json:"expiresAt"`
+	Files     []SharedFileResponse `json:"files"`
+	Limit     int64                `json:"limit"`
+	Offset    int64                `json:"offset"`
+	VersionId int64                `json:"versionId"`
+}
+
+// ShortLinkResponse defines model for ShortLinkResponse.
+type ShortLinkResponse struct {
+	Code       string              `json:"code"`
+	CreatedAt  time.Time           `json:"createdAt"`
+	HitCount   int64               `json:"hitCount"`
+	Id         int64               `json:"id"`
+	LastHitAt  *time.Time          `json:"lastHitAt"`
+	ProjectId  *int64              `json:"projectId"`
+	RevokedAt  *time.Time          `json:"revokedAt"`
+	TargetType ShortLinkTargetType `json:"targetType"`
+	UpdatedAt  time.Time           `json:"updatedAt"`
+	Url        string              `json:"url"`
+	VersionId  *int64              `json:"versionId"`
+}
+
+// ShortLinkTargetType defines model for ShortLinkTargetType.
+type ShortLinkTargetType string
+
 // TokenInfoResponse defines model for TokenInfoResponse.
 type TokenInfoResponse struct {
 	Subject string `json:"subject"`
 }
 
+// UpdateLatestVersionPolicyRequest defines model for UpdateLatestVersionPolicyRequest.
+type UpdateLatestVersionPolicyRequest struct {
+	// PinnedVersionId Required for the pinned strategy
+	PinnedVersionId *int64 `json:"pinnedVersionId,omitempty"`
+
+	// Strategy released picks the most recently released version, semver the released version with the highest
+	// semantic version as its name and pinned the pinned version while it stays released.
+	Strategy LatestVersionStrategy `json:"strategy"`
+}
+
+// UpdateLocationRequest defines model for UpdateLocationRequest.
+type UpdateLocationRequest struct {
+	Address *string  `json:"address,omitempty"`
+	Lat     *float64 `json:"lat,omitempty"`
+	Lon     *float64 `json:"lon,omitempty"`
+	Name    string   `json:"name"`
+}
+
+// UpdateMemberRequest defines model for UpdateMemberRequest.
+type UpdateMemberRequest struct {
+	// Role Viewers can read the project and its versions and files, editors can also change them and admins can additionally manage the members and delete the project.
+	Role MemberRole `json:"role"`
+}
+
 // UpdateProjectRequest defines model for UpdateProjectRequest.
 type UpdateProjectRequest struct {
-	Name string `json:"name"`
-	Slug string `json:"slug"`
+	LocationId *int64 `json:"locationId,omitempty"`
+	Name       string `json:"name"`
+	Slug       string `json:"slug"`
+}
+
+// UpdateShortLinkRequest defines model for UpdateShortLinkRequest.
+type UpdateShortLinkRequest struct {
+	ProjectId  *int64              `json:"projectId,omitempty"`
+	TargetType ShortLinkTargetType `json:"targetType"`
+	VersionId  *int64              `json:"versionId,omitempty"`
 }
 
 // UpdateVersionRequest defines model for UpdateVersionRequest.
@@ -119,62 +1224,367 @@ type UpdateVersionRequest struct {
 	Name        string  `json:"name"`
 }
 
+// UpdateVersionStatusRequest defines model for UpdateVersionStatusRequest.
+type UpdateVersionStatusRequest struct {
+	Status VersionStatus `json:"status"`
+}
+
+// UpdateWebhookRequest defines model for UpdateWebhookRequest.
+type UpdateWebhookRequest struct {
+	Events   []WebhookEvent `json:"events"`
+	IsActive bool           `json:"isActive"`
+	Url      string         `json:"url"`
+}
+
+// UploadSessionResponse defines model for UploadSessionResponse.
+type UploadSessionResponse struct {
+	CreatedAt  time.Time          `json:"createdAt"`
+	ExpiresAt  time.Time          `json:"expiresAt"`
+	FileId     int64              `json:"fileId"`
+	Id         openapi_types.UUID `json:"id"`
+	IsComplete bool               `json:"isComplete"`
+	Offset     int64              `json:"offset"`
+	Size       int64              `json:"size"`
+	UpdatedAt  time.Time          `json:"updatedAt"`
+}
+
 // UserResponse defines model for UserResponse.
 type UserResponse struct {
-	CreatedAt     time.Time           `json:"createdAt"`
-	Email         openapi_types.Email `json:"email"`
-	EmailVerified bool                `json:"emailVerified"`
-	Id            int64               `json:"id"`
-	Name          string              `json:"name"`
-	UpdatedAt     time.Time           `json:"updatedAt"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Email Missing when the account has no email or its email already belongs to another user
+	Email         *openapi_types.Email `json:"email"`
+	EmailVerified bool                 `json:"emailVerified"`
+	Id            int64                `json:"id"`
+	Name          string               `json:"name"`
+	UpdatedAt     time.Time            `json:"updatedAt"`
+}
+
+// VerifyFilesResponse defines model for VerifyFilesResponse.
+type VerifyFilesResponse struct {
+	Files  []FileVerificationResponse `json:"files"`
+	Limit  int64                      `json:"limit"`
+	Offset int64                      `json:"offset"`
+}
+
+// VersionComparisonResponse defines model for VersionComparisonResponse.
+type VersionComparisonResponse struct {
+	Added         []ComparedFileResponse   `json:"added"`
+	BaseVersion   VersionResponse          `json:"baseVersion"`
+	Modified      []FileChangeResponse     `json:"modified"`
+	Removed       []ComparedFileResponse   `json:"removed"`
+	Renamed       []FileChangeResponse     `json:"renamed"`
+	Summary       VersionComparisonSummary `json:"summary"`
+	TargetVersion VersionResponse          `json:"targetVersion"`
+}
+
+// VersionComparisonSummary defines model for VersionComparisonSummary.
+type VersionComparisonSummary struct {
+	Added     int `json:"added"`
+	Modified  int `json:"modified"`
+	Removed   int `json:"removed"`
+	Renamed   int `json:"renamed"`
+	Unchanged int `json:"unchanged"`
 }
 
 // VersionResponse defines model for VersionResponse.
 type VersionResponse struct {
-	CreatedAt   time.Time `json:"createdAt"`
-	Description *string   `json:"description"`
-	Id          int64     `json:"id"`
-	Name        string    `json:"name"`
-	ProjectId   int64     `json:"projectId"`
-	UpdatedAt   time.Time `json:"updatedAt"`
+	CreatedAt   time.Time     `json:"createdAt"`
+	Description *string       `json:"description"`
+	Id          int64         `json:"id"`
+	Name        string        `json:"name"`
+	ProjectId   int64         `json:"projectId"`
+	ReleasedAt  *time.Time    `json:"releasedAt"`
+	Status      VersionStatus `json:"status"`
+	UpdatedAt   time.Time     `json:"updatedAt"`
+}
+
+// VersionStatus defines model for VersionStatus.
+type VersionStatus string
+
+// WebhookDeliveryResponse defines model for WebhookDeliveryResponse.
+type WebhookDeliveryResponse struct {
+	Attempts    int32      `json:"attempts"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	DeliveredAt *time.Time `json:"deliveredAt"`
+
+	// Event Subscriptions of a project are deleted with it, only webhooks without a project receive project.deleted. version.status_changed covers submitting a draft for review and sending it back.
+	Event WebhookEvent `json:"event"`
+
+	// EventId ID of the event, shared by its redeliveries
+	EventId   string  `json:"eventId"`
+	Id        int64   `json:"id"`
+	LastError *string `json:"lastError"`
+
+	// Payload JSON body that is posted, the event with the API representation of the resource as data
+	Payload interface{} `json:"payload"`
+
+	// RedeliveryOf Delivery this one sends again
+	RedeliveryOf *int64 `json:"redeliveryOf"`
+
+	// ResponseBody Start of the body of the last response
+	ResponseBody   *string               `json:"responseBody"`
+	ResponseStatus *int32                `json:"responseStatus"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	UpdatedAt      time.Time             `json:"updatedAt"`
+	WebhookId      int64                 `json:"webhookId"`
+}
+
+// WebhookDeliveryStatus defines model for WebhookDeliveryStatus.
+type WebhookDeliveryStatus string
+
+// WebhookEvent Subscriptions of a project are deleted with it, only webhooks without a project receive project.deleted. version.status_changed covers submitting a draft for review and sending it back.
+type WebhookEvent string
+
+// WebhookResponse defines model for WebhookResponse.
+type WebhookResponse struct {
+	CreatedAt time.Time      `json:"createdAt"`
+	Events    []WebhookEvent `json:"events"`
+	Id        int64          `json:"id"`
+	IsActive  bool           `json:"isActive"`
+	ProjectId *int64         `json:"projectId"`
+
+	// Secret Key the deliveries are signed with, only returned when the webhook is created
+	Secret    *string   `json:"secret,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	Url       string    `json:"url"`
 }
 
+// HeaderContentDigest defines model for HeaderContentDigest.
+type HeaderContentDigest = string
+
+// HeaderLastEventId defines model for HeaderLastEventId.
+type HeaderLastEventId = string
+
+// HeaderReprDigest defines model for HeaderReprDigest.
+type HeaderReprDigest = string
+
+// HeaderUploadOffset defines model for HeaderUploadOffset.
+type HeaderUploadOffset = int64
+
 // PathFileId defines model for PathFileId.
 type PathFileId = int64
 
+// PathFileRevisionId defines model for PathFileRevisionId.
+type PathFileRevisionId = int64
+
+// PathJobId defines model for PathJobId.
+type PathJobId = int64
+
+// PathLocationId defines model for PathLocationId.
+type PathLocationId = int64
+
+// PathOtherVersionId defines model for PathOtherVersionId.
+type PathOtherVersionId = int64
+
 // PathProjectId defines model for PathProjectId.
 type PathProjectId = int64
 
+// PathShareLinkId defines model for PathShareLinkId.
+type PathShareLinkId = int64
+
+// PathShareLinkToken defines model for PathShareLinkToken.
+type PathShareLinkToken = string
+
+// PathShortLinkId defines model for PathShortLinkId.
+type PathShortLinkId = int64
+
+// PathUploadId defines model for PathUploadId.
+type PathUploadId = openapi_types.UUID
+
 // PathUserId defines model for PathUserId.
 type PathUserId = int64
 
 // PathVersionId defines model for PathVersionId.
 type PathVersionId = int64
 
+// PathWebhookDeliveryId defines model for PathWebhookDeliveryId.
+type PathWebhookDeliveryId = int64
+
+// PathWebhookId defines model for PathWebhookId.
+type PathWebhookId = int64
+
+// QueryArchiveFormat defines model for QueryArchiveFormat.
+type QueryArchiveFormat string
+
+// QueryAuditAction Attach and detach are recorded on the file with the version in the after or before state, clone on the new version, revoke on share and short links
+type QueryAuditAction = AuditAction
+
+// QueryAuditActor defines model for QueryAuditActor.
+type QueryAuditActor = string
+
+// QueryAuditFormat defines model for QueryAuditFormat.
+type QueryAuditFormat string
+
+// QueryAuditFrom defines model for QueryAuditFrom.
+type QueryAuditFrom = time.Time
+
+// QueryAuditResourceId defines model for QueryAuditResourceId.
+type QueryAuditResourceId = int64
+
+// QueryAuditResourceType Membership events use the project ID as resource ID
+type QueryAuditResourceType = AuditResourceType
+
+// QueryAuditTo defines model for QueryAuditTo.
+type QueryAuditTo = time.Time
+
+// QueryBoundingBox defines model for QueryBoundingBox.
+type QueryBoundingBox = string
+
+// QueryComparisonFormat defines model for QueryComparisonFormat.
+type QueryComparisonFormat string
+
+// QueryFileId defines model for QueryFileId.
+type QueryFileId = int64
+
+// QueryFileName defines model for QueryFileName.
+type QueryFileName = string
+
+// QueryJobKind defines model for QueryJobKind.
+type QueryJobKind = string
+
+// QueryJobStatus defines model for QueryJobStatus.
+type QueryJobStatus = JobStatus
+
+// QueryLatestVersionStrategy released picks the most recently released version, semver the released version with the highest
+// semantic version as its name and pinned the pinned version while it stays released.
+type QueryLatestVersionStrategy = LatestVersionStrategy
+
 // QueryLimit defines model for QueryLimit.
 type QueryLimit = int64
 
+// QueryLocationId defines model for QueryLocationId.
+type QueryLocationId = int64
+
+// QueryNear defines model for QueryNear.
+type QueryNear = string
+
 // QueryOffset defines model for QueryOffset.
 type QueryOffset = int64
 
 // QueryProjectId defines model for QueryProjectId.
 type QueryProjectId = int64
 
+// QueryQRCodeFormat defines model for QueryQRCodeFormat.
+type QueryQRCodeFormat string
+
+// QueryQRCodeLabel defines model for QueryQRCodeLabel.
+type QueryQRCodeLabel = string
+
+// QueryQRCodeLevel defines model for QueryQRCodeLevel.
+type QueryQRCodeLevel string
+
+// QueryQRCodeSize defines model for QueryQRCodeSize.
+type QueryQRCodeSize = int
+
+// QueryRadiusKm defines model for QueryRadiusKm.
+type QueryRadiusKm = float64
+
+// QuerySearchMode defines model for QuerySearchMode.
+type QuerySearchMode string
+
+// QuerySearchTerm defines model for QuerySearchTerm.
+type QuerySearchTerm = string
+
+// QuerySearchTypes defines model for QuerySearchTypes.
+type QuerySearchTypes = string
+
 // QueryVersionId defines model for QueryVersionId.
 type QueryVersionId = int64
 
+// QueryVersionStatus defines model for QueryVersionStatus.
+type QueryVersionStatus = VersionStatus
+
 // BadRequest defines model for BadRequest.
 type BadRequest = ErrorResponse
 
 // Conflict defines model for Conflict.
 type Conflict = ErrorResponse
 
+// Forbidden defines model for Forbidden.
+type Forbidden = ErrorResponse
+
+// Gone defines model for Gone.
+type Gone = ErrorResponse
+
 // InternalServerError defines model for InternalServerError.
 type InternalServerError = ErrorResponse
 
 // NotFound defines model for NotFound.
 type NotFound = ErrorResponse
 
+// PayloadTooLarge defines model for PayloadTooLarge.
+type PayloadTooLarge = ErrorResponse
+
+// Unauthorized defines model for Unauthorized.
+type Unauthorized = ErrorResponse
+
+// UnprocessableEntity defines model for UnprocessableEntity.
+type UnprocessableEntity = ErrorResponse
+
+// VerifyFilesParams defines parameters for VerifyFiles.
+type VerifyFilesParams struct {
+	// Limit Maximum of items to return per page
+	Limit *QueryLimit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Offset of items to skip
+	Offset *QueryOffset `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// ListJobsParams defines parameters for ListJobs.
+type ListJobsParams struct {
+	// Limit Maximum of items to return per page
+	Limit *QueryLimit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Offset of items to skip
+	Offset *QueryOffset `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Status Only return jobs with this status
+	Status *QueryJobStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// Kind Only return jobs of this kind
+	Kind *QueryJobKind `form:"kind,omitempty" json:"kind,omitempty"`
+}
+
+// ListAuditEventsParams defines parameters for ListAuditEvents.
+type ListAuditEventsParams struct {
+	// Limit Maximum of items to return per page
+	Limit *QueryLimit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Offset of items to skip
+	Offset *QueryOffset `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// ResourceType Only return events of this type of resource
+	ResourceType *QueryAuditResourceType `form:"resourceType,omitempty" json:"resourceType,omitempty"`
+
+	// ResourceId Only return events of the resource with this ID, requires resourceType
+	ResourceId *QueryAuditResourceId `form:"resourceId,omitempty" json:"resourceId,omitempty"`
+
+	// Actor Only return events of the caller with this token subject
+	Actor *QueryAuditActor `form:"actor,omitempty" json:"actor,omitempty"`
+
+	// Action Only return events with this action
+	Action *QueryAuditAction `form:"action,omitempty" json:"action,omitempty"`
+
+	// From Only return events that occurred at or after this time
+	From *QueryAuditFrom `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Only return events that occurred before this time
+	To *QueryAuditTo `form:"to,omitempty" json:"to,omitempty"`
+
+	// Format Return the events as JSON or export them as CSV
+	Format *ListAuditEventsParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// ListAuditEventsParamsFormat defines parameters for ListAuditEvents.
+type ListAuditEventsParamsFormat string
+
+// StreamEventsParams defines parameters for StreamEvents.
+type StreamEventsParams struct {
+	// LastEventID ID of the last event the client received, the events after it are replayed
+	LastEventID *HeaderLastEventId `json:"Last-Event-ID,omitempty"`
+}
+
 // ListFilesParams defines parameters for ListFiles.
 type ListFilesParams struct {
 	// Limit Maximum of items to return per page
@@ -187,11 +1597,71 @@ type ListFilesParams struct {
 	VersionId *QueryVersionId `form:"versionId,omitempty" json:"versionId,omitempty"`
 }
 
+// GetFileQRCodeParams defines parameters for GetFileQRCode.
+type GetFileQRCodeParams struct {
+	// Format Image format of the QR code
+	Format *GetFileQRCodeParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+
+	// Size Width of the QR code in pixels, a label adds to the height
+	Size *QueryQRCodeSize `form:"size,omitempty" json:"size,omitempty"`
+
+	// Level Error correction level, higher levels survive more damage but need more modules
+	Level *GetFileQRCodeParamsLevel `form:"level,omitempty" json:"level,omitempty"`
+
+	// Label Caption printed below the QR code
+	Label *QueryQRCodeLabel `form:"label,omitempty" json:"label,omitempty"`
+}
+
+// GetFileQRCodeParamsFormat defines parameters for GetFileQRCode.
+type GetFileQRCodeParamsFormat string
+
+// GetFileQRCodeParamsLevel defines parameters for GetFileQRCode.
+type GetFileQRCodeParamsLevel string
+
+// ListFileRevisionsParams defines parameters for ListFileRevisions.
+type ListFileRevisionsParams struct {
+	// Limit Maximum of items to return per page
+	Limit *QueryLimit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Offset of items to skip
+	Offset *QueryOffset `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
 // UploadFileMultipartBody defines parameters for UploadFile.
 type UploadFileMultipartBody struct {
 	File openapi_types.File `json:"file"`
 }
 
+// UploadFileParams defines parameters for UploadFile.
+type UploadFileParams struct {
+	// ReprDigest Expected SHA-256 digest of the complete file as defined in RFC 9530, e.g. sha-256=:<base64>:
+	ReprDigest *HeaderReprDigest `json:"Repr-Digest,omitempty"`
+}
+
+// CreateUploadSessionParams defines parameters for CreateUploadSession.
+type CreateUploadSessionParams struct {
+	// ReprDigest Expected SHA-256 digest of the complete file as defined in RFC 9530, e.g. sha-256=:<base64>:
+	ReprDigest *HeaderReprDigest `json:"Repr-Digest,omitempty"`
+}
+
+// UploadChunkParams defines parameters for UploadChunk.
+type UploadChunkParams struct {
+	// UploadOffset Offset of the chunk within the file, must equal the number of bytes received so far
+	UploadOffset HeaderUploadOffset `json:"Upload-Offset"`
+
+	// ContentDigest Expected SHA-256 digest of the request body as defined in RFC 9530, e.g. sha-256=:<base64>:
+	ContentDigest *HeaderContentDigest `json:"Content-Digest,omitempty"`
+}
+
+// ListLocationsParams defines parameters for ListLocations.
+type ListLocationsParams struct {
+	// Limit Maximum of items to return per page
+	Limit *QueryLimit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Offset of items to skip
+	Offset *QueryOffset `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
 // ListProjectsParams defines parameters for ListProjects.
 type ListProjectsParams struct {
 	// Limit Maximum of items to return per page
@@ -199,8 +1669,134 @@ type ListProjectsParams struct {
 
 	// Offset Offset of items to skip
 	Offset *QueryOffset `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// LocationId Location ID
+	LocationId *QueryLocationId `form:"locationId,omitempty" json:"locationId,omitempty"`
+
+	// Near Only return projects whose location has coordinates, sorted by their distance to this point given as lat,lon.
+	Near *QueryNear `form:"near,omitempty" json:"near,omitempty"`
+
+	// RadiusKm Maximum distance in kilometres to the near point. Requires near.
+	RadiusKm *QueryRadiusKm `form:"radiusKm,omitempty" json:"radiusKm,omitempty"`
+
+	// Bbox Only return projects whose location lies within the box given as minLat,minLon,maxLat,maxLon. A box with minLon greater than maxLon crosses the antimeridian.
+	Bbox *QueryBoundingBox `form:"bbox,omitempty" json:"bbox,omitempty"`
+}
+
+// GetLatestFileByNameParams defines parameters for GetLatestFileByName.
+type GetLatestFileByNameParams struct {
+	// Name File name
+	Name QueryFileName `form:"name" json:"name"`
+}
+
+// ListProjectMembersParams defines parameters for ListProjectMembers.
+type ListProjectMembersParams struct {
+	// Limit Maximum of items to return per page
+	Limit *QueryLimit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Offset of items to skip
+	Offset *QueryOffset `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// GetProjectQRCodeParams defines parameters for GetProjectQRCode.
+type GetProjectQRCodeParams struct {
+	// Format Image format of the QR code
+	Format *GetProjectQRCodeParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+
+	// Size Width of the QR code in pixels, a label adds to the height
+	Size *QueryQRCodeSize `form:"size,omitempty" json:"size,omitempty"`
+
+	// Level Error correction level, higher levels survive more damage but need more modules
+	Level *GetProjectQRCodeParamsLevel `form:"level,omitempty" json:"level,omitempty"`
+
+	// Label Caption printed below the QR code
+	Label *QueryQRCodeLabel `form:"label,omitempty" json:"label,omitempty"`
+}
+
+// GetProjectQRCodeParamsFormat defines parameters for GetProjectQRCode.
+type GetProjectQRCodeParamsFormat string
+
+// GetProjectQRCodeParamsLevel defines parameters for GetProjectQRCode.
+type GetProjectQRCodeParamsLevel string
+
+// GetLatestVersionParams defines parameters for GetLatestVersion.
+type GetLatestVersionParams struct {
+	// Strategy Resolve with this strategy instead of the one configured on the project
+	Strategy *QueryLatestVersionStrategy `form:"strategy,omitempty" json:"strategy,omitempty"`
+}
+
+// SearchParams defines parameters for Search.
+type SearchParams struct {
+	// Q Search term in web search syntax, quoted phrases, "or" and a leading "-" to exclude a word are supported
+	Q QuerySearchTerm `form:"q" json:"q"`
+
+	// Mode Search the metadata of projects, versions and files, or the text extracted from files. Content hits are files with a snippet of the matching text as highlight, the text is extracted shortly after an upload
+	Mode *SearchParamsMode `form:"mode,omitempty" json:"mode,omitempty"`
+
+	// Types Comma-separated types to search, all types are searched when omitted
+	Types *QuerySearchTypes `form:"types,omitempty" json:"types,omitempty"`
+
+	// Limit Maximum of items to return per page
+	Limit *QueryLimit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Offset of items to skip
+	Offset *QueryOffset `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// SearchParamsMode defines parameters for Search.
+type SearchParamsMode string
+
+// ListShareLinksParams defines parameters for ListShareLinks.
+type ListShareLinksParams struct {
+	// Limit Maximum of items to return per page
+	Limit *QueryLimit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Offset of items to skip
+	Offset *QueryOffset `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// FileId File ID
+	FileId *QueryFileId `form:"fileId,omitempty" json:"fileId,omitempty"`
+
+	// VersionId Version ID
+	VersionId *QueryVersionId `form:"versionId,omitempty" json:"versionId,omitempty"`
+}
+
+// ListShortLinksParams defines parameters for ListShortLinks.
+type ListShortLinksParams struct {
+	// Limit Maximum of items to return per page
+	Limit *QueryLimit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Offset of items to skip
+	Offset *QueryOffset `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// ProjectId Project ID
+	ProjectId *QueryProjectId `form:"projectId,omitempty" json:"projectId,omitempty"`
+
+	// VersionId Version ID
+	VersionId *QueryVersionId `form:"versionId,omitempty" json:"versionId,omitempty"`
+}
+
+// GetShortLinkQRCodeParams defines parameters for GetShortLinkQRCode.
+type GetShortLinkQRCodeParams struct {
+	// Format Image format of the QR code
+	Format *GetShortLinkQRCodeParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+
+	// Size Width of the QR code in pixels, a label adds to the height
+	Size *QueryQRCodeSize `form:"size,omitempty" json:"size,omitempty"`
+
+	// Level Error correction level, higher levels survive more damage but need more modules
+	Level *GetShortLinkQRCodeParamsLevel `form:"level,omitempty" json:"level,omitempty"`
+
+	// Label Caption printed below the QR code
+	Label *QueryQRCodeLabel `form:"label,omitempty" json:"label,omitempty"`
 }
 
+// GetShortLinkQRCodeParamsFormat defines parameters for GetShortLinkQRCode.
+type GetShortLinkQRCodeParamsFormat string
+
+// GetShortLinkQRCodeParamsLevel defines parameters for GetShortLinkQRCode.
+type GetShortLinkQRCodeParamsLevel string
+
 // ListVersionsParams defines parameters for ListVersions.
 type ListVersionsParams struct {
 	// Limit Maximum of items to return per page
@@ -211,6 +1807,83 @@ type ListVersionsParams struct {
 
 	// ProjectId Project ID
 	ProjectId *QueryProjectId `form:"projectId,omitempty" json:"projectId,omitempty"`
+
+	// Status Only return versions with this status
+	Status *QueryVersionStatus `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// DownloadVersionArchiveParams defines parameters for DownloadVersionArchive.
+type DownloadVersionArchiveParams struct {
+	// Format Archive format
+	Format *DownloadVersionArchiveParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// DownloadVersionArchiveParamsFormat defines parameters for DownloadVersionArchive.
+type DownloadVersionArchiveParamsFormat string
+
+// CompareVersionsParams defines parameters for CompareVersions.
+type CompareVersionsParams struct {
+	// Format Render the comparison as JSON or as a plain-text changelog
+	Format *CompareVersionsParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// CompareVersionsParamsFormat defines parameters for CompareVersions.
+type CompareVersionsParamsFormat string
+
+// ImportVersionArchiveMultipartBody defines parameters for ImportVersionArchive.
+type ImportVersionArchiveMultipartBody struct {
+	File openapi_types.File `json:"file"`
+}
+
+// GetVersionQRCodeParams defines parameters for GetVersionQRCode.
+type GetVersionQRCodeParams struct {
+	// Format Image format of the QR code
+	Format *GetVersionQRCodeParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+
+	// Size Width of the QR code in pixels, a label adds to the height
+	Size *QueryQRCodeSize `form:"size,omitempty" json:"size,omitempty"`
+
+	// Level Error correction level, higher levels survive more damage but need more modules
+	Level *GetVersionQRCodeParamsLevel `form:"level,omitempty" json:"level,omitempty"`
+
+	// Label Caption printed below the QR code
+	Label *QueryQRCodeLabel `form:"label,omitempty" json:"label,omitempty"`
+}
+
+// GetVersionQRCodeParamsFormat defines parameters for GetVersionQRCode.
+type GetVersionQRCodeParamsFormat string
+
+// GetVersionQRCodeParamsLevel defines parameters for GetVersionQRCode.
+type GetVersionQRCodeParamsLevel string
+
+// ListWebhooksParams defines parameters for ListWebhooks.
+type ListWebhooksParams struct {
+	// Limit Maximum of items to return per page
+	Limit *QueryLimit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Offset of items to skip
+	Offset *QueryOffset `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// ProjectId Project ID
+	ProjectId *QueryProjectId `form:"projectId,omitempty" json:"projectId,omitempty"`
+}
+
+// ListWebhookDeliveriesParams defines parameters for ListWebhookDeliveries.
+type ListWebhookDeliveriesParams struct {
+	// Limit Maximum of items to return per page
+	Limit *QueryLimit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Offset of items to skip
+	Offset *QueryOffset `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// OpenShareLinkParams defines parameters for OpenShareLink.
+type OpenShareLinkParams struct {
+	// Limit Maximum of items to return per page
+	Limit *QueryLimit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Offset of items to skip
+	Offset *QueryOffset `form:"offset,omitempty" json:"offset,omitempty"`
 }
 
 // CreateFileJSONRequestBody defines body for CreateFile for application/json ContentType.
@@ -219,12 +1892,39 @@ type CreateFileJSONRequestBody = CreateFileRequest
 // UploadFileMultipartRequestBody defines body for UploadFile for multipart/form-data ContentType.
 type UploadFileMultipartRequestBody UploadFileMultipartBody
 
+// CreateUploadSessionJSONRequestBody defines body for CreateUploadSession for application/json ContentType.
+type CreateUploadSessionJSONRequestBody = CreateUploadSessionRequest
+
+// CreateLocationJSONRequestBody defines body for CreateLocation for application/json ContentType.
+type CreateLocationJSONRequestBody = CreateLocationRequest
+
+// UpdateLocationByIdJSONRequestBody defines body for UpdateLocationById for application/json ContentType.
+type UpdateLocationByIdJSONRequestBody = UpdateLocationRequest
+
 // CreateProjectJSONRequestBody defines body for CreateProject for application/json ContentType.
 type CreateProjectJSONRequestBody = CreateProjectRequest
 
 // UpdateProjectByIdJSONRequestBody defines body for UpdateProjectById for application/json ContentType.
 type UpdateProjectByIdJSONRequestBody = UpdateProjectRequest
 
+// UpdateLatestVersionPolicyJSONRequestBody defines body for UpdateLatestVersionPolicy for application/json ContentType.
+type UpdateLatestVersionPolicyJSONRequestBody = UpdateLatestVersionPolicyRequest
+
+// CreateProjectMemberJSONRequestBody defines body for CreateProjectMember for application/json ContentType.
+type CreateProjectMemberJSONRequestBody = CreateMemberRequest
+
+// UpdateProjectMemberJSONRequestBody defines body for UpdateProjectMember for application/json ContentType.
+type UpdateProjectMemberJSONRequestBody = UpdateMemberRequest
+
+// CreateShareLinkJSONRequestBody defines body for CreateShareLink for application/json ContentType.
+type CreateShareLinkJSONRequestBody = CreateShareLinkRequest
+
+// CreateShortLinkJSONRequestBody defines body for CreateShortLink for application/json ContentType.
+type CreateShortLinkJSONRequestBody = CreateShortLinkRequest
+
+// UpdateShortLinkByIdJSONRequestBody defines body for UpdateShortLinkById for application/json ContentType.
+type UpdateShortLinkByIdJSONRequestBody = UpdateShortLinkRequest
+
 // CreateUserJSONRequestBody defines body for CreateUser for application/json ContentType.
 type CreateUserJSONRequestBody = CreateUserRequest
 
@@ -237,47 +1937,252 @@ type UpdateVersionByIdJSONRequestBody = UpdateVersionRequest
 // AttachFileToVersionJSONRequestBody defines body for AttachFileToVersion for application/json ContentType.
 type AttachFileToVersionJSONRequestBody = AttachFileToVersionRequest
 
+// CloneVersionJSONRequestBody defines body for CloneVersion for application/json ContentType.
+type CloneVersionJSONRequestBody = CloneVersionRequest
+
 // DetachFileFromVersionJSONRequestBody defines body for DetachFileFromVersion for application/json ContentType.
 type DetachFileFromVersionJSONRequestBody = DetachFileFromVersionRequest
 
+// ImportVersionArchiveMultipartRequestBody defines body for ImportVersionArchive for multipart/form-data ContentType.
+type ImportVersionArchiveMultipartRequestBody ImportVersionArchiveMultipartBody
+
+// UpdateVersionStatusJSONRequestBody defines body for UpdateVersionStatus for application/json ContentType.
+type UpdateVersionStatusJSONRequestBody = UpdateVersionStatusRequest
+
+// CreateWebhookJSONRequestBody defines body for CreateWebhook for application/json ContentType.
+type CreateWebhookJSONRequestBody = CreateWebhookRequest
+
+// UpdateWebhookJSONRequestBody defines body for UpdateWebhook for application/json ContentType.
+type UpdateWebhookJSONRequestBody = UpdateWebhookRequest
+
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xb3ZPbthH/Vzho3kqJPOeaSfhU21dnrk1sN/HloZ5rB0euTvCRAA2AZysa/u8dfPBL",
-	"BEWeKsmSmxk/+EQSu9jfbz+wS65RzLKcUaBSoGiNcsxxBhK4/ustlstXJIXrRP1FKIpQjuUS+YjiDFCE",
-	"Fuaijzh8LAiHBEWSF+AjES8hw+qpBeMZlihChMrvLpGP5CoH8yfcA0dl6Ws5bzn7ALEcFJXX1/ch7UYA",
-	"HxRVmIv7kPMbcEEYHRT1WF//X6X9swC++olkRKonEhAxJ7kkTMn8GX8mWZF5bOERCZnwJPM4yIJTLwfu",
-	"5fgekG/U+6iWafRL9YJtXeAzzvIUUHQRhn5fs8yIspczQu1fwzq/WSwEOJQ2v3d0Fg8kH1CUmVWcmjr1",
-	"rDQLhzXrULKrnL3kXV8N6NOm6w5AdnjTFW0vDYtuc8qFmz9BjVLRUeSMCtCR4AVOfoGPBQgNVMyoBKr/",
-	"i/M8JTFWugUfhFJw3RL6DYcFitCfgibKBOaqCP7GOeO/WCFGZHejL3DiVUJLH71kdJGS+IgK1BJLH11T",
-	"CZzi9Ffgj8D1o8dTpBLuGemeEV/66DWTr1hBk+Op8ppJz4hU1+xjatXnUuJYZ4t3zFK0xZicsxy4JIZN",
-	"izqnbGXlSPRo4uX7asXb+kZ2p9xP84YDlqAUG9THOE5LG5StZmrJufwsG/cQkhN635Otnx6WbEPFE4T/",
-	"vPLsQ33ZPhJpcd9TNq/vz7FUZEER+vd7PPs9nP0w+8/tn78Z3YVe1h/bjMqbgzuBDJO0q9oHtqTzhMFf",
-	"7U/zmGWoBbV5xLFLfeE34GRBwAbBBS5SiaIFTgX4jQyTLO0Cd4ylgKlaoW/Xv7Ml9a4YTEPUr5Xr6jJs",
-	"nDHadzyprdgrwoX0bNxWGU8uwWsgpUWa4rveXhtj9bdaJYmLeeiybt7ObPvyQWu1Zm2Xpa6gChOvOMtO",
-	"KlB0Y19PlwyEUIVStB5hT3WjS4YJQ0MiYk2j5LnsgvksfPbdLLyYhRfvwjDS/+ZhGP6r7UgJljCTJAMX",
-	"3CR5cgXgIyJeMnW/7FJryN0yksE7/WtbdQmfZZCnmNDdeLw1DvtIkN9hoyh9dunY3YDk1m6LPDmA6TeY",
-	"QVRJ1oDclupX7qO31DJnBwgXpX4iQipaiWFeKRPq/+hKeqwG6HC0rCVizvFK/Z1Wh4yRo0DfyKwu9bfW",
-	"5iMuXJ1J6pLfbG/INjaVbjHP0TdUB8npoNRVxBAuY0aqJQ7Zycbi07KTzYnT7VRnlF3tVEt02WkThdOO",
-	"4F+qsjyFaDpWzr5jD0Cv6YINYykKc/dowq9udMm50Tp9JWcAs5lzKXOnHtPMmebYHn3Qw9JotbaneDJ8",
-	"ojqhimr6MW4zeRyNDAdzmT3hvLfj5AkX3m0Mtp9iVQCGuOBErn5VZYfhxpsc6HXyklFqkwZr/3DDUxSh",
-	"pZS5iILgAVZxyvDDPGFxzricExZwwGkmAvvLLIHHYP4J0nT2QNknGqjVSDKLGV2Q+4Jjq2elWke47tsR",
-	"umD97vEVi98agd7zt9dewuIiAyrr5YjU5u/e1pRFKELhPJxf6KIuB4pzgiL07Tycf2vyzlLbIsA5CR4v",
-	"gvrgcQ+VSYzmiirNyUU/2gye3rtLvOaWoDXsKP1pd9sxw9TbmwZ8ebvRC38Whnvrt/YPb46e65t/KHNf",
-	"GrGu1Wr1glafvvTRX6Y84upsa4oXWYb5Sgchmng4Tb2FBUvie1E1UwS6VRGACQfATevVjrdAyBcsWe3N",
-	"fP3ebtl1fRUWyx5+F3tToHtWdowQTMz5svgZJTzsUfikMXRAWPpdpw3WplNWmhBStYG6+F7p35UNXqz0",
-	"uOlpXtwaLzuc7LIfvF4z76VFTRv0ctw69YRkf+Y0u/awNqV3tzKTuL5POGPejyAPZK/waKTeOR59KcR+",
-	"BDkG1yD/g4R9oinDyWAau7I32Dh3LExZLEHOhOSAsy62dYF0RyjWk+HN6uj8Ia2MbnF9EqJFXuHpTls3",
-	"+f7gHMp5WZFKkmMuA4XWLMESd0Hsd3CnQdsfd7gq2K8oST6ZgZfhD+MPtGf/e6KsodUkwrbbw4Olc9XY",
-	"Pmb1fOhauNesP+VyOG8AqLBsuuwjRXHT3DtcXbzRdTyy1/cmFudQHTetFQeiDgcN1nWjYEKlbE2yc/HX",
-	"vJB2lvWyNVWvBus4zVDVfFDbhcdk/ZmWz5PQywtnPdWagewNwP1HTeesZlLU/IM/2wufBE8NAK0YWwj7",
-	"Gvq2PHojgB80ibbfeDtyBu0Mpg5QN3+ZMriTbwsDX0UEA3mfBYEZSAzlhueFXAKVysqQ1Iw4kIeOoVK5",
-	"51F9zY4jdCzdGES8v1XxshvN5RI83DbaE4AIJHsAOqvGC5MxqSfuhwSnP9b/qhDytO09Yqw4CtbafMlS",
-	"bsNJQbNzTraf0Ry0oprqb2dWTmk4NxOhA8n2G1CDrYDq3a0THKQdqe52vsF2ys2DxwayCv3m1bOR5oHd",
-	"6EHrno23fI5c+vRe4zuH5sFjDYsDUYdLB+v6U60JzQNrkp1j9daJ9uk3D6p3YDZDZsdphpLcQW0XHpP1",
-	"Z5rtJqG3pXmwbwAP1TzYJWr+wZ9JzYMJFBqJsQHW3yjOqmFajmW87DPO8SXjiXJuyzeXk5g3IeqfDU+M",
-	"Laoxv2QNY3bjSgJTuOL8nO1E2bL107v/O74Ya1R8WXCWjTJGH+PVegbUon6lMwqClMU4XTIho+/D70NU",
-	"3pb/DQAA//8hzPutW0MAAA==",
+	"H4sIAAAAAAAC/+y9DXPbttIo/FcwfJ+ZvnceWpId58szZ+ZJ46R1T9Kmdnp67znq7UAkZKEmARYAbasZ",
+	"//c7iy+CEklRsuQ4jc6caSySABaL3cViv/ApSnhecEaYktHJp2hGcEqE/vOcFOKUXhKp4FdKZCJooShn",
+	"0Ul08f2rg6Onz1Cq3yM+RWpGEHSVEUXQlGYEYYlSMqWMpIgydP72NXr59MkoiiOZzEiOoVM1L0h0Ekkl",
+	"KLuM7u7i6Jci4zj9aTqVpGHYH8t8QgQMN5krIpEgCaHXJEWSoykWtb6nXORYRScRZerZcRS7wShT5JKI",
+	"6A6GK7DAOVF2xt/ryb/mTBGm2qb+5rYgiSIpasaBIH+W8HPC03kLCmJEBpcDJGcY2v/jZFyORk+SCZbk",
+	"2bH+m5xEcURhMLMcURwxnJPoJLKwHVjgunFppvMOS/XmmjB1li5P5uzUwZ1hqRCB78xSZhT+dAiO9UP9",
+	"WiI8VUQgqhAWMN8iw3OStkEMwx/o8Q/OTnsB3EV3K5DfhwDvgX2AbB3UdxOzee5Bn5XsCt1QNaNMP4Ap",
+	"xCgvYVn+LHGmH7KVDNAIuAHkwEISR0CkVJA0OlGiJJ1ck1NG8zKPTkYNHBRHH7CavaUZMcSlBy+wmlVD",
+	"T83LtcbsGOecXFNJOWsdT1QfbGPMH/ikdag/+GRLo7zjCVZds8qqD7Yx3k9qRsS/iOjEJK9/tI1xPwj+",
+	"B0lU65CFf7+N0S5mWJB3lF21jieDL7Y64kd+RVjLoEq/6xpuWZ6YzrlQK6ZTfbGN6Rix0Tpc6V73Gqss",
+	"aRrFLXP7RRLRPo55uY0ZraL5662S+69kMuP86pRk9JqIeeuoafXBFodtHe7Gv7/vaD+XRMxfiWRGr8lb",
+	"+/3iNmdfI9uf3aH+hJbBLuFeVuOnZIrLDAD4ixZRHBEGu9B/7C+FxeDyr+i3JoIyUJUpVa8SA8TS1suy",
+	"ORJElYI5pQZ2XqRmVCJsGjUD6l9WgP6XINPoJPr/hpUmPTRv5TCEYgk0LnpB5hQEnGVEBHBqOYJkOQGR",
+	"2Q4uFyt0lQqmtiU8N/CEKqBEP1z89CPiApHbggutNObw+PXFvzZZ4z8kZ8Ei25+JvF61xG8Fz3uhUc2w",
+	"QjxJSiFIiuBvYTVZg02akzbAYYhG1kixIge2ZReQ50TyUiTkLO0Fqj9KmFbBmp+dxsiyrPTvP86LNtBF",
+	"NfBmvB1Cr8fpDT/gdF4Q+OGgWAGknccanFWDrA70R74BVUzIlAuykiAU35gcvuUlSym7/JbfdsNnVSGJ",
+	"bmZcEuQUQJRRIsNjwoTfokt6TRgwX07ZO6xi+IezOMe3+he+fcfZAL3S32paMh+gS0GwYQDMkPkMJYJL",
+	"SaTuGzOYjKApxWzQgovJhN/WsEFuMRzCopPo6Wjw/Gl8NHh6HD89HDw9jJ8OXh61o+Y1zwssqOSsXQ6x",
+	"lAh/0jMfh7IIS4RRkWHKDhS5VSiZYXZJMn65PZEE/XbIpOosVAcdnqOz0zY43CGpAY2HcX92hVF+1F02",
+	"jq9Ha4bAvmpXB4BkCLtUMw1Ry+x/4JN/UrZCyP3BJ5WIuILPm0Gyr5ooKzFmkAG5VQLrza8doAuFVSl7",
+	"gFRJWWmaNIPlX/aTUxUEHqh3WBGprCp6oQRW5HLeRO2SZ9ekBpf5FlEmFcGp2yg4A4ZgU3pZghTjRjJY",
+	"CdI6DTtu34k0Q11Niua0gWXf41swHwCkVJEctBYv4YhABb5so8hMd9jMEqNRA1PkZij72tstDtvZpX7s",
+	"rgPu3rUzbe1Mfk/G/ZFgsdl+MMMSJZyLlDJYoBhJLhRsZXMgAipQSqXCLCGAeU1FBadMVXtGhlWc8Vb5",
+	"zggWzfOLnh4ORk+Pn8RPBs8Pnx+3M+FqA5gnDXlFixZAuDNeNYDSSA7dhisNWc0eUQfOvmpf/tBWsYFm",
+	"9fP5a562HprOcnzpjkyOy38+RwlPySZbWcEug53M/JLXlx37mAHvHZ6QbBm611j/hQoB0wK1KeM3PWDM",
+	"dHfNxPShzAsteKHj54ad3Ybz7HgVnOS6Cc43QnCBEi4E0UcwlMF3MZrRyxkR5pdEshTXcEDNuSAoxRrz",
+	"k1IhRkhqHuY8LTPStiHobloQ/z5A+7so1r9/juLo+5Wov6B/NWzjv9JUzRYIAozbBb0lmYwRRhrHCKep",
+	"NAxP0IzQy1nrNgDDNMJ+9PRZIFOPRscvAp4KVmSJss9xSkv5z7x9M/AiiTJ0RTOeEyWIhxckjhFSA3Tu",
+	"zjrwsE1ECTdgy2YRR+Q2yUpJr8l7NwGj3lSaOy8nGWmWGsbmXs3vgmCRzN4DmS/7xfQ7PY2cKJxihWG1",
+	"nPCOkTUwSYRZqk38MgbFFRpojdVqNCRFcOo0XwyQdfugGYWztzDOAauxYCQZLYrKk5BjlcwouzQdYqnJ",
+	"PQMaiKtxqAyG0nbDbG6Pw5ghY9lrQXduGLyJ3N2cA6oPHlmtrYP0Dfo+EpG3o5aIHOjmhkyQNI/knCl8",
+	"G6M/Sw6zKWYCS8DrOOJiHGlMY5QRDOcuNI4OxhGQmqaJlCCMbrhINVZlWRR692yZ+Z+dGnIlywqQZTlm",
+	"Jc6iVXOdF6RBOX3N8xwfSAL+SZgT9GE2SN0qRjjL7EMNuH5KUnQzIwzxnKr2SehWLULY0mlsqbQd+Jod",
+	"tQ66fdW+cYY21nuqTV4bXa3ie8bbjZpfh0T7lgWRBWfSLO+3OD03nmH45Tjh5FOEiyKjRpcb6iPmyaee",
+	"Q+rd7dwOYoasT/9bnCI36F0MvuNpRpMHBMCPeBdHb7mY0DQl7OGGr4a8i6PvOCMPN7Qe7S6OzpgiguHs",
+	"gohrInSzhwPCDY7M6MgMfxdHP3L1FuxQDwfKj1whM6T2Ucxhe/nI+TssLh9wVezA6CPnyAwNgkSrWwtA",
+	"UNACh6Ao10b3gmlCGRbzSjIF8tE0ldeX/32bZ/Xmix8vAfjTPwGiXxgu1YwL+hd5wCWqjaqhKARPiJR4",
+	"kpE3TFE1f0hggsGRHR0+sz3AAK+UwokOCvjIrfgNRGwheEGEokb8Tr1ZrnOf6TIaxGFowfJxkTJjM7X7",
+	"jD9tu0ZOOwNIBuhXqma8VIiqWqMpzzJ+YzrSJmmmqg5KpmiGqPoGOs0IliQFjXjjCd2Fysx/HIoq5Ywb",
+	"x9JdHHX60swyaC0rJeZPQZAgCRdpZYyC3t3WW83X2rCN3smFs7/D3ktilGScEdcBIzeuWQw44Vf6lfbg",
+	"68G1Eosyyq5koH4m2sQdxVFZpOaPlGRE/6G712+ssiuIVFzAI7P5/27Mx1EcYT1H3dj+Ybr7PdNGsd8L",
+	"ntFkrrsAwKLfgmVZ6mxBClj06hApzxJLBIw99nu7G2Pr/VtasY+h47DuXowRK7MMDA/Wdm4dARWnoxyn",
+	"GvNwFOE3LIojaAJ86lTipQlqOAzXLFFoS+OA7TR1NJwHgEiWXGXOo0eQx7dfiU92KaKTyDFQdLcIwV0c",
+	"GSrsO6L3Ga0YMhV4qhrHM7F2Z0VdFz88ej4YDUaDwz4YpunaOnQcObfXK9XXi2UkBpHWYrYSLFHze64J",
+	"nlhwO67vDQzFmw4+CWYce9+4o864cu4veCRrXlRLHY4uQ5QES9kqRrudqe8J2BrkjBbOQVlKElrz0dkp",
+	"HOk99Z2dBsKusvhXBzgQvCCvpIbVmY2jOMr9UFFsAqF+B+Gpf3ChzI+aIGs9FcbRa5ClqzbhhBfz03C6",
+	"genAENDi8aHQRmwUPPbiCgZM/Uaiz72M176k0hi5K3gnnGcEa9GYLsIR/IxOl8erbT8Wam+x0ttUE1yh",
+	"KIguSMJZBbJtUK3ZSm5i1rNXdenO2keDUaMuGjKAbt1ElsbvSlITXtm2ASUzklzJMq8D8OTw6fTpJJ08",
+	"f5aOnr9Ijp9MXuBkNHp2TI7x6PDZ8bPDo8nhNCHPnycvnr18cvx0Mk1eHifPn754eUzS9MnOZFtOc89i",
+	"Fbihxlqk082Qbgw7A9O+QeS1K4kf9b5h3tf0oAK0Ry5iQ8dUmQeMMxLXdEGjsHSiYvV+Kq1luepmdHS8",
+	"QU9NAta7kYMgXGti9isSV7TUSI5aYzPE2CJIGtZkfqAVa3Wr7sEIemTn+GsdHaepIFLWAThjaQnjkRty",
+	"iQ5j9HI0GqHvZoSpGH1Lskta5n2oLcOq1q9zsTVZqp1p/mXo7Tx4OWodxhmyYRtgtWGMB69zlMMXtWEO",
+	"X/QZZ3mhNEogRIPde6HMXtm6TIJnK9UG2wV8eRe7ONMNjJEh1FW0Ks+6wLcOxlb4s5pzuvcpbzX/Ly/K",
+	"+zn64LehJZqUWXm5xG3VtlVgpYhg0Un0f/+DD/4aHbw8+P23//6vlauru41XLbKPqG7FE7ktqCDyVZ1x",
+	"oqPR0bOD0eHB6MXH0ehE/38wGo3+HcU9Nd2eJoPV+M7x7Sm/YXDIlEueqXuuZYGlBPdFfe7ySSJIL5Xi",
+	"OrTj33cHqFaiaz1tiHrrehahT/6eqFdg5FN9DhAero9Vk23jJ4CmHUEm5v6CyE5VumEPf/7k+fHhi6Pj",
+	"FTS1AkbdcQd0skPikhzTrE6Jf/AZG6Sc/I99NEh0KK2H0DRpIEz94l9E0Cklae2kMMWZJIESVEN9oOEv",
+	"i7kf+IyhU076bTyxB64OSztyVp1/Fs4cFWBvqZBqJweDw6aDQbwGj61BOhZrVd/tmLIZCu2UdO3yUXVk",
+	"0Crmtd1pE5pR/tmZaVdBjYXA8/Xmvlq+lGKB3mdKFfJkOCSiGAQkPwTw5DDlScHFasUHuo0dEpqweEqc",
+	"6RvC3x/A+N3fVlw37S/BkhMpsfH5dOPAfdg4RretVIcbLNupP5whQQpBJGE2zGjBlBeDZcWEFsB6ocK4",
+	"i+QAnYHRPSNThcBobw9p38D5n6MMpDpYAmAvRhOibgi8ZibERcZoSlQyqw3k4zfVjLBB3V4Ip93Duzu7",
+	"/OtSPu3M8dVdmhOlxDlpmW4IT/Rsepi8wEfk4OlkRA6O0xfTg5f46eHBKHk2PZwcpU/IceMxeBPboudM",
+	"2RqOJ6t56KgzdgmLECOSF2quzdYmMoZxBVPDbN5kivnP4W9xJVl6WBPqMqTp4GtWa8HCGEyoiYyBf19r",
+	"k3E7LU9teksXETQacABqvlnLRWY36S+Kt06in9WovqDfk1tEGMSvtSVza5eRdTfWifIhrE7GcZS2HisO",
+	"NzxWbGTNovK1zWyvNW5TfZqNX4rcqqFOjtjQ7tVlY+k2fJ2HRi+3pCaQTfB8ycaFSpYRKSsqoBJlVCqS",
+	"gsgqsNA0gitf6wxbkxlmOmv58RjJnKdw24TUJIMqkg1H9Ua5ZTtcQFYrjHJh6v1uuN3bRf92HG/7/bYh",
+	"xQOOUxDQbwMuSVpDxf2puKfmt9xwizb3mthZFjPGVri833NJ6zraNQ1DBmZUKi7m4V4RI6mwUBDkiRU6",
+	"7MDek6PoPjzfxxAe8qLP7rKT7TSJh/TSxonmLOrM1B0hAyXOXn82vw2xtVo+HwQbk//am5/0Eahd6tbi",
+	"2gWJYQ2nq0pwm88aMBovLnIfiqmiZZ3fmF9pYpQ6ctz8KalOEimZIDjFxhcwwcnVlGaZMYJUuNHNl1By",
+	"lsNZ9w1TYt5Oo8RFRFa9EWihUxVh2y+ZxFOyxcXuY1NVswaBhKvECwMiZZKmxjmPTY2DKF5yE8rhDQUI",
+	"29yF/QgnwGULzdgKD55UPA0ZDDfRxXKnAUnQ3EfCTzFdWvLgdcvCW6OErf7QQQFMCftnL1tPE1ndLVt5",
+	"LNAL1NCw0bmJhF8erZbxS+iJ/UyacP0Dn3QIaqVIXqi6c+Bpry3LFbzqOmevp/js8BRzRVm92eo83jjK",
+	"oHzZspSA3R8StUqGrzHNrIRaOdWMJ1f3Q1aOb19tvl7WxrIsXnQGu32rRcoffIJusER/lqSExA6q+Tsw",
+	"FDl5p41FomSvGtIIfwVLleuNSpSWRKcbQcdpqRMMdRwfIBlZMuyt1/YTXUH+88JZaNvHHJsv7lAcCEPP",
+	"YPXlc2gLyCKktzp3tXD1suQsiC7wYHpn5i/fUYs49TJkCcm1rOsPOsy0XZAUlDGS1nJz7nsMDrLTN0sO",
+	"rzmX3It4CdQm9PbMk3exnKigyZUxGuRcmtqFTOkMIPuBD+CSJL+2YaKLL6sgZZ0lKtWYSZJjpmjiPwG7",
+	"qZJGR4HQYzMZ3cj+6TubafOFgqPJPIjbHoeVJdxTIFkNmMdPnUqC75bphEpVxRHLjv12PddKQ2xyw26b",
+	"ueT/FSn6ywTGfW54ZzL3iu3Y1QrwOeIdvhPAVGjO6MDVg0+rsqL1X6FG08wqm/USwqpxu3DWgSttd18T",
+	"6C+IoMz02nDzA590oAaqi/TGTKgpfgmI0ZNrw4uLpdsJj7kAqf64rUL72hG8IzRVwLbhyoZ+7wJTNtS7",
+	"N55cZN2DY8kB2oYj5wV8VCLbJff3Rq+P/NtUWPsR2/Dko+YeF6Z8udX+uAri/zbFVjBqO75s9Ndjw5cD",
+	"aw18+fi6zfHlR23Dl9XJHxe2XIZ/b1z5uJlNMeVHbMNTvQAs7dKfUv/NumFPtvf5F6U2BNNdgbzHRWQ2",
+	"WGbtRdqcyPyIjXjiK71AO8xWeFQxEmukTmwxP2LbKRCPKF7AUY5BrUFKEw0uaIvLUQE7opL+gZwNx+z1",
+	"k0N2sizbSjoJi9H5BJS29W1NTAlmvFzWB4SFkCjBDAkbu+iTUsEIBhaxpupWJKWK24Y4k9xmSduC0VCb",
+	"Kc0ps+/TVHv9cZbNUY4ZNt8hey7Qn5v8/XD4QWBLu9ZgwgM9rCbjnLK6Ic2/W1qNRdX8wYjZlURrKpt2",
+	"WqvgGBZIqxdQixGHgkc2xNWEqZrKUBAToVth065eN2JwfNQk2pZE2WZi2e5Q0BRn2U/T6OQ/657Sf2tI",
+	"ml8jPerxpkQ9Bmkf5mHF9aKm7kejsDD1y76nHcziC881lQJUumCZLkbnTe6+dB3kNEl0I3BRmNt77C09",
+	"+h+CFL6U8XLlOx0rqHukPo4M2XJ1FW5rXUHZSfNg6DpvK9y2IQMUq2uMWvBnVMU2fnmGr4nO/wVfg5qR",
+	"uRaPE4JMJRKSmlpw10TgzFcXvH+8mMDsqq4UD0bPRs9fHj0PuptmHKsm+aCoykhDVc92hKo+mVmOzhrL",
+	"OigbyZhGbvw4IDs7o07y9RFrq4oo9K2FYHru4oo1bEXLXPYlnO70FBuxvmTOebgd1uZgvuYlq/f9pBeq",
+	"Hn2e6QzLD03poDZhbjlMfLMgz7WyWVdDbSom3SskY2eqeVN218lwCNtiNuNSnbwYvRgN5fBwcPj82fOj",
+	"o6fPRqPB0c/F/7l9wQ/x6ZMfrke318/zI/Xny+TtofrxePLnM3J2JL5/Ov/36ObXxrC5rWacdm78JsvM",
+	"B4pVA4e0vrDgi2xUp7pwNVu5P/27ZozsKmZ6+6kaW0xu2DWLDLU+NDzsp+O2ZjoE4dUAcjtxLtqEH7Tc",
+	"wJpukfTRedEXxNd9LBpt4mgNz/yyL2RZ2tjSn9VCPr/68yh/eXt9nEYPaPGcUbWslxwe7S5XI8NSfU/V",
+	"fSeyUjZtM9t6C6rCvQtCfGZd489hN30+nPZgr3kwSkSA1rhmi6zfp+iWL6D3kBI7efhjbeW6Dmu2Jqd7",
+	"UDu2LbxrwKAujnnGprxdaLgr91bmsLsPmyb2i8ZlY3hnW2GU5ejOxSxLM7QO6w2CEYOwyzWlxI7CPzvw",
+	"sS++9diLb5mF2nLxrYXBWz0UZvB96azu0lkGS/tSS62llgyCvpR6PetxZu0mita59UveWLzVYlGe68ft",
+	"oHzOMj9UQh3s67DSTGD6eojCPQEMzSiqFfp6aHPoqnPs0W7NmSuPMdGT6bPkcHJEDl5Mj/HBcfKCHLxM",
+	"n00OjvDhdESeJs8nL9MoXnW/eGvtjFaraMPR99nzw9GLF8+O++lMa5Vme4z1IsLjdnUzqbGp+GN3gNZG",
+	"6pafIyTEV6BbcDaafGbjEdf5ukkCpw99eSLjSDdDNhfO/MCZIDidB0WGXG0RZEtpr1/kbuW+sFT0bmWl",
+	"F7qd7Pb2yniPKB6pfzk+/XK+9YSVxrILX3byit3gq6uXOwMJSdobX601qRZwNcHSaSwbhOvmPPW80nsZ",
+	"F0puNQAlSM6vdzBZQYCUtw2tLPMci3lP9FVLfWHbeeV903VYIL9wSRe7rqCNLUFV2K7wEyxsL6K9qDDQ",
+	"QrPdRQBCMuoqAlCjjJBDmz70S909dslMPFy6ZPpdweW90Bf234HKz+CN39XZaks74tYKqDaRhknj3bnt",
+	"faMz3iPa8EMaqZuVfWZ/gMsO8l5O0jeX8cQRZb9D5iu5CfrSQqogQhLDYBCdlgp8s2BKdl0sob0tLaNf",
+	"3Y9+pao2KNlhMy7u5z/ZqCipbnTWpzKpTtXSd5eDJi5IkCWyvTSBoJ7Iyvl21+uY8HRuig/qa9WlImlc",
+	"TacKa1xdehbC/NzFuVWJD1fI1tSE9dh3prJBQLE07V+sNazNCmz91LL14ZFh639bbrXYn/80XZ6/o25z",
+	"yR1nBEnCUonwpQla2Mi9ZxjlW57OGy/fEj7QQyPe/g0LilzbPjTsvg2kgt/zR6MG9utTJqOPpF0QDPco",
+	"i+Kzgu7vZ+8UxNUoVYVbx82rKq0soHlhfeuFVkLZtEB5TWK9GZGNNVhkmSTECvKmyivh+zZJ/sYJvQWK",
+	"LCf+pzQFSX0+hCA2T8FU7kFU2eB8i1FzDTEvVdBIkITAPfT298B2MHDK0KB2kWCKEg4v4B6/nCpT9hDp",
+	"TUn7/8y2Zm5GNNhAVCEo4TZYvjFsYAmg2mYHlgyCJxaeytcatHJPqlbuyXKrQGr5mYX7rXtY7btx1IwC",
+	"e7QduOqZ7nc1pv5p73X0v10MdfW5/d0U4TvoqrqymNzXrT734+x7GsWX7OCb1RvuMp5v020kSSJIA3P9",
+	"k7ir35wGoJlK0ktmecoylLne2119Dk0si8GeXBFot1nrHjEa93cadErgWmJXb++CQWwpqJpfAJ0YYvyp",
+	"IOwsfc0Zs9ELPHzwi8iCKV2ReZJxfDWwsxhQPhQEZ7mf10FKroeDG5JlB1eM37Ah9EbTg4SzKb0shbtn",
+	"0IFWG1xf+UvZlDdoFjz5YAbUelPKkzJ3apMP8l/8rBIR0Ulkbs0Ec1pBGC4oOBMGo8ET43GdaVwMcUGH",
+	"14dDnR1mgwyvtdkQ3oIu1xRfcTDDcmbvRDUypapmbTYAyFjjU+QKftl0DpDCiTESSZP4ZlO5/DW5LkBR",
+	"W5pTomBDSLgQpb3LkPkxYQQQ44SlA6RtnMFestQfuiTKVt3Oi1LpC3kd7AOkL8h3VxW4RLwc66L5dDo3",
+	"0MN+ATLNu9RD+6rGqcA5UUTI1tSu6pOhvrf/nbZQ3sX9vv7J2DHvfqt0CL2GR6PR1m6kbjIZt17QfTwa",
+	"tfXnARwGN/7rJoermyxewX08erK6Ue2W+6d9IGu6lP4uNCTaBbZ32jrC5FNH81O78pCGZUxROsUS+mji",
+	"q0/GiXS3ksHuTY5rUyNUdX2rodstebW7D748GjseHa9u8SNX/r7/3RGlEboBVfYhSleO67JJ74DCE9KV",
+	"zJT+vImTq0sB8zH1OGO4D5ZIhaZUSDVAb/XJwjQRmOlbYgA0expaJllXNOwhxWfPz8OCnb1b/JOyHXPQ",
+	"Upm1r1c6v6UsDUnyD0NIvQh/+OkPPjlL7wIGqFPmdwTQvJEg/QF63i0V1Krj7UXnWhSDF2gGzKxnp+sS",
+	"zlAQJTr28J9BPkqE0dTLRGMUNIYQjKaCyBmSZIWEPIdh9pT4aCjxePRydYPXnE0zmqgtkq6mg4qc6iS8",
+	"gnihdu2KnR4uX3EGtZsZTWaBSZ6l2qiwuNm/wcnMWvhnPEvdaRArMmaLZv0JmXJhusJTZUsOm/EG6Fdg",
+	"CGN6+EcirxHOsqoCgr3+HwuCyK0pNg9OgtcX/4rHTEd46F5NjAdKOftGIaDmublUDn0ktwolJMtkdS2K",
+	"5sB/xOi/Y3QQo/+JEUYKTxAXCKMEC0HhbGkMKjDwmBWCTOmtM2J+gyRHshAEp3JGiJJ2WHKNsxKbmiy5",
+	"KW28rO0ExYkfodKjoTu3q2YjkjdoeZau1+5VorhYuwnQ71pt4JLI9Vp85GuOoKl49xpgU4lrYHmdMJvI",
+	"63pvwX0VaVx5vWIMaDf/PUvhX8pZLILV9z/O0tiwcKzZNxZGUJ+lcZJR8IMUY3YYN/nQ4hfTJ8lgMIgP",
+	"Y2Pbi60pLz6M43H0aRyNtZ8b/j2B/+hSGdLYueD33TiKIfFriCfJwQj+dxgfHj0faOvWuCF36evWh7Ww",
+	"R9442rEtVAb2xn3BjHOgSyiZT5FUgmB92DSiWxvp7HLKuLHqlb9j03bh/T7WyzMYM72P2GtOdSRq5Tk+",
+	"O9U15mveZGZv7DS/9I7CUK0yu3MiD9BH2GVwlhGhjeRjZkeVDhw+9fDDEPNvYJOyZbbgpdlazGRs/Rlj",
+	"oI6X7DO2a7178emY6U0AvQKDYw6QUok0LrFEM4KFmhCsbF18CKiFfWmGi4IwCa0ESYyJGJ4bHrOeZcBE",
+	"FTAA3sMxM7ig3nemt8h3WKoDjZiDs9MAg9JuwdT45wQpMjwHu4HgOSjHoFSAhlFOp0QMkL874+wUUcm+",
+	"UWNmXgESyOByoJfIxBC7aGF9RSy0MV/CzprAspkhbPywQ2Ds509lBY1eWFgw2NbJdYhBrX+YCTnMzHiZ",
+	"pUgQc8fsjQ5F0I9vNDbtZzpEYYqzzNxYO8UCTcgMuIbKMUuptDi3wyeggxCJyqIi4xs8b9rZLzRnbLit",
+	"f09wSgQs1xvrU+6xf2hJr/FyYNiyXeSfoD4BEZaKTtCiw2/MgJlO0KdxRFMtpHt1F8Vj46PRTZY6hdfV",
+	"VqS/ado+9HfV/bFjc2ntWIeEjKMTB9Ph3d2YjdlJxVrr7QyPXMxD2ydbUyDqV1M3YAXEptTja56blcat",
+	"DiVPFrYcQ/co04IPvHBUzYNdx10/EW47Pt680ezib1h4hMpxlXK8c/1u74AJVZosW3K1mN+/3cXe/FKn",
+	"I3O/vvWFWG3VBVRtZZWqATxy6w5tW59xgUwOt+pK6aKQ19bn/zWQiZkrwmChWPR/OEpZFEHeHWerjtvE",
+	"uDoZnerngOpv5zbWa3tuteNlpftHjl5b4tjbwJaW2awGGMFAa14021Yioc2gv6N1HD0YT+/t+s2E8R1R",
+	"q6iilf2HrpJbq07iyr49sGedJ4o0K/c+TGtCmckh6qPoxtFMHzT00OekEAenumpc2wLYr4fwqf3y7m5P",
+	"fk1yyRKIpcG1qO9P0WqBOScs1dW+0c/nKOH6jl2sUAZXkrjAKUPzJOM3NrrJBJ2RFBXlJKMJ+uX83bJT",
+	"ycrCn89fm+pFm5N0T9XZjOSso2s1uoCE6/WavCPXJFu3DZ6QrJVFuynE4nHPG62i2RHwVPtY1maS2m2A",
+	"jbzyRidhmABoKJ8vdRCgaRXbavHaeZXwnEjrwmp00NTuRHwI3nh8QYDtV0PudZL1j66eeE2U1ubEP/zk",
+	"/lxbbXEruWN6dl+fezj3Ss9XoPQ4stwugdt0lfYQm/f4ysagewC0PZmpINIGQhATXsxN4o/0ceoD5C5v",
+	"M1pVQVk4kytCCkRVUzyOhuqLYqr9IfQhGMJSxv35wWgx7XR/AcNIp++brAtwvmnrVzi2PyCANyvX3EKV",
+	"45FGBmCIYJFRInpwgincde9DcdzTPxZK5N+6bLp5mSlaYKGGsGUcuOThiviX69/021/q6UruLozFlKO9",
+	"EXj39sejoz5wFYInREpIvHvDFFXzLTL7L8WmB37D3bKdvc2aQRSFO89IUxzP17NNSZLpAgFQjMxGOXhB",
+	"IHT8GTOucsoQFykRIB84I+CIz0FGJbOSXcnYhxCYB+7gtJ4cWRIMZgK1un6PS0Lc1+uzULLws7h/mssm",
+	"doiAmiZsWh/85OtsdenC5mP77V4bbpQHpiwDbP2yzEHiON7d0OJhWsvhJ/PHCjfVa8wSkj0kx8HXv1jQ",
+	"NvRt7UlowYmpF7GBhtbycT0mIhg9vLRbPPLvBd0O7Lm2FNmlINIatXqRbAHxdMty4VVRmJI5VgmBDhXK",
+	"uVTo2TF6T781Gk5tJZFZMpSXUplcAQ2UzQKwKotVm2Kb0EDNkckUhodvJnNFZBUyKXVE4ABd2DIhcBjK",
+	"CzV3upGyehDDmRtIEKVLMsALLCXJJ9ncIMSObY5WMxwMY+JDtS1CA9B2sHoNoz4oA/fVs+ps0reVlf1r",
+	"K2j3sgje7WXS3yqQ5Piwx9y/48w44w57TOCDKd70kfN3WFySx3W8NH6rWxVIxj6iNlAr3RUQ7Z6zs4U4",
+	"dkl0nLiYI9c2Nr91sTGSSThfcklq4fD6ZGgeG+FLhQ+sb3azvfOA/U0qZ9Qm9Xjsp9v2ZmXBujm6q56t",
+	"Csh0CNppUObi5TkPfDJfvrd7H5zpgzOzigCaqKdJcg0/VffYLJyCFzJZXQujdek6WYpmGahmpl5WkK4E",
+	"F0l/o9DEF6hrqQKEWRrbNB2qTAJMrT/M5q7P2DiYYLpgZ8Nz2zXCftrLstDENDrQN45OfOdR9LWfxj97",
+	"cKpb66VQxLqYbNyMm0iwTlZmoyU2R00uVIaWhkhrFAqEKIg2VreT4XdE7ZgGRw8qYPdOyq5wrJ4kWpS9",
+	"SVTN3N9AkUZ/tDSp3/kRF4izh3hdFqsm+7TFKRlen7c9Ut6+rtJ80V8vXWXPSo+BlcwC9uWmQLNxsroz",
+	"Ce6D++jx5cGFzNGzyY8E96/vcI5TWsp/9i/V8C2sLGWX3/Lb3Z/x3MLss/OqQ2FREaujff8oPBIup5lW",
+	"NgZdt/aSXhMWG7PuhGiXtapy+J0m0+Zyrm6g3N3hcuHOzQc+W/rR90fLpaNldTlKAw02iN/hJ59X3iMH",
+	"0GJ+Y43igxvrb5UJuO2zk1MZF3fSmjRpc33udIlGD8nDe5Wr6/TSi0gazy6vajao9beZ2rXLW6OzXZ0t",
+	"Ntmq9mT+mE4WPSh9xcZm44rMff8dmYaqFExWgX7+yh6tkCHmi/fAM+2ad5H2rqBLy1Vl2hQ7ZkBRGIwD",
+	"VMVoyrOM34CLPfjwG4kMkL6jgmc0aay2A8Yq/a1J6P7RXJN1L0bsedKAAfVw+wj8Rxyb4mgT8j6M01Rf",
+	"CGhIW+d5LOuKDb7TRn4yRHpgifTAEGlXFWFDqTbW/oP5/BFrJw3g7klxM1Kc8ZsaOQYSshLtuuaZ5Jm5",
+	"utJRo/20Q5U5Nxu5rCyvSPCMIM5CqQrFzwrKWCCfndKDV4nuVuPqjgh6ZybWJor+PNbWPW9t5dRvzP/b",
+	"Ya9V8t762TpUp4AP4aoxIhoZsTEIxrLAezvGw+gwjzN0xuJgb1WtrKqBi7dJY7GvOwysPfeIbmuqWZhH",
+	"KuENpAbEz2SSdYN/dUl4nynO4lWaViV6FV/BGj3F+/BTKYlYEV/Uj51iz7cJZghnN3gukbmcHD7LJcmu",
+	"iTQx7Tr1zvRU27V8bJJpl7aFDW2VRXuGi2s87YOMPtctEJqOPP3bms0rNof7nB96kWlKcq7IKoPpZyPT",
+	"XZ0sNth3Rg+47+zvXtnugQP4wvBIyAS5o+r1N5971n7z1uENyr9ZhrpHBbj1Dx77InD7InBVEbj7uOuH",
+	"7gC/2rGhz/72dG4aGU6ismY8qF2vu+jDKGWbs2LMFrwVJoh80bJmyjIknEmamosT9AXl5jEj10T4+5s7",
+	"/R3WdPRQhoJwzAslsCKX851fPyv34YhbcX40WXebuK7ZFiYJFsmsla/elll2AFdQIPMh4kDDjl8Yzu1l",
+	"zzIrL6ubYYIXQXfVfTHmfYy4MP3BdPQg5FYJrO/l0Arvh9O3MSoyTJl+HaP3WFxBPTzd00/TKU2I52gJ",
+	"Hh9LnCjnKRmg76llPYHZFUkRTgSXEkH2qAGGmxjnJCtTYm4TWQgPkIQsb6sXBmcbBWyath+J6B/1aJq8",
+	"N5vOWqPAPL9gc6GZxd5SaAmu8wamgNEtS9fZfIYFOdBa5QYJorq11kkbUkRJ8Lp+d1KzLfwCvn6nIXl8",
+	"Ic9rlnR9wJtCKrT9fXNOA0IK6Tmg3XYb+JmU5h5Wm1Pyy/k7e5QisAkwQ7NpvYK33oJcMIw1wFvein1V",
+	"SbDuJQkvmUIlUzRDFDaqggrYwrTf6ZrD7sIFEiWT7jJsN5QcIL1s7j7YAkt5w0WKsLzSKjKFXUfw8nKG",
+	"vv/48QOaYEkTBJgmTFEXNg1QlpIIE6NDJaKXjAvSGiPt6WWnUdJ+lM9klA/G30dKzx0b2EDpip1aual5",
+	"ixh+kg6vK67O9vjfOEjyohppx9pEH1LZHzq6DvYVQS1FKq5PVkMjN9vrI55buarbIswkuNy1ED0+HCEo",
+	"/mFLItqMQXdTIoDnsmdJShWvsmf1+PYMwkUVKqMDgWG0llLA8GpP6l9LeV9NCHht8cmFWqFhmyu4DSFy",
+	"4ay8BadM37tHWVi3IDiJUhleVtqmVnOhHqtavb5N6kE1a4e5/VEz1MU9hdbIv6LyHrq4wCzleeDXECSl",
+	"wpB34NGPK7XbKeQLMeKhTUvHOpo/4Wvz5e/uS4XFJVFI6eq1vs1ZGo6x8M21o7XaRcKMkFQGm0iT29Tt",
+	"K6bDdlXcEtiOVXE7ymdTxf34e1V8WRV33NTKTM17CehMFq8rVXH73T30Ez/SrvWTHqSy10+6VXFHUA2q",
+	"+IKMLltysrVYlgrilty91zGSoIJQpkDxBi+ptGk87m4lJ+vWFZVaB59wNas5L5yeHnTcEluyC/LeVbzI",
+	"ZqJ4z1+PRP8/0Bp5jcnuJbU3iP8gDP5ePCuAURMsgWD9M+9MC8uywHGGZxtDQTxV3CMYpMZD+3CQfTjI",
+	"2uEg2+KpvtYjw0dN1iO9+VSBGRTUtdTcKLX1g4AzIe1VtK/MhLQOsZfSJiB1VVaFgNedniNhgM9114l8",
+	"hAkWnykUNax4U8pa4Kmhk2XSGeak64D4qvIoktST0a6Kpst+McsbLcnnDbzCIR7XWJuh4leEHVA25Wst",
+	"00dodgatdrhefpCvZtGQXg5EDWJXrl+YONR6J4skYuOtvT3h5uG58qFk6+dUSzUNLNpOGpbfx012VXF0",
+	"Vyz+nXwvFwqrUu7e/+Jwt/e+VN6X64qemktFdGiIVfD47pREH7/9WfTEHtHjX211xGu/+t2B3+7p8JN3",
+	"f/Wojmgxv/Em1+nW/WKrI372UvTOsbm4ndVkRpvWstM13aeMPBo7XC8iKcoGIjFejW3Tya68L5vsTXsy",
+	"/RLln6+f2IO0V2x9Q8iZoNek1VFzoS+mA0fNv88+6CgVLAaXfyHbTkeoZBmCmWihbCLKsVI4mZkrDmqp",
+	"ifySqJmzSOMxyzGjUyLVAKgNZVQqm4pIBcqJwilWWBuskxlJrmSZywF6q4fwJRwlzk1mlTFq6+sPSeqv",
+	"hh4zf2PX2amMtTme3GIAF42jHLMSZ+j/P/pfgyKdjqOm9MRTG9FuSfyVxdg9ZUHPQ4kdzbmR1ttpLv+i",
+	"xbp3Csa1HjboYL899VVjfFJGljVkYrg7zbGnt03YWzPiAfQOILdcUbrs9eG6hB7cwqe4Lvvl00VigCu8",
+	"sYSLMEozqBxwo+/Lc2D5fMOmUONXGkxg7I/8PknAu99rGyBda8fdV5F5gBJKeo1srpOJuLzP8XCYZJx1",
+	"uFzNORS2SJ317vmXsmp/8jyh972Q38Pt0W2bxg9rPsGCjJkN4J8QdUMIM/FEDtTY7m6WvHULqFuT8IK6",
+	"rvQMUEFZFfKEBLmmNptSPx0zyUuRkCoTTALmTFN7NedCyrVUWKhgK/YfOFgGqL4W2ss8Zj7FzAMBq2QD",
+	"rrRs8Une5nXTtvwaAHvcwiIEcW8z+kqK6Wh+ua/E4XmBBRl+4mpGxL9qlqqWeKqCCyWDpE6cpiSNXYU1",
+	"+MMUiQZlOucpnVJXbqCuoOufelj30Grchm0bVG4LbArHEKem6wzTyRxJ+pcJOHl/9v6NrkBgLsLB/ktE",
+	"JcqphBIkA/RqzCzAtk67ViH8p4LgoiBYSFSylAiEmQFUg6JTVbUws1MdoF8BYKOx/kMXWtBizsBLpbmt",
+	"TehINECMjpuHqgum8oO5hS3jl43Cx8x5Y//H2ocBaPBTjRh6HyFe+/ludorYSOBUgwaiJ44As0ON5HqP",
+	"+0PEpuLGEGI9m1vd8BWulFUCKCXN54ZF47hTht8Knj/uvbgR1r3q/uhM+6G6aMs/3msrpTnsjO3a+4Xi",
+	"gkhb8cPdoADcBPYuZ+WyR14owaM/0SGURlmXWp03inzu9s/6QQBuCOEsIQP0lmcm7FkQNM2wUoT5y+VJ",
+	"sEfyKSKABwOPRClRpKoPpDVkr2G/YUpQaxAbs5JJPPWVhuQ8d2nEKSLBhwgjWcqCJpSXUu/ggkgNrGZv",
+	"NMU0s7GeVMC8Y6vgyzJTY5b5jE5eqoTnpLoVFUaZD5C1W9nRcq5FFGbocDQaeUi4CN4co+/ot9CPm9qY",
+	"AZoEmZayuW7YmV7a7ZrlOqVPXmaKFlioISgVB2CcrAugQgCEihqR4aRnH6NbJXzMXR3Rb/4rPoGj40Mb",
+	"9JuQu7fud4nA48MeMH3Ac7D5feT8HcRMbzPvTi/YYlmXUIrpROt7itN71vT0HosNanpaWrxHGsf6uvM+",
+	"iWOfxFElcdyLc6SJKzv55JzNde55z6/DrGyEMw61EZREGZ2SZJ5kZIBegYWMpEgJzCRVvvSm0TcUR5T9",
+	"DjYzcgN7K2y9qcA3LB6z6oXi5vO4MtfVvq0eK45kWRAhSbrwkSl+6N+NQbD4lwN0rnug7DL2H4FbDxq5",
+	"r+C33cxWlOgeM11ztKZSmeLzE5OaYqwbixVJg+LdGVHNCkTNcW7j/r4AF7+BdO/o/5oKc3shgIwcqbkK",
+	"VwqkGzKZcd6rRIv7tObLg+uraTJzHLuyqv6v3sBvH+lDku/aFJiRmnNNx+YeI0BEasRYrSZjc+mXX92k",
+	"HnXw8a7DiB0W9mHEJoz4pqIKxxP+UXvpljfXznWG4ANjDv7h4qcfneKqU5BtjcXKDk4SQZQvuM1NbWxT",
+	"6BrNiDCVusbsfx+c8uQDpL9d0EuGVSkImhGcEoFmPEslGkdyho+ePvvHOPLWgBm5tfnQKfr+/avXBxff",
+	"vzp6+sz5DKs+P9KcSIXzYsxMp1DzJeVVlQEoATlAbzHNSArbIb0m+vhtjtdKUDcncmvQTnGGJji54tMp",
+	"VJ2x+KtJhDHrkAXUjC1IQqirTk4MhjX0WFWChTNSlZx0j2tNx6xqa2wM7isYZIXkaLLda3+X5Zudhovb",
+	"MT6T68+Pvg8X19WEywlMXV8NqaukckuTzZKiYfccfrJ/9YoZrwhsfYXyVzfO134R0bYjxp0o05JDyUAa",
+	"tu0XbeHjO1ve0UMKgL1brSsjyZPLYoBtXaNovIqfIcpwosDu5nrxmxrjOmelIr626j/bJLJdHUo32eT2",
+	"NP6YruG/8VS2/j44DCTo6sNl9TEwAJEKTamQKq5U6sCfZHxP5nI2pUheqM5z4Gkoyu/FLl/0RbNL2Niz",
+	"xobif4Fg9elnS6wy/GT/np/pmj72V7uX+ueSlPYgVRgPkjsHuo4Qh1OPdqfqSKZgg5nH1QkMnZ0u1L5r",
+	"qtpjG9Zpab57vgoanHoENfHM0bY3EDdcF7e8ShJS/P0iJbd2W4tWmTw96ljfdkb5c/gJbBvtIY0fjDdQ",
+	"xxOYYtFA8vUijXG9sm5YYtEWu7VcAkM1Ebq+Pi0sVLtA4BQgKbCaRXHEcE6ikygx3se6ihN3xLItUu+T",
+	"0dHybM1qxJGx4Ojv3nFD0Msfw/GVTxumG3XBcbeR6fuwB3FAWbH7UxJJSkHVPDr5z281WWzCwntXlZLD",
+	"T7rEybqUFdzCAkYv+MPkDuggHH+tSZgIE3zk3HYmMgaOltNamhoVvguwPkDcjuaS7958NDuLHsXFsALr",
+	"SOPL960yUDdiJLRLwKrzPqsmjdH3b16dVs9Tzr5p0Jd+KggLr0i5RzV/XTrnS75mCyaSLruz6glwPFFE",
+	"HUidBbmNTLgah5+TQhyc0kt7xWMTtPbrIXxqv7y723wz+eK4Hwi2/6UEnveHmvuGn6b6Yqt2UVAx4c5Z",
+	"z6X7GbqDQNQH4j9o5S74Wo+hHi/xP9JKTZ+fX07rN32ZvcWmjy17ixc4qN7vJ818Z+lrzhhJFIwE9KN9",
+	"LZZeS5FFJ9FMqeJkOMx4grMZl+rkxejFKLr77e7/DQDBrXRpdooBAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
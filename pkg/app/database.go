@@ -19,7 +19,9 @@ import (
 	"app/pkg/database"
 )
 
-func NewDatabase(config config.DatabaseConfig) *database.Queries {
+// NewDatabase migrates the schema and returns the connection pool, wrap it in
+// a transaction.Manager and database.New for queries.
+func NewDatabase(config config.DatabaseConfig) *pgxpool.Pool {
 	ctx := context.Background()
 
 	sqlDB, err := sql.Open("pgx", config.DSN)
@@ -63,7 +65,5 @@ func NewDatabase(config config.DatabaseConfig) *database.Queries {
 		log.Fatalf("failed to create database connection pool: %s\n", err)
 	}
 
-	queries := database.New(pool)
-
-	return queries
+	return pool
 }
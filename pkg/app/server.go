@@ -2,18 +2,35 @@ package app
 
 import (
 	"app/pkg/api"
+	"app/pkg/archive"
+	"app/pkg/audit"
+	"app/pkg/content"
+	"app/pkg/database"
+	"app/pkg/events"
 	"app/pkg/file"
+	"app/pkg/jobs"
+	"app/pkg/location"
+	"app/pkg/membership"
 	"app/pkg/platform/auth"
 	"app/pkg/platform/config"
 	"app/pkg/platform/handler"
+	"app/pkg/platform/links"
 	"app/pkg/platform/swagger"
+	"app/pkg/platform/transaction"
 	"app/pkg/project"
+	"app/pkg/qrcode"
+	"app/pkg/reconcile"
+	"app/pkg/search"
+	"app/pkg/sharelink"
+	"app/pkg/shortlink"
 	"app/pkg/user"
 	"app/pkg/version"
+	"app/pkg/webhook"
 	"fmt"
 	"log"
 	"net"
 	"net/http"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3filter"
 	"github.com/go-chi/chi/v5"
@@ -22,7 +39,11 @@ import (
 	nethttpmiddleware "github.com/oapi-codegen/nethttp-middleware"
 )
 
-func NewServer() *http.Server {
+// NewServer wires the application. The job runner and the event hub aren't
+// started yet, the caller starts them next to the server and drains the
+// runner on shutdown. The hub is closed by the server's shutdown, which ends
+// the open event streams.
+func NewServer() (*http.Server, *jobs.Runner, *events.Hub) {
 	cfg, err := config.Load()
 	if err != nil {
 		log.Fatalf("load config failed: %v", err)
@@ -34,23 +55,58 @@ func NewServer() *http.Server {
 	}
 
 	fileStorage := NewFileStorage(cfg.Storage)
-	queries := NewDatabase(cfg.Database)
+	pool := NewDatabase(cfg.Database)
+	transactions := transaction.NewManager(pool)
+	queries := database.New(transactions)
 
+	locationRepository := location.NewRepository(queries)
+	membershipRepository := membership.NewRepository(queries)
 	projectRepository := project.NewRepository(queries)
 	versionRepository := version.NewRepository(queries)
 	fileRepository := file.NewRepository(queries)
 	userRepository := user.NewRepository(queries)
+	shortLinkRepository := shortlink.NewRepository(queries)
+	shareLinkRepository := sharelink.NewRepository(queries)
+	searchRepository := search.NewRepository(queries)
+	contentRepository := content.NewRepository(queries)
+	jobRepository := jobs.NewRepository(queries)
+	reconcileRepository := reconcile.NewRepository(queries)
+	auditRepository := audit.NewRepository(queries)
+	webhookRepository := webhook.NewRepository(queries)
+	eventRepository := events.NewRepository(queries)
 
-	projectService := project.NewService(projectRepository)
-	versionService := version.NewVersionService(versionRepository)
-	fileService := file.NewFileService(fileRepository, fileStorage)
-	userService := user.NewService(userRepository)
+	auditService := audit.NewService(auditRepository)
+	jobService := jobs.NewService(jobRepository)
+	membershipService := membership.NewService(membershipRepository, auditService, transactions)
+	locationService := location.NewService(locationRepository, membershipService, auditService, transactions)
+	webhookSender := webhook.NewSender(cfg.Webhooks.Timeout, cfg.Webhooks.AllowPrivateNetworks)
+	webhookService := webhook.NewService(webhookRepository, membershipService, jobService, transactions, webhookSender)
+	eventHub := events.NewHub(pool, cfg.Events.BufferSize)
+	eventService := events.NewService(eventRepository, eventHub, membershipService)
+	projectService := project.NewService(projectRepository, locationService, membershipService, auditService, webhookService, eventService, transactions)
+	fileAuthorizer := file.NewAuthorizer(fileRepository, membershipService)
+	versionService := version.NewVersionService(versionRepository, membershipService, fileAuthorizer, auditService, webhookService, eventService, transactions)
+	contentService := content.NewService(contentRepository, fileStorage, jobService)
+	reconcileService := reconcile.NewService(reconcileRepository, fileStorage, cfg.Storage.OrphanGracePeriod, cfg.Storage.DeleteOrphans)
+	fileService := file.NewFileService(fileRepository, fileStorage, membershipService, fileAuthorizer, versionService, contentService, auditService, webhookService, eventService, transactions)
+	userService := user.NewService(userRepository, auditService, transactions)
+	shortLinkService := shortlink.NewService(shortLinkRepository, versionService, membershipService, auditService, transactions)
+	archiveService := archive.NewService(versionService, fileService, membershipService)
+	searchService := search.NewService(searchRepository, membershipService)
+	shareLinkSigner := sharelink.NewSigner(cfg.Share.Secret)
+	shareLinkService := sharelink.NewService(shareLinkRepository, shareLinkSigner, fileService, fileAuthorizer, versionService, membershipService, auditService, transactions)
 
 	authenticator, err := auth.NewAuthenticator(cfg.Auth)
 	if err != nil {
 		log.Fatalf("creating auth middleware failed: %v", err)
 	}
 
+	linkBuilder, err := links.NewBuilder(cfg.Server.PublicURL)
+	if err != nil {
+		log.Fatalf("creating link builder failed: %v", err)
+	}
+	qrRenderer := qrcode.NewRenderer(linkBuilder)
+
 	oapiMiddleware := nethttpmiddleware.OapiRequestValidatorWithOptions(openapi, &nethttpmiddleware.Options{
 		DoNotValidateServers: true,
 		ErrorHandler: func(w http.ResponseWriter, message string, statusCode int) {
@@ -65,27 +121,64 @@ func NewServer() *http.Server {
 
 	router.Use(middleware.Heartbeat("/heartbeat"))
 	router.Use(middleware.RequestID)
+	router.Use(audit.Middleware)
 	router.Use(middleware.Logger)
 	router.Use(middleware.Recoverer)
 	router.Use(render.SetContentType(render.ContentTypeJSON))
 
-	projectHandler := project.NewHandler(projectService)
-	versionHandler := version.NewHandler(versionService)
-	fileHandler := file.NewHandler(fileService)
+	locationHandler := location.NewHandler(locationService)
+	membershipHandler := membership.NewHandler(membershipService)
+	projectHandler := project.NewHandler(projectService, qrRenderer)
+	versionHandler := version.NewHandler(versionService, qrRenderer)
+	fileHandler := file.NewHandler(fileService, qrRenderer)
 	userHandler := user.NewHandler(userService)
+	shortLinkHandler := shortlink.NewHandler(shortLinkService, linkBuilder, qrRenderer)
+	archiveHandler := archive.NewHandler(archiveService)
+	searchHandler := search.NewHandler(searchService)
+	jobHandler := jobs.NewHandler(jobService)
+	auditHandler := audit.NewHandler(auditService)
+	webhookHandler := webhook.NewHandler(webhookService)
+	eventHandler := events.NewHandler(eventService, cfg.Events.HeartbeatInterval)
+	shareLinkHandler := sharelink.NewHandler(shareLinkService, shareLinkSigner, linkBuilder)
 
 	router.Route("/api", func(r chi.Router) {
+		r.Use(authenticator.Middleware)
 		r.Use(oapiMiddleware)
+		r.Use(user.NewPrincipalMiddleware(userService, authenticator))
+		locationHandler.RegisterRoutes(r)
 		projectHandler.RegisterRoutes(r)
+		membershipHandler.RegisterRoutes(r)
 		versionHandler.RegisterRoutes(r)
 		fileHandler.RegisterRoutes(r)
+		archiveHandler.RegisterRoutes(r)
+		searchHandler.RegisterRoutes(r)
+		jobHandler.RegisterRoutes(r)
+		auditHandler.RegisterRoutes(r)
+		webhookHandler.RegisterRoutes(r)
+		eventHandler.RegisterRoutes(r)
 		userHandler.RegisterRoutes(r)
+		shortLinkHandler.RegisterRoutes(r)
+		shareLinkHandler.RegisterRoutes(r)
 	})
 
+	shortLinkHandler.RegisterPublicRoutes(router)
+	shareLinkHandler.RegisterPublicRoutes(router)
+
 	swagger.SetupRoutes(router, openapi)
 
-	return &http.Server{
+	jobRunner := jobs.NewRunner(jobRepository, jobService, cfg.Jobs.Concurrency)
+	jobRunner.Register(content.JobKindExtract, contentService.Extract)
+	jobRunner.Register(webhook.JobKindDeliver, webhookService.Deliver)
+	jobRunner.Register(reconcile.JobKindReconcile, reconcileService.ReconcileJob)
+	jobRunner.Schedule(reconcile.JobKindReconcile, 24*time.Hour)
+	jobRunner.Register(file.JobKindDeleteExpiredUploads, fileService.DeleteExpiredUploads)
+	jobRunner.Schedule(file.JobKindDeleteExpiredUploads, time.Hour)
+
+	server := &http.Server{
 		Addr:    net.JoinHostPort(cfg.Server.Bind, fmt.Sprintf("%d", cfg.Server.Port)),
 		Handler: router,
 	}
+	server.RegisterOnShutdown(eventHub.Close)
+
+	return server, jobRunner, eventHub
 }
@@ -3,18 +3,41 @@ package app
 import (
 	"app/pkg/platform/config"
 	"app/pkg/storage"
+	"context"
 	"fmt"
 	"log"
+	"time"
 )
 
 func NewFileStorage(cfg config.StorageConfig) storage.FileStorage {
 	backend := storage.Type(cfg.Provider)
-	if backend == storage.TypeFileSystem {
+
+	switch backend {
+	case storage.TypeFileSystem:
 		fileStorage, err := storage.NewFilesystemStorage(cfg.Path)
 		if err != nil {
 			log.Fatalf("failed to initialize file storage backend %s: %s\n", backend, err)
 		}
 
+		return fileStorage
+	case storage.TypeS3:
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		fileStorage, err := storage.NewS3Storage(ctx, storage.S3Options{
+			Endpoint:        cfg.S3.Endpoint,
+			Region:          cfg.S3.Region,
+			Bucket:          cfg.S3.Bucket,
+			AccessKeyID:     cfg.S3.AccessKeyID,
+			SecretAccessKey: cfg.S3.SecretAccessKey,
+			SessionToken:    cfg.S3.SessionToken,
+			UseSSL:          cfg.S3.UseSSL,
+			UsePathStyle:    cfg.S3.UsePathStyle,
+		})
+		if err != nil {
+			log.Fatalf("failed to initialize file storage backend %s: %s\n", backend, err)
+		}
+
 		return fileStorage
 	}
 
@@ -0,0 +1,158 @@
+package archive
+
+import (
+	"app/pkg/api"
+	"app/pkg/platform/auth"
+	"app/pkg/platform/handler"
+	"app/pkg/version"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/dustin/go-humanize"
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Get("/v1/versions/{versionId}/archive", h.Download)
+	r.Post("/v1/versions/{versionId}/import", h.Import)
+}
+
+func (h *Handler) Download(w http.ResponseWriter, r *http.Request) {
+	versionId, err := strconv.ParseInt(chi.URLParam(r, "versionId"), 10, 64)
+	if err != nil {
+		handler.WriteError(w, http.StatusBadRequest, "invalid version id")
+		return
+	}
+
+	format := FormatZip
+	if r.URL.Query().Has("format") {
+		format = Format(r.URL.Query().Get("format"))
+	}
+	if !format.IsValid() {
+		handler.WriteError(w, http.StatusBadRequest, ErrInvalidFormat.Error())
+		return
+	}
+
+	archive, err := h.service.Open(r.Context(), versionId)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, version.ErrVersionNotFound) {
+		handler.WriteError(w, http.StatusNotFound, "version not found")
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	name := sanitizeName(archive.Version.Name)
+	if name == "" {
+		name = fmt.Sprintf("version-%d", versionId)
+	}
+
+	filename := fmt.Sprintf("%s.%s", name, format)
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	w.WriteHeader(http.StatusOK)
+
+	// The status is already sent, aborting the response is the only way left
+	// to tell the client the archive is incomplete.
+	if err := h.service.Write(r.Context(), archive, format, w); err != nil {
+		log.Printf("error writing archive of version %d: %v", versionId, err)
+		panic(http.ErrAbortHandler)
+	}
+}
+
+func (h *Handler) Import(w http.ResponseWriter, r *http.Request) {
+	versionId, err := strconv.ParseInt(chi.URLParam(r, "versionId"), 10, 64)
+	if err != nil {
+		handler.WriteError(w, http.StatusBadRequest, "invalid version id")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, 1*humanize.GiByte)
+
+	multipartFile, multipartFileHeader, err := r.FormFile("file")
+	if _, ok := errors.AsType[*http.MaxBytesError](err); ok {
+		handler.WriteError(w, http.StatusRequestEntityTooLarge, "archive too large")
+		return
+	}
+	if err != nil {
+		handler.WriteInvalidRequestPayloadError(w)
+		return
+	}
+	defer func() {
+		if err := multipartFile.Close(); err != nil {
+			log.Printf("error closing uploaded archive: %v", err)
+		}
+	}()
+
+	entries, err := h.service.Import(r.Context(), versionId, ImportRequest{
+		Archive: multipartFile,
+		Size:    multipartFileHeader.Size,
+	})
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, version.ErrVersionNotFound) {
+		handler.WriteError(w, http.StatusNotFound, "version not found")
+		return
+	}
+	if errors.Is(err, version.ErrVersionNotEditable) {
+		handler.WriteError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if errors.Is(err, ErrImportInvalidArchive) {
+		handler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, ErrImportTooManyEntries) || errors.Is(err, ErrImportTooLarge) {
+		handler.WriteError(w, http.StatusRequestEntityTooLarge, err.Error())
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	handler.WriteJson(w, http.StatusOK, toImportVersionArchiveResponse(entries))
+}
+
+func toImportVersionArchiveResponse(entries []ImportEntry) api.ImportVersionArchiveResponse {
+	response := api.ImportVersionArchiveResponse{
+		Entries: make([]api.ImportEntryResponse, len(entries)),
+	}
+	for i, entry := range entries {
+		item := api.ImportEntryResponse{
+			Path:   entry.Path,
+			Status: api.ImportEntryStatus(entry.Status),
+			FileId: entry.FileID,
+		}
+		if entry.Error != nil {
+			item.Error = new(entry.Error.Error())
+		}
+		response.Entries[i] = item
+
+		if entry.Status == ImportStatusImported {
+			response.Imported++
+		} else {
+			response.Failed++
+		}
+	}
+	return response
+}
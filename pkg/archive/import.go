@@ -0,0 +1,133 @@
+package archive
+
+import (
+	"app/pkg/file"
+	"archive/zip"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"path"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/dustin/go-humanize"
+)
+
+const (
+	maxImportEntries = 1000
+	maxImportSize    = 4 * humanize.GiByte
+	// maxCompressionRatio is far above what documents compress to and far
+	// below what zip bombs need, small entries can't do harm and aren't
+	// checked.
+	maxCompressionRatio     = 100
+	compressionRatioMinSize = 1 * humanize.MiByte
+)
+
+var (
+	ErrImportInvalidArchive  = errors.New("not a valid ZIP archive")
+	ErrImportTooManyEntries  = errors.New("archive has too many entries")
+	ErrImportTooLarge        = errors.New("archive contents are too large")
+	ErrImportUnsafeName      = errors.New("entry name is unsafe")
+	ErrImportNotRegularFile  = errors.New("entry is not a regular file")
+	ErrImportSuspiciousRatio = errors.New("entry compression ratio is too high")
+	ErrImportCorruptEntry    = errors.New("entry is corrupt")
+	ErrImportStoreFailed     = errors.New("entry could not be stored")
+)
+
+// importEntries stores every safe entry of the archive as its own file. The
+// limits are checked against the sizes the archive declares before anything
+// is stored, archive/zip refuses to inflate an entry past its declared size.
+func (s *service) importEntries(ctx context.Context, req ImportRequest) ([]ImportEntry, []int64, error) {
+	zr, err := zip.NewReader(req.Archive, req.Size)
+	if err != nil && !errors.Is(err, zip.ErrInsecurePath) {
+		return nil, nil, ErrImportInvalidArchive
+	}
+
+	entries := slices.DeleteFunc(slices.Clone(zr.File), func(f *zip.File) bool {
+		return f.FileInfo().IsDir() || strings.HasPrefix(f.Name, "__MACOSX/")
+	})
+	if len(entries) > maxImportEntries {
+		return nil, nil, ErrImportTooManyEntries
+	}
+
+	var total uint64
+	for _, f := range entries {
+		total += f.UncompressedSize64
+		if total > maxImportSize {
+			return nil, nil, ErrImportTooLarge
+		}
+	}
+
+	results := make([]ImportEntry, len(entries))
+	var fileIds []int64
+	for i, f := range entries {
+		stored, err := s.importEntry(ctx, f)
+		if err != nil {
+			results[i] = ImportEntry{Path: f.Name, Status: ImportStatusFailed, Error: err}
+			continue
+		}
+
+		results[i] = ImportEntry{Path: f.Name, Status: ImportStatusImported, FileID: &stored.ID}
+		fileIds = append(fileIds, stored.ID)
+	}
+
+	return results, fileIds, nil
+}
+
+func (s *service) importEntry(ctx context.Context, f *zip.File) (file.File, error) {
+	name, ok := entryName(f.Name)
+	if !ok {
+		return file.File{}, ErrImportUnsafeName
+	}
+	if !f.Mode().IsRegular() {
+		return file.File{}, ErrImportNotRegularFile
+	}
+	if f.UncompressedSize64 > compressionRatioMinSize && (f.CompressedSize64 == 0 || f.UncompressedSize64/f.CompressedSize64 > maxCompressionRatio) {
+		return file.File{}, ErrImportSuspiciousRatio
+	}
+
+	contents, err := f.Open()
+	if err != nil {
+		return file.File{}, ErrImportCorruptEntry
+	}
+	defer contents.Close()
+
+	stored, err := s.fileService.CreateWithContents(ctx, file.CreateFileWithContentsRequest{
+		Name:     name,
+		Contents: contents,
+		Size:     int64(f.UncompressedSize64),
+	})
+	if errors.Is(err, zip.ErrFormat) || errors.Is(err, zip.ErrChecksum) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return file.File{}, ErrImportCorruptEntry
+	}
+	if err != nil {
+		log.Printf("error importing archive entry %q: %v", f.Name, err)
+		return file.File{}, ErrImportStoreFailed
+	}
+
+	return stored, nil
+}
+
+// entryName turns the path of an entry into a file name. Folders inside the
+// archive are flattened, entries that point outside of the archive or carry
+// control characters are refused.
+func entryName(name string) (string, bool) {
+	name = strings.ReplaceAll(name, "\\", "/")
+	if path.IsAbs(name) || strings.Contains(name, ":") {
+		return "", false
+	}
+	if slices.Contains(strings.Split(name, "/"), "..") {
+		return "", false
+	}
+	if strings.ContainsFunc(name, unicode.IsControl) {
+		return "", false
+	}
+
+	base := strings.TrimSpace(path.Base(name))
+	if base == "" || base == "." || base == "/" {
+		return "", false
+	}
+	return base, true
+}
@@ -0,0 +1,86 @@
+package archive
+
+import (
+	"app/pkg/file"
+	"app/pkg/version"
+	"io"
+	"time"
+)
+
+type Format string
+
+const (
+	FormatZip   Format = "zip"
+	FormatTarGz Format = "tar.gz"
+)
+
+func (f Format) IsValid() bool {
+	return f == FormatZip || f == FormatTarGz
+}
+
+func (f Format) ContentType() string {
+	if f == FormatTarGz {
+		return "application/gzip"
+	}
+	return "application/zip"
+}
+
+// Archive is everything that goes into the archive of a version, the paths
+// of the entries are unique within the archive.
+type Archive struct {
+	Version version.Version
+	Entries []Entry
+}
+
+type Entry struct {
+	Path string
+	File file.File
+}
+
+// Manifest is written as manifest.json next to the files so an offline copy
+// can be checked against the checksums the server recorded.
+type Manifest struct {
+	GeneratedAt time.Time       `json:"generatedAt"`
+	Version     ManifestVersion `json:"version"`
+	Files       []ManifestFile  `json:"files"`
+}
+
+type ManifestVersion struct {
+	ID          int64      `json:"id"`
+	ProjectID   int64      `json:"projectId"`
+	Name        string     `json:"name"`
+	Description *string    `json:"description"`
+	Status      string     `json:"status"`
+	ReleasedAt  *time.Time `json:"releasedAt"`
+}
+
+type ManifestFile struct {
+	ID        int64     `json:"id"`
+	Path      string    `json:"path"`
+	Name      string    `json:"name"`
+	Size      *int64    `json:"size"`
+	MimeType  *string   `json:"mimeType"`
+	Checksum  *string   `json:"checksum"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type ImportRequest struct {
+	Archive io.ReaderAt
+	Size    int64
+}
+
+type ImportStatus string
+
+const (
+	ImportStatusImported ImportStatus = "imported"
+	ImportStatusFailed   ImportStatus = "failed"
+)
+
+// ImportEntry reports what happened to one entry of an imported archive, the
+// file is only set for imported entries and the error only for failed ones.
+type ImportEntry struct {
+	Path   string
+	Status ImportStatus
+	FileID *int64
+	Error  error
+}
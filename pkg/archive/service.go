@@ -0,0 +1,258 @@
+package archive
+
+import (
+	"app/pkg/file"
+	"app/pkg/membership"
+	"app/pkg/version"
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"path"
+	"strings"
+	"time"
+)
+
+const manifestPath = "manifest.json"
+
+var ErrInvalidFormat = errors.New("invalid archive format")
+
+type Service interface {
+	// Open collects the complete files of a version and assigns each a
+	// unique path in the archive.
+	Open(ctx context.Context, versionId int64) (Archive, error)
+	// Write streams the archive to w, file contents are copied straight from
+	// storage one file at a time.
+	Write(ctx context.Context, archive Archive, format Format, w io.Writer) error
+	// Import stores each entry of a ZIP archive as a file of a draft version.
+	// Entries fail on their own, the imported files are attached to the
+	// version all at once.
+	Import(ctx context.Context, versionId int64, req ImportRequest) ([]ImportEntry, error)
+}
+
+type service struct {
+	versionService    version.Service
+	fileService       file.Service
+	membershipService membership.Service
+}
+
+func NewService(versionService version.Service, fileService file.Service, membershipService membership.Service) Service {
+	return &service{versionService: versionService, fileService: fileService, membershipService: membershipService}
+}
+
+func (s *service) Open(ctx context.Context, versionId int64) (Archive, error) {
+	v, err := s.versionService.GetById(ctx, versionId)
+	if err != nil {
+		return Archive{}, err
+	}
+
+	files, err := s.fileService.ListCompleteByVersion(ctx, versionId)
+	if err != nil {
+		return Archive{}, err
+	}
+
+	return Archive{Version: v, Entries: toEntries(files)}, nil
+}
+
+func (s *service) Import(ctx context.Context, versionId int64, req ImportRequest) ([]ImportEntry, error) {
+	v, err := s.versionService.GetById(ctx, versionId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.membershipService.Authorize(ctx, v.ProjectID, membership.RoleEditor); err != nil {
+		return nil, err
+	}
+	if !v.IsEditable() {
+		return nil, version.ErrVersionNotEditable
+	}
+
+	entries, fileIds, err := s.importEntries(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if len(fileIds) == 0 {
+		return entries, nil
+	}
+
+	if err := s.versionService.AttachFiles(ctx, versionId, fileIds); err != nil {
+		for _, id := range fileIds {
+			if deleteErr := s.fileService.Delete(ctx, id); deleteErr != nil {
+				log.Printf("error deleting imported file %d: %v", id, deleteErr)
+			}
+		}
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (s *service) Write(ctx context.Context, archive Archive, format Format, w io.Writer) error {
+	manifest, err := json.MarshalIndent(toManifest(archive), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case FormatZip:
+		return s.writeZip(ctx, archive, manifest, w)
+	case FormatTarGz:
+		return s.writeTarGz(ctx, archive, manifest, w)
+	}
+	return ErrInvalidFormat
+}
+
+func (s *service) writeZip(ctx context.Context, archive Archive, manifest []byte, w io.Writer) error {
+	zw := zip.NewWriter(w)
+
+	mw, err := zw.CreateHeader(&zip.FileHeader{Name: manifestPath, Method: zip.Deflate, Modified: time.Now()})
+	if err != nil {
+		return err
+	}
+	if _, err := mw.Write(manifest); err != nil {
+		return err
+	}
+
+	for _, entry := range archive.Entries {
+		fw, err := zw.CreateHeader(&zip.FileHeader{Name: entry.Path, Method: zip.Deflate, Modified: entry.File.UpdatedAt})
+		if err != nil {
+			return err
+		}
+		if err := s.copyFile(ctx, archive.Version.ID, entry.File, fw); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+func (s *service) writeTarGz(ctx context.Context, archive Archive, manifest []byte, w io.Writer) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	err := tw.WriteHeader(&tar.Header{
+		Name:    manifestPath,
+		Mode:    0o644,
+		Size:    int64(len(manifest)),
+		ModTime: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := tw.Write(manifest); err != nil {
+		return err
+	}
+
+	for _, entry := range archive.Entries {
+		var size int64
+		if entry.File.Size != nil {
+			size = *entry.File.Size
+		}
+
+		err := tw.WriteHeader(&tar.Header{
+			Name:    entry.Path,
+			Mode:    0o644,
+			Size:    size,
+			ModTime: entry.File.UpdatedAt,
+		})
+		if err != nil {
+			return err
+		}
+		if err := s.copyFile(ctx, archive.Version.ID, entry.File, tw); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+// copyFile writes the contents of the revision the version holds.
+func (s *service) copyFile(ctx context.Context, versionId int64, f file.File, w io.Writer) error {
+	_, reader, err := s.fileService.DownloadInVersion(ctx, versionId, f.ID)
+	if err != nil {
+		return fmt.Errorf("open file %d: %w", f.ID, err)
+	}
+	defer reader.Close()
+
+	if _, err := io.Copy(w, reader); err != nil {
+		return fmt.Errorf("copy file %d: %w", f.ID, err)
+	}
+	return nil
+}
+
+// toEntries gives every file a path in the archive. Files arrive ordered by
+// name and ID, so when names collide the oldest file keeps the name and the
+// others are numbered the same way on every download.
+func toEntries(files []file.File) []Entry {
+	taken := map[string]bool{manifestPath: true}
+
+	entries := make([]Entry, len(files))
+	for i, f := range files {
+		name := sanitizeName(f.Name)
+		if name == "" {
+			name = fmt.Sprintf("file-%d", f.ID)
+		}
+
+		ext := path.Ext(name)
+		stem := strings.TrimSuffix(name, ext)
+		candidate := name
+		for n := 2; taken[strings.ToLower(candidate)]; n++ {
+			candidate = fmt.Sprintf("%s (%d)%s", stem, n, ext)
+		}
+		taken[strings.ToLower(candidate)] = true
+
+		entries[i] = Entry{Path: candidate, File: f}
+	}
+	return entries
+}
+
+// sanitizeName keeps file names from escaping the archive root or creating
+// directories when the archive is extracted.
+func sanitizeName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r < 0x20 {
+			return '_'
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "." || name == ".." {
+		return ""
+	}
+	return name
+}
+
+func toManifest(archive Archive) Manifest {
+	files := make([]ManifestFile, len(archive.Entries))
+	for i, entry := range archive.Entries {
+		files[i] = ManifestFile{
+			ID:        entry.File.ID,
+			Path:      entry.Path,
+			Name:      entry.File.Name,
+			Size:      entry.File.Size,
+			MimeType:  entry.File.MimeType,
+			Checksum:  entry.File.Checksum,
+			UpdatedAt: entry.File.UpdatedAt,
+		}
+	}
+
+	return Manifest{
+		GeneratedAt: time.Now().UTC(),
+		Version: ManifestVersion{
+			ID:          archive.Version.ID,
+			ProjectID:   archive.Version.ProjectID,
+			Name:        archive.Version.Name,
+			Description: archive.Version.Description,
+			Status:      string(archive.Version.Status),
+			ReleasedAt:  archive.Version.ReleasedAt,
+		},
+		Files: files,
+	}
+}
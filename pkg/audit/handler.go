@@ -0,0 +1,212 @@
+package audit
+
+import (
+	"app/pkg/api"
+	"app/pkg/platform/auth"
+	"app/pkg/platform/handler"
+	"encoding/csv"
+	"errors"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+var csvHeader = []string{
+	"id", "occurredAt", "actor", "actorId", "action", "resourceType", "resourceId",
+	"before", "after", "requestId", "clientIp",
+}
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Get("/v1/audit", h.List)
+}
+
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseListEventsFilter(r)
+	if err != nil {
+		handler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if r.URL.Query().Get("format") == "csv" {
+		h.export(w, r, filter)
+		return
+	}
+
+	limit, offset := handler.ParsePagination(r)
+
+	events, err := h.service.List(r.Context(), filter, limit, offset)
+	if err != nil {
+		writeListError(w, err)
+		return
+	}
+
+	handler.WriteJson(w, http.StatusOK, toListAuditEventsResponse(events, limit, offset))
+}
+
+// export streams the events as CSV. The response is only started with the
+// first row, so a rejected filter still gets an error status, a failure after
+// that cuts the export short.
+func (h *Handler) export(w http.ResponseWriter, r *http.Request, filter ListEventsFilter) {
+	var out *csv.Writer
+	start := func() error {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": "audit-events.csv"}))
+		w.WriteHeader(http.StatusOK)
+		out = csv.NewWriter(w)
+		return out.Write(csvHeader)
+	}
+
+	err := h.service.Export(r.Context(), filter, func(event Event) error {
+		if out == nil {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		return out.Write(toCsvRecord(event))
+	})
+	if err != nil && out == nil {
+		writeListError(w, err)
+		return
+	}
+	if err != nil {
+		log.Printf("error exporting audit events: %v", err)
+		return
+	}
+
+	if out == nil {
+		if err := start(); err != nil {
+			log.Printf("error exporting audit events: %v", err)
+			return
+		}
+	}
+	out.Flush()
+	if err := out.Error(); err != nil {
+		log.Printf("error exporting audit events: %v", err)
+	}
+}
+
+func writeListError(w http.ResponseWriter, err error) {
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrAuditInvalidResourceType) || errors.Is(err, ErrAuditResourceIdWithoutType) || errors.Is(err, ErrAuditInvalidRange) {
+		handler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	handler.WriteInternalServerError(w)
+}
+
+func parseListEventsFilter(r *http.Request) (ListEventsFilter, error) {
+	query := r.URL.Query()
+
+	var filter ListEventsFilter
+	if query.Has("resourceType") {
+		filter.ResourceType = new(ResourceType(query.Get("resourceType")))
+	}
+	if query.Has("resourceId") {
+		resourceId, err := strconv.ParseInt(query.Get("resourceId"), 10, 64)
+		if err != nil {
+			return ListEventsFilter{}, errors.New("invalid resource id")
+		}
+		filter.ResourceID = &resourceId
+	}
+	if query.Has("actor") {
+		filter.Actor = new(query.Get("actor"))
+	}
+	if query.Has("action") {
+		filter.Action = new(Action(query.Get("action")))
+	}
+	if query.Has("from") {
+		from, err := time.Parse(time.RFC3339, query.Get("from"))
+		if err != nil {
+			return ListEventsFilter{}, errors.New("invalid from time")
+		}
+		filter.From = &from
+	}
+	if query.Has("to") {
+		to, err := time.Parse(time.RFC3339, query.Get("to"))
+		if err != nil {
+			return ListEventsFilter{}, errors.New("invalid to time")
+		}
+		filter.To = &to
+	}
+	return filter, nil
+}
+
+func toAuditEventResponse(e Event) api.AuditEventResponse {
+	return api.AuditEventResponse{
+		Id:           e.ID,
+		OccurredAt:   e.OccurredAt,
+		Actor:        e.Actor,
+		ActorId:      e.ActorID,
+		Action:       api.AuditAction(e.Action),
+		ResourceType: api.AuditResourceType(e.ResourceType),
+		ResourceId:   e.ResourceID,
+		Before:       e.Before,
+		After:        e.After,
+		RequestId:    e.RequestID,
+		ClientIp:     e.ClientIP,
+	}
+}
+
+func toListAuditEventsResponse(events []Event, limit, offset int64) api.ListAuditEventsResponse {
+	items := make([]api.AuditEventResponse, len(events))
+	for i, event := range events {
+		items[i] = toAuditEventResponse(event)
+	}
+	return api.ListAuditEventsResponse{
+		Limit:  limit,
+		Offset: offset,
+		Events: items,
+	}
+}
+
+func toCsvRecord(e Event) []string {
+	var actorId string
+	if e.ActorID != nil {
+		actorId = strconv.FormatInt(*e.ActorID, 10)
+	}
+	return []string{
+		strconv.FormatInt(e.ID, 10),
+		e.OccurredAt.UTC().Format(time.RFC3339),
+		csvText(orEmpty(e.Actor)),
+		actorId,
+		string(e.Action),
+		string(e.ResourceType),
+		strconv.FormatInt(e.ResourceID, 10),
+		csvText(string(e.Before)),
+		csvText(string(e.After)),
+		csvText(orEmpty(e.RequestID)),
+		csvText(orEmpty(e.ClientIP)),
+	}
+}
+
+// csvText keeps spreadsheets from evaluating a cell as a formula. Actors and
+// request IDs come from outside, a leading quote makes them plain text.
+func csvText(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+func orEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
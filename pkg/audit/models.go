@@ -0,0 +1,90 @@
+package audit
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type ResourceType string
+
+const (
+	ResourceTypeProject  ResourceType = "project"
+	ResourceTypeVersion  ResourceType = "version"
+	ResourceTypeFile     ResourceType = "file"
+	ResourceTypeUser     ResourceType = "user"
+	ResourceTypeLocation ResourceType = "location"
+	// ResourceTypeMembership events are recorded on the project, the member
+	// is in the before or after state.
+	ResourceTypeMembership ResourceType = "membership"
+	ResourceTypeShareLink  ResourceType = "share_link"
+	ResourceTypeShortLink  ResourceType = "short_link"
+)
+
+func (t ResourceType) IsValid() bool {
+	switch t {
+	case ResourceTypeProject, ResourceTypeVersion, ResourceTypeFile, ResourceTypeUser, ResourceTypeLocation,
+		ResourceTypeMembership, ResourceTypeShareLink, ResourceTypeShortLink:
+		return true
+	}
+	return false
+}
+
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+	// ActionClone is recorded on the new version, the source is in its after
+	// state.
+	ActionClone Action = "clone"
+	// ActionUpload records new contents of a file, ActionRestore an earlier
+	// revision made current again.
+	ActionUpload       Action = "upload"
+	ActionRestore      Action = "restore"
+	ActionStatusChange Action = "status_change"
+	// ActionAttach and ActionDetach are recorded on the file, the version is
+	// in the after or before state.
+	ActionAttach             Action = "attach"
+	ActionDetach             Action = "detach"
+	ActionUpdateLatestPolicy Action = "update_latest_policy"
+	// ActionRevoke is recorded when a share or short link stops working,
+	// the link itself is kept.
+	ActionRevoke Action = "revoke"
+)
+
+// Event is one entry of the audit log. Actor is the token subject of the
+// caller, Before and After are JSON snapshots of the resource.
+type Event struct {
+	ID           int64
+	OccurredAt   time.Time
+	Actor        *string
+	ActorID      *int64
+	Action       Action
+	ResourceType ResourceType
+	ResourceID   int64
+	Before       json.RawMessage
+	After        json.RawMessage
+	RequestID    *string
+	ClientIP     *string
+}
+
+// Entry describes a change to record. Before and After are marshaled to JSON,
+// nil leaves them out, for example Before of a created resource.
+type Entry struct {
+	Action       Action
+	ResourceType ResourceType
+	ResourceID   int64
+	Before       any
+	After        any
+}
+
+// ListEventsFilter narrows the log down, From is inclusive and To exclusive.
+type ListEventsFilter struct {
+	ResourceType *ResourceType
+	ResourceID   *int64
+	Actor        *string
+	Action       *Action
+	From         *time.Time
+	To           *time.Time
+}
@@ -0,0 +1,89 @@
+package audit
+
+import (
+	"app/pkg/database"
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type Repository interface {
+	Create(ctx context.Context, event Event) (Event, error)
+	// List returns the events newest first, beforeId skips the events from
+	// that one on.
+	List(ctx context.Context, filter ListEventsFilter, beforeId *int64, limit, offset int64) ([]Event, error)
+}
+
+type repository struct {
+	queries *database.Queries
+}
+
+func NewRepository(queries *database.Queries) Repository {
+	return &repository{queries: queries}
+}
+
+func (r *repository) Create(ctx context.Context, event Event) (Event, error) {
+	row, err := r.queries.CreateAuditEvent(ctx, &database.CreateAuditEventParams{
+		Actor:        event.Actor,
+		ActorId:      event.ActorID,
+		Action:       string(event.Action),
+		ResourceType: string(event.ResourceType),
+		ResourceId:   event.ResourceID,
+		Before:       event.Before,
+		After:        event.After,
+		RequestId:    event.RequestID,
+		ClientIp:     event.ClientIP,
+	})
+	if err != nil {
+		return Event{}, err
+	}
+	return toEvent(row), nil
+}
+
+func (r *repository) List(ctx context.Context, filter ListEventsFilter, beforeId *int64, limit, offset int64) ([]Event, error) {
+	rows, err := r.queries.ListAuditEvents(ctx, &database.ListAuditEventsParams{
+		ResourceType: (*string)(filter.ResourceType),
+		ResourceId:   filter.ResourceID,
+		Actor:        filter.Actor,
+		Action:       (*string)(filter.Action),
+		From:         toTimestamp(filter.From),
+		To:           toTimestamp(filter.To),
+		BeforeId:     beforeId,
+		Limit:        limit,
+		Offset:       offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+	events := make([]Event, len(rows))
+	for i, row := range rows {
+		events[i] = toEvent(row)
+	}
+	return events, nil
+}
+
+func toEvent(row *database.AuditEvent) Event {
+	return Event{
+		ID:           row.ID,
+		OccurredAt:   row.OccurredAt.Time,
+		Actor:        row.Actor,
+		ActorID:      row.ActorID,
+		Action:       Action(row.Action),
+		ResourceType: ResourceType(row.ResourceType),
+		ResourceID:   row.ResourceID,
+		Before:       row.Before,
+		After:        row.After,
+		RequestID:    row.RequestID,
+		ClientIP:     row.ClientIp,
+	}
+}
+
+// toTimestamp passes the time in UTC, the timestamps of the log are written
+// by the database without a time zone.
+func toTimestamp(t *time.Time) pgtype.Timestamp {
+	if t == nil {
+		return pgtype.Timestamp{}
+	}
+	return pgtype.Timestamp{Time: t.UTC(), Valid: true}
+}
@@ -0,0 +1,127 @@
+package audit
+
+import (
+	"app/pkg/platform/auth"
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+const exportBatchSize = 500
+
+var (
+	ErrAuditInvalidResourceType   = errors.New("invalid audit resource type")
+	ErrAuditResourceIdWithoutType = errors.New("resource id requires a resource type")
+	ErrAuditInvalidRange          = errors.New("from must be before to")
+)
+
+type Service interface {
+	// Record appends an event for the caller of the context. Call it in the
+	// transaction of the change, so the change fails when it can't be
+	// recorded.
+	Record(ctx context.Context, entry Entry) error
+	List(ctx context.Context, filter ListEventsFilter, limit, offset int64) ([]Event, error)
+	// Export calls fn with every event matching the filter, newest first,
+	// reading the log in batches.
+	Export(ctx context.Context, filter ListEventsFilter, fn func(Event) error) error
+}
+
+type service struct {
+	repository Repository
+}
+
+func NewService(repository Repository) Service {
+	return &service{repository: repository}
+}
+
+func (s *service) Record(ctx context.Context, entry Entry) error {
+	event := Event{
+		Action:       entry.Action,
+		ResourceType: entry.ResourceType,
+		ResourceID:   entry.ResourceID,
+	}
+
+	var err error
+	if event.Before, err = marshal(entry.Before); err != nil {
+		return err
+	}
+	if event.After, err = marshal(entry.After); err != nil {
+		return err
+	}
+
+	// The token is there before the user is provisioned, so changes to the
+	// caller's own user are attributed as well.
+	if tokenContext, ok := auth.GetTokenContext(ctx); ok {
+		event.Actor = &tokenContext.Subject
+	}
+	if principal, ok := auth.GetPrincipal(ctx); ok && principal.UserID != 0 {
+		event.ActorID = &principal.UserID
+	}
+	if source, ok := GetSource(ctx); ok {
+		if source.RequestID != "" {
+			event.RequestID = &source.RequestID
+		}
+		if source.ClientIP != "" {
+			event.ClientIP = &source.ClientIP
+		}
+	}
+
+	_, err = s.repository.Create(ctx, event)
+	return err
+}
+
+func (s *service) List(ctx context.Context, filter ListEventsFilter, limit, offset int64) ([]Event, error) {
+	if err := authorize(ctx, filter); err != nil {
+		return nil, err
+	}
+	return s.repository.List(ctx, filter, nil, limit, offset)
+}
+
+func (s *service) Export(ctx context.Context, filter ListEventsFilter, fn func(Event) error) error {
+	if err := authorize(ctx, filter); err != nil {
+		return err
+	}
+
+	var beforeId *int64
+	for {
+		events, err := s.repository.List(ctx, filter, beforeId, exportBatchSize, 0)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			if err := fn(event); err != nil {
+				return err
+			}
+		}
+		if len(events) < exportBatchSize {
+			return nil
+		}
+		beforeId = &events[len(events)-1].ID
+	}
+}
+
+// authorize restricts the log to instance admins, as it spans all projects,
+// and validates the filter.
+func authorize(ctx context.Context, filter ListEventsFilter) error {
+	principal, ok := auth.GetPrincipal(ctx)
+	if !ok || !principal.IsAdmin {
+		return auth.ErrForbidden
+	}
+	if filter.ResourceType != nil && !filter.ResourceType.IsValid() {
+		return ErrAuditInvalidResourceType
+	}
+	if filter.ResourceID != nil && filter.ResourceType == nil {
+		return ErrAuditResourceIdWithoutType
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return ErrAuditInvalidRange
+	}
+	return nil
+}
+
+func marshal(v any) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	return json.Marshal(v)
+}
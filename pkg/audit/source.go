@@ -0,0 +1,46 @@
+package audit
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+type sourceContextKey struct{}
+
+// Source is where a request came from, it's stored with the events the
+// request records.
+type Source struct {
+	RequestID string
+	ClientIP  string
+}
+
+func WithSource(ctx context.Context, source Source) context.Context {
+	return context.WithValue(ctx, sourceContextKey{}, source)
+}
+
+func GetSource(ctx context.Context) (Source, bool) {
+	source, ok := ctx.Value(sourceContextKey{}).(Source)
+	return source, ok
+}
+
+// Middleware stores the source of the request in its context. It must run
+// after middleware.RequestID. The client IP is the remote address of the
+// connection, put middleware.RealIP in front when running behind a trusted
+// proxy.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientIP, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			clientIP = r.RemoteAddr
+		}
+
+		ctx := WithSource(r.Context(), Source{
+			RequestID: middleware.GetReqID(r.Context()),
+			ClientIP:  clientIP,
+		})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
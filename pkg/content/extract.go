@@ -0,0 +1,277 @@
+package content
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ledongthuc/pdf"
+)
+
+const (
+	// maxSourceSize bounds the files that are read into memory for
+	// extraction, and the decompressed size of each part of an Office file.
+	maxSourceSize = 64 << 20
+	// maxContentLength keeps the extracted text well below the 1 MB limit
+	// PostgreSQL puts on a tsvector.
+	maxContentLength = 512 << 10
+	// extractTimeout bounds the time an extraction may hold a job runner,
+	// crafted documents can keep a parser busy for much longer.
+	extractTimeout = 2 * time.Minute
+)
+
+var (
+	ErrUnsupportedType = errors.New("unsupported file type")
+	ErrSourceTooLarge  = errors.New("file too large to extract")
+	ErrExtractTimeout  = errors.New("extraction timed out")
+)
+
+const (
+	mimeTypePdf  = "application/pdf"
+	mimeTypeDocx = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	mimeTypeXlsx = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	mimeTypePptx = "application/vnd.openxmlformats-officedocument.presentationml.presentation"
+)
+
+// extractor returns the text of a file. Extractors stop between pages or
+// parts once the context is done.
+type extractor func(ctx context.Context, data []byte) (string, error)
+
+// extractWithTimeout gives up on an extraction that takes longer than
+// extractTimeout with ErrExtractTimeout. A parser stuck within a page can't be
+// interrupted, it is left to finish in the background while the job moves on.
+func extractWithTimeout(ctx context.Context, extract extractor, data []byte) (string, error) {
+	ctx, cancel := context.WithTimeoutCause(ctx, extractTimeout, ErrExtractTimeout)
+	defer cancel()
+
+	type result struct {
+		text string
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		text, err := extract(ctx, data)
+		done <- result{text: text, err: err}
+	}()
+
+	select {
+	case r := <-done:
+		if r.err != nil && ctx.Err() != nil {
+			return "", context.Cause(ctx)
+		}
+		return r.text, r.err
+	case <-ctx.Done():
+		return "", context.Cause(ctx)
+	}
+}
+
+// extractorFor picks the extractor from the detected MIME type. Markdown is
+// detected as plain text, the extension is only consulted for text that was
+// detected as something more specific.
+func extractorFor(name string, mimeType *string) (extractor, bool) {
+	var mediaType string
+	if mimeType != nil {
+		mediaType, _, _ = mime.ParseMediaType(*mimeType)
+	}
+
+	switch mediaType {
+	case mimeTypePdf:
+		return extractPdf, true
+	case mimeTypeDocx:
+		return officeExtractor(isDocxTextPart), true
+	case mimeTypeXlsx:
+		return officeExtractor(isXlsxTextPart), true
+	case mimeTypePptx:
+		return officeExtractor(isPptxTextPart), true
+	case "text/plain", "text/markdown":
+		return extractPlainText, true
+	}
+
+	switch strings.ToLower(path.Ext(name)) {
+	case ".md", ".markdown", ".txt":
+		if strings.HasPrefix(mediaType, "text/") {
+			return extractPlainText, true
+		}
+	}
+	return nil, false
+}
+
+func extractPlainText(_ context.Context, data []byte) (string, error) {
+	return string(data), nil
+}
+
+// extractPdf returns the text of the pages in order. The PDF reader panics on
+// some malformed documents, which fails the extraction like any other error.
+func extractPdf(ctx context.Context, data []byte) (text string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("malformed pdf: %v", r)
+		}
+	}()
+
+	reader, err := pdf.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	fonts := make(map[string]*pdf.Font)
+	for i := 1; i <= reader.NumPage() && b.Len() < maxContentLength; i++ {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		page := reader.Page(i)
+		if page.V.IsNull() {
+			continue
+		}
+		for _, name := range page.Fonts() {
+			if _, ok := fonts[name]; !ok {
+				font := page.Font(name)
+				fonts[name] = &font
+			}
+		}
+
+		pageText, err := page.GetPlainText(fonts)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(pageText)
+		b.WriteByte('\n')
+	}
+	return b.String(), nil
+}
+
+// officeExtractor reads the text runs of the parts of an Office Open XML
+// package that hold the document text.
+func officeExtractor(isTextPart func(name string) bool) extractor {
+	return func(ctx context.Context, data []byte) (string, error) {
+		reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return "", err
+		}
+
+		var parts []*zip.File
+		for _, file := range reader.File {
+			if isTextPart(file.Name) {
+				parts = append(parts, file)
+			}
+		}
+		slices.SortFunc(parts, func(a, b *zip.File) int {
+			return comparePartNames(a.Name, b.Name)
+		})
+
+		var b strings.Builder
+		for _, part := range parts {
+			if b.Len() >= maxContentLength {
+				break
+			}
+			if err := ctx.Err(); err != nil {
+				return "", err
+			}
+			if err := extractXmlText(part, &b); err != nil {
+				return "", fmt.Errorf("%s: %w", part.Name, err)
+			}
+		}
+		return b.String(), nil
+	}
+}
+
+func isDocxTextPart(name string) bool {
+	return name == "word/document.xml"
+}
+
+func isXlsxTextPart(name string) bool {
+	return name == "xl/sharedStrings.xml"
+}
+
+func isPptxTextPart(name string) bool {
+	return strings.HasPrefix(name, "ppt/slides/slide") && strings.HasSuffix(name, ".xml")
+}
+
+// comparePartNames orders numbered parts such as slide2.xml before
+// slide10.xml.
+func comparePartNames(a, b string) int {
+	an, aOk := partNumber(a)
+	bn, bOk := partNumber(b)
+	if aOk && bOk && an != bn {
+		return an - bn
+	}
+	return strings.Compare(a, b)
+}
+
+func partNumber(name string) (int, bool) {
+	base := strings.TrimSuffix(path.Base(name), path.Ext(name))
+	n, err := strconv.Atoi(strings.TrimLeftFunc(base, unicode.IsLetter))
+	return n, err == nil
+}
+
+// extractXmlText appends the character data of the text elements, which are
+// w:t, a:t and t in the WordprocessingML, DrawingML and SpreadsheetML
+// vocabularies. Paragraphs and shared strings end with a line break.
+func extractXmlText(part *zip.File, b *strings.Builder) error {
+	reader, err := part.Open()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	decoder := xml.NewDecoder(io.LimitReader(reader, maxSourceSize))
+	inText := false
+	for b.Len() < maxContentLength {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "t":
+				inText = true
+			case "tab", "br":
+				b.WriteByte(' ')
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "p", "si":
+				b.WriteByte('\n')
+			}
+		case xml.CharData:
+			if inText {
+				b.Write(t)
+			}
+		}
+	}
+	return nil
+}
+
+// normalizeContent makes the text storable, PostgreSQL rejects NUL bytes and
+// invalid UTF-8, and cuts it to maxContentLength on a rune boundary.
+func normalizeContent(text string) string {
+	if len(text) > maxContentLength {
+		cut := maxContentLength
+		for cut > 0 && !utf8.RuneStart(text[cut]) {
+			cut--
+		}
+		text = text[:cut]
+	}
+	text = strings.ToValidUTF8(text, "")
+	return strings.ReplaceAll(text, "\x00", "")
+}
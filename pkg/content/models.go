@@ -0,0 +1,28 @@
+package content
+
+type Status string
+
+const (
+	StatusPending     Status = "pending"
+	StatusExtracted   Status = "extracted"
+	StatusUnsupported Status = "unsupported"
+	StatusFailed      Status = "failed"
+)
+
+// Document is a complete file whose text is to be extracted.
+type Document struct {
+	FileID   int64
+	Name     string
+	Path     *string
+	MimeType *string
+	Size     *int64
+}
+
+// Extraction is the outcome of extracting the text of a file. Error explains
+// why a file is unsupported or why the extraction failed.
+type Extraction struct {
+	FileID  int64
+	Status  Status
+	Content string
+	Error   *string
+}
@@ -0,0 +1,57 @@
+package content
+
+import (
+	"app/pkg/database"
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrDocumentNotFound = errors.New("document not found")
+
+type Repository interface {
+	GetDocument(ctx context.Context, fileId int64) (Document, error)
+	// MarkPending records that the text of the file is about to be extracted,
+	// discarding text extracted before.
+	MarkPending(ctx context.Context, fileId int64) error
+	Save(ctx context.Context, extraction Extraction) error
+}
+
+type repository struct {
+	queries *database.Queries
+}
+
+func NewRepository(queries *database.Queries) Repository {
+	return &repository{queries: queries}
+}
+
+func (r *repository) GetDocument(ctx context.Context, fileId int64) (Document, error) {
+	row, err := r.queries.GetFile(ctx, fileId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Document{}, ErrDocumentNotFound
+	}
+	if err != nil {
+		return Document{}, err
+	}
+	return Document{
+		FileID:   row.ID,
+		Name:     row.Name,
+		Path:     row.Path,
+		MimeType: row.MimeType,
+		Size:     row.Size,
+	}, nil
+}
+
+func (r *repository) MarkPending(ctx context.Context, fileId int64) error {
+	return r.queries.UpsertPendingFileContent(ctx, fileId)
+}
+
+func (r *repository) Save(ctx context.Context, extraction Extraction) error {
+	return r.queries.UpdateFileContent(ctx, &database.UpdateFileContentParams{
+		FileId:  extraction.FileID,
+		Status:  string(extraction.Status),
+		Content: extraction.Content,
+		Error:   extraction.Error,
+	})
+}
@@ -0,0 +1,132 @@
+package content
+
+import (
+	"app/pkg/jobs"
+	"app/pkg/storage"
+	"context"
+	"errors"
+	"io"
+	"log"
+)
+
+const JobKindExtract = "content.extract"
+
+type extractPayload struct {
+	FileID int64 `json:"fileId"`
+}
+
+type Service interface {
+	// Enqueue schedules the extraction of the text of a complete file as a
+	// background job.
+	Enqueue(ctx context.Context, fileId int64) error
+	// Extract is the processor of JobKindExtract jobs. Files that can't be
+	// extracted, or take too long, are recorded as unsupported or failed,
+	// only errors reading the file or saving the text fail the job so it is
+	// retried.
+	Extract(ctx context.Context, job jobs.Job) error
+}
+
+type service struct {
+	repository  Repository
+	fileStorage storage.FileStorage
+	jobService  jobs.Service
+}
+
+func NewService(repository Repository, fileStorage storage.FileStorage, jobService jobs.Service) Service {
+	return &service{repository: repository, fileStorage: fileStorage, jobService: jobService}
+}
+
+func (s *service) Enqueue(ctx context.Context, fileId int64) error {
+	if err := s.repository.MarkPending(ctx, fileId); err != nil {
+		return err
+	}
+
+	_, err := s.jobService.Enqueue(ctx, jobs.EnqueueRequest{
+		Kind:    JobKindExtract,
+		Payload: extractPayload{FileID: fileId},
+	})
+	return err
+}
+
+func (s *service) Extract(ctx context.Context, job jobs.Job) error {
+	var payload extractPayload
+	if err := job.Decode(&payload); err != nil {
+		return err
+	}
+
+	document, err := s.repository.GetDocument(ctx, payload.FileID)
+	if errors.Is(err, ErrDocumentNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	extraction := Extraction{FileID: document.FileID, Status: StatusExtracted}
+	text, err := s.extractText(ctx, document)
+	var readErr *readError
+	switch {
+	case errors.As(err, &readErr):
+		return readErr.err
+	// The job was cancelled rather than the extraction timing out, it is
+	// retried.
+	case ctx.Err() != nil:
+		return ctx.Err()
+	case errors.Is(err, ErrUnsupportedType) || errors.Is(err, ErrSourceTooLarge):
+		extraction.Status = StatusUnsupported
+		extraction.Error = new(err.Error())
+	case err != nil:
+		log.Printf("error extracting content of file %d: %v", document.FileID, err)
+		extraction.Status = StatusFailed
+		extraction.Error = new(err.Error())
+	default:
+		extraction.Content = normalizeContent(text)
+	}
+
+	return s.repository.Save(ctx, extraction)
+}
+
+// readError wraps failures to read the file from storage, which are worth
+// retrying unlike failures to make sense of its contents.
+type readError struct {
+	err error
+}
+
+func (e *readError) Error() string {
+	return e.err.Error()
+}
+
+func (e *readError) Unwrap() error {
+	return e.err
+}
+
+func (s *service) extractText(ctx context.Context, document Document) (string, error) {
+	extract, ok := extractorFor(document.Name, document.MimeType)
+	if !ok || document.Path == nil {
+		return "", ErrUnsupportedType
+	}
+	if document.Size != nil && *document.Size > maxSourceSize {
+		return "", ErrSourceTooLarge
+	}
+
+	reader, err := s.fileStorage.Retrieve(ctx, *document.Path)
+	if err != nil {
+		return "", &readError{err: err}
+	}
+	defer func(reader io.ReadSeekCloser) {
+		err := reader.Close()
+		if err != nil {
+			log.Printf("error closing file during content extraction: %v", err)
+		}
+	}(reader)
+
+	data, err := io.ReadAll(io.LimitReader(reader, maxSourceSize+1))
+	if err != nil {
+		return "", &readError{err: err}
+	}
+	if len(data) > maxSourceSize {
+		return "", ErrSourceTooLarge
+	}
+
+	return extractWithTimeout(ctx, extract, data)
+}
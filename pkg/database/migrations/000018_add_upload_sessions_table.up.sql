@@ -0,0 +1,15 @@
+CREATE TABLE upload_sessions
+(
+    id             UUID PRIMARY KEY   DEFAULT gen_random_uuid(),
+    created_at     TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at     TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at     TIMESTAMP NOT NULL,
+    file_id        BIGINT    NOT NULL
+        CONSTRAINT fk_upload_sessions_file REFERENCES files (id) ON DELETE CASCADE,
+    size           BIGINT    NOT NULL,
+    received_bytes BIGINT    NOT NULL DEFAULT 0,
+    chunk_paths    TEXT[]    NOT NULL DEFAULT '{}',
+    is_complete    BOOLEAN   NOT NULL DEFAULT FALSE
+);
+
+CREATE INDEX idx_upload_sessions_file_id ON upload_sessions (file_id);
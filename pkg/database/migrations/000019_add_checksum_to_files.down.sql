@@ -0,0 +1,5 @@
+ALTER TABLE upload_sessions
+    DROP COLUMN checksum;
+
+ALTER TABLE files
+    DROP COLUMN checksum;
@@ -0,0 +1,5 @@
+ALTER TABLE files
+    ADD COLUMN checksum TEXT;
+
+ALTER TABLE upload_sessions
+    ADD COLUMN checksum TEXT;
@@ -0,0 +1,4 @@
+DROP INDEX idx_projects_location_id;
+
+ALTER TABLE projects
+    ALTER COLUMN location_id TYPE INTEGER;
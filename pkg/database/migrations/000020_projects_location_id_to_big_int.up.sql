@@ -0,0 +1,4 @@
+ALTER TABLE projects
+    ALTER COLUMN location_id TYPE BIGINT;
+
+CREATE INDEX idx_projects_location_id ON projects (location_id);
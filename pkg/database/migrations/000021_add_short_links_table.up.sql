@@ -0,0 +1,22 @@
+CREATE TABLE short_links
+(
+    id          BIGSERIAL PRIMARY KEY,
+    created_at  TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    code        TEXT      NOT NULL
+        CONSTRAINT uq_short_links_code UNIQUE,
+    target_type TEXT      NOT NULL
+        CONSTRAINT chk_short_links_target_type CHECK (target_type IN ('project', 'version', 'latest_version')),
+    -- Deleting the target keeps the code around so a printed label can be
+    -- re-pointed instead of reprinted.
+    project_id  BIGINT
+        CONSTRAINT fk_short_links_project REFERENCES projects (id) ON DELETE SET NULL,
+    version_id  BIGINT
+        CONSTRAINT fk_short_links_version REFERENCES versions (id) ON DELETE SET NULL,
+    revoked_at  TIMESTAMP,
+    hit_count   BIGINT    NOT NULL DEFAULT 0,
+    last_hit_at TIMESTAMP
+);
+
+CREATE INDEX idx_short_links_project_id ON short_links (project_id);
+CREATE INDEX idx_short_links_version_id ON short_links (version_id);
@@ -0,0 +1,3 @@
+ALTER TABLE files DROP COLUMN created_by;
+
+DROP TABLE project_memberships;
@@ -0,0 +1,20 @@
+CREATE TABLE project_memberships
+(
+    project_id BIGINT    NOT NULL
+        CONSTRAINT fk_project_memberships_project REFERENCES projects (id) ON DELETE CASCADE,
+    user_id    BIGINT    NOT NULL
+        CONSTRAINT fk_project_memberships_user REFERENCES users (id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    role       TEXT      NOT NULL
+        CONSTRAINT chk_project_memberships_role CHECK (role IN ('viewer', 'editor', 'admin')),
+    PRIMARY KEY (project_id, user_id)
+);
+
+CREATE INDEX idx_project_memberships_user_id ON project_memberships (user_id);
+
+-- Files that aren't attached to a version yet are only accessible to the user
+-- that created them.
+ALTER TABLE files
+    ADD COLUMN created_by BIGINT
+        CONSTRAINT fk_files_created_by REFERENCES users (id) ON DELETE SET NULL;
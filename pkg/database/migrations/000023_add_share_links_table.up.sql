@@ -0,0 +1,22 @@
+CREATE TABLE share_links
+(
+    id             BIGSERIAL PRIMARY KEY,
+    created_at     TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at     TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    file_id        BIGINT
+        CONSTRAINT fk_share_links_file REFERENCES files (id) ON DELETE CASCADE,
+    version_id     BIGINT
+        CONSTRAINT fk_share_links_version REFERENCES versions (id) ON DELETE CASCADE,
+    expires_at     TIMESTAMP NOT NULL,
+    max_downloads  BIGINT,
+    download_count BIGINT    NOT NULL DEFAULT 0,
+    password_hash  TEXT,
+    revoked_at     TIMESTAMP,
+    created_by     BIGINT
+        CONSTRAINT fk_share_links_created_by REFERENCES users (id) ON DELETE SET NULL,
+    CONSTRAINT chk_share_links_target CHECK ((file_id IS NULL) <> (version_id IS NULL))
+);
+
+CREATE INDEX idx_share_links_file_id ON share_links (file_id);
+CREATE INDEX idx_share_links_version_id ON share_links (version_id);
+CREATE INDEX idx_share_links_created_by ON share_links (created_by);
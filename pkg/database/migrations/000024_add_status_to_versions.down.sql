@@ -0,0 +1,5 @@
+DROP INDEX idx_versions_project_id_status;
+
+ALTER TABLE versions
+    DROP COLUMN released_at,
+    DROP COLUMN status;
@@ -0,0 +1,8 @@
+ALTER TABLE versions
+    ADD COLUMN status      TEXT NOT NULL DEFAULT 'draft'
+        CONSTRAINT chk_versions_status CHECK (status IN ('draft', 'in_review', 'released', 'superseded', 'withdrawn')),
+    -- Set once when the version is first released, released contents stay
+    -- immutable even after the version is superseded or withdrawn.
+    ADD COLUMN released_at TIMESTAMP;
+
+CREATE INDEX idx_versions_project_id_status ON versions (project_id, status);
@@ -0,0 +1,5 @@
+DROP INDEX idx_versions_project_id_released_at;
+
+ALTER TABLE projects
+    DROP COLUMN pinned_version_id,
+    DROP COLUMN latest_version_strategy;
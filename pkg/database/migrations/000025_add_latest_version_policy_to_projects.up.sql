@@ -0,0 +1,8 @@
+ALTER TABLE projects
+    -- Decides which released version is served as the current documentation
+    -- of the project.
+    ADD COLUMN latest_version_strategy TEXT NOT NULL DEFAULT 'released'
+        CONSTRAINT chk_projects_latest_version_strategy CHECK (latest_version_strategy IN ('released', 'semver', 'pinned')),
+    ADD COLUMN pinned_version_id       BIGINT REFERENCES versions (id) ON DELETE SET NULL;
+
+CREATE INDEX idx_versions_project_id_released_at ON versions (project_id, released_at DESC) WHERE status = 'released';
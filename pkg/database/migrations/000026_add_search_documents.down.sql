@@ -0,0 +1,9 @@
+DROP TRIGGER trg_files_search_document ON files;
+DROP TRIGGER trg_versions_search_document ON versions;
+DROP TRIGGER trg_projects_search_document ON projects;
+
+DROP FUNCTION sync_file_search_document();
+DROP FUNCTION sync_version_search_document();
+DROP FUNCTION sync_project_search_document();
+
+DROP TABLE search_documents;
@@ -0,0 +1,98 @@
+-- One document per searchable project, version and file, kept in sync by
+-- triggers. The simple configuration doesn't stem, documentation comes in
+-- several languages and names are often part numbers or codes, which are
+-- additionally split on dashes, underscores and dots.
+CREATE TABLE search_documents
+(
+    entity_type   TEXT   NOT NULL
+        CONSTRAINT chk_search_documents_entity_type CHECK (entity_type IN ('project', 'version', 'file')),
+    entity_id     BIGINT NOT NULL,
+    -- Files have no project, they can be attached to versions of several.
+    project_id    BIGINT,
+    title         TEXT   NOT NULL,
+    body          TEXT   NOT NULL DEFAULT '',
+    search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', title), 'A') ||
+        setweight(to_tsvector('simple', translate(title, '-_.', '   ')), 'A') ||
+        setweight(to_tsvector('simple', translate(body, '-_.', '   ')), 'B')
+        ) STORED,
+    PRIMARY KEY (entity_type, entity_id)
+);
+
+CREATE INDEX idx_search_documents_search_vector ON search_documents USING GIN (search_vector);
+CREATE INDEX idx_search_documents_project_id ON search_documents (project_id);
+
+CREATE FUNCTION sync_project_search_document() RETURNS TRIGGER AS
+$$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        DELETE FROM search_documents WHERE entity_type = 'project' AND entity_id = OLD.id;
+        RETURN OLD;
+    END IF;
+    INSERT INTO search_documents (entity_type, entity_id, project_id, title, body)
+    VALUES ('project', NEW.id, NEW.id, NEW.name, NEW.slug)
+    ON CONFLICT (entity_type, entity_id) DO UPDATE SET title = excluded.title,
+                                                       body  = excluded.body;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE FUNCTION sync_version_search_document() RETURNS TRIGGER AS
+$$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        DELETE FROM search_documents WHERE entity_type = 'version' AND entity_id = OLD.id;
+        RETURN OLD;
+    END IF;
+    INSERT INTO search_documents (entity_type, entity_id, project_id, title, body)
+    VALUES ('version', NEW.id, NEW.project_id, NEW.name, coalesce(NEW.description, ''))
+    ON CONFLICT (entity_type, entity_id) DO UPDATE SET project_id = excluded.project_id,
+                                                       title      = excluded.title,
+                                                       body       = excluded.body;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE FUNCTION sync_file_search_document() RETURNS TRIGGER AS
+$$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        DELETE FROM search_documents WHERE entity_type = 'file' AND entity_id = OLD.id;
+        RETURN OLD;
+    END IF;
+    INSERT INTO search_documents (entity_type, entity_id, title)
+    VALUES ('file', NEW.id, NEW.name)
+    ON CONFLICT (entity_type, entity_id) DO UPDATE SET title = excluded.title;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_projects_search_document
+    AFTER INSERT OR UPDATE OF name, slug OR DELETE
+    ON projects
+    FOR EACH ROW
+EXECUTE FUNCTION sync_project_search_document();
+
+CREATE TRIGGER trg_versions_search_document
+    AFTER INSERT OR UPDATE OF name, description, project_id OR DELETE
+    ON versions
+    FOR EACH ROW
+EXECUTE FUNCTION sync_version_search_document();
+
+CREATE TRIGGER trg_files_search_document
+    AFTER INSERT OR UPDATE OF name OR DELETE
+    ON files
+    FOR EACH ROW
+EXECUTE FUNCTION sync_file_search_document();
+
+INSERT INTO search_documents (entity_type, entity_id, project_id, title, body)
+SELECT 'project', id, id, name, slug
+FROM projects;
+
+INSERT INTO search_documents (entity_type, entity_id, project_id, title, body)
+SELECT 'version', id, project_id, name, coalesce(description, '')
+FROM versions;
+
+INSERT INTO search_documents (entity_type, entity_id, title)
+SELECT 'file', id, name
+FROM files;
@@ -0,0 +1,18 @@
+-- Text extracted from the contents of files, filled in the background once a
+-- file is complete.
+CREATE TABLE file_contents
+(
+    file_id       BIGINT PRIMARY KEY
+        CONSTRAINT fk_file_contents_file REFERENCES files (id) ON DELETE CASCADE,
+    created_at    TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at    TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    status        TEXT      NOT NULL DEFAULT 'pending'
+        CONSTRAINT chk_file_contents_status CHECK (status IN ('pending', 'extracted', 'unsupported', 'failed')),
+    content       TEXT      NOT NULL DEFAULT '',
+    error         TEXT,
+    extracted_at  TIMESTAMP,
+    search_vector tsvector GENERATED ALWAYS AS (to_tsvector('simple', content)) STORED
+);
+
+CREATE INDEX idx_file_contents_search_vector ON file_contents USING GIN (search_vector);
+CREATE INDEX idx_file_contents_pending ON file_contents (created_at) WHERE status = 'pending';
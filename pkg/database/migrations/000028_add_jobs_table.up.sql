@@ -0,0 +1,27 @@
+CREATE TABLE jobs
+(
+    id           BIGSERIAL PRIMARY KEY,
+    created_at   TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at   TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    kind         TEXT      NOT NULL,
+    payload      JSONB     NOT NULL DEFAULT '{}',
+    -- Failed jobs are dead letters, they ran out of attempts and are only
+    -- run again when retried by an admin.
+    status       TEXT      NOT NULL DEFAULT 'pending'
+        CONSTRAINT chk_jobs_status CHECK (status IN ('pending', 'running', 'completed', 'failed')),
+    attempts     INTEGER   NOT NULL DEFAULT 0,
+    max_attempts INTEGER   NOT NULL DEFAULT 5
+        CONSTRAINT chk_jobs_max_attempts CHECK (max_attempts > 0),
+    run_at       TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    locked_at    TIMESTAMP,
+    last_error   TEXT,
+    completed_at TIMESTAMP,
+    -- A job with a key is only ever queued once, scheduled jobs use their kind
+    -- and period so several instances don't queue the same run.
+    unique_key   TEXT
+);
+
+CREATE INDEX idx_jobs_pending_run_at ON jobs (run_at, id) WHERE status = 'pending';
+CREATE INDEX idx_jobs_running_locked_at ON jobs (locked_at) WHERE status = 'running';
+CREATE INDEX idx_jobs_status_kind ON jobs (status, kind);
+CREATE UNIQUE INDEX idx_jobs_unique_key ON jobs (unique_key);
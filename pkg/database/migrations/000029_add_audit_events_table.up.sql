@@ -0,0 +1,35 @@
+CREATE TABLE audit_events
+(
+    id            BIGSERIAL PRIMARY KEY,
+    occurred_at   TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    -- The subject of the caller's token, the user id is kept as well but not
+    -- as a foreign key so the log outlives the users it mentions. Both are
+    -- null for work the application does on its own behalf.
+    actor         TEXT,
+    actor_id      BIGINT,
+    action        TEXT      NOT NULL,
+    resource_type TEXT      NOT NULL,
+    resource_id   BIGINT    NOT NULL,
+    before        JSONB,
+    after         JSONB,
+    request_id    TEXT,
+    client_ip     TEXT
+);
+
+CREATE INDEX idx_audit_events_resource ON audit_events (resource_type, resource_id, id);
+CREATE INDEX idx_audit_events_actor ON audit_events (actor, id);
+CREATE INDEX idx_audit_events_occurred_at ON audit_events (occurred_at);
+
+-- The log is append-only, events can't be changed or removed afterwards.
+CREATE FUNCTION audit_events_append_only() RETURNS TRIGGER AS
+$$
+BEGIN
+    RAISE EXCEPTION 'audit events are append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_audit_events_append_only
+    BEFORE UPDATE OR DELETE OR TRUNCATE
+    ON audit_events
+    FOR EACH STATEMENT
+EXECUTE FUNCTION audit_events_append_only();
@@ -0,0 +1,39 @@
+CREATE TABLE webhook_subscriptions
+(
+    id         BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    -- Global subscriptions without a project receive the events of every
+    -- project.
+    project_id BIGINT REFERENCES projects (id) ON DELETE CASCADE,
+    url        TEXT      NOT NULL,
+    secret     TEXT      NOT NULL,
+    events     TEXT[]    NOT NULL,
+    is_active  BOOLEAN   NOT NULL DEFAULT TRUE,
+    created_by BIGINT    REFERENCES users (id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_webhook_subscriptions_project_id ON webhook_subscriptions (project_id);
+
+CREATE TABLE webhook_deliveries
+(
+    id              BIGSERIAL PRIMARY KEY,
+    created_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    subscription_id BIGINT    NOT NULL REFERENCES webhook_subscriptions (id) ON DELETE CASCADE,
+    event           TEXT      NOT NULL,
+    -- Redeliveries keep the event id, so receivers can tell them apart from
+    -- new events.
+    event_id        TEXT      NOT NULL,
+    payload         JSONB     NOT NULL,
+    status          TEXT      NOT NULL DEFAULT 'pending'
+        CONSTRAINT chk_webhook_deliveries_status CHECK (status IN ('pending', 'succeeded', 'failed')),
+    attempts        INTEGER   NOT NULL DEFAULT 0,
+    response_status INTEGER,
+    response_body   TEXT,
+    last_error      TEXT,
+    delivered_at    TIMESTAMP,
+    redelivery_of   BIGINT    REFERENCES webhook_deliveries (id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_webhook_deliveries_subscription_id ON webhook_deliveries (subscription_id, id);
@@ -0,0 +1,7 @@
+ALTER TABLE versions_files
+    DROP COLUMN revision_id;
+
+ALTER TABLE files
+    DROP COLUMN current_revision_id;
+
+DROP TABLE file_revisions;
@@ -0,0 +1,47 @@
+-- Every upload of a file is kept as an immutable revision. The file keeps the
+-- columns of its current revision, so reading a file doesn't need the join.
+CREATE TABLE file_revisions
+(
+    id         BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    file_id    BIGINT    NOT NULL REFERENCES files (id) ON DELETE CASCADE,
+    number     INTEGER   NOT NULL,
+    path       TEXT      NOT NULL,
+    size       BIGINT    NOT NULL,
+    mime_type  TEXT      NOT NULL,
+    checksum   TEXT,
+    created_by BIGINT    REFERENCES users (id) ON DELETE SET NULL,
+    UNIQUE (file_id, number)
+);
+
+CREATE INDEX idx_file_revisions_path ON file_revisions (path);
+
+ALTER TABLE files
+    ADD COLUMN current_revision_id BIGINT REFERENCES file_revisions (id);
+
+-- A version follows the current revision of a file until it pins one, which
+-- happens at the latest when it's released.
+ALTER TABLE versions_files
+    ADD COLUMN revision_id BIGINT REFERENCES file_revisions (id);
+
+-- The contents uploaded so far become the first revision of their files, and
+-- released versions pin them.
+INSERT INTO file_revisions (created_at, file_id, number, path, size, mime_type, checksum, created_by)
+SELECT updated_at, id, 1, path, size, COALESCE(mime_type, 'application/octet-stream'), checksum, created_by
+FROM files
+WHERE is_complete = TRUE
+  AND path IS NOT NULL
+  AND size IS NOT NULL;
+
+UPDATE files
+SET current_revision_id = file_revisions.id
+FROM file_revisions
+WHERE file_revisions.file_id = files.id;
+
+UPDATE versions_files
+SET revision_id = files.current_revision_id
+FROM files,
+     versions
+WHERE files.id = versions_files.file_id
+  AND versions.id = versions_files.version_id
+  AND versions.released_at IS NOT NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE locations
+    DROP COLUMN created_by;
@@ -0,0 +1,5 @@
+-- Locations created before this have no creator, only instance admins and the
+-- admins of the projects using them may change them.
+ALTER TABLE locations
+    ADD COLUMN created_by BIGINT
+        CONSTRAINT fk_locations_created_by REFERENCES users (id) ON DELETE SET NULL;
@@ -0,0 +1,7 @@
+-- Users without an email get a placeholder that can't receive mail instead
+-- of being deleted, so their memberships survive the rollback.
+UPDATE users
+SET email = 'user-' || id || '@placeholder.invalid'
+WHERE email IS NULL;
+ALTER TABLE users
+    ALTER COLUMN email SET NOT NULL;
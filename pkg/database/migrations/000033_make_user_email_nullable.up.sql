@@ -0,0 +1,4 @@
+-- Accounts without an email claim, or whose email already belongs to another
+-- user, are provisioned without one.
+ALTER TABLE users
+    ALTER COLUMN email DROP NOT NULL;
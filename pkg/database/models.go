@@ -8,15 +8,71 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type AuditEvent struct {
+	ID           int64
+	OccurredAt   pgtype.Timestamp
+	Actor        *string
+	ActorID      *int64
+	Action       string
+	ResourceType string
+	ResourceID   int64
+	Before       []byte
+	After        []byte
+	RequestID    *string
+	ClientIp     *string
+}
+
 type File struct {
-	ID         int64
-	CreatedAt  pgtype.Timestamp
-	UpdatedAt  pgtype.Timestamp
-	Name       string
-	Size       *int64
-	Path       *string
-	MimeType   *string
-	IsComplete bool
+	ID                int64
+	CreatedAt         pgtype.Timestamp
+	UpdatedAt         pgtype.Timestamp
+	Name              string
+	Size              *int64
+	Path              *string
+	MimeType          *string
+	IsComplete        bool
+	Checksum          *string
+	CreatedBy         *int64
+	CurrentRevisionID *int64
+}
+
+type FileContent struct {
+	FileID       int64
+	CreatedAt    pgtype.Timestamp
+	UpdatedAt    pgtype.Timestamp
+	Status       string
+	Content      string
+	Error        *string
+	ExtractedAt  pgtype.Timestamp
+	SearchVector interface{}
+}
+
+type FileRevision struct {
+	ID        int64
+	CreatedAt pgtype.Timestamp
+	FileID    int64
+	Number    int32
+	Path      string
+	Size      int64
+	MimeType  string
+	Checksum  *string
+	CreatedBy *int64
+}
+
+type Job struct {
+	ID          int64
+	CreatedAt   pgtype.Timestamp
+	UpdatedAt   pgtype.Timestamp
+	Kind        string
+	Payload     []byte
+	Status      string
+	Attempts    int32
+	MaxAttempts int32
+	RunAt       pgtype.Timestamp
+	LockedAt    pgtype.Timestamp
+	LastError   *string
+	CompletedAt pgtype.Timestamp
+	UniqueKey   *string
 }
 
 type Location struct {
@@ -27,15 +83,75 @@ type Location struct {
 	Address   *string
 	Lat       *float64
 	Lon       *float64
+	CreatedBy *int64
 }
 
 type Project struct {
+	ID                    int64
+	CreatedAt             pgtype.Timestamp
+	UpdatedAt             pgtype.Timestamp
+	Slug                  string
+	Name                  string
+	LocationID            *int64
+	LatestVersionStrategy string
+	PinnedVersionID       *int64
+}
+
+type ProjectMembership struct {
+	ProjectID int64
+	UserID    int64
+	CreatedAt pgtype.Timestamp
+	UpdatedAt pgtype.Timestamp
+	Role      string
+}
+
+type SearchDocument struct {
+	EntityType   string
+	EntityID     int64
+	ProjectID    *int64
+	Title        string
+	Body         string
+	SearchVector interface{}
+}
+
+type ShareLink struct {
+	ID            int64
+	CreatedAt     pgtype.Timestamp
+	UpdatedAt     pgtype.Timestamp
+	FileID        *int64
+	VersionID     *int64
+	ExpiresAt     pgtype.Timestamp
+	MaxDownloads  *int64
+	DownloadCount int64
+	PasswordHash  *string
+	RevokedAt     pgtype.Timestamp
+	CreatedBy     *int64
+}
+
+type ShortLink struct {
 	ID         int64
 	CreatedAt  pgtype.Timestamp
 	UpdatedAt  pgtype.Timestamp
-	Slug       string
-	Name       string
-	LocationID *int32
+	Code       string
+	TargetType string
+	ProjectID  *int64
+	VersionID  *int64
+	RevokedAt  pgtype.Timestamp
+	HitCount   int64
+	LastHitAt  pgtype.Timestamp
+}
+
+type UploadSession struct {
+	ID            pgtype.UUID
+	CreatedAt     pgtype.Timestamp
+	UpdatedAt     pgtype.Timestamp
+	ExpiresAt     pgtype.Timestamp
+	FileID        int64
+	Size          int64
+	ReceivedBytes int64
+	ChunkPaths    []string
+	IsComplete    bool
+	Checksum      *string
 }
 
 type User struct {
@@ -43,7 +159,7 @@ type User struct {
 	CreatedAt         pgtype.Timestamp
 	UpdatedAt         pgtype.Timestamp
 	Name              string
-	Email             string
+	Email             *string
 	EmailVerified     bool
 	KeycloakReference *string
 }
@@ -55,9 +171,41 @@ type Version struct {
 	Name        string
 	Description *string
 	ProjectID   int64
+	Status      string
+	ReleasedAt  pgtype.Timestamp
 }
 
 type VersionsFile struct {
-	VersionID int64
-	FileID    int64
+	VersionID  int64
+	FileID     int64
+	RevisionID *int64
+}
+
+type WebhookDelivery struct {
+	ID             int64
+	CreatedAt      pgtype.Timestamp
+	UpdatedAt      pgtype.Timestamp
+	SubscriptionID int64
+	Event          string
+	EventID        string
+	Payload        []byte
+	Status         string
+	Attempts       int32
+	ResponseStatus *int32
+	ResponseBody   *string
+	LastError      *string
+	DeliveredAt    pgtype.Timestamp
+	RedeliveryOf   *int64
+}
+
+type WebhookSubscription struct {
+	ID        int64
+	CreatedAt pgtype.Timestamp
+	UpdatedAt pgtype.Timestamp
+	ProjectID *int64
+	Url       string
+	Secret    string
+	Events    []string
+	IsActive  bool
+	CreatedBy *int64
 }
@@ -6,7 +6,9 @@ SELECT id,
        updated_at,
        slug,
        name,
-       location_id
+       location_id,
+       latest_version_strategy,
+       pinned_version_id
 FROM projects
 WHERE id = $1
 LIMIT 1;
@@ -16,14 +18,39 @@ SELECT count(projects.id)
 FROM projects;
 
 -- name: ListProjects :many
-SELECT id,
-       created_at,
-       updated_at,
-       slug,
-       name,
-       location_id
-FROM projects
-ORDER BY created_at DESC
+-- Distances are great-circle distances in kilometres computed with the
+-- haversine formula. They are only meaningful when a reference point is given,
+-- in which case projects without coordinates are left out of the results.
+SELECT p.id,
+       p.created_at,
+       p.updated_at,
+       p.slug,
+       p.name,
+       p.location_id,
+       COALESCE(d.distance_km, 0)::DOUBLE PRECISION AS distance_km
+FROM projects p
+         LEFT JOIN locations l ON l.id = p.location_id
+         LEFT JOIN LATERAL (SELECT (2 * 6371.0088 * asin(least(1.0, sqrt(
+        power(sin(radians(l.lat - sqlc.narg('nearLat')::DOUBLE PRECISION) / 2), 2) +
+        cos(radians(sqlc.narg('nearLat')::DOUBLE PRECISION)) * cos(radians(l.lat)) *
+        power(sin(radians(l.lon - sqlc.narg('nearLon')::DOUBLE PRECISION) / 2), 2)))))::DOUBLE PRECISION AS distance_km) d
+                   ON TRUE
+WHERE (sqlc.narg('memberId')::BIGINT IS NULL OR EXISTS (SELECT 1
+                                                          FROM project_memberships m
+                                                          WHERE m.project_id = p.id
+                                                            AND m.user_id = sqlc.narg('memberId')))
+  AND (sqlc.narg('locationId')::BIGINT IS NULL OR p.location_id = sqlc.narg('locationId'))
+  AND (sqlc.narg('nearLat')::DOUBLE PRECISION IS NULL OR d.distance_km IS NOT NULL)
+  AND (sqlc.narg('radiusKm')::DOUBLE PRECISION IS NULL OR d.distance_km <= sqlc.narg('radiusKm')::DOUBLE PRECISION)
+  AND (sqlc.narg('minLat')::DOUBLE PRECISION IS NULL OR
+       (l.lat BETWEEN sqlc.narg('minLat')::DOUBLE PRECISION AND sqlc.narg('maxLat')::DOUBLE PRECISION AND
+        CASE
+            -- A box whose west edge lies east of its east edge crosses the antimeridian.
+            WHEN sqlc.narg('minLon')::DOUBLE PRECISION <= sqlc.narg('maxLon')::DOUBLE PRECISION
+                THEN l.lon BETWEEN sqlc.narg('minLon')::DOUBLE PRECISION AND sqlc.narg('maxLon')::DOUBLE PRECISION
+            ELSE l.lon >= sqlc.narg('minLon')::DOUBLE PRECISION OR l.lon <= sqlc.narg('maxLon')::DOUBLE PRECISION
+            END))
+ORDER BY d.distance_km ASC NULLS LAST, p.created_at DESC
 LIMIT sqlc.arg('limit')::BIGINT OFFSET sqlc.arg('offset')::BIGINT;
 
 -- name: CreateProject :one
@@ -56,6 +83,11 @@ WHERE project_id = $1;
 SELECT *
 FROM versions
 WHERE (sqlc.narg('projectId')::BIGINT IS NULL OR project_id = sqlc.narg('projectId'))
+  AND (sqlc.narg('status')::TEXT IS NULL OR status = sqlc.narg('status'))
+  AND (sqlc.narg('memberId')::BIGINT IS NULL OR EXISTS (SELECT 1
+                                                          FROM project_memberships m
+                                                          WHERE m.project_id = versions.project_id
+                                                            AND m.user_id = sqlc.narg('memberId')))
 ORDER BY created_at DESC
 LIMIT sqlc.arg('limit')::BIGINT OFFSET sqlc.arg('offset')::BIGINT;
 
@@ -65,11 +97,28 @@ SELECT id,
        updated_at,
        name,
        description,
-       project_id
+       project_id,
+       status,
+       released_at
 FROM versions
 WHERE id = $1
 LIMIT 1;
 
+-- name: LockVersion :one
+-- Holds the version until the transaction ends, so files can't be attached or
+-- detached while it is being released.
+SELECT id,
+       created_at,
+       updated_at,
+       name,
+       description,
+       project_id,
+       status,
+       released_at
+FROM versions
+WHERE id = $1
+    FOR UPDATE;
+
 -- name: CreateVersion :one
 INSERT INTO versions (name, description, project_id)
 VALUES ($1, $2, $3)
@@ -83,6 +132,60 @@ SET updated_at  = CURRENT_TIMESTAMP,
 WHERE id = $1
 RETURNING *;
 
+-- name: UpdateVersionStatus :one
+UPDATE versions
+SET updated_at  = CURRENT_TIMESTAMP,
+    status      = sqlc.arg('status'),
+    released_at = CASE
+                      WHEN sqlc.arg('status') = 'released' THEN COALESCE(released_at, CURRENT_TIMESTAMP)
+                      ELSE released_at END
+WHERE id = sqlc.arg('id')
+  AND status = sqlc.arg('expectedStatus')
+RETURNING *;
+
+-- name: ListVersionFiles :many
+-- Files have the contents of the revision the version pins, or of their
+-- current revision.
+SELECT files.id,
+       files.name,
+       file_revisions.id AS revision_id,
+       file_revisions.size,
+       file_revisions.mime_type,
+       file_revisions.checksum
+FROM files
+         INNER JOIN versions_files ON versions_files.file_id = files.id
+         LEFT JOIN file_revisions
+                   ON file_revisions.id = COALESCE(versions_files.revision_id, files.current_revision_id)
+WHERE versions_files.version_id = $1
+ORDER BY files.name, files.id;
+
+-- name: CountIncompleteFilesByVersionId :one
+SELECT count(*)
+FROM versions_files
+         INNER JOIN files ON files.id = versions_files.file_id
+WHERE versions_files.version_id = $1
+  AND files.is_complete = FALSE;
+
+-- name: ListReleasedVersionsByProjectId :many
+SELECT *
+FROM versions
+WHERE project_id = $1
+  AND status = 'released'
+ORDER BY released_at DESC, id DESC;
+
+-- name: GetLatestVersionPolicy :one
+SELECT latest_version_strategy, pinned_version_id
+FROM projects
+WHERE id = $1;
+
+-- name: UpdateLatestVersionPolicy :one
+UPDATE projects
+SET updated_at              = CURRENT_TIMESTAMP,
+    latest_version_strategy = $2,
+    pinned_version_id       = $3
+WHERE id = $1
+RETURNING latest_version_strategy, pinned_version_id;
+
 -- name: DeleteVersion :exec
 DELETE
 FROM versions
@@ -90,6 +193,19 @@ WHERE id = $1;
 
 -- Locations
 
+-- name: GetLocation :one
+SELECT id,
+       created_at,
+       updated_at,
+       name,
+       address,
+       lat,
+       lon,
+       created_by
+FROM locations
+WHERE id = $1
+LIMIT 1;
+
 -- name: ListLocations :many
 SELECT id,
        created_at,
@@ -97,9 +213,58 @@ SELECT id,
        name,
        address,
        lat,
-       lon
+       lon,
+       created_by
 FROM locations
-ORDER BY created_at DESC;
+WHERE (sqlc.narg('memberId')::BIGINT IS NULL OR locations.created_by = sqlc.narg('memberId') OR EXISTS (SELECT 1
+                                                                                                       FROM projects p
+                                                                                                                INNER JOIN project_memberships m ON m.project_id = p.id
+                                                                                                       WHERE p.location_id = locations.id
+                                                                                                         AND m.user_id = sqlc.narg('memberId')))
+ORDER BY created_at DESC
+LIMIT sqlc.arg('limit')::BIGINT OFFSET sqlc.arg('offset')::BIGINT;
+
+-- name: ListLocationsByIds :many
+SELECT id,
+       created_at,
+       updated_at,
+       name,
+       address,
+       lat,
+       lon,
+       created_by
+FROM locations
+WHERE id = ANY (sqlc.arg('ids')::BIGINT[])
+  AND (sqlc.narg('memberId')::BIGINT IS NULL OR locations.created_by = sqlc.narg('memberId') OR EXISTS (SELECT 1
+                                                                                                       FROM projects p
+                                                                                                                INNER JOIN project_memberships m ON m.project_id = p.id
+                                                                                                       WHERE p.location_id = locations.id
+                                                                                                         AND m.user_id = sqlc.narg('memberId')));
+
+-- name: CreateLocation :one
+INSERT INTO locations (name, address, lat, lon, created_by)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: ListProjectIdsByLocationId :many
+SELECT id
+FROM projects
+WHERE location_id = $1;
+
+-- name: UpdateLocation :one
+UPDATE locations
+SET updated_at = CURRENT_TIMESTAMP,
+    name       = $2,
+    address    = $3,
+    lat        = $4,
+    lon        = $5
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteLocation :exec
+DELETE
+FROM locations
+WHERE id = $1;
 
 -- Files
 
@@ -110,17 +275,32 @@ FROM files
 WHERE versions_files.version_id = $1;
 
 -- name: ListFiles :many
+-- A member sees the files attached to versions of their projects and the files
+-- they created themselves. The files of a version have the contents of the
+-- revision it pins.
 SELECT files.id,
        files.created_at,
        files.updated_at,
        files.name,
-       files.size,
-       files.path,
-       files.mime_type,
-       files.is_complete
+       file_revisions.size,
+       file_revisions.path,
+       file_revisions.mime_type,
+       files.is_complete,
+       file_revisions.checksum,
+       files.created_by,
+       file_revisions.id AS revision_id
 FROM files
-         LEFT JOIN versions_files ON files.id = versions_files.file_id
-WHERE (sqlc.narg('versionId')::BIGINT IS NULL OR versions_files.version_id = sqlc.narg('versionId'))
+         LEFT JOIN versions_files
+                   ON versions_files.file_id = files.id AND versions_files.version_id = sqlc.narg('versionId')
+         LEFT JOIN file_revisions
+                   ON file_revisions.id = COALESCE(versions_files.revision_id, files.current_revision_id)
+WHERE (sqlc.narg('versionId')::BIGINT IS NULL OR versions_files.version_id IS NOT NULL)
+  AND (sqlc.narg('memberId')::BIGINT IS NULL OR files.created_by = sqlc.narg('memberId') OR EXISTS (SELECT 1
+                                                                                                   FROM versions_files vf
+                                                                                                            INNER JOIN versions v ON v.id = vf.version_id
+                                                                                                            INNER JOIN project_memberships m ON m.project_id = v.project_id
+                                                                                                   WHERE vf.file_id = files.id
+                                                                                                     AND m.user_id = sqlc.narg('memberId')))
 ORDER BY files.created_at DESC
 LIMIT sqlc.arg('limit')::BIGINT OFFSET sqlc.arg('offset')::BIGINT;
 
@@ -132,14 +312,94 @@ SELECT id,
        size,
        path,
        mime_type,
-       is_complete
+       is_complete,
+       checksum,
+       created_by,
+       current_revision_id
 FROM files
 WHERE id = $1
 LIMIT 1;
 
+-- name: ListCompleteFilesByVersionId :many
+SELECT files.id,
+       files.created_at,
+       files.updated_at,
+       files.name,
+       file_revisions.size,
+       file_revisions.path,
+       file_revisions.mime_type,
+       files.is_complete,
+       file_revisions.checksum,
+       files.created_by,
+       file_revisions.id AS revision_id
+FROM files
+         INNER JOIN versions_files ON versions_files.file_id = files.id
+         LEFT JOIN file_revisions
+                   ON file_revisions.id = COALESCE(versions_files.revision_id, files.current_revision_id)
+WHERE versions_files.version_id = $1
+  AND files.is_complete = TRUE
+ORDER BY files.name, files.id;
+
+-- name: GetFileByNameInVersions :one
+-- Versions are given in order of precedence, the copy from the first version
+-- that contains a file with the name wins, with the revision that version
+-- pins.
+SELECT files.id,
+       files.created_at,
+       files.updated_at,
+       files.name,
+       file_revisions.size,
+       file_revisions.path,
+       file_revisions.mime_type,
+       files.is_complete,
+       file_revisions.checksum,
+       files.created_by,
+       file_revisions.id AS revision_id
+FROM files
+         INNER JOIN versions_files ON versions_files.file_id = files.id
+         LEFT JOIN file_revisions
+                   ON file_revisions.id = COALESCE(versions_files.revision_id, files.current_revision_id)
+WHERE files.name = sqlc.arg('name')
+  AND versions_files.version_id = ANY (sqlc.arg('versionIds')::BIGINT[])
+ORDER BY array_position(sqlc.arg('versionIds')::BIGINT[], versions_files.version_id), files.id DESC
+LIMIT 1;
+
+-- name: GetFileInVersion :one
+-- The file with the revision the version pins.
+SELECT files.id,
+       files.created_at,
+       files.updated_at,
+       files.name,
+       file_revisions.size,
+       file_revisions.path,
+       file_revisions.mime_type,
+       files.is_complete,
+       file_revisions.checksum,
+       files.created_by,
+       file_revisions.id AS revision_id
+FROM files
+         INNER JOIN versions_files ON versions_files.file_id = files.id
+         LEFT JOIN file_revisions
+                   ON file_revisions.id = COALESCE(versions_files.revision_id, files.current_revision_id)
+WHERE versions_files.version_id = sqlc.arg('versionId')
+  AND files.id = sqlc.arg('fileId');
+
+-- name: ListProjectIdsByFileId :many
+SELECT DISTINCT versions.project_id
+FROM versions_files
+         INNER JOIN versions ON versions.id = versions_files.version_id
+WHERE versions_files.file_id = $1;
+
+-- name: ListCompleteFiles :many
+SELECT *
+FROM files
+WHERE is_complete = TRUE
+ORDER BY id
+LIMIT sqlc.arg('limit')::BIGINT OFFSET sqlc.arg('offset')::BIGINT;
+
 -- name: CreateFile :one
-INSERT INTO files (name, size, path, mime_type, is_complete)
-VALUES ($1, $2, $3, $4, $5)
+INSERT INTO files (name, size, path, mime_type, is_complete, checksum, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
 RETURNING *;
 
 -- name: UpdateFile :one
@@ -149,18 +409,53 @@ SET updated_at  = current_timestamp,
     size        = $3,
     path        = $4,
     mime_type   = $5,
-    is_complete = $6
+    is_complete = $6,
+    checksum    = $7
 WHERE id = $1
 RETURNING *;
 
+-- name: IsFileReleased :one
+-- A file is released once any version containing it has been released.
+SELECT EXISTS (SELECT 1
+               FROM versions_files
+                        INNER JOIN versions ON versions.id = versions_files.version_id
+               WHERE versions_files.file_id = $1
+                 AND versions.released_at IS NOT NULL);
+
 -- name: DeleteFile :exec
 DELETE
 FROM files
 WHERE id = $1;
 
--- name: AttachFileToVersion :exec
+-- name: AttachFileToVersion :execrows
+-- A pinned revision must belong to the file, nothing is attached otherwise.
+INSERT INTO versions_files (version_id, file_id, revision_id)
+SELECT sqlc.arg('versionId'), sqlc.arg('fileId'), sqlc.narg('revisionId')
+WHERE sqlc.narg('revisionId')::BIGINT IS NULL
+   OR EXISTS (SELECT 1
+              FROM file_revisions
+              WHERE id = sqlc.narg('revisionId')
+                AND file_id = sqlc.arg('fileId'));
+
+-- name: AttachFilesToVersion :exec
+-- A single statement, so either all files are attached or none.
 INSERT INTO versions_files (version_id, file_id)
-VALUES ($1, $2);
+SELECT sqlc.arg('versionId')::BIGINT, unnest(sqlc.arg('fileIds')::BIGINT[]);
+
+-- name: CopyVersionFiles :execrows
+INSERT INTO versions_files (version_id, file_id, revision_id)
+SELECT sqlc.arg('targetVersionId')::BIGINT, source.file_id, source.revision_id
+FROM versions_files source
+WHERE source.version_id = sqlc.arg('sourceVersionId');
+
+-- name: PinVersionFiles :exec
+-- Pins the current revision of the files the version doesn't pin yet.
+UPDATE versions_files
+SET revision_id = files.current_revision_id
+FROM files
+WHERE files.id = versions_files.file_id
+  AND versions_files.version_id = $1
+  AND versions_files.revision_id IS NULL;
 
 -- name: DetachFileFromVersion :exec
 DELETE
@@ -168,6 +463,260 @@ FROM versions_files
 WHERE version_id = $1
   AND file_id = $2;
 
+-- File revisions
+
+-- name: LockFile :exec
+-- Holds the file until the transaction ends, so uploads to it number their
+-- revisions one after the other.
+SELECT id
+FROM files
+WHERE id = $1
+    FOR UPDATE;
+
+-- name: CreateFileRevision :one
+INSERT INTO file_revisions (file_id, number, path, size, mime_type, checksum, created_by)
+SELECT sqlc.arg('fileId'),
+       COALESCE(MAX(number), 0) + 1,
+       sqlc.arg('path'),
+       sqlc.arg('size'),
+       sqlc.arg('mimeType'),
+       sqlc.narg('checksum'),
+       sqlc.narg('createdBy')
+FROM file_revisions
+WHERE file_id = sqlc.arg('fileId')
+RETURNING *;
+
+-- name: GetFileRevision :one
+SELECT *
+FROM file_revisions
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListFileRevisions :many
+SELECT *
+FROM file_revisions
+WHERE file_id = sqlc.arg('fileId')
+ORDER BY number DESC
+LIMIT sqlc.arg('limit')::BIGINT OFFSET sqlc.arg('offset')::BIGINT;
+
+-- name: ListFileRevisionPaths :many
+SELECT path
+FROM file_revisions
+WHERE file_id = $1;
+
+-- name: SetCurrentFileRevision :one
+-- Makes the revision current and copies its contents to the file.
+UPDATE files
+SET updated_at          = CURRENT_TIMESTAMP,
+    current_revision_id = file_revisions.id,
+    size                = file_revisions.size,
+    path                = file_revisions.path,
+    mime_type           = file_revisions.mime_type,
+    is_complete         = TRUE,
+    checksum            = file_revisions.checksum
+FROM file_revisions
+WHERE files.id = file_revisions.file_id
+  AND file_revisions.id = $1
+RETURNING files.*;
+
+-- name: SetFileRevisionChecksum :exec
+UPDATE file_revisions
+SET checksum = $2
+WHERE id = $1;
+
+-- Upload sessions
+
+-- name: GetUploadSession :one
+SELECT *
+FROM upload_sessions
+WHERE id = $1
+  AND file_id = $2
+LIMIT 1;
+
+-- name: CreateUploadSession :one
+INSERT INTO upload_sessions (file_id, size, expires_at, checksum)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: AppendUploadSessionChunk :one
+UPDATE upload_sessions
+SET updated_at     = CURRENT_TIMESTAMP,
+    received_bytes = received_bytes + sqlc.arg('chunkSize')::BIGINT,
+    chunk_paths    = array_append(chunk_paths, sqlc.arg('chunkPath')::TEXT)
+WHERE id = sqlc.arg('id')
+  AND received_bytes = sqlc.arg('expectedOffset')::BIGINT
+  AND is_complete = FALSE
+RETURNING *;
+
+-- name: CompleteUploadSession :one
+-- Claims the session for the request that assembles it, a concurrent request
+-- reaching the final offset as well finds it complete.
+UPDATE upload_sessions
+SET updated_at  = CURRENT_TIMESTAMP,
+    chunk_paths = '{}',
+    is_complete = TRUE
+WHERE id = $1
+  AND is_complete = FALSE
+RETURNING *;
+
+-- name: DeleteUploadSession :exec
+DELETE
+FROM upload_sessions
+WHERE id = $1;
+
+-- name: ListExpiredUploadSessions :many
+SELECT *
+FROM upload_sessions
+WHERE expires_at < sqlc.arg('expiredBefore')
+ORDER BY expires_at
+LIMIT sqlc.arg('limit')::BIGINT;
+
+-- name: ListUploadSessionChunkPaths :many
+SELECT unnest(chunk_paths)::TEXT AS chunk_path
+FROM upload_sessions
+WHERE file_id = $1;
+
+-- Short links
+
+-- name: GetShortLink :one
+SELECT *
+FROM short_links
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetShortLinkByCode :one
+SELECT *
+FROM short_links
+WHERE code = $1
+LIMIT 1;
+
+-- name: ListShortLinks :many
+SELECT *
+FROM short_links
+WHERE (sqlc.narg('projectId')::BIGINT IS NULL OR project_id = sqlc.narg('projectId'))
+  AND (sqlc.narg('versionId')::BIGINT IS NULL OR version_id = sqlc.narg('versionId'))
+  AND (sqlc.narg('memberId')::BIGINT IS NULL OR EXISTS (SELECT 1
+                                                          FROM project_memberships m
+                                                          WHERE m.project_id = COALESCE(short_links.project_id,
+                                                                                        (SELECT v.project_id
+                                                                                         FROM versions v
+                                                                                         WHERE v.id = short_links.version_id))
+                                                            AND m.user_id = sqlc.narg('memberId')))
+ORDER BY created_at DESC
+LIMIT sqlc.arg('limit')::BIGINT OFFSET sqlc.arg('offset')::BIGINT;
+
+-- name: CreateShortLink :one
+INSERT INTO short_links (code, target_type, project_id, version_id)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: UpdateShortLinkTarget :one
+UPDATE short_links
+SET updated_at  = CURRENT_TIMESTAMP,
+    target_type = $2,
+    project_id  = $3,
+    version_id  = $4
+WHERE id = $1
+RETURNING *;
+
+-- name: RevokeShortLink :one
+UPDATE short_links
+SET updated_at = CURRENT_TIMESTAMP,
+    revoked_at = COALESCE(revoked_at, CURRENT_TIMESTAMP)
+WHERE id = $1
+RETURNING *;
+
+-- name: RecordShortLinkHit :exec
+UPDATE short_links
+SET hit_count   = hit_count + 1,
+    last_hit_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- Share links
+
+-- name: GetShareLink :one
+SELECT *
+FROM share_links
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListShareLinks :many
+SELECT *
+FROM share_links
+WHERE (sqlc.narg('fileId')::BIGINT IS NULL OR file_id = sqlc.narg('fileId'))
+  AND (sqlc.narg('versionId')::BIGINT IS NULL OR version_id = sqlc.narg('versionId'))
+  AND (sqlc.narg('createdBy')::BIGINT IS NULL OR created_by = sqlc.narg('createdBy'))
+ORDER BY created_at DESC
+LIMIT sqlc.arg('limit')::BIGINT OFFSET sqlc.arg('offset')::BIGINT;
+
+-- name: CreateShareLink :one
+INSERT INTO share_links (file_id, version_id, expires_at, max_downloads, password_hash, created_by)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: RevokeShareLink :one
+UPDATE share_links
+SET updated_at = CURRENT_TIMESTAMP,
+    revoked_at = COALESCE(revoked_at, CURRENT_TIMESTAMP)
+WHERE id = $1
+RETURNING *;
+
+-- name: ConsumeShareLinkDownload :one
+-- Returns no row once the download limit is reached, so concurrent downloads
+-- can't exceed it.
+UPDATE share_links
+SET download_count = download_count + 1
+WHERE id = $1
+  AND (max_downloads IS NULL OR download_count < max_downloads)
+RETURNING *;
+
+-- name: IsFileInVersion :one
+SELECT EXISTS (SELECT 1
+               FROM versions_files
+               WHERE version_id = $1
+                 AND file_id = $2);
+
+-- Project memberships
+
+-- name: GetProjectMembership :one
+SELECT *
+FROM project_memberships
+WHERE project_id = $1
+  AND user_id = $2
+LIMIT 1;
+
+-- name: ListProjectMemberships :many
+SELECT *
+FROM project_memberships
+WHERE project_id = $1
+ORDER BY created_at
+LIMIT sqlc.arg('limit')::BIGINT OFFSET sqlc.arg('offset')::BIGINT;
+
+-- name: CountProjectMembershipsByRole :one
+SELECT count(*)
+FROM project_memberships
+WHERE project_id = $1
+  AND role = $2;
+
+-- name: CreateProjectMembership :one
+INSERT INTO project_memberships (project_id, user_id, role)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: UpdateProjectMembership :one
+UPDATE project_memberships
+SET updated_at = CURRENT_TIMESTAMP,
+    role       = $3
+WHERE project_id = $1
+  AND user_id = $2
+RETURNING *;
+
+-- name: DeleteProjectMembership :exec
+DELETE
+FROM project_memberships
+WHERE project_id = $1
+  AND user_id = $2;
+
 -- Users
 -- name: GetUserById :one
 SELECT id,
@@ -193,7 +742,339 @@ FROM users
 WHERE keycloak_reference = $1
 LIMIT 1;
 
+-- name: GetUserByEmail :one
+SELECT id,
+       created_at,
+       updated_at,
+       name,
+       email,
+       email_verified,
+       keycloak_reference
+FROM users
+WHERE email = $1
+LIMIT 1;
+
 -- name: CreateUser :one
-INSERT INTO users (name, email, email_verified)
-VALUES ($1, $2, $3)
+INSERT INTO users (name, email, email_verified, keycloak_reference)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: LinkUserToKeycloakReference :one
+-- Returns no row when the user was linked to another account meanwhile.
+UPDATE users
+SET updated_at         = CURRENT_TIMESTAMP,
+    name               = $2,
+    email_verified     = $3,
+    keycloak_reference = $4
+WHERE id = $1
+  AND keycloak_reference IS NULL
 RETURNING *;
+
+-- name: UpsertUserByKeycloakReference :one
+-- Returns no row when the stored claims are already up to date.
+INSERT INTO users (name, email, email_verified, keycloak_reference)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (keycloak_reference) DO UPDATE
+    SET updated_at     = CURRENT_TIMESTAMP,
+        name           = EXCLUDED.name,
+        email          = EXCLUDED.email,
+        email_verified = EXCLUDED.email_verified
+WHERE (users.name, users.email, users.email_verified) IS DISTINCT FROM
+      (EXCLUDED.name, EXCLUDED.email, EXCLUDED.email_verified)
+RETURNING *;
+
+-- Search
+
+-- name: Search :many
+-- Hits are ranked across types, the visibility rules match the ones of the
+-- list queries of each type.
+SELECT d.entity_type                                                                                     AS type,
+       d.entity_id                                                                                       AS id,
+       d.project_id,
+       d.title,
+       ts_headline('simple', concat_ws(' ', d.title, nullif(d.body, '')), q, 'HighlightAll=true')::TEXT AS highlight,
+       ts_rank(d.search_vector, q)::REAL                                                                 AS rank
+FROM search_documents d,
+     websearch_to_tsquery('simple', sqlc.arg('query')) q
+WHERE d.search_vector @@ q
+  AND (sqlc.narg('types')::TEXT[] IS NULL OR d.entity_type = ANY (sqlc.narg('types')::TEXT[]))
+  AND (sqlc.narg('memberId')::BIGINT IS NULL
+    OR (d.entity_type <> 'file' AND EXISTS (SELECT 1
+                                            FROM project_memberships m
+                                            WHERE m.project_id = d.project_id
+                                              AND m.user_id = sqlc.narg('memberId')))
+    OR (d.entity_type = 'file' AND EXISTS (SELECT 1
+                                           FROM files f
+                                           WHERE f.id = d.entity_id
+                                             AND f.created_by = sqlc.narg('memberId')))
+    OR (d.entity_type = 'file' AND EXISTS (SELECT 1
+                                           FROM versions_files vf
+                                                    INNER JOIN versions v ON v.id = vf.version_id
+                                                    INNER JOIN project_memberships m ON m.project_id = v.project_id
+                                           WHERE vf.file_id = d.entity_id
+                                             AND m.user_id = sqlc.narg('memberId'))))
+ORDER BY rank DESC, d.entity_type, d.entity_id
+LIMIT sqlc.arg('limit')::BIGINT OFFSET sqlc.arg('offset')::BIGINT;
+
+-- name: SearchFileContents :many
+-- Searches the extracted text of files, the visibility rules match the ones
+-- of ListFiles.
+SELECT f.id,
+       f.name,
+       ts_headline('simple', c.content, q, 'MaxFragments=2, MinWords=5, MaxWords=20')::TEXT AS snippet,
+       ts_rank(c.search_vector, q)::REAL                                                  AS rank
+FROM file_contents c
+         INNER JOIN files f ON f.id = c.file_id,
+     websearch_to_tsquery('simple', sqlc.arg('query')) q
+WHERE c.search_vector @@ q
+  AND (sqlc.narg('memberId')::BIGINT IS NULL OR f.created_by = sqlc.narg('memberId') OR EXISTS (SELECT 1
+                                                                                               FROM versions_files vf
+                                                                                                        INNER JOIN versions v ON v.id = vf.version_id
+                                                                                                        INNER JOIN project_memberships m ON m.project_id = v.project_id
+                                                                                               WHERE vf.file_id = f.id
+                                                                                                 AND m.user_id = sqlc.narg('memberId')))
+ORDER BY rank DESC, f.id
+LIMIT sqlc.arg('limit')::BIGINT OFFSET sqlc.arg('offset')::BIGINT;
+
+-- File contents
+
+-- name: UpsertPendingFileContent :exec
+INSERT INTO file_contents (file_id)
+VALUES ($1)
+ON CONFLICT (file_id) DO UPDATE SET updated_at   = CURRENT_TIMESTAMP,
+                                    status       = 'pending',
+                                    content      = '',
+                                    error        = NULL,
+                                    extracted_at = NULL;
+
+-- name: UpdateFileContent :exec
+UPDATE file_contents
+SET updated_at   = CURRENT_TIMESTAMP,
+    status       = sqlc.arg('status'),
+    content      = sqlc.arg('content'),
+    error        = sqlc.narg('error'),
+    extracted_at = CURRENT_TIMESTAMP
+WHERE file_id = sqlc.arg('fileId');
+
+-- Jobs
+
+-- name: EnqueueJob :one
+-- Returns no row when a job with the same unique key was queued before.
+INSERT INTO jobs (kind, payload, max_attempts, run_at, unique_key)
+VALUES (sqlc.arg('kind'), sqlc.arg('payload'), sqlc.arg('maxAttempts'), CURRENT_TIMESTAMP + sqlc.arg('delay')::INTERVAL,
+        sqlc.narg('uniqueKey'))
+ON CONFLICT (unique_key) DO NOTHING
+RETURNING *;
+
+-- name: ClaimJob :one
+-- Locks the next due job of the given kinds, concurrent workers skip the rows
+-- locked by others instead of waiting for them.
+UPDATE jobs
+SET updated_at = CURRENT_TIMESTAMP,
+    status     = 'running',
+    attempts   = attempts + 1,
+    locked_at  = CURRENT_TIMESTAMP
+WHERE id = (SELECT id
+            FROM jobs
+            WHERE status = 'pending'
+              AND run_at <= CURRENT_TIMESTAMP
+              AND kind = ANY (sqlc.arg('kinds')::TEXT[])
+            ORDER BY run_at, id
+            LIMIT 1 FOR UPDATE SKIP LOCKED)
+RETURNING *;
+
+-- name: CompleteJob :exec
+UPDATE jobs
+SET updated_at   = CURRENT_TIMESTAMP,
+    status       = 'completed',
+    locked_at    = NULL,
+    last_error   = NULL,
+    completed_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- name: RescheduleJob :exec
+UPDATE jobs
+SET updated_at = CURRENT_TIMESTAMP,
+    status     = 'pending',
+    locked_at  = NULL,
+    last_error = sqlc.arg('lastError'),
+    run_at     = CURRENT_TIMESTAMP + sqlc.arg('delay')::INTERVAL
+WHERE id = sqlc.arg('id');
+
+-- name: FailJob :exec
+UPDATE jobs
+SET updated_at = CURRENT_TIMESTAMP,
+    status     = 'failed',
+    locked_at  = NULL,
+    last_error = sqlc.arg('lastError')
+WHERE id = sqlc.arg('id');
+
+-- name: HeartbeatJob :exec
+UPDATE jobs
+SET locked_at = CURRENT_TIMESTAMP
+WHERE id = $1
+  AND status = 'running';
+
+-- name: ReleaseStaleJobs :execrows
+-- Puts jobs back whose worker stopped without reporting back, the attempt
+-- still counts, so jobs without attempts left fail instead.
+UPDATE jobs
+SET updated_at = CURRENT_TIMESTAMP,
+    status     = CASE WHEN attempts < max_attempts THEN 'pending' ELSE 'failed' END,
+    locked_at  = NULL,
+    last_error = 'worker stopped while running the job'
+WHERE status = 'running'
+  AND locked_at <= CURRENT_TIMESTAMP - sqlc.arg('timeout')::INTERVAL;
+
+-- name: GetJob :one
+SELECT *
+FROM jobs
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListJobs :many
+SELECT *
+FROM jobs
+WHERE (sqlc.narg('status')::TEXT IS NULL OR status = sqlc.narg('status'))
+  AND (sqlc.narg('kind')::TEXT IS NULL OR kind = sqlc.narg('kind'))
+ORDER BY id DESC
+LIMIT sqlc.arg('limit')::BIGINT OFFSET sqlc.arg('offset')::BIGINT;
+
+-- name: RetryJob :one
+-- Gives a dead letter a fresh set of attempts.
+UPDATE jobs
+SET updated_at = CURRENT_TIMESTAMP,
+    status     = 'pending',
+    attempts   = 0,
+    run_at     = CURRENT_TIMESTAMP
+WHERE id = $1
+  AND status = 'failed'
+RETURNING *;
+
+-- name: DeleteCompletedJobs :execrows
+DELETE
+FROM jobs
+WHERE status = 'completed'
+  AND completed_at <= CURRENT_TIMESTAMP - sqlc.arg('age')::INTERVAL;
+
+-- Storage reconciliation
+
+-- name: ListFilePaths :many
+-- Pages through the stored assets of file revisions by id.
+SELECT id,
+       file_id,
+       path
+FROM file_revisions
+WHERE id > sqlc.arg('afterId')
+ORDER BY id
+LIMIT sqlc.arg('limit')::BIGINT;
+
+-- name: ListReferencedFilePaths :many
+SELECT DISTINCT path
+FROM file_revisions
+WHERE path = ANY (sqlc.arg('paths')::TEXT[]);
+
+-- Audit log
+
+-- name: CreateAuditEvent :one
+INSERT INTO audit_events (actor, actor_id, action, resource_type, resource_id, before, after, request_id, client_ip)
+VALUES (sqlc.narg('actor'), sqlc.narg('actorId'), sqlc.arg('action'), sqlc.arg('resourceType'),
+        sqlc.arg('resourceId'), sqlc.narg('before'), sqlc.narg('after'), sqlc.narg('requestId'),
+        sqlc.narg('clientIp'))
+RETURNING *;
+
+-- name: ListAuditEvents :many
+-- Newest first. beforeId pages through the log by id for exports, which
+-- unlike offsets isn't thrown off by events written in the meantime.
+SELECT *
+FROM audit_events
+WHERE (sqlc.narg('resourceType')::TEXT IS NULL OR resource_type = sqlc.narg('resourceType'))
+  AND (sqlc.narg('resourceId')::BIGINT IS NULL OR resource_id = sqlc.narg('resourceId'))
+  AND (sqlc.narg('actor')::TEXT IS NULL OR actor = sqlc.narg('actor'))
+  AND (sqlc.narg('action')::TEXT IS NULL OR action = sqlc.narg('action'))
+  AND (sqlc.narg('from')::TIMESTAMP IS NULL OR occurred_at >= sqlc.narg('from'))
+  AND (sqlc.narg('to')::TIMESTAMP IS NULL OR occurred_at < sqlc.narg('to'))
+  AND (sqlc.narg('beforeId')::BIGINT IS NULL OR id < sqlc.narg('beforeId'))
+ORDER BY id DESC
+LIMIT sqlc.arg('limit')::BIGINT OFFSET sqlc.arg('offset')::BIGINT;
+
+-- Webhooks
+
+-- name: CreateWebhookSubscription :one
+INSERT INTO webhook_subscriptions (project_id, url, secret, events, created_by)
+VALUES (sqlc.narg('projectId'), sqlc.arg('url'), sqlc.arg('secret'), sqlc.arg('events')::TEXT[],
+        sqlc.narg('createdBy'))
+RETURNING *;
+
+-- name: GetWebhookSubscription :one
+SELECT *
+FROM webhook_subscriptions
+WHERE id = $1;
+
+-- name: ListWebhookSubscriptions :many
+SELECT *
+FROM webhook_subscriptions
+WHERE (sqlc.narg('projectId')::BIGINT IS NULL OR project_id = sqlc.narg('projectId'))
+ORDER BY id
+LIMIT sqlc.arg('limit')::BIGINT OFFSET sqlc.arg('offset')::BIGINT;
+
+-- name: UpdateWebhookSubscription :one
+UPDATE webhook_subscriptions
+SET updated_at = CURRENT_TIMESTAMP,
+    url        = sqlc.arg('url'),
+    events     = sqlc.arg('events')::TEXT[],
+    is_active  = sqlc.arg('isActive')
+WHERE id = sqlc.arg('id')
+RETURNING *;
+
+-- name: DeleteWebhookSubscription :exec
+DELETE
+FROM webhook_subscriptions
+WHERE id = $1;
+
+-- name: CreateWebhookDeliveries :many
+-- Queues the event for every active subscription to it, global ones and those
+-- of the given projects.
+INSERT INTO webhook_deliveries (subscription_id, event, event_id, payload)
+SELECT id, sqlc.arg('event')::TEXT, sqlc.arg('eventId')::TEXT, sqlc.arg('payload')::JSONB
+FROM webhook_subscriptions
+WHERE is_active
+  AND sqlc.arg('event')::TEXT = ANY (events)
+  AND (project_id IS NULL OR project_id = ANY (sqlc.arg('projectIds')::BIGINT[]))
+RETURNING *;
+
+-- name: CreateWebhookRedelivery :one
+INSERT INTO webhook_deliveries (subscription_id, event, event_id, payload, redelivery_of)
+SELECT subscription_id, event, event_id, payload, id
+FROM webhook_deliveries
+WHERE webhook_deliveries.id = $1
+RETURNING *;
+
+-- name: GetWebhookDelivery :one
+SELECT *
+FROM webhook_deliveries
+WHERE id = $1;
+
+-- name: ListWebhookDeliveries :many
+SELECT *
+FROM webhook_deliveries
+WHERE subscription_id = sqlc.arg('subscriptionId')
+ORDER BY id DESC
+LIMIT sqlc.arg('limit')::BIGINT OFFSET sqlc.arg('offset')::BIGINT;
+
+-- name: RecordWebhookDeliveryAttempt :one
+UPDATE webhook_deliveries
+SET updated_at      = CURRENT_TIMESTAMP,
+    status          = sqlc.arg('status'),
+    attempts        = attempts + 1,
+    response_status = sqlc.narg('responseStatus'),
+    response_body   = sqlc.narg('responseBody'),
+    last_error      = sqlc.narg('lastError'),
+    delivered_at    = CASE WHEN sqlc.arg('status') = 'succeeded' THEN CURRENT_TIMESTAMP END
+WHERE id = sqlc.arg('id')
+RETURNING *;
+
+-- Events
+
+-- name: NotifyEvent :exec
+SELECT pg_notify(sqlc.arg('channel')::TEXT, sqlc.arg('payload')::TEXT);
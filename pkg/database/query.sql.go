@@ -7,23 +7,225 @@ package database
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
-const attachFileToVersion = `-- name: AttachFileToVersion :exec
-INSERT INTO versions_files (version_id, file_id)
-VALUES ($1, $2)
+const appendUploadSessionChunk = `-- name: AppendUploadSessionChunk :one
+UPDATE upload_sessions
+SET updated_at     = CURRENT_TIMESTAMP,
+    received_bytes = received_bytes + $1::BIGINT,
+    chunk_paths    = array_append(chunk_paths, $2::TEXT)
+WHERE id = $3
+  AND received_bytes = $4::BIGINT
+  AND is_complete = FALSE
+RETURNING id, created_at, updated_at, expires_at, file_id, size, received_bytes, chunk_paths, is_complete, checksum
+`
+
+type AppendUploadSessionChunkParams struct {
+	ChunkSize      int64
+	ChunkPath      string
+	ID             pgtype.UUID
+	ExpectedOffset int64
+}
+
+func (q *Queries) AppendUploadSessionChunk(ctx context.Context, arg *AppendUploadSessionChunkParams) (*UploadSession, error) {
+	row := q.db.QueryRow(ctx, appendUploadSessionChunk,
+		arg.ChunkSize,
+		arg.ChunkPath,
+		arg.ID,
+		arg.ExpectedOffset,
+	)
+	var i UploadSession
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ExpiresAt,
+		&i.FileID,
+		&i.Size,
+		&i.ReceivedBytes,
+		&i.ChunkPaths,
+		&i.IsComplete,
+		&i.Checksum,
+	)
+	return &i, err
+}
+
+const attachFileToVersion = `-- name: AttachFileToVersion :execrows
+INSERT INTO versions_files (version_id, file_id, revision_id)
+SELECT $1, $2, $3
+WHERE $3::BIGINT IS NULL
+   OR EXISTS (SELECT 1
+              FROM file_revisions
+              WHERE id = $3
+                AND file_id = $2)
 `
 
 type AttachFileToVersionParams struct {
-	VersionID int64
-	FileID    int64
+	VersionId  int64
+	FileId     int64
+	RevisionId *int64
+}
+
+// A pinned revision must belong to the file, nothing is attached otherwise.
+func (q *Queries) AttachFileToVersion(ctx context.Context, arg *AttachFileToVersionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, attachFileToVersion, arg.VersionId, arg.FileId, arg.RevisionId)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const attachFilesToVersion = `-- name: AttachFilesToVersion :exec
+INSERT INTO versions_files (version_id, file_id)
+SELECT $1::BIGINT, unnest($2::BIGINT[])
+`
+
+type AttachFilesToVersionParams struct {
+	VersionId int64
+	FileIds   []int64
+}
+
+// A single statement, so either all files are attached or none.
+func (q *Queries) AttachFilesToVersion(ctx context.Context, arg *AttachFilesToVersionParams) error {
+	_, err := q.db.Exec(ctx, attachFilesToVersion, arg.VersionId, arg.FileIds)
+	return err
 }
 
-func (q *Queries) AttachFileToVersion(ctx context.Context, arg *AttachFileToVersionParams) error {
-	_, err := q.db.Exec(ctx, attachFileToVersion, arg.VersionID, arg.FileID)
+const claimJob = `-- name: ClaimJob :one
+UPDATE jobs
+SET updated_at = CURRENT_TIMESTAMP,
+    status     = 'running',
+    attempts   = attempts + 1,
+    locked_at  = CURRENT_TIMESTAMP
+WHERE id = (SELECT id
+            FROM jobs
+            WHERE status = 'pending'
+              AND run_at <= CURRENT_TIMESTAMP
+              AND kind = ANY ($1::TEXT[])
+            ORDER BY run_at, id
+            LIMIT 1 FOR UPDATE SKIP LOCKED)
+RETURNING id, created_at, updated_at, kind, payload, status, attempts, max_attempts, run_at, locked_at, last_error, completed_at, unique_key
+`
+
+// Locks the next due job of the given kinds, concurrent workers skip the rows
+// locked by others instead of waiting for them.
+func (q *Queries) ClaimJob(ctx context.Context, kinds []string) (*Job, error) {
+	row := q.db.QueryRow(ctx, claimJob, kinds)
+	var i Job
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Kind,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.MaxAttempts,
+		&i.RunAt,
+		&i.LockedAt,
+		&i.LastError,
+		&i.CompletedAt,
+		&i.UniqueKey,
+	)
+	return &i, err
+}
+
+const completeJob = `-- name: CompleteJob :exec
+UPDATE jobs
+SET updated_at   = CURRENT_TIMESTAMP,
+    status       = 'completed',
+    locked_at    = NULL,
+    last_error   = NULL,
+    completed_at = CURRENT_TIMESTAMP
+WHERE id = $1
+`
+
+func (q *Queries) CompleteJob(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, completeJob, id)
 	return err
 }
 
+const completeUploadSession = `-- name: CompleteUploadSession :one
+UPDATE upload_sessions
+SET updated_at  = CURRENT_TIMESTAMP,
+    chunk_paths = '{}',
+    is_complete = TRUE
+WHERE id = $1
+  AND is_complete = FALSE
+RETURNING id, created_at, updated_at, expires_at, file_id, size, received_bytes, chunk_paths, is_complete, checksum
+`
+
+// Claims the session for the request that assembles it, a concurrent request
+// reaching the final offset as well finds it complete.
+func (q *Queries) CompleteUploadSession(ctx context.Context, id pgtype.UUID) (*UploadSession, error) {
+	row := q.db.QueryRow(ctx, completeUploadSession, id)
+	var i UploadSession
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ExpiresAt,
+		&i.FileID,
+		&i.Size,
+		&i.ReceivedBytes,
+		&i.ChunkPaths,
+		&i.IsComplete,
+		&i.Checksum,
+	)
+	return &i, err
+}
+
+const consumeShareLinkDownload = `-- name: ConsumeShareLinkDownload :one
+UPDATE share_links
+SET download_count = download_count + 1
+WHERE id = $1
+  AND (max_downloads IS NULL OR download_count < max_downloads)
+RETURNING id, created_at, updated_at, file_id, version_id, expires_at, max_downloads, download_count, password_hash, revoked_at, created_by
+`
+
+// Returns no row once the download limit is reached, so concurrent downloads
+// can't exceed it.
+func (q *Queries) ConsumeShareLinkDownload(ctx context.Context, id int64) (*ShareLink, error) {
+	row := q.db.QueryRow(ctx, consumeShareLinkDownload, id)
+	var i ShareLink
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.FileID,
+		&i.VersionID,
+		&i.ExpiresAt,
+		&i.MaxDownloads,
+		&i.DownloadCount,
+		&i.PasswordHash,
+		&i.RevokedAt,
+		&i.CreatedBy,
+	)
+	return &i, err
+}
+
+const copyVersionFiles = `-- name: CopyVersionFiles :execrows
+INSERT INTO versions_files (version_id, file_id, revision_id)
+SELECT $1::BIGINT, source.file_id, source.revision_id
+FROM versions_files source
+WHERE source.version_id = $2
+`
+
+type CopyVersionFilesParams struct {
+	TargetVersionId int64
+	SourceVersionId int64
+}
+
+func (q *Queries) CopyVersionFiles(ctx context.Context, arg *CopyVersionFilesParams) (int64, error) {
+	result, err := q.db.Exec(ctx, copyVersionFiles, arg.TargetVersionId, arg.SourceVersionId)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const countFilesByVersionId = `-- name: CountFilesByVersionId :one
 
 SELECT count(files.id)
@@ -40,6 +242,40 @@ func (q *Queries) CountFilesByVersionId(ctx context.Context, versionID int64) (i
 	return count, err
 }
 
+const countIncompleteFilesByVersionId = `-- name: CountIncompleteFilesByVersionId :one
+SELECT count(*)
+FROM versions_files
+         INNER JOIN files ON files.id = versions_files.file_id
+WHERE versions_files.version_id = $1
+  AND files.is_complete = FALSE
+`
+
+func (q *Queries) CountIncompleteFilesByVersionId(ctx context.Context, versionID int64) (int64, error) {
+	row := q.db.QueryRow(ctx, countIncompleteFilesByVersionId, versionID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countProjectMembershipsByRole = `-- name: CountProjectMembershipsByRole :one
+SELECT count(*)
+FROM project_memberships
+WHERE project_id = $1
+  AND role = $2
+`
+
+type CountProjectMembershipsByRoleParams struct {
+	ProjectID int64
+	Role      string
+}
+
+func (q *Queries) CountProjectMembershipsByRole(ctx context.Context, arg *CountProjectMembershipsByRoleParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countProjectMembershipsByRole, arg.ProjectID, arg.Role)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countProjects = `-- name: CountProjects :one
 SELECT count(projects.id)
 FROM projects
@@ -67,10 +303,61 @@ func (q *Queries) CountVersionsByProjectId(ctx context.Context, projectID int64)
 	return count, err
 }
 
+const createAuditEvent = `-- name: CreateAuditEvent :one
+
+INSERT INTO audit_events (actor, actor_id, action, resource_type, resource_id, before, after, request_id, client_ip)
+VALUES ($1, $2, $3, $4,
+        $5, $6, $7, $8,
+        $9)
+RETURNING id, occurred_at, actor, actor_id, action, resource_type, resource_id, before, after, request_id, client_ip
+`
+
+type CreateAuditEventParams struct {
+	Actor        *string
+	ActorId      *int64
+	Action       string
+	ResourceType string
+	ResourceId   int64
+	Before       []byte
+	After        []byte
+	RequestId    *string
+	ClientIp     *string
+}
+
+// Audit log
+func (q *Queries) CreateAuditEvent(ctx context.Context, arg *CreateAuditEventParams) (*AuditEvent, error) {
+	row := q.db.QueryRow(ctx, createAuditEvent,
+		arg.Actor,
+		arg.ActorId,
+		arg.Action,
+		arg.ResourceType,
+		arg.ResourceId,
+		arg.Before,
+		arg.After,
+		arg.RequestId,
+		arg.ClientIp,
+	)
+	var i AuditEvent
+	err := row.Scan(
+		&i.ID,
+		&i.OccurredAt,
+		&i.Actor,
+		&i.ActorID,
+		&i.Action,
+		&i.ResourceType,
+		&i.ResourceID,
+		&i.Before,
+		&i.After,
+		&i.RequestID,
+		&i.ClientIp,
+	)
+	return &i, err
+}
+
 const createFile = `-- name: CreateFile :one
-INSERT INTO files (name, size, path, mime_type, is_complete)
-VALUES ($1, $2, $3, $4, $5)
-RETURNING id, created_at, updated_at, name, size, path, mime_type, is_complete
+INSERT INTO files (name, size, path, mime_type, is_complete, checksum, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, created_at, updated_at, name, size, path, mime_type, is_complete, checksum, created_by, current_revision_id
 `
 
 type CreateFileParams struct {
@@ -79,6 +366,8 @@ type CreateFileParams struct {
 	Path       *string
 	MimeType   *string
 	IsComplete bool
+	Checksum   *string
+	CreatedBy  *int64
 }
 
 func (q *Queries) CreateFile(ctx context.Context, arg *CreateFileParams) (*File, error) {
@@ -88,6 +377,8 @@ func (q *Queries) CreateFile(ctx context.Context, arg *CreateFileParams) (*File,
 		arg.Path,
 		arg.MimeType,
 		arg.IsComplete,
+		arg.Checksum,
+		arg.CreatedBy,
 	)
 	var i File
 	err := row.Scan(
@@ -99,6 +390,92 @@ func (q *Queries) CreateFile(ctx context.Context, arg *CreateFileParams) (*File,
 		&i.Path,
 		&i.MimeType,
 		&i.IsComplete,
+		&i.Checksum,
+		&i.CreatedBy,
+		&i.CurrentRevisionID,
+	)
+	return &i, err
+}
+
+const createFileRevision = `-- name: CreateFileRevision :one
+INSERT INTO file_revisions (file_id, number, path, size, mime_type, checksum, created_by)
+SELECT $1,
+       COALESCE(MAX(number), 0) + 1,
+       $2,
+       $3,
+       $4,
+       $5,
+       $6
+FROM file_revisions
+WHERE file_id = $1
+RETURNING id, created_at, file_id, number, path, size, mime_type, checksum, created_by
+`
+
+type CreateFileRevisionParams struct {
+	FileId    int64
+	Path      string
+	Size      int64
+	MimeType  string
+	Checksum  *string
+	CreatedBy *int64
+}
+
+func (q *Queries) CreateFileRevision(ctx context.Context, arg *CreateFileRevisionParams) (*FileRevision, error) {
+	row := q.db.QueryRow(ctx, createFileRevision,
+		arg.FileId,
+		arg.Path,
+		arg.Size,
+		arg.MimeType,
+		arg.Checksum,
+		arg.CreatedBy,
+	)
+	var i FileRevision
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.FileID,
+		&i.Number,
+		&i.Path,
+		&i.Size,
+		&i.MimeType,
+		&i.Checksum,
+		&i.CreatedBy,
+	)
+	return &i, err
+}
+
+const createLocation = `-- name: CreateLocation :one
+INSERT INTO locations (name, address, lat, lon, created_by)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, created_at, updated_at, name, address, lat, lon, created_by
+`
+
+type CreateLocationParams struct {
+	Name      string
+	Address   *string
+	Lat       *float64
+	Lon       *float64
+	CreatedBy *int64
+}
+
+func (q *Queries) CreateLocation(ctx context.Context, arg *CreateLocationParams) (*Location, error) {
+	row := q.db.QueryRow(ctx, createLocation,
+		arg.Name,
+		arg.Address,
+		arg.Lat,
+		arg.Lon,
+		arg.CreatedBy,
+	)
+	var i Location
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Name,
+		&i.Address,
+		&i.Lat,
+		&i.Lon,
+		&i.CreatedBy,
 	)
 	return &i, err
 }
@@ -106,13 +483,13 @@ func (q *Queries) CreateFile(ctx context.Context, arg *CreateFileParams) (*File,
 const createProject = `-- name: CreateProject :one
 INSERT INTO projects (slug, name, location_id)
 VALUES ($1, $2, $3)
-RETURNING id, created_at, updated_at, slug, name, location_id
+RETURNING id, created_at, updated_at, slug, name, location_id, latest_version_strategy, pinned_version_id
 `
 
 type CreateProjectParams struct {
 	Slug       string
 	Name       string
-	LocationID *int32
+	LocationID *int64
 }
 
 func (q *Queries) CreateProject(ctx context.Context, arg *CreateProjectParams) (*Project, error) {
@@ -125,24 +502,170 @@ func (q *Queries) CreateProject(ctx context.Context, arg *CreateProjectParams) (
 		&i.Slug,
 		&i.Name,
 		&i.LocationID,
+		&i.LatestVersionStrategy,
+		&i.PinnedVersionID,
 	)
 	return &i, err
 }
 
-const createUser = `-- name: CreateUser :one
-INSERT INTO users (name, email, email_verified)
+const createProjectMembership = `-- name: CreateProjectMembership :one
+INSERT INTO project_memberships (project_id, user_id, role)
 VALUES ($1, $2, $3)
+RETURNING project_id, user_id, created_at, updated_at, role
+`
+
+type CreateProjectMembershipParams struct {
+	ProjectID int64
+	UserID    int64
+	Role      string
+}
+
+func (q *Queries) CreateProjectMembership(ctx context.Context, arg *CreateProjectMembershipParams) (*ProjectMembership, error) {
+	row := q.db.QueryRow(ctx, createProjectMembership, arg.ProjectID, arg.UserID, arg.Role)
+	var i ProjectMembership
+	err := row.Scan(
+		&i.ProjectID,
+		&i.UserID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Role,
+	)
+	return &i, err
+}
+
+const createShareLink = `-- name: CreateShareLink :one
+INSERT INTO share_links (file_id, version_id, expires_at, max_downloads, password_hash, created_by)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, created_at, updated_at, file_id, version_id, expires_at, max_downloads, download_count, password_hash, revoked_at, created_by
+`
+
+type CreateShareLinkParams struct {
+	FileID       *int64
+	VersionID    *int64
+	ExpiresAt    pgtype.Timestamp
+	MaxDownloads *int64
+	PasswordHash *string
+	CreatedBy    *int64
+}
+
+func (q *Queries) CreateShareLink(ctx context.Context, arg *CreateShareLinkParams) (*ShareLink, error) {
+	row := q.db.QueryRow(ctx, createShareLink,
+		arg.FileID,
+		arg.VersionID,
+		arg.ExpiresAt,
+		arg.MaxDownloads,
+		arg.PasswordHash,
+		arg.CreatedBy,
+	)
+	var i ShareLink
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.FileID,
+		&i.VersionID,
+		&i.ExpiresAt,
+		&i.MaxDownloads,
+		&i.DownloadCount,
+		&i.PasswordHash,
+		&i.RevokedAt,
+		&i.CreatedBy,
+	)
+	return &i, err
+}
+
+const createShortLink = `-- name: CreateShortLink :one
+INSERT INTO short_links (code, target_type, project_id, version_id)
+VALUES ($1, $2, $3, $4)
+RETURNING id, created_at, updated_at, code, target_type, project_id, version_id, revoked_at, hit_count, last_hit_at
+`
+
+type CreateShortLinkParams struct {
+	Code       string
+	TargetType string
+	ProjectID  *int64
+	VersionID  *int64
+}
+
+func (q *Queries) CreateShortLink(ctx context.Context, arg *CreateShortLinkParams) (*ShortLink, error) {
+	row := q.db.QueryRow(ctx, createShortLink,
+		arg.Code,
+		arg.TargetType,
+		arg.ProjectID,
+		arg.VersionID,
+	)
+	var i ShortLink
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Code,
+		&i.TargetType,
+		&i.ProjectID,
+		&i.VersionID,
+		&i.RevokedAt,
+		&i.HitCount,
+		&i.LastHitAt,
+	)
+	return &i, err
+}
+
+const createUploadSession = `-- name: CreateUploadSession :one
+INSERT INTO upload_sessions (file_id, size, expires_at, checksum)
+VALUES ($1, $2, $3, $4)
+RETURNING id, created_at, updated_at, expires_at, file_id, size, received_bytes, chunk_paths, is_complete, checksum
+`
+
+type CreateUploadSessionParams struct {
+	FileID    int64
+	Size      int64
+	ExpiresAt pgtype.Timestamp
+	Checksum  *string
+}
+
+func (q *Queries) CreateUploadSession(ctx context.Context, arg *CreateUploadSessionParams) (*UploadSession, error) {
+	row := q.db.QueryRow(ctx, createUploadSession,
+		arg.FileID,
+		arg.Size,
+		arg.ExpiresAt,
+		arg.Checksum,
+	)
+	var i UploadSession
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ExpiresAt,
+		&i.FileID,
+		&i.Size,
+		&i.ReceivedBytes,
+		&i.ChunkPaths,
+		&i.IsComplete,
+		&i.Checksum,
+	)
+	return &i, err
+}
+
+const createUser = `-- name: CreateUser :one
+INSERT INTO users (name, email, email_verified, keycloak_reference)
+VALUES ($1, $2, $3, $4)
 RETURNING id, created_at, updated_at, name, email, email_verified, keycloak_reference
 `
 
 type CreateUserParams struct {
-	Name          string
-	Email         string
-	EmailVerified bool
+	Name              string
+	Email             *string
+	EmailVerified     bool
+	KeycloakReference *string
 }
 
 func (q *Queries) CreateUser(ctx context.Context, arg *CreateUserParams) (*User, error) {
-	row := q.db.QueryRow(ctx, createUser, arg.Name, arg.Email, arg.EmailVerified)
+	row := q.db.QueryRow(ctx, createUser,
+		arg.Name,
+		arg.Email,
+		arg.EmailVerified,
+		arg.KeycloakReference,
+	)
 	var i User
 	err := row.Scan(
 		&i.ID,
@@ -159,7 +682,7 @@ func (q *Queries) CreateUser(ctx context.Context, arg *CreateUserParams) (*User,
 const createVersion = `-- name: CreateVersion :one
 INSERT INTO versions (name, description, project_id)
 VALUES ($1, $2, $3)
-RETURNING id, created_at, updated_at, name, description, project_id
+RETURNING id, created_at, updated_at, name, description, project_id, status, released_at
 `
 
 type CreateVersionParams struct {
@@ -178,45 +701,241 @@ func (q *Queries) CreateVersion(ctx context.Context, arg *CreateVersionParams) (
 		&i.Name,
 		&i.Description,
 		&i.ProjectID,
+		&i.Status,
+		&i.ReleasedAt,
 	)
 	return &i, err
 }
 
-const deleteFile = `-- name: DeleteFile :exec
-DELETE
-FROM files
-WHERE id = $1
+const createWebhookDeliveries = `-- name: CreateWebhookDeliveries :many
+INSERT INTO webhook_deliveries (subscription_id, event, event_id, payload)
+SELECT id, $1::TEXT, $2::TEXT, $3::JSONB
+FROM webhook_subscriptions
+WHERE is_active
+  AND $1::TEXT = ANY (events)
+  AND (project_id IS NULL OR project_id = ANY ($4::BIGINT[]))
+RETURNING id, created_at, updated_at, subscription_id, event, event_id, payload, status, attempts, response_status, response_body, last_error, delivered_at, redelivery_of
 `
 
-func (q *Queries) DeleteFile(ctx context.Context, id int64) error {
-	_, err := q.db.Exec(ctx, deleteFile, id)
-	return err
+type CreateWebhookDeliveriesParams struct {
+	Event      string
+	EventId    string
+	Payload    []byte
+	ProjectIds []int64
 }
 
-const deleteProject = `-- name: DeleteProject :exec
-DELETE
-FROM projects
-WHERE id = $1
+// Queues the event for every active subscription to it, global ones and those
+// of the given projects.
+func (q *Queries) CreateWebhookDeliveries(ctx context.Context, arg *CreateWebhookDeliveriesParams) ([]*WebhookDelivery, error) {
+	rows, err := q.db.Query(ctx, createWebhookDeliveries,
+		arg.Event,
+		arg.EventId,
+		arg.Payload,
+		arg.ProjectIds,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*WebhookDelivery
+	for rows.Next() {
+		var i WebhookDelivery
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.SubscriptionID,
+			&i.Event,
+			&i.EventID,
+			&i.Payload,
+			&i.Status,
+			&i.Attempts,
+			&i.ResponseStatus,
+			&i.ResponseBody,
+			&i.LastError,
+			&i.DeliveredAt,
+			&i.RedeliveryOf,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const createWebhookRedelivery = `-- name: CreateWebhookRedelivery :one
+INSERT INTO webhook_deliveries (subscription_id, event, event_id, payload, redelivery_of)
+SELECT subscription_id, event, event_id, payload, id
+FROM webhook_deliveries
+WHERE webhook_deliveries.id = $1
+RETURNING id, created_at, updated_at, subscription_id, event, event_id, payload, status, attempts, response_status, response_body, last_error, delivered_at, redelivery_of
 `
 
-func (q *Queries) DeleteProject(ctx context.Context, id int64) error {
-	_, err := q.db.Exec(ctx, deleteProject, id)
-	return err
+func (q *Queries) CreateWebhookRedelivery(ctx context.Context, id int64) (*WebhookDelivery, error) {
+	row := q.db.QueryRow(ctx, createWebhookRedelivery, id)
+	var i WebhookDelivery
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SubscriptionID,
+		&i.Event,
+		&i.EventID,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.ResponseStatus,
+		&i.ResponseBody,
+		&i.LastError,
+		&i.DeliveredAt,
+		&i.RedeliveryOf,
+	)
+	return &i, err
 }
 
-const deleteVersion = `-- name: DeleteVersion :exec
-DELETE
-FROM versions
-WHERE id = $1
+const createWebhookSubscription = `-- name: CreateWebhookSubscription :one
+
+INSERT INTO webhook_subscriptions (project_id, url, secret, events, created_by)
+VALUES ($1, $2, $3, $4::TEXT[],
+        $5)
+RETURNING id, created_at, updated_at, project_id, url, secret, events, is_active, created_by
 `
 
-func (q *Queries) DeleteVersion(ctx context.Context, id int64) error {
-	_, err := q.db.Exec(ctx, deleteVersion, id)
-	return err
+type CreateWebhookSubscriptionParams struct {
+	ProjectId *int64
+	Url       string
+	Secret    string
+	Events    []string
+	CreatedBy *int64
 }
 
-const detachFileFromVersion = `-- name: DetachFileFromVersion :exec
-DELETE
+// Webhooks
+func (q *Queries) CreateWebhookSubscription(ctx context.Context, arg *CreateWebhookSubscriptionParams) (*WebhookSubscription, error) {
+	row := q.db.QueryRow(ctx, createWebhookSubscription,
+		arg.ProjectId,
+		arg.Url,
+		arg.Secret,
+		arg.Events,
+		arg.CreatedBy,
+	)
+	var i WebhookSubscription
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ProjectID,
+		&i.Url,
+		&i.Secret,
+		&i.Events,
+		&i.IsActive,
+		&i.CreatedBy,
+	)
+	return &i, err
+}
+
+const deleteCompletedJobs = `-- name: DeleteCompletedJobs :execrows
+DELETE
+FROM jobs
+WHERE status = 'completed'
+  AND completed_at <= CURRENT_TIMESTAMP - $1::INTERVAL
+`
+
+func (q *Queries) DeleteCompletedJobs(ctx context.Context, age pgtype.Interval) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteCompletedJobs, age)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteFile = `-- name: DeleteFile :exec
+DELETE
+FROM files
+WHERE id = $1
+`
+
+func (q *Queries) DeleteFile(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, deleteFile, id)
+	return err
+}
+
+const deleteLocation = `-- name: DeleteLocation :exec
+DELETE
+FROM locations
+WHERE id = $1
+`
+
+func (q *Queries) DeleteLocation(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, deleteLocation, id)
+	return err
+}
+
+const deleteProject = `-- name: DeleteProject :exec
+DELETE
+FROM projects
+WHERE id = $1
+`
+
+func (q *Queries) DeleteProject(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, deleteProject, id)
+	return err
+}
+
+const deleteProjectMembership = `-- name: DeleteProjectMembership :exec
+DELETE
+FROM project_memberships
+WHERE project_id = $1
+  AND user_id = $2
+`
+
+type DeleteProjectMembershipParams struct {
+	ProjectID int64
+	UserID    int64
+}
+
+func (q *Queries) DeleteProjectMembership(ctx context.Context, arg *DeleteProjectMembershipParams) error {
+	_, err := q.db.Exec(ctx, deleteProjectMembership, arg.ProjectID, arg.UserID)
+	return err
+}
+
+const deleteUploadSession = `-- name: DeleteUploadSession :exec
+DELETE
+FROM upload_sessions
+WHERE id = $1
+`
+
+func (q *Queries) DeleteUploadSession(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteUploadSession, id)
+	return err
+}
+
+const deleteVersion = `-- name: DeleteVersion :exec
+DELETE
+FROM versions
+WHERE id = $1
+`
+
+func (q *Queries) DeleteVersion(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, deleteVersion, id)
+	return err
+}
+
+const deleteWebhookSubscription = `-- name: DeleteWebhookSubscription :exec
+DELETE
+FROM webhook_subscriptions
+WHERE id = $1
+`
+
+func (q *Queries) DeleteWebhookSubscription(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, deleteWebhookSubscription, id)
+	return err
+}
+
+const detachFileFromVersion = `-- name: DetachFileFromVersion :exec
+DELETE
 FROM versions_files
 WHERE version_id = $1
   AND file_id = $2
@@ -232,6 +951,71 @@ func (q *Queries) DetachFileFromVersion(ctx context.Context, arg *DetachFileFrom
 	return err
 }
 
+const enqueueJob = `-- name: EnqueueJob :one
+
+INSERT INTO jobs (kind, payload, max_attempts, run_at, unique_key)
+VALUES ($1, $2, $3, CURRENT_TIMESTAMP + $4::INTERVAL,
+        $5)
+ON CONFLICT (unique_key) DO NOTHING
+RETURNING id, created_at, updated_at, kind, payload, status, attempts, max_attempts, run_at, locked_at, last_error, completed_at, unique_key
+`
+
+type EnqueueJobParams struct {
+	Kind        string
+	Payload     []byte
+	MaxAttempts int32
+	Delay       pgtype.Interval
+	UniqueKey   *string
+}
+
+// Jobs
+// Returns no row when a job with the same unique key was queued before.
+func (q *Queries) EnqueueJob(ctx context.Context, arg *EnqueueJobParams) (*Job, error) {
+	row := q.db.QueryRow(ctx, enqueueJob,
+		arg.Kind,
+		arg.Payload,
+		arg.MaxAttempts,
+		arg.Delay,
+		arg.UniqueKey,
+	)
+	var i Job
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Kind,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.MaxAttempts,
+		&i.RunAt,
+		&i.LockedAt,
+		&i.LastError,
+		&i.CompletedAt,
+		&i.UniqueKey,
+	)
+	return &i, err
+}
+
+const failJob = `-- name: FailJob :exec
+UPDATE jobs
+SET updated_at = CURRENT_TIMESTAMP,
+    status     = 'failed',
+    locked_at  = NULL,
+    last_error = $1
+WHERE id = $2
+`
+
+type FailJobParams struct {
+	LastError *string
+	ID        int64
+}
+
+func (q *Queries) FailJob(ctx context.Context, arg *FailJobParams) error {
+	_, err := q.db.Exec(ctx, failJob, arg.LastError, arg.ID)
+	return err
+}
+
 const getFile = `-- name: GetFile :one
 SELECT id,
        created_at,
@@ -240,7 +1024,10 @@ SELECT id,
        size,
        path,
        mime_type,
-       is_complete
+       is_complete,
+       checksum,
+       created_by,
+       current_revision_id
 FROM files
 WHERE id = $1
 LIMIT 1
@@ -258,203 +1045,2194 @@ func (q *Queries) GetFile(ctx context.Context, id int64) (*File, error) {
 		&i.Path,
 		&i.MimeType,
 		&i.IsComplete,
+		&i.Checksum,
+		&i.CreatedBy,
+		&i.CurrentRevisionID,
 	)
 	return &i, err
 }
 
-const getProject = `-- name: GetProject :one
-
-SELECT id,
-       created_at,
-       updated_at,
-       slug,
-       name,
-       location_id
-FROM projects
-WHERE id = $1
+const getFileByNameInVersions = `-- name: GetFileByNameInVersions :one
+SELECT files.id,
+       files.created_at,
+       files.updated_at,
+       files.name,
+       file_revisions.size,
+       file_revisions.path,
+       file_revisions.mime_type,
+       files.is_complete,
+       file_revisions.checksum,
+       files.created_by,
+       file_revisions.id AS revision_id
+FROM files
+         INNER JOIN versions_files ON versions_files.file_id = files.id
+         LEFT JOIN file_revisions
+                   ON file_revisions.id = COALESCE(versions_files.revision_id, files.current_revision_id)
+WHERE files.name = $1
+  AND versions_files.version_id = ANY ($2::BIGINT[])
+ORDER BY array_position($2::BIGINT[], versions_files.version_id), files.id DESC
 LIMIT 1
 `
 
-// Projects
-func (q *Queries) GetProject(ctx context.Context, id int64) (*Project, error) {
-	row := q.db.QueryRow(ctx, getProject, id)
-	var i Project
+type GetFileByNameInVersionsParams struct {
+	Name       string
+	VersionIds []int64
+}
+
+type GetFileByNameInVersionsRow struct {
+	ID         int64
+	CreatedAt  pgtype.Timestamp
+	UpdatedAt  pgtype.Timestamp
+	Name       string
+	Size       *int64
+	Path       *string
+	MimeType   *string
+	IsComplete bool
+	Checksum   *string
+	CreatedBy  *int64
+	RevisionID *int64
+}
+
+// Versions are given in order of precedence, the copy from the first version
+// that contains a file with the name wins, with the revision that version
+// pins.
+func (q *Queries) GetFileByNameInVersions(ctx context.Context, arg *GetFileByNameInVersionsParams) (*GetFileByNameInVersionsRow, error) {
+	row := q.db.QueryRow(ctx, getFileByNameInVersions, arg.Name, arg.VersionIds)
+	var i GetFileByNameInVersionsRow
 	err := row.Scan(
 		&i.ID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
-		&i.Slug,
 		&i.Name,
-		&i.LocationID,
+		&i.Size,
+		&i.Path,
+		&i.MimeType,
+		&i.IsComplete,
+		&i.Checksum,
+		&i.CreatedBy,
+		&i.RevisionID,
 	)
 	return &i, err
 }
 
-const getUserById = `-- name: GetUserById :one
-SELECT id,
-       created_at,
-       updated_at,
-       name,
-       email,
-       email_verified,
-       keycloak_reference
-FROM users
+const getFileInVersion = `-- name: GetFileInVersion :one
+SELECT files.id,
+       files.created_at,
+       files.updated_at,
+       files.name,
+       file_revisions.size,
+       file_revisions.path,
+       file_revisions.mime_type,
+       files.is_complete,
+       file_revisions.checksum,
+       files.created_by,
+       file_revisions.id AS revision_id
+FROM files
+         INNER JOIN versions_files ON versions_files.file_id = files.id
+         LEFT JOIN file_revisions
+                   ON file_revisions.id = COALESCE(versions_files.revision_id, files.current_revision_id)
+WHERE versions_files.version_id = $1
+  AND files.id = $2
+`
+
+type GetFileInVersionParams struct {
+	VersionId int64
+	FileId    int64
+}
+
+type GetFileInVersionRow struct {
+	ID         int64
+	CreatedAt  pgtype.Timestamp
+	UpdatedAt  pgtype.Timestamp
+	Name       string
+	Size       *int64
+	Path       *string
+	MimeType   *string
+	IsComplete bool
+	Checksum   *string
+	CreatedBy  *int64
+	RevisionID *int64
+}
+
+// The file with the revision the version pins.
+func (q *Queries) GetFileInVersion(ctx context.Context, arg *GetFileInVersionParams) (*GetFileInVersionRow, error) {
+	row := q.db.QueryRow(ctx, getFileInVersion, arg.VersionId, arg.FileId)
+	var i GetFileInVersionRow
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Name,
+		&i.Size,
+		&i.Path,
+		&i.MimeType,
+		&i.IsComplete,
+		&i.Checksum,
+		&i.CreatedBy,
+		&i.RevisionID,
+	)
+	return &i, err
+}
+
+const getFileRevision = `-- name: GetFileRevision :one
+SELECT id, created_at, file_id, number, path, size, mime_type, checksum, created_by
+FROM file_revisions
 WHERE id = $1
 LIMIT 1
 `
 
-// Users
-func (q *Queries) GetUserById(ctx context.Context, id int64) (*User, error) {
-	row := q.db.QueryRow(ctx, getUserById, id)
-	var i User
+func (q *Queries) GetFileRevision(ctx context.Context, id int64) (*FileRevision, error) {
+	row := q.db.QueryRow(ctx, getFileRevision, id)
+	var i FileRevision
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.FileID,
+		&i.Number,
+		&i.Path,
+		&i.Size,
+		&i.MimeType,
+		&i.Checksum,
+		&i.CreatedBy,
+	)
+	return &i, err
+}
+
+const getJob = `-- name: GetJob :one
+SELECT id, created_at, updated_at, kind, payload, status, attempts, max_attempts, run_at, locked_at, last_error, completed_at, unique_key
+FROM jobs
+WHERE id = $1
+LIMIT 1
+`
+
+func (q *Queries) GetJob(ctx context.Context, id int64) (*Job, error) {
+	row := q.db.QueryRow(ctx, getJob, id)
+	var i Job
 	err := row.Scan(
 		&i.ID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
-		&i.Name,
-		&i.Email,
-		&i.EmailVerified,
-		&i.KeycloakReference,
+		&i.Kind,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.MaxAttempts,
+		&i.RunAt,
+		&i.LockedAt,
+		&i.LastError,
+		&i.CompletedAt,
+		&i.UniqueKey,
 	)
 	return &i, err
 }
 
-const getUserByKeycloakReference = `-- name: GetUserByKeycloakReference :one
+const getLatestVersionPolicy = `-- name: GetLatestVersionPolicy :one
+SELECT latest_version_strategy, pinned_version_id
+FROM projects
+WHERE id = $1
+`
+
+type GetLatestVersionPolicyRow struct {
+	LatestVersionStrategy string
+	PinnedVersionID       *int64
+}
+
+func (q *Queries) GetLatestVersionPolicy(ctx context.Context, id int64) (*GetLatestVersionPolicyRow, error) {
+	row := q.db.QueryRow(ctx, getLatestVersionPolicy, id)
+	var i GetLatestVersionPolicyRow
+	err := row.Scan(&i.LatestVersionStrategy, &i.PinnedVersionID)
+	return &i, err
+}
+
+const getLocation = `-- name: GetLocation :one
+
 SELECT id,
        created_at,
        updated_at,
        name,
-       email,
-       email_verified,
-       keycloak_reference
-FROM users
-WHERE keycloak_reference = $1
+       address,
+       lat,
+       lon,
+       created_by
+FROM locations
+WHERE id = $1
 LIMIT 1
 `
 
-func (q *Queries) GetUserByKeycloakReference(ctx context.Context, keycloakReference *string) (*User, error) {
-	row := q.db.QueryRow(ctx, getUserByKeycloakReference, keycloakReference)
-	var i User
+// Locations
+func (q *Queries) GetLocation(ctx context.Context, id int64) (*Location, error) {
+	row := q.db.QueryRow(ctx, getLocation, id)
+	var i Location
 	err := row.Scan(
 		&i.ID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Name,
-		&i.Email,
-		&i.EmailVerified,
-		&i.KeycloakReference,
+		&i.Address,
+		&i.Lat,
+		&i.Lon,
+		&i.CreatedBy,
 	)
 	return &i, err
 }
 
-const getVersion = `-- name: GetVersion :one
+const getProject = `-- name: GetProject :one
+
 SELECT id,
        created_at,
        updated_at,
+       slug,
        name,
-       description,
-       project_id
-FROM versions
+       location_id,
+       latest_version_strategy,
+       pinned_version_id
+FROM projects
 WHERE id = $1
 LIMIT 1
 `
 
-func (q *Queries) GetVersion(ctx context.Context, id int64) (*Version, error) {
-	row := q.db.QueryRow(ctx, getVersion, id)
-	var i Version
+// Projects
+func (q *Queries) GetProject(ctx context.Context, id int64) (*Project, error) {
+	row := q.db.QueryRow(ctx, getProject, id)
+	var i Project
 	err := row.Scan(
 		&i.ID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Slug,
 		&i.Name,
-		&i.Description,
-		&i.ProjectID,
+		&i.LocationID,
+		&i.LatestVersionStrategy,
+		&i.PinnedVersionID,
 	)
 	return &i, err
 }
 
-const listFiles = `-- name: ListFiles :many
-SELECT files.id,
-       files.created_at,
-       files.updated_at,
-       files.name,
-       files.size,
-       files.path,
-       files.mime_type,
-       files.is_complete
-FROM files
-         LEFT JOIN versions_files ON files.id = versions_files.file_id
-WHERE ($1::BIGINT IS NULL OR versions_files.version_id = $1)
-ORDER BY files.created_at DESC
-LIMIT $3::BIGINT OFFSET $2::BIGINT
+const getProjectMembership = `-- name: GetProjectMembership :one
+
+SELECT project_id, user_id, created_at, updated_at, role
+FROM project_memberships
+WHERE project_id = $1
+  AND user_id = $2
+LIMIT 1
+`
+
+type GetProjectMembershipParams struct {
+	ProjectID int64
+	UserID    int64
+}
+
+// Project memberships
+func (q *Queries) GetProjectMembership(ctx context.Context, arg *GetProjectMembershipParams) (*ProjectMembership, error) {
+	row := q.db.QueryRow(ctx, getProjectMembership, arg.ProjectID, arg.UserID)
+	var i ProjectMembership
+	err := row.Scan(
+		&i.ProjectID,
+		&i.UserID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Role,
+	)
+	return &i, err
+}
+
+const getShareLink = `-- name: GetShareLink :one
+
+SELECT id, created_at, updated_at, file_id, version_id, expires_at, max_downloads, download_count, password_hash, revoked_at, created_by
+FROM share_links
+WHERE id = $1
+LIMIT 1
+`
+
+// Share links
+func (q *Queries) GetShareLink(ctx context.Context, id int64) (*ShareLink, error) {
+	row := q.db.QueryRow(ctx, getShareLink, id)
+	var i ShareLink
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.FileID,
+		&i.VersionID,
+		&i.ExpiresAt,
+		&i.MaxDownloads,
+		&i.DownloadCount,
+		&i.PasswordHash,
+		&i.RevokedAt,
+		&i.CreatedBy,
+	)
+	return &i, err
+}
+
+const getShortLink = `-- name: GetShortLink :one
+
+SELECT id, created_at, updated_at, code, target_type, project_id, version_id, revoked_at, hit_count, last_hit_at
+FROM short_links
+WHERE id = $1
+LIMIT 1
+`
+
+// Short links
+func (q *Queries) GetShortLink(ctx context.Context, id int64) (*ShortLink, error) {
+	row := q.db.QueryRow(ctx, getShortLink, id)
+	var i ShortLink
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Code,
+		&i.TargetType,
+		&i.ProjectID,
+		&i.VersionID,
+		&i.RevokedAt,
+		&i.HitCount,
+		&i.LastHitAt,
+	)
+	return &i, err
+}
+
+const getShortLinkByCode = `-- name: GetShortLinkByCode :one
+SELECT id, created_at, updated_at, code, target_type, project_id, version_id, revoked_at, hit_count, last_hit_at
+FROM short_links
+WHERE code = $1
+LIMIT 1
+`
+
+func (q *Queries) GetShortLinkByCode(ctx context.Context, code string) (*ShortLink, error) {
+	row := q.db.QueryRow(ctx, getShortLinkByCode, code)
+	var i ShortLink
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Code,
+		&i.TargetType,
+		&i.ProjectID,
+		&i.VersionID,
+		&i.RevokedAt,
+		&i.HitCount,
+		&i.LastHitAt,
+	)
+	return &i, err
+}
+
+const getUploadSession = `-- name: GetUploadSession :one
+
+SELECT id, created_at, updated_at, expires_at, file_id, size, received_bytes, chunk_paths, is_complete, checksum
+FROM upload_sessions
+WHERE id = $1
+  AND file_id = $2
+LIMIT 1
+`
+
+type GetUploadSessionParams struct {
+	ID     pgtype.UUID
+	FileID int64
+}
+
+// Upload sessions
+func (q *Queries) GetUploadSession(ctx context.Context, arg *GetUploadSessionParams) (*UploadSession, error) {
+	row := q.db.QueryRow(ctx, getUploadSession, arg.ID, arg.FileID)
+	var i UploadSession
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ExpiresAt,
+		&i.FileID,
+		&i.Size,
+		&i.ReceivedBytes,
+		&i.ChunkPaths,
+		&i.IsComplete,
+		&i.Checksum,
+	)
+	return &i, err
+}
+
+const getUserByEmail = `-- name: GetUserByEmail :one
+SELECT id,
+       created_at,
+       updated_at,
+       name,
+       email,
+       email_verified,
+       keycloak_reference
+FROM users
+WHERE email = $1
+LIMIT 1
+`
+
+func (q *Queries) GetUserByEmail(ctx context.Context, email *string) (*User, error) {
+	row := q.db.QueryRow(ctx, getUserByEmail, email)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Name,
+		&i.Email,
+		&i.EmailVerified,
+		&i.KeycloakReference,
+	)
+	return &i, err
+}
+
+const getUserById = `-- name: GetUserById :one
+SELECT id,
+       created_at,
+       updated_at,
+       name,
+       email,
+       email_verified,
+       keycloak_reference
+FROM users
+WHERE id = $1
+LIMIT 1
+`
+
+// Users
+func (q *Queries) GetUserById(ctx context.Context, id int64) (*User, error) {
+	row := q.db.QueryRow(ctx, getUserById, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Name,
+		&i.Email,
+		&i.EmailVerified,
+		&i.KeycloakReference,
+	)
+	return &i, err
+}
+
+const getUserByKeycloakReference = `-- name: GetUserByKeycloakReference :one
+SELECT id,
+       created_at,
+       updated_at,
+       name,
+       email,
+       email_verified,
+       keycloak_reference
+FROM users
+WHERE keycloak_reference = $1
+LIMIT 1
+`
+
+func (q *Queries) GetUserByKeycloakReference(ctx context.Context, keycloakReference *string) (*User, error) {
+	row := q.db.QueryRow(ctx, getUserByKeycloakReference, keycloakReference)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Name,
+		&i.Email,
+		&i.EmailVerified,
+		&i.KeycloakReference,
+	)
+	return &i, err
+}
+
+const getVersion = `-- name: GetVersion :one
+SELECT id,
+       created_at,
+       updated_at,
+       name,
+       description,
+       project_id,
+       status,
+       released_at
+FROM versions
+WHERE id = $1
+LIMIT 1
+`
+
+func (q *Queries) GetVersion(ctx context.Context, id int64) (*Version, error) {
+	row := q.db.QueryRow(ctx, getVersion, id)
+	var i Version
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Name,
+		&i.Description,
+		&i.ProjectID,
+		&i.Status,
+		&i.ReleasedAt,
+	)
+	return &i, err
+}
+
+const getWebhookDelivery = `-- name: GetWebhookDelivery :one
+SELECT id, created_at, updated_at, subscription_id, event, event_id, payload, status, attempts, response_status, response_body, last_error, delivered_at, redelivery_of
+FROM webhook_deliveries
+WHERE id = $1
+`
+
+func (q *Queries) GetWebhookDelivery(ctx context.Context, id int64) (*WebhookDelivery, error) {
+	row := q.db.QueryRow(ctx, getWebhookDelivery, id)
+	var i WebhookDelivery
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SubscriptionID,
+		&i.Event,
+		&i.EventID,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.ResponseStatus,
+		&i.ResponseBody,
+		&i.LastError,
+		&i.DeliveredAt,
+		&i.RedeliveryOf,
+	)
+	return &i, err
+}
+
+const getWebhookSubscription = `-- name: GetWebhookSubscription :one
+SELECT id, created_at, updated_at, project_id, url, secret, events, is_active, created_by
+FROM webhook_subscriptions
+WHERE id = $1
+`
+
+func (q *Queries) GetWebhookSubscription(ctx context.Context, id int64) (*WebhookSubscription, error) {
+	row := q.db.QueryRow(ctx, getWebhookSubscription, id)
+	var i WebhookSubscription
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ProjectID,
+		&i.Url,
+		&i.Secret,
+		&i.Events,
+		&i.IsActive,
+		&i.CreatedBy,
+	)
+	return &i, err
+}
+
+const heartbeatJob = `-- name: HeartbeatJob :exec
+UPDATE jobs
+SET locked_at = CURRENT_TIMESTAMP
+WHERE id = $1
+  AND status = 'running'
+`
+
+func (q *Queries) HeartbeatJob(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, heartbeatJob, id)
+	return err
+}
+
+const isFileInVersion = `-- name: IsFileInVersion :one
+SELECT EXISTS (SELECT 1
+               FROM versions_files
+               WHERE version_id = $1
+                 AND file_id = $2)
+`
+
+type IsFileInVersionParams struct {
+	VersionID int64
+	FileID    int64
+}
+
+func (q *Queries) IsFileInVersion(ctx context.Context, arg *IsFileInVersionParams) (bool, error) {
+	row := q.db.QueryRow(ctx, isFileInVersion, arg.VersionID, arg.FileID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const isFileReleased = `-- name: IsFileReleased :one
+SELECT EXISTS (SELECT 1
+               FROM versions_files
+                        INNER JOIN versions ON versions.id = versions_files.version_id
+               WHERE versions_files.file_id = $1
+                 AND versions.released_at IS NOT NULL)
+`
+
+// A file is released once any version containing it has been released.
+func (q *Queries) IsFileReleased(ctx context.Context, fileID int64) (bool, error) {
+	row := q.db.QueryRow(ctx, isFileReleased, fileID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const linkUserToKeycloakReference = `-- name: LinkUserToKeycloakReference :one
+UPDATE users
+SET updated_at         = CURRENT_TIMESTAMP,
+    name               = $2,
+    email_verified     = $3,
+    keycloak_reference = $4
+WHERE id = $1
+  AND keycloak_reference IS NULL
+RETURNING id, created_at, updated_at, name, email, email_verified, keycloak_reference
+`
+
+type LinkUserToKeycloakReferenceParams struct {
+	ID                int64
+	Name              string
+	EmailVerified     bool
+	KeycloakReference *string
+}
+
+// Returns no row when the user was linked to another account meanwhile.
+func (q *Queries) LinkUserToKeycloakReference(ctx context.Context, arg *LinkUserToKeycloakReferenceParams) (*User, error) {
+	row := q.db.QueryRow(ctx, linkUserToKeycloakReference,
+		arg.ID,
+		arg.Name,
+		arg.EmailVerified,
+		arg.KeycloakReference,
+	)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Name,
+		&i.Email,
+		&i.EmailVerified,
+		&i.KeycloakReference,
+	)
+	return &i, err
+}
+
+const listAuditEvents = `-- name: ListAuditEvents :many
+SELECT id, occurred_at, actor, actor_id, action, resource_type, resource_id, before, after, request_id, client_ip
+FROM audit_events
+WHERE ($1::TEXT IS NULL OR resource_type = $1)
+  AND ($2::BIGINT IS NULL OR resource_id = $2)
+  AND ($3::TEXT IS NULL OR actor = $3)
+  AND ($4::TEXT IS NULL OR action = $4)
+  AND ($5::TIMESTAMP IS NULL OR occurred_at >= $5)
+  AND ($6::TIMESTAMP IS NULL OR occurred_at < $6)
+  AND ($7::BIGINT IS NULL OR id < $7)
+ORDER BY id DESC
+LIMIT $9::BIGINT OFFSET $8::BIGINT
+`
+
+type ListAuditEventsParams struct {
+	ResourceType *string
+	ResourceId   *int64
+	Actor        *string
+	Action       *string
+	From         pgtype.Timestamp
+	To           pgtype.Timestamp
+	BeforeId     *int64
+	Offset       int64
+	Limit        int64
+}
+
+// Newest first. beforeId pages through the log by id for exports, which
+// unlike offsets isn't thrown off by events written in the meantime.
+func (q *Queries) ListAuditEvents(ctx context.Context, arg *ListAuditEventsParams) ([]*AuditEvent, error) {
+	rows, err := q.db.Query(ctx, listAuditEvents,
+		arg.ResourceType,
+		arg.ResourceId,
+		arg.Actor,
+		arg.Action,
+		arg.From,
+		arg.To,
+		arg.BeforeId,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*AuditEvent
+	for rows.Next() {
+		var i AuditEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.OccurredAt,
+			&i.Actor,
+			&i.ActorID,
+			&i.Action,
+			&i.ResourceType,
+			&i.ResourceID,
+			&i.Before,
+			&i.After,
+			&i.RequestID,
+			&i.ClientIp,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCompleteFiles = `-- name: ListCompleteFiles :many
+SELECT id, created_at, updated_at, name, size, path, mime_type, is_complete, checksum, created_by, current_revision_id
+FROM files
+WHERE is_complete = TRUE
+ORDER BY id
+LIMIT $2::BIGINT OFFSET $1::BIGINT
+`
+
+type ListCompleteFilesParams struct {
+	Offset int64
+	Limit  int64
+}
+
+func (q *Queries) ListCompleteFiles(ctx context.Context, arg *ListCompleteFilesParams) ([]*File, error) {
+	rows, err := q.db.Query(ctx, listCompleteFiles, arg.Offset, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*File
+	for rows.Next() {
+		var i File
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Name,
+			&i.Size,
+			&i.Path,
+			&i.MimeType,
+			&i.IsComplete,
+			&i.Checksum,
+			&i.CreatedBy,
+			&i.CurrentRevisionID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCompleteFilesByVersionId = `-- name: ListCompleteFilesByVersionId :many
+SELECT files.id,
+       files.created_at,
+       files.updated_at,
+       files.name,
+       file_revisions.size,
+       file_revisions.path,
+       file_revisions.mime_type,
+       files.is_complete,
+       file_revisions.checksum,
+       files.created_by,
+       file_revisions.id AS revision_id
+FROM files
+         INNER JOIN versions_files ON versions_files.file_id = files.id
+         LEFT JOIN file_revisions
+                   ON file_revisions.id = COALESCE(versions_files.revision_id, files.current_revision_id)
+WHERE versions_files.version_id = $1
+  AND files.is_complete = TRUE
+ORDER BY files.name, files.id
+`
+
+type ListCompleteFilesByVersionIdRow struct {
+	ID         int64
+	CreatedAt  pgtype.Timestamp
+	UpdatedAt  pgtype.Timestamp
+	Name       string
+	Size       *int64
+	Path       *string
+	MimeType   *string
+	IsComplete bool
+	Checksum   *string
+	CreatedBy  *int64
+	RevisionID *int64
+}
+
+func (q *Queries) ListCompleteFilesByVersionId(ctx context.Context, versionID int64) ([]*ListCompleteFilesByVersionIdRow, error) {
+	rows, err := q.db.Query(ctx, listCompleteFilesByVersionId, versionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*ListCompleteFilesByVersionIdRow
+	for rows.Next() {
+		var i ListCompleteFilesByVersionIdRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Name,
+			&i.Size,
+			&i.Path,
+			&i.MimeType,
+			&i.IsComplete,
+			&i.Checksum,
+			&i.CreatedBy,
+			&i.RevisionID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listExpiredUploadSessions = `-- name: ListExpiredUploadSessions :many
+SELECT id, created_at, updated_at, expires_at, file_id, size, received_bytes, chunk_paths, is_complete, checksum
+FROM upload_sessions
+WHERE expires_at < $1
+ORDER BY expires_at
+LIMIT $2::BIGINT
+`
+
+type ListExpiredUploadSessionsParams struct {
+	ExpiredBefore pgtype.Timestamp
+	Limit         int64
+}
+
+func (q *Queries) ListExpiredUploadSessions(ctx context.Context, arg *ListExpiredUploadSessionsParams) ([]*UploadSession, error) {
+	rows, err := q.db.Query(ctx, listExpiredUploadSessions, arg.ExpiredBefore, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*UploadSession
+	for rows.Next() {
+		var i UploadSession
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ExpiresAt,
+			&i.FileID,
+			&i.Size,
+			&i.ReceivedBytes,
+			&i.ChunkPaths,
+			&i.IsComplete,
+			&i.Checksum,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listFilePaths = `-- name: ListFilePaths :many
+
+SELECT id,
+       file_id,
+       path
+FROM file_revisions
+WHERE id > $1
+ORDER BY id
+LIMIT $2::BIGINT
+`
+
+type ListFilePathsParams struct {
+	AfterId int64
+	Limit   int64
+}
+
+type ListFilePathsRow struct {
+	ID     int64
+	FileID int64
+	Path   string
+}
+
+// Storage reconciliation
+// Pages through the stored assets of file revisions by id.
+func (q *Queries) ListFilePaths(ctx context.Context, arg *ListFilePathsParams) ([]*ListFilePathsRow, error) {
+	rows, err := q.db.Query(ctx, listFilePaths, arg.AfterId, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*ListFilePathsRow
+	for rows.Next() {
+		var i ListFilePathsRow
+		if err := rows.Scan(&i.ID, &i.FileID, &i.Path); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listFileRevisionPaths = `-- name: ListFileRevisionPaths :many
+SELECT path
+FROM file_revisions
+WHERE file_id = $1
+`
+
+func (q *Queries) ListFileRevisionPaths(ctx context.Context, fileID int64) ([]string, error) {
+	rows, err := q.db.Query(ctx, listFileRevisionPaths, fileID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var path string
+		if err := rows.Scan(&path); err != nil {
+			return nil, err
+		}
+		items = append(items, path)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listFileRevisions = `-- name: ListFileRevisions :many
+SELECT id, created_at, file_id, number, path, size, mime_type, checksum, created_by
+FROM file_revisions
+WHERE file_id = $1
+ORDER BY number DESC
+LIMIT $3::BIGINT OFFSET $2::BIGINT
+`
+
+type ListFileRevisionsParams struct {
+	FileId int64
+	Offset int64
+	Limit  int64
+}
+
+func (q *Queries) ListFileRevisions(ctx context.Context, arg *ListFileRevisionsParams) ([]*FileRevision, error) {
+	rows, err := q.db.Query(ctx, listFileRevisions, arg.FileId, arg.Offset, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*FileRevision
+	for rows.Next() {
+		var i FileRevision
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.FileID,
+			&i.Number,
+			&i.Path,
+			&i.Size,
+			&i.MimeType,
+			&i.Checksum,
+			&i.CreatedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listFiles = `-- name: ListFiles :many
+SELECT files.id,
+       files.created_at,
+       files.updated_at,
+       files.name,
+       file_revisions.size,
+       file_revisions.path,
+       file_revisions.mime_type,
+       files.is_complete,
+       file_revisions.checksum,
+       files.created_by,
+       file_revisions.id AS revision_id
+FROM files
+         LEFT JOIN versions_files
+                   ON versions_files.file_id = files.id AND versions_files.version_id = $1
+         LEFT JOIN file_revisions
+                   ON file_revisions.id = COALESCE(versions_files.revision_id, files.current_revision_id)
+WHERE ($1::BIGINT IS NULL OR versions_files.version_id IS NOT NULL)
+  AND ($2::BIGINT IS NULL OR files.created_by = $2 OR EXISTS (SELECT 1
+                                                                                                   FROM versions_files vf
+                                                                                                            INNER JOIN versions v ON v.id = vf.version_id
+                                                                                                            INNER JOIN project_memberships m ON m.project_id = v.project_id
+                                                                                                   WHERE vf.file_id = files.id
+                                                                                                     AND m.user_id = $2))
+ORDER BY files.created_at DESC
+LIMIT $4::BIGINT OFFSET $3::BIGINT
+`
+
+type ListFilesParams struct {
+	VersionId *int64
+	MemberId  *int64
+	Offset    int64
+	Limit     int64
+}
+
+type ListFilesRow struct {
+	ID         int64
+	CreatedAt  pgtype.Timestamp
+	UpdatedAt  pgtype.Timestamp
+	Name       string
+	Size       *int64
+	Path       *string
+	MimeType   *string
+	IsComplete bool
+	Checksum   *string
+	CreatedBy  *int64
+	RevisionID *int64
+}
+
+// A member sees the files attached to versions of their projects and the files
+// they created themselves. The files of a version have the contents of the
+// revision it pins.
+func (q *Queries) ListFiles(ctx context.Context, arg *ListFilesParams) ([]*ListFilesRow, error) {
+	rows, err := q.db.Query(ctx, listFiles,
+		arg.VersionId,
+		arg.MemberId,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*ListFilesRow
+	for rows.Next() {
+		var i ListFilesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Name,
+			&i.Size,
+			&i.Path,
+			&i.MimeType,
+			&i.IsComplete,
+			&i.Checksum,
+			&i.CreatedBy,
+			&i.RevisionID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listJobs = `-- name: ListJobs :many
+SELECT id, created_at, updated_at, kind, payload, status, attempts, max_attempts, run_at, locked_at, last_error, completed_at, unique_key
+FROM jobs
+WHERE ($1::TEXT IS NULL OR status = $1)
+  AND ($2::TEXT IS NULL OR kind = $2)
+ORDER BY id DESC
+LIMIT $4::BIGINT OFFSET $3::BIGINT
+`
+
+type ListJobsParams struct {
+	Status *string
+	Kind   *string
+	Offset int64
+	Limit  int64
+}
+
+func (q *Queries) ListJobs(ctx context.Context, arg *ListJobsParams) ([]*Job, error) {
+	rows, err := q.db.Query(ctx, listJobs,
+		arg.Status,
+		arg.Kind,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*Job
+	for rows.Next() {
+		var i Job
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Kind,
+			&i.Payload,
+			&i.Status,
+			&i.Attempts,
+			&i.MaxAttempts,
+			&i.RunAt,
+			&i.LockedAt,
+			&i.LastError,
+			&i.CompletedAt,
+			&i.UniqueKey,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLocations = `-- name: ListLocations :many
+SELECT id,
+       created_at,
+       updated_at,
+       name,
+       address,
+       lat,
+       lon,
+       created_by
+FROM locations
+WHERE ($1::BIGINT IS NULL OR locations.created_by = $1 OR EXISTS (SELECT 1
+                                                                                                       FROM projects p
+                                                                                                                INNER JOIN project_memberships m ON m.project_id = p.id
+                                                                                                       WHERE p.location_id = locations.id
+                                                                                                         AND m.user_id = $1))
+ORDER BY created_at DESC
+LIMIT $3::BIGINT OFFSET $2::BIGINT
+`
+
+type ListLocationsParams struct {
+	MemberId *int64
+	Offset   int64
+	Limit    int64
+}
+
+func (q *Queries) ListLocations(ctx context.Context, arg *ListLocationsParams) ([]*Location, error) {
+	rows, err := q.db.Query(ctx, listLocations, arg.MemberId, arg.Offset, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*Location
+	for rows.Next() {
+		var i Location
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Name,
+			&i.Address,
+			&i.Lat,
+			&i.Lon,
+			&i.CreatedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLocationsByIds = `-- name: ListLocationsByIds :many
+SELECT id,
+       created_at,
+       updated_at,
+       name,
+       address,
+       lat,
+       lon,
+       created_by
+FROM locations
+WHERE id = ANY ($1::BIGINT[])
+  AND ($2::BIGINT IS NULL OR locations.created_by = $2 OR EXISTS (SELECT 1
+                                                                                                       FROM projects p
+                                                                                                                INNER JOIN project_memberships m ON m.project_id = p.id
+                                                                                                       WHERE p.location_id = locations.id
+                                                                                                         AND m.user_id = $2))
+`
+
+type ListLocationsByIdsParams struct {
+	Ids      []int64
+	MemberId *int64
+}
+
+func (q *Queries) ListLocationsByIds(ctx context.Context, arg *ListLocationsByIdsParams) ([]*Location, error) {
+	rows, err := q.db.Query(ctx, listLocationsByIds, arg.Ids, arg.MemberId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*Location
+	for rows.Next() {
+		var i Location
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Name,
+			&i.Address,
+			&i.Lat,
+			&i.Lon,
+			&i.CreatedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProjectIdsByFileId = `-- name: ListProjectIdsByFileId :many
+SELECT DISTINCT versions.project_id
+FROM versions_files
+         INNER JOIN versions ON versions.id = versions_files.version_id
+WHERE versions_files.file_id = $1
+`
+
+func (q *Queries) ListProjectIdsByFileId(ctx context.Context, fileID int64) ([]int64, error) {
+	rows, err := q.db.Query(ctx, listProjectIdsByFileId, fileID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var project_id int64
+		if err := rows.Scan(&project_id); err != nil {
+			return nil, err
+		}
+		items = append(items, project_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProjectIdsByLocationId = `-- name: ListProjectIdsByLocationId :many
+SELECT id
+FROM projects
+WHERE location_id = $1
+`
+
+func (q *Queries) ListProjectIdsByLocationId(ctx context.Context, locationID *int64) ([]int64, error) {
+	rows, err := q.db.Query(ctx, listProjectIdsByLocationId, locationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProjectMemberships = `-- name: ListProjectMemberships :many
+SELECT project_id, user_id, created_at, updated_at, role
+FROM project_memberships
+WHERE project_id = $1
+ORDER BY created_at
+LIMIT $3::BIGINT OFFSET $2::BIGINT
+`
+
+type ListProjectMembershipsParams struct {
+	ProjectID int64
+	Offset    int64
+	Limit     int64
+}
+
+func (q *Queries) ListProjectMemberships(ctx context.Context, arg *ListProjectMembershipsParams) ([]*ProjectMembership, error) {
+	rows, err := q.db.Query(ctx, listProjectMemberships, arg.ProjectID, arg.Offset, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*ProjectMembership
+	for rows.Next() {
+		var i ProjectMembership
+		if err := rows.Scan(
+			&i.ProjectID,
+			&i.UserID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Role,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProjects = `-- name: ListProjects :many
+SELECT p.id,
+       p.created_at,
+       p.updated_at,
+       p.slug,
+       p.name,
+       p.location_id,
+       COALESCE(d.distance_km, 0)::DOUBLE PRECISION AS distance_km
+FROM projects p
+         LEFT JOIN locations l ON l.id = p.location_id
+         LEFT JOIN LATERAL (SELECT (2 * 6371.0088 * asin(least(1.0, sqrt(
+        power(sin(radians(l.lat - $1::DOUBLE PRECISION) / 2), 2) +
+        cos(radians($1::DOUBLE PRECISION)) * cos(radians(l.lat)) *
+        power(sin(radians(l.lon - $2::DOUBLE PRECISION) / 2), 2)))))::DOUBLE PRECISION AS distance_km) d
+                   ON TRUE
+WHERE ($3::BIGINT IS NULL OR EXISTS (SELECT 1
+                                                          FROM project_memberships m
+                                                          WHERE m.project_id = p.id
+                                                            AND m.user_id = $3))
+  AND ($4::BIGINT IS NULL OR p.location_id = $4)
+  AND ($1::DOUBLE PRECISION IS NULL OR d.distance_km IS NOT NULL)
+  AND ($5::DOUBLE PRECISION IS NULL OR d.distance_km <= $5::DOUBLE PRECISION)
+  AND ($6::DOUBLE PRECISION IS NULL OR
+       (l.lat BETWEEN $6::DOUBLE PRECISION AND $7::DOUBLE PRECISION AND
+        CASE
+            -- A box whose west edge lies east of its east edge crosses the antimeridian.
+            WHEN $8::DOUBLE PRECISION <= $9::DOUBLE PRECISION
+                THEN l.lon BETWEEN $8::DOUBLE PRECISION AND $9::DOUBLE PRECISION
+            ELSE l.lon >= $8::DOUBLE PRECISION OR l.lon <= $9::DOUBLE PRECISION
+            END))
+ORDER BY d.distance_km ASC NULLS LAST, p.created_at DESC
+LIMIT $11::BIGINT OFFSET $10::BIGINT
+`
+
+type ListProjectsParams struct {
+	NearLat    *float64
+	NearLon    *float64
+	MemberId   *int64
+	LocationId *int64
+	RadiusKm   *float64
+	MinLat     *float64
+	MaxLat     *float64
+	MinLon     *float64
+	MaxLon     *float64
+	Offset     int64
+	Limit      int64
+}
+
+type ListProjectsRow struct {
+	ID         int64
+	CreatedAt  pgtype.Timestamp
+	UpdatedAt  pgtype.Timestamp
+	Slug       string
+	Name       string
+	LocationID *int64
+	DistanceKm float64
+}
+
+// Distances are great-circle distances in kilometres computed with the
+// haversine formula. They are only meaningful when a reference point is given,
+// in which case projects without coordinates are left out of the results.
+func (q *Queries) ListProjects(ctx context.Context, arg *ListProjectsParams) ([]*ListProjectsRow, error) {
+	rows, err := q.db.Query(ctx, listProjects,
+		arg.NearLat,
+		arg.NearLon,
+		arg.MemberId,
+		arg.LocationId,
+		arg.RadiusKm,
+		arg.MinLat,
+		arg.MaxLat,
+		arg.MinLon,
+		arg.MaxLon,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*ListProjectsRow
+	for rows.Next() {
+		var i ListProjectsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Slug,
+			&i.Name,
+			&i.LocationID,
+			&i.DistanceKm,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listReferencedFilePaths = `-- name: ListReferencedFilePaths :many
+SELECT DISTINCT path
+FROM file_revisions
+WHERE path = ANY ($1::TEXT[])
+`
+
+func (q *Queries) ListReferencedFilePaths(ctx context.Context, paths []string) ([]string, error) {
+	rows, err := q.db.Query(ctx, listReferencedFilePaths, paths)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var path string
+		if err := rows.Scan(&path); err != nil {
+			return nil, err
+		}
+		items = append(items, path)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listReleasedVersionsByProjectId = `-- name: ListReleasedVersionsByProjectId :many
+SELECT id, created_at, updated_at, name, description, project_id, status, released_at
+FROM versions
+WHERE project_id = $1
+  AND status = 'released'
+ORDER BY released_at DESC, id DESC
+`
+
+func (q *Queries) ListReleasedVersionsByProjectId(ctx context.Context, projectID int64) ([]*Version, error) {
+	rows, err := q.db.Query(ctx, listReleasedVersionsByProjectId, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*Version
+	for rows.Next() {
+		var i Version
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Name,
+			&i.Description,
+			&i.ProjectID,
+			&i.Status,
+			&i.ReleasedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listShareLinks = `-- name: ListShareLinks :many
+SELECT id, created_at, updated_at, file_id, version_id, expires_at, max_downloads, download_count, password_hash, revoked_at, created_by
+FROM share_links
+WHERE ($1::BIGINT IS NULL OR file_id = $1)
+  AND ($2::BIGINT IS NULL OR version_id = $2)
+  AND ($3::BIGINT IS NULL OR created_by = $3)
+ORDER BY created_at DESC
+LIMIT $5::BIGINT OFFSET $4::BIGINT
+`
+
+type ListShareLinksParams struct {
+	FileId    *int64
+	VersionId *int64
+	CreatedBy *int64
+	Offset    int64
+	Limit     int64
+}
+
+func (q *Queries) ListShareLinks(ctx context.Context, arg *ListShareLinksParams) ([]*ShareLink, error) {
+	rows, err := q.db.Query(ctx, listShareLinks,
+		arg.FileId,
+		arg.VersionId,
+		arg.CreatedBy,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*ShareLink
+	for rows.Next() {
+		var i ShareLink
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.FileID,
+			&i.VersionID,
+			&i.ExpiresAt,
+			&i.MaxDownloads,
+			&i.DownloadCount,
+			&i.PasswordHash,
+			&i.RevokedAt,
+			&i.CreatedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listShortLinks = `-- name: ListShortLinks :many
+SELECT id, created_at, updated_at, code, target_type, project_id, version_id, revoked_at, hit_count, last_hit_at
+FROM short_links
+WHERE ($1::BIGINT IS NULL OR project_id = $1)
+  AND ($2::BIGINT IS NULL OR version_id = $2)
+  AND ($3::BIGINT IS NULL OR EXISTS (SELECT 1
+                                                          FROM project_memberships m
+                                                          WHERE m.project_id = COALESCE(short_links.project_id,
+                                                                                        (SELECT v.project_id
+                                                                                         FROM versions v
+                                                                                         WHERE v.id = short_links.version_id))
+                                                            AND m.user_id = $3))
+ORDER BY created_at DESC
+LIMIT $5::BIGINT OFFSET $4::BIGINT
+`
+
+type ListShortLinksParams struct {
+	ProjectId *int64
+	VersionId *int64
+	MemberId  *int64
+	Offset    int64
+	Limit     int64
+}
+
+func (q *Queries) ListShortLinks(ctx context.Context, arg *ListShortLinksParams) ([]*ShortLink, error) {
+	rows, err := q.db.Query(ctx, listShortLinks,
+		arg.ProjectId,
+		arg.VersionId,
+		arg.MemberId,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*ShortLink
+	for rows.Next() {
+		var i ShortLink
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Code,
+			&i.TargetType,
+			&i.ProjectID,
+			&i.VersionID,
+			&i.RevokedAt,
+			&i.HitCount,
+			&i.LastHitAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUploadSessionChunkPaths = `-- name: ListUploadSessionChunkPaths :many
+SELECT unnest(chunk_paths)::TEXT AS chunk_path
+FROM upload_sessions
+WHERE file_id = $1
+`
+
+func (q *Queries) ListUploadSessionChunkPaths(ctx context.Context, fileID int64) ([]string, error) {
+	rows, err := q.db.Query(ctx, listUploadSessionChunkPaths, fileID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var chunk_path string
+		if err := rows.Scan(&chunk_path); err != nil {
+			return nil, err
+		}
+		items = append(items, chunk_path)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listVersionFiles = `-- name: ListVersionFiles :many
+SELECT files.id,
+       files.name,
+       file_revisions.id AS revision_id,
+       file_revisions.size,
+       file_revisions.mime_type,
+       file_revisions.checksum
+FROM files
+         INNER JOIN versions_files ON versions_files.file_id = files.id
+         LEFT JOIN file_revisions
+                   ON file_revisions.id = COALESCE(versions_files.revision_id, files.current_revision_id)
+WHERE versions_files.version_id = $1
+ORDER BY files.name, files.id
+`
+
+type ListVersionFilesRow struct {
+	ID         int64
+	Name       string
+	RevisionID *int64
+	Size       *int64
+	MimeType   *string
+	Checksum   *string
+}
+
+// Files have the contents of the revision the version pins, or of their
+// current revision.
+func (q *Queries) ListVersionFiles(ctx context.Context, versionID int64) ([]*ListVersionFilesRow, error) {
+	rows, err := q.db.Query(ctx, listVersionFiles, versionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*ListVersionFilesRow
+	for rows.Next() {
+		var i ListVersionFilesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.RevisionID,
+			&i.Size,
+			&i.MimeType,
+			&i.Checksum,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listVersions = `-- name: ListVersions :many
+SELECT id, created_at, updated_at, name, description, project_id, status, released_at
+FROM versions
+WHERE ($1::BIGINT IS NULL OR project_id = $1)
+  AND ($2::TEXT IS NULL OR status = $2)
+  AND ($3::BIGINT IS NULL OR EXISTS (SELECT 1
+                                                          FROM project_memberships m
+                                                          WHERE m.project_id = versions.project_id
+                                                            AND m.user_id = $3))
+ORDER BY created_at DESC
+LIMIT $5::BIGINT OFFSET $4::BIGINT
+`
+
+type ListVersionsParams struct {
+	ProjectId *int64
+	Status    *string
+	MemberId  *int64
+	Offset    int64
+	Limit     int64
+}
+
+func (q *Queries) ListVersions(ctx context.Context, arg *ListVersionsParams) ([]*Version, error) {
+	rows, err := q.db.Query(ctx, listVersions,
+		arg.ProjectId,
+		arg.Status,
+		arg.MemberId,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*Version
+	for rows.Next() {
+		var i Version
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Name,
+			&i.Description,
+			&i.ProjectID,
+			&i.Status,
+			&i.ReleasedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWebhookDeliveries = `-- name: ListWebhookDeliveries :many
+SELECT id, created_at, updated_at, subscription_id, event, event_id, payload, status, attempts, response_status, response_body, last_error, delivered_at, redelivery_of
+FROM webhook_deliveries
+WHERE subscription_id = $1
+ORDER BY id DESC
+LIMIT $3::BIGINT OFFSET $2::BIGINT
+`
+
+type ListWebhookDeliveriesParams struct {
+	SubscriptionId int64
+	Offset         int64
+	Limit          int64
+}
+
+func (q *Queries) ListWebhookDeliveries(ctx context.Context, arg *ListWebhookDeliveriesParams) ([]*WebhookDelivery, error) {
+	rows, err := q.db.Query(ctx, listWebhookDeliveries, arg.SubscriptionId, arg.Offset, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*WebhookDelivery
+	for rows.Next() {
+		var i WebhookDelivery
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.SubscriptionID,
+			&i.Event,
+			&i.EventID,
+			&i.Payload,
+			&i.Status,
+			&i.Attempts,
+			&i.ResponseStatus,
+			&i.ResponseBody,
+			&i.LastError,
+			&i.DeliveredAt,
+			&i.RedeliveryOf,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWebhookSubscriptions = `-- name: ListWebhookSubscriptions :many
+SELECT id, created_at, updated_at, project_id, url, secret, events, is_active, created_by
+FROM webhook_subscriptions
+WHERE ($1::BIGINT IS NULL OR project_id = $1)
+ORDER BY id
+LIMIT $3::BIGINT OFFSET $2::BIGINT
+`
+
+type ListWebhookSubscriptionsParams struct {
+	ProjectId *int64
+	Offset    int64
+	Limit     int64
+}
+
+func (q *Queries) ListWebhookSubscriptions(ctx context.Context, arg *ListWebhookSubscriptionsParams) ([]*WebhookSubscription, error) {
+	rows, err := q.db.Query(ctx, listWebhookSubscriptions, arg.ProjectId, arg.Offset, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*WebhookSubscription
+	for rows.Next() {
+		var i WebhookSubscription
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ProjectID,
+			&i.Url,
+			&i.Secret,
+			&i.Events,
+			&i.IsActive,
+			&i.CreatedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockFile = `-- name: LockFile :exec
+
+SELECT id
+FROM files
+WHERE id = $1
+    FOR UPDATE
+`
+
+// File revisions
+// Holds the file until the transaction ends, so uploads to it number their
+// revisions one after the other.
+func (q *Queries) LockFile(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, lockFile, id)
+	return err
+}
+
+const lockVersion = `-- name: LockVersion :one
+SELECT id,
+       created_at,
+       updated_at,
+       name,
+       description,
+       project_id,
+       status,
+       released_at
+FROM versions
+WHERE id = $1
+    FOR UPDATE
+`
+
+// Holds the version until the transaction ends, so files can't be attached or
+// detached while it is being released.
+func (q *Queries) LockVersion(ctx context.Context, id int64) (*Version, error) {
+	row := q.db.QueryRow(ctx, lockVersion, id)
+	var i Version
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Name,
+		&i.Description,
+		&i.ProjectID,
+		&i.Status,
+		&i.ReleasedAt,
+	)
+	return &i, err
+}
+
+const notifyEvent = `-- name: NotifyEvent :exec
+
+SELECT pg_notify($1::TEXT, $2::TEXT)
+`
+
+type NotifyEventParams struct {
+	Channel string
+	Payload string
+}
+
+// Events
+func (q *Queries) NotifyEvent(ctx context.Context, arg *NotifyEventParams) error {
+	_, err := q.db.Exec(ctx, notifyEvent, arg.Channel, arg.Payload)
+	return err
+}
+
+const pinVersionFiles = `-- name: PinVersionFiles :exec
+UPDATE versions_files
+SET revision_id = files.current_revision_id
+FROM files
+WHERE files.id = versions_files.file_id
+  AND versions_files.version_id = $1
+  AND versions_files.revision_id IS NULL
+`
+
+// Pins the current revision of the files the version doesn't pin yet.
+func (q *Queries) PinVersionFiles(ctx context.Context, versionID int64) error {
+	_, err := q.db.Exec(ctx, pinVersionFiles, versionID)
+	return err
+}
+
+const recordShortLinkHit = `-- name: RecordShortLinkHit :exec
+UPDATE short_links
+SET hit_count   = hit_count + 1,
+    last_hit_at = CURRENT_TIMESTAMP
+WHERE id = $1
+`
+
+func (q *Queries) RecordShortLinkHit(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, recordShortLinkHit, id)
+	return err
+}
+
+const recordWebhookDeliveryAttempt = `-- name: RecordWebhookDeliveryAttempt :one
+UPDATE webhook_deliveries
+SET updated_at      = CURRENT_TIMESTAMP,
+    status          = $1,
+    attempts        = attempts + 1,
+    response_status = $2,
+    response_body   = $3,
+    last_error      = $4,
+    delivered_at    = CASE WHEN $1 = 'succeeded' THEN CURRENT_TIMESTAMP END
+WHERE id = $5
+RETURNING id, created_at, updated_at, subscription_id, event, event_id, payload, status, attempts, response_status, response_body, last_error, delivered_at, redelivery_of
+`
+
+type RecordWebhookDeliveryAttemptParams struct {
+	Status         string
+	ResponseStatus *int32
+	ResponseBody   *string
+	LastError      *string
+	ID             int64
+}
+
+func (q *Queries) RecordWebhookDeliveryAttempt(ctx context.Context, arg *RecordWebhookDeliveryAttemptParams) (*WebhookDelivery, error) {
+	row := q.db.QueryRow(ctx, recordWebhookDeliveryAttempt,
+		arg.Status,
+		arg.ResponseStatus,
+		arg.ResponseBody,
+		arg.LastError,
+		arg.ID,
+	)
+	var i WebhookDelivery
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SubscriptionID,
+		&i.Event,
+		&i.EventID,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.ResponseStatus,
+		&i.ResponseBody,
+		&i.LastError,
+		&i.DeliveredAt,
+		&i.RedeliveryOf,
+	)
+	return &i, err
+}
+
+const releaseStaleJobs = `-- name: ReleaseStaleJobs :execrows
+UPDATE jobs
+SET updated_at = CURRENT_TIMESTAMP,
+    status     = CASE WHEN attempts < max_attempts THEN 'pending' ELSE 'failed' END,
+    locked_at  = NULL,
+    last_error = 'worker stopped while running the job'
+WHERE status = 'running'
+  AND locked_at <= CURRENT_TIMESTAMP - $1::INTERVAL
+`
+
+// Puts jobs back whose worker stopped without reporting back, the attempt
+// still counts, so jobs without attempts left fail instead.
+func (q *Queries) ReleaseStaleJobs(ctx context.Context, timeout pgtype.Interval) (int64, error) {
+	result, err := q.db.Exec(ctx, releaseStaleJobs, timeout)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const rescheduleJob = `-- name: RescheduleJob :exec
+UPDATE jobs
+SET updated_at = CURRENT_TIMESTAMP,
+    status     = 'pending',
+    locked_at  = NULL,
+    last_error = $1,
+    run_at     = CURRENT_TIMESTAMP + $2::INTERVAL
+WHERE id = $3
+`
+
+type RescheduleJobParams struct {
+	LastError *string
+	Delay     pgtype.Interval
+	ID        int64
+}
+
+func (q *Queries) RescheduleJob(ctx context.Context, arg *RescheduleJobParams) error {
+	_, err := q.db.Exec(ctx, rescheduleJob, arg.LastError, arg.Delay, arg.ID)
+	return err
+}
+
+const retryJob = `-- name: RetryJob :one
+UPDATE jobs
+SET updated_at = CURRENT_TIMESTAMP,
+    status     = 'pending',
+    attempts   = 0,
+    run_at     = CURRENT_TIMESTAMP
+WHERE id = $1
+  AND status = 'failed'
+RETURNING id, created_at, updated_at, kind, payload, status, attempts, max_attempts, run_at, locked_at, last_error, completed_at, unique_key
+`
+
+// Gives a dead letter a fresh set of attempts.
+func (q *Queries) RetryJob(ctx context.Context, id int64) (*Job, error) {
+	row := q.db.QueryRow(ctx, retryJob, id)
+	var i Job
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Kind,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.MaxAttempts,
+		&i.RunAt,
+		&i.LockedAt,
+		&i.LastError,
+		&i.CompletedAt,
+		&i.UniqueKey,
+	)
+	return &i, err
+}
+
+const revokeShareLink = `-- name: RevokeShareLink :one
+UPDATE share_links
+SET updated_at = CURRENT_TIMESTAMP,
+    revoked_at = COALESCE(revoked_at, CURRENT_TIMESTAMP)
+WHERE id = $1
+RETURNING id, created_at, updated_at, file_id, version_id, expires_at, max_downloads, download_count, password_hash, revoked_at, created_by
+`
+
+func (q *Queries) RevokeShareLink(ctx context.Context, id int64) (*ShareLink, error) {
+	row := q.db.QueryRow(ctx, revokeShareLink, id)
+	var i ShareLink
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.FileID,
+		&i.VersionID,
+		&i.ExpiresAt,
+		&i.MaxDownloads,
+		&i.DownloadCount,
+		&i.PasswordHash,
+		&i.RevokedAt,
+		&i.CreatedBy,
+	)
+	return &i, err
+}
+
+const revokeShortLink = `-- name: RevokeShortLink :one
+UPDATE short_links
+SET updated_at = CURRENT_TIMESTAMP,
+    revoked_at = COALESCE(revoked_at, CURRENT_TIMESTAMP)
+WHERE id = $1
+RETURNING id, created_at, updated_at, code, target_type, project_id, version_id, revoked_at, hit_count, last_hit_at
+`
+
+func (q *Queries) RevokeShortLink(ctx context.Context, id int64) (*ShortLink, error) {
+	row := q.db.QueryRow(ctx, revokeShortLink, id)
+	var i ShortLink
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Code,
+		&i.TargetType,
+		&i.ProjectID,
+		&i.VersionID,
+		&i.RevokedAt,
+		&i.HitCount,
+		&i.LastHitAt,
+	)
+	return &i, err
+}
+
+const search = `-- name: Search :many
+
+SELECT d.entity_type                                                                                     AS type,
+       d.entity_id                                                                                       AS id,
+       d.project_id,
+       d.title,
+       ts_headline('simple', concat_ws(' ', d.title, nullif(d.body, '')), q, 'HighlightAll=true')::TEXT AS highlight,
+       ts_rank(d.search_vector, q)::REAL                                                                 AS rank
+FROM search_documents d,
+     websearch_to_tsquery('simple', $1) q
+WHERE d.search_vector @@ q
+  AND ($2::TEXT[] IS NULL OR d.entity_type = ANY ($2::TEXT[]))
+  AND ($3::BIGINT IS NULL
+    OR (d.entity_type <> 'file' AND EXISTS (SELECT 1
+                                            FROM project_memberships m
+                                            WHERE m.project_id = d.project_id
+                                              AND m.user_id = $3))
+    OR (d.entity_type = 'file' AND EXISTS (SELECT 1
+                                           FROM files f
+                                           WHERE f.id = d.entity_id
+                                             AND f.created_by = $3))
+    OR (d.entity_type = 'file' AND EXISTS (SELECT 1
+                                           FROM versions_files vf
+                                                    INNER JOIN versions v ON v.id = vf.version_id
+                                                    INNER JOIN project_memberships m ON m.project_id = v.project_id
+                                           WHERE vf.file_id = d.entity_id
+                                             AND m.user_id = $3)))
+ORDER BY rank DESC, d.entity_type, d.entity_id
+LIMIT $5::BIGINT OFFSET $4::BIGINT
 `
 
-type ListFilesParams struct {
-	VersionId *int64
-	Offset    int64
-	Limit     int64
+type SearchParams struct {
+	Query    string
+	Types    []string
+	MemberId *int64
+	Offset   int64
+	Limit    int64
 }
 
-func (q *Queries) ListFiles(ctx context.Context, arg *ListFilesParams) ([]*File, error) {
-	rows, err := q.db.Query(ctx, listFiles, arg.VersionId, arg.Offset, arg.Limit)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []*File
-	for rows.Next() {
-		var i File
-		if err := rows.Scan(
-			&i.ID,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-			&i.Name,
-			&i.Size,
-			&i.Path,
-			&i.MimeType,
-			&i.IsComplete,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, &i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+type SearchRow struct {
+	Type      string
+	ID        int64
+	ProjectID *int64
+	Title     string
+	Highlight string
+	Rank      float32
 }
 
-const listLocations = `-- name: ListLocations :many
-
-SELECT id,
-       created_at,
-       updated_at,
-       name,
-       address,
-       lat,
-       lon
-FROM locations
-ORDER BY created_at DESC
-`
-
-// Locations
-func (q *Queries) ListLocations(ctx context.Context) ([]*Location, error) {
-	rows, err := q.db.Query(ctx, listLocations)
+// Search
+// Hits are ranked across types, the visibility rules match the ones of the
+// list queries of each type.
+func (q *Queries) Search(ctx context.Context, arg *SearchParams) ([]*SearchRow, error) {
+	rows, err := q.db.Query(ctx, search,
+		arg.Query,
+		arg.Types,
+		arg.MemberId,
+		arg.Offset,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []*Location
+	var items []*SearchRow
 	for rows.Next() {
-		var i Location
+		var i SearchRow
 		if err := rows.Scan(
+			&i.Type,
 			&i.ID,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-			&i.Name,
-			&i.Address,
-			&i.Lat,
-			&i.Lon,
+			&i.ProjectID,
+			&i.Title,
+			&i.Highlight,
+			&i.Rank,
 		); err != nil {
 			return nil, err
 		}
@@ -466,39 +3244,60 @@ func (q *Queries) ListLocations(ctx context.Context) ([]*Location, error) {
 	return items, nil
 }
 
-const listProjects = `-- name: ListProjects :many
-SELECT id,
-       created_at,
-       updated_at,
-       slug,
-       name,
-       location_id
-FROM projects
-ORDER BY created_at DESC
-LIMIT $2::BIGINT OFFSET $1::BIGINT
+const searchFileContents = `-- name: SearchFileContents :many
+SELECT f.id,
+       f.name,
+       ts_headline('simple', c.content, q, 'MaxFragments=2, MinWords=5, MaxWords=20')::TEXT AS snippet,
+       ts_rank(c.search_vector, q)::REAL                                                  AS rank
+FROM file_contents c
+         INNER JOIN files f ON f.id = c.file_id,
+     websearch_to_tsquery('simple', $1) q
+WHERE c.search_vector @@ q
+  AND ($2::BIGINT IS NULL OR f.created_by = $2 OR EXISTS (SELECT 1
+                                                                                               FROM versions_files vf
+                                                                                                        INNER JOIN versions v ON v.id = vf.version_id
+                                                                                                        INNER JOIN project_memberships m ON m.project_id = v.project_id
+                                                                                               WHERE vf.file_id = f.id
+                                                                                                 AND m.user_id = $2))
+ORDER BY rank DESC, f.id
+LIMIT $4::BIGINT OFFSET $3::BIGINT
 `
 
-type ListProjectsParams struct {
-	Offset int64
-	Limit  int64
+type SearchFileContentsParams struct {
+	Query    string
+	MemberId *int64
+	Offset   int64
+	Limit    int64
+}
+
+type SearchFileContentsRow struct {
+	ID      int64
+	Name    string
+	Snippet string
+	Rank    float32
 }
 
-func (q *Queries) ListProjects(ctx context.Context, arg *ListProjectsParams) ([]*Project, error) {
-	rows, err := q.db.Query(ctx, listProjects, arg.Offset, arg.Limit)
+// Searches the extracted text of files, the visibility rules match the ones
+// of ListFiles.
+func (q *Queries) SearchFileContents(ctx context.Context, arg *SearchFileContentsParams) ([]*SearchFileContentsRow, error) {
+	rows, err := q.db.Query(ctx, searchFileContents,
+		arg.Query,
+		arg.MemberId,
+		arg.Offset,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []*Project
+	var items []*SearchFileContentsRow
 	for rows.Next() {
-		var i Project
+		var i SearchFileContentsRow
 		if err := rows.Scan(
 			&i.ID,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-			&i.Slug,
 			&i.Name,
-			&i.LocationID,
+			&i.Snippet,
+			&i.Rank,
 		); err != nil {
 			return nil, err
 		}
@@ -510,45 +3309,55 @@ func (q *Queries) ListProjects(ctx context.Context, arg *ListProjectsParams) ([]
 	return items, nil
 }
 
-const listVersions = `-- name: ListVersions :many
-SELECT id, created_at, updated_at, name, description, project_id
-FROM versions
-WHERE ($1::BIGINT IS NULL OR project_id = $1)
-ORDER BY created_at DESC
-LIMIT $3::BIGINT OFFSET $2::BIGINT
+const setCurrentFileRevision = `-- name: SetCurrentFileRevision :one
+UPDATE files
+SET updated_at          = CURRENT_TIMESTAMP,
+    current_revision_id = file_revisions.id,
+    size                = file_revisions.size,
+    path                = file_revisions.path,
+    mime_type           = file_revisions.mime_type,
+    is_complete         = TRUE,
+    checksum            = file_revisions.checksum
+FROM file_revisions
+WHERE files.id = file_revisions.file_id
+  AND file_revisions.id = $1
+RETURNING files.id, files.created_at, files.updated_at, files.name, files.size, files.path, files.mime_type, files.is_complete, files.checksum, files.created_by, files.current_revision_id
 `
 
-type ListVersionsParams struct {
-	ProjectId *int64
-	Offset    int64
-	Limit     int64
+// Makes the revision current and copies its contents to the file.
+func (q *Queries) SetCurrentFileRevision(ctx context.Context, id int64) (*File, error) {
+	row := q.db.QueryRow(ctx, setCurrentFileRevision, id)
+	var i File
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Name,
+		&i.Size,
+		&i.Path,
+		&i.MimeType,
+		&i.IsComplete,
+		&i.Checksum,
+		&i.CreatedBy,
+		&i.CurrentRevisionID,
+	)
+	return &i, err
 }
 
-func (q *Queries) ListVersions(ctx context.Context, arg *ListVersionsParams) ([]*Version, error) {
-	rows, err := q.db.Query(ctx, listVersions, arg.ProjectId, arg.Offset, arg.Limit)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []*Version
-	for rows.Next() {
-		var i Version
-		if err := rows.Scan(
-			&i.ID,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-			&i.Name,
-			&i.Description,
-			&i.ProjectID,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, &i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+const setFileRevisionChecksum = `-- name: SetFileRevisionChecksum :exec
+UPDATE file_revisions
+SET checksum = $2
+WHERE id = $1
+`
+
+type SetFileRevisionChecksumParams struct {
+	ID       int64
+	Checksum *string
+}
+
+func (q *Queries) SetFileRevisionChecksum(ctx context.Context, arg *SetFileRevisionChecksumParams) error {
+	_, err := q.db.Exec(ctx, setFileRevisionChecksum, arg.ID, arg.Checksum)
+	return err
 }
 
 const updateFile = `-- name: UpdateFile :one
@@ -558,9 +3367,10 @@ SET updated_at  = current_timestamp,
     size        = $3,
     path        = $4,
     mime_type   = $5,
-    is_complete = $6
+    is_complete = $6,
+    checksum    = $7
 WHERE id = $1
-RETURNING id, created_at, updated_at, name, size, path, mime_type, is_complete
+RETURNING id, created_at, updated_at, name, size, path, mime_type, is_complete, checksum, created_by, current_revision_id
 `
 
 type UpdateFileParams struct {
@@ -570,6 +3380,7 @@ type UpdateFileParams struct {
 	Path       *string
 	MimeType   *string
 	IsComplete bool
+	Checksum   *string
 }
 
 func (q *Queries) UpdateFile(ctx context.Context, arg *UpdateFileParams) (*File, error) {
@@ -580,6 +3391,7 @@ func (q *Queries) UpdateFile(ctx context.Context, arg *UpdateFileParams) (*File,
 		arg.Path,
 		arg.MimeType,
 		arg.IsComplete,
+		arg.Checksum,
 	)
 	var i File
 	err := row.Scan(
@@ -591,6 +3403,104 @@ func (q *Queries) UpdateFile(ctx context.Context, arg *UpdateFileParams) (*File,
 		&i.Path,
 		&i.MimeType,
 		&i.IsComplete,
+		&i.Checksum,
+		&i.CreatedBy,
+		&i.CurrentRevisionID,
+	)
+	return &i, err
+}
+
+const updateFileContent = `-- name: UpdateFileContent :exec
+UPDATE file_contents
+SET updated_at   = CURRENT_TIMESTAMP,
+    status       = $1,
+    content      = $2,
+    error        = $3,
+    extracted_at = CURRENT_TIMESTAMP
+WHERE file_id = $4
+`
+
+type UpdateFileContentParams struct {
+	Status  string
+	Content string
+	Error   *string
+	FileId  int64
+}
+
+func (q *Queries) UpdateFileContent(ctx context.Context, arg *UpdateFileContentParams) error {
+	_, err := q.db.Exec(ctx, updateFileContent,
+		arg.Status,
+		arg.Content,
+		arg.Error,
+		arg.FileId,
+	)
+	return err
+}
+
+const updateLatestVersionPolicy = `-- name: UpdateLatestVersionPolicy :one
+UPDATE projects
+SET updated_at              = CURRENT_TIMESTAMP,
+    latest_version_strategy = $2,
+    pinned_version_id       = $3
+WHERE id = $1
+RETURNING latest_version_strategy, pinned_version_id
+`
+
+type UpdateLatestVersionPolicyParams struct {
+	ID                    int64
+	LatestVersionStrategy string
+	PinnedVersionID       *int64
+}
+
+type UpdateLatestVersionPolicyRow struct {
+	LatestVersionStrategy string
+	PinnedVersionID       *int64
+}
+
+func (q *Queries) UpdateLatestVersionPolicy(ctx context.Context, arg *UpdateLatestVersionPolicyParams) (*UpdateLatestVersionPolicyRow, error) {
+	row := q.db.QueryRow(ctx, updateLatestVersionPolicy, arg.ID, arg.LatestVersionStrategy, arg.PinnedVersionID)
+	var i UpdateLatestVersionPolicyRow
+	err := row.Scan(&i.LatestVersionStrategy, &i.PinnedVersionID)
+	return &i, err
+}
+
+const updateLocation = `-- name: UpdateLocation :one
+UPDATE locations
+SET updated_at = CURRENT_TIMESTAMP,
+    name       = $2,
+    address    = $3,
+    lat        = $4,
+    lon        = $5
+WHERE id = $1
+RETURNING id, created_at, updated_at, name, address, lat, lon, created_by
+`
+
+type UpdateLocationParams struct {
+	ID      int64
+	Name    string
+	Address *string
+	Lat     *float64
+	Lon     *float64
+}
+
+func (q *Queries) UpdateLocation(ctx context.Context, arg *UpdateLocationParams) (*Location, error) {
+	row := q.db.QueryRow(ctx, updateLocation,
+		arg.ID,
+		arg.Name,
+		arg.Address,
+		arg.Lat,
+		arg.Lon,
+	)
+	var i Location
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Name,
+		&i.Address,
+		&i.Lat,
+		&i.Lon,
+		&i.CreatedBy,
 	)
 	return &i, err
 }
@@ -602,14 +3512,14 @@ SET updated_at  = CURRENT_TIMESTAMP,
     name        = $3,
     location_id = $4
 WHERE id = $1
-RETURNING id, created_at, updated_at, slug, name, location_id
+RETURNING id, created_at, updated_at, slug, name, location_id, latest_version_strategy, pinned_version_id
 `
 
 type UpdateProjectParams struct {
 	ID         int64
 	Slug       string
 	Name       string
-	LocationID *int32
+	LocationID *int64
 }
 
 func (q *Queries) UpdateProject(ctx context.Context, arg *UpdateProjectParams) (*Project, error) {
@@ -627,6 +3537,76 @@ func (q *Queries) UpdateProject(ctx context.Context, arg *UpdateProjectParams) (
 		&i.Slug,
 		&i.Name,
 		&i.LocationID,
+		&i.LatestVersionStrategy,
+		&i.PinnedVersionID,
+	)
+	return &i, err
+}
+
+const updateProjectMembership = `-- name: UpdateProjectMembership :one
+UPDATE project_memberships
+SET updated_at = CURRENT_TIMESTAMP,
+    role       = $3
+WHERE project_id = $1
+  AND user_id = $2
+RETURNING project_id, user_id, created_at, updated_at, role
+`
+
+type UpdateProjectMembershipParams struct {
+	ProjectID int64
+	UserID    int64
+	Role      string
+}
+
+func (q *Queries) UpdateProjectMembership(ctx context.Context, arg *UpdateProjectMembershipParams) (*ProjectMembership, error) {
+	row := q.db.QueryRow(ctx, updateProjectMembership, arg.ProjectID, arg.UserID, arg.Role)
+	var i ProjectMembership
+	err := row.Scan(
+		&i.ProjectID,
+		&i.UserID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Role,
+	)
+	return &i, err
+}
+
+const updateShortLinkTarget = `-- name: UpdateShortLinkTarget :one
+UPDATE short_links
+SET updated_at  = CURRENT_TIMESTAMP,
+    target_type = $2,
+    project_id  = $3,
+    version_id  = $4
+WHERE id = $1
+RETURNING id, created_at, updated_at, code, target_type, project_id, version_id, revoked_at, hit_count, last_hit_at
+`
+
+type UpdateShortLinkTargetParams struct {
+	ID         int64
+	TargetType string
+	ProjectID  *int64
+	VersionID  *int64
+}
+
+func (q *Queries) UpdateShortLinkTarget(ctx context.Context, arg *UpdateShortLinkTargetParams) (*ShortLink, error) {
+	row := q.db.QueryRow(ctx, updateShortLinkTarget,
+		arg.ID,
+		arg.TargetType,
+		arg.ProjectID,
+		arg.VersionID,
+	)
+	var i ShortLink
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Code,
+		&i.TargetType,
+		&i.ProjectID,
+		&i.VersionID,
+		&i.RevokedAt,
+		&i.HitCount,
+		&i.LastHitAt,
 	)
 	return &i, err
 }
@@ -637,7 +3617,7 @@ SET updated_at  = CURRENT_TIMESTAMP,
     name        = $2,
     description = $3
 WHERE id = $1
-RETURNING id, created_at, updated_at, name, description, project_id
+RETURNING id, created_at, updated_at, name, description, project_id, status, released_at
 `
 
 type UpdateVersionParams struct {
@@ -656,6 +3636,139 @@ func (q *Queries) UpdateVersion(ctx context.Context, arg *UpdateVersionParams) (
 		&i.Name,
 		&i.Description,
 		&i.ProjectID,
+		&i.Status,
+		&i.ReleasedAt,
+	)
+	return &i, err
+}
+
+const updateVersionStatus = `-- name: UpdateVersionStatus :one
+UPDATE versions
+SET updated_at  = CURRENT_TIMESTAMP,
+    status      = $1,
+    released_at = CASE
+                      WHEN $1 = 'released' THEN COALESCE(released_at, CURRENT_TIMESTAMP)
+                      ELSE released_at END
+WHERE id = $2
+  AND status = $3
+RETURNING id, created_at, updated_at, name, description, project_id, status, released_at
+`
+
+type UpdateVersionStatusParams struct {
+	Status         string
+	ID             int64
+	ExpectedStatus string
+}
+
+func (q *Queries) UpdateVersionStatus(ctx context.Context, arg *UpdateVersionStatusParams) (*Version, error) {
+	row := q.db.QueryRow(ctx, updateVersionStatus, arg.Status, arg.ID, arg.ExpectedStatus)
+	var i Version
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Name,
+		&i.Description,
+		&i.ProjectID,
+		&i.Status,
+		&i.ReleasedAt,
+	)
+	return &i, err
+}
+
+const updateWebhookSubscription = `-- name: UpdateWebhookSubscription :one
+UPDATE webhook_subscriptions
+SET updated_at = CURRENT_TIMESTAMP,
+    url        = $1,
+    events     = $2::TEXT[],
+    is_active  = $3
+WHERE id = $4
+RETURNING id, created_at, updated_at, project_id, url, secret, events, is_active, created_by
+`
+
+type UpdateWebhookSubscriptionParams struct {
+	Url      string
+	Events   []string
+	IsActive bool
+	ID       int64
+}
+
+func (q *Queries) UpdateWebhookSubscription(ctx context.Context, arg *UpdateWebhookSubscriptionParams) (*WebhookSubscription, error) {
+	row := q.db.QueryRow(ctx, updateWebhookSubscription,
+		arg.Url,
+		arg.Events,
+		arg.IsActive,
+		arg.ID,
+	)
+	var i WebhookSubscription
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ProjectID,
+		&i.Url,
+		&i.Secret,
+		&i.Events,
+		&i.IsActive,
+		&i.CreatedBy,
+	)
+	return &i, err
+}
+
+const upsertPendingFileContent = `-- name: UpsertPendingFileContent :exec
+
+INSERT INTO file_contents (file_id)
+VALUES ($1)
+ON CONFLICT (file_id) DO UPDATE SET updated_at   = CURRENT_TIMESTAMP,
+                                    status       = 'pending',
+                                    content      = '',
+                                    error        = NULL,
+                                    extracted_at = NULL
+`
+
+// File contents
+func (q *Queries) UpsertPendingFileContent(ctx context.Context, fileID int64) error {
+	_, err := q.db.Exec(ctx, upsertPendingFileContent, fileID)
+	return err
+}
+
+const upsertUserByKeycloakReference = `-- name: UpsertUserByKeycloakReference :one
+INSERT INTO users (name, email, email_verified, keycloak_reference)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (keycloak_reference) DO UPDATE
+    SET updated_at     = CURRENT_TIMESTAMP,
+        name           = EXCLUDED.name,
+        email          = EXCLUDED.email,
+        email_verified = EXCLUDED.email_verified
+WHERE (users.name, users.email, users.email_verified) IS DISTINCT FROM
+      (EXCLUDED.name, EXCLUDED.email, EXCLUDED.email_verified)
+RETURNING id, created_at, updated_at, name, email, email_verified, keycloak_reference
+`
+
+type UpsertUserByKeycloakReferenceParams struct {
+	Name              string
+	Email             *string
+	EmailVerified     bool
+	KeycloakReference *string
+}
+
+// Returns no row when the stored claims are already up to date.
+func (q *Queries) UpsertUserByKeycloakReference(ctx context.Context, arg *UpsertUserByKeycloakReferenceParams) (*User, error) {
+	row := q.db.QueryRow(ctx, upsertUserByKeycloakReference,
+		arg.Name,
+		arg.Email,
+		arg.EmailVerified,
+		arg.KeycloakReference,
+	)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Name,
+		&i.Email,
+		&i.EmailVerified,
+		&i.KeycloakReference,
 	)
 	return &i, err
 }
@@ -0,0 +1,133 @@
+package events
+
+import (
+	"app/pkg/api"
+	"app/pkg/platform/auth"
+	"app/pkg/platform/handler"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler struct {
+	service   Service
+	heartbeat time.Duration
+}
+
+// NewHandler returns a handler that sends a heartbeat once per interval
+// while a stream is quiet, so proxies don't close it as idle.
+func NewHandler(service Service, heartbeat time.Duration) *Handler {
+	return &Handler{service: service, heartbeat: heartbeat}
+}
+
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Get("/v1/events", h.Stream)
+}
+
+func (h *Handler) Stream(w http.ResponseWriter, r *http.Request) {
+	subscription, replay, err := h.service.Subscribe(r.Context(), r.Header.Get("Last-Event-ID"))
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrHubClosed) {
+		handler.WriteError(w, http.StatusServiceUnavailable, "server is shutting down")
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+	defer subscription.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	// Keeps reverse proxies such as nginx from buffering the stream.
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	controller := http.NewResponseController(w)
+	if err := controller.Flush(); err != nil {
+		log.Printf("failed to flush event stream: %v", err)
+		return
+	}
+
+	// The empty ID makes the client forget the unknown one, it doesn't
+	// reconnect with it again.
+	if subscription.Reset {
+		if _, err := fmt.Fprint(w, "id:\nevent: reset\ndata: {}\n\n"); err != nil {
+			return
+		}
+	}
+	for _, message := range replay {
+		if err := h.write(w, r, message); err != nil {
+			return
+		}
+	}
+	if err := controller.Flush(); err != nil {
+		return
+	}
+
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		case message, ok := <-subscription.C:
+			if !ok {
+				return
+			}
+			if err := h.write(w, r, message); err != nil {
+				return
+			}
+		}
+		if err := controller.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// write sends the message when the caller may see it. An error means the
+// stream has to end.
+func (h *Handler) write(w http.ResponseWriter, r *http.Request, message Message) error {
+	visible, err := h.service.Visible(r.Context(), message)
+	if err != nil {
+		log.Printf("error checking visibility of event %s: %v", message.ID, err)
+		return err
+	}
+	if !visible {
+		return nil
+	}
+
+	data, err := json.Marshal(toEventResponse(message))
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", message.ID, message.Event, data)
+	return err
+}
+
+func toEventResponse(m Message) api.EventResponse {
+	var data any
+	if m.Data != nil {
+		data = m.Data
+	}
+	return api.EventResponse{
+		Id:         m.ID,
+		Event:      api.WebhookEvent(m.Event),
+		OccurredAt: m.OccurredAt,
+		ProjectIds: m.ProjectIDs,
+		Data:       data,
+	}
+}
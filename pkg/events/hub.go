@@ -0,0 +1,207 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	// channel is the Postgres notification channel the instances share.
+	channel = "docport_events"
+	// subscriberBuffer is how many messages a stream may fall behind before
+	// it's dropped.
+	subscriberBuffer = 64
+
+	reconnectMin = time.Second
+	reconnectMax = 30 * time.Second
+)
+
+var ErrHubClosed = errors.New("event hub closed")
+
+// Hub fans the messages published on any instance out to the streams of this
+// one. It listens on a connection of its own and keeps the latest messages
+// for replay. Messages published while the connection is down are missed.
+type Hub struct {
+	pool *pgxpool.Pool
+	size int
+
+	mu          sync.Mutex
+	buffer      []Message
+	subscribers map[*Subscription]struct{}
+	closed      bool
+
+	stop context.CancelFunc
+	wg   sync.WaitGroup
+}
+
+// Subscription receives the messages published after it was opened. C is
+// closed when the subscription falls too far behind, is closed or the hub
+// shuts down.
+type Subscription struct {
+	C <-chan Message
+	// Reset is set when the last event ID isn't buffered, e.g. as it was
+	// received from another instance or has been pushed out. Messages may
+	// have been missed, the client has to refetch instead of replaying.
+	Reset bool
+	c     chan Message
+	hub   *Hub
+}
+
+// NewHub returns a hub that keeps the last size messages for replay.
+func NewHub(pool *pgxpool.Pool, size int) *Hub {
+	return &Hub{
+		pool:        pool,
+		size:        size,
+		subscribers: make(map[*Subscription]struct{}),
+	}
+}
+
+// Start listens for messages until Close.
+func (h *Hub) Start() {
+	ctx, stop := context.WithCancel(context.Background())
+	h.stop = stop
+
+	h.wg.Go(func() {
+		h.listen(ctx)
+	})
+}
+
+// Close stops listening and closes every subscription, which ends the
+// streams so the HTTP server can shut down.
+func (h *Hub) Close() {
+	h.stop()
+	h.wg.Wait()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for subscription := range h.subscribers {
+		delete(h.subscribers, subscription)
+		close(subscription.c)
+	}
+}
+
+// Subscribe opens a subscription and returns the buffered messages after the
+// one with lastEventId. An empty ID replays nothing, so does one the buffer
+// doesn't hold, which resets the subscription instead.
+func (h *Hub) Subscribe(lastEventId string) (*Subscription, []Message, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, nil, ErrHubClosed
+	}
+
+	c := make(chan Message, subscriberBuffer)
+	subscription := &Subscription{C: c, c: c, hub: h}
+
+	var replay []Message
+	if lastEventId != "" {
+		i := slices.IndexFunc(h.buffer, func(message Message) bool {
+			return message.ID == lastEventId
+		})
+		if i < 0 {
+			subscription.Reset = true
+		} else {
+			replay = slices.Clone(h.buffer[i+1:])
+		}
+	}
+
+	h.subscribers[subscription] = struct{}{}
+
+	return subscription, replay, nil
+}
+
+// Close ends the subscription, it may be called more than once.
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+
+	if _, ok := s.hub.subscribers[s]; ok {
+		delete(s.hub.subscribers, s)
+		close(s.c)
+	}
+}
+
+// listen receives messages and reconnects with backoff when the connection
+// is lost.
+func (h *Hub) listen(ctx context.Context) {
+	delay := reconnectMin
+	for {
+		err := h.receive(ctx, func() {
+			delay = reconnectMin
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("error listening for events, reconnecting in %s: %v", delay, err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, reconnectMax)
+	}
+}
+
+// receive dispatches the messages of one connection until it fails. The
+// connection is taken out of the pool, it keeps listening otherwise.
+func (h *Hub) receive(ctx context.Context, listening func()) error {
+	pooled, err := h.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+		return err
+	}
+	listening()
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var message Message
+		if err := json.Unmarshal([]byte(notification.Payload), &message); err != nil {
+			log.Printf("error decoding event: %v", err)
+			continue
+		}
+		h.dispatch(message)
+	}
+}
+
+// dispatch buffers the message and hands it to the subscriptions. One that
+// can't take it is closed rather than holding up the others, its client
+// reconnects and catches up from the buffer.
+func (h *Hub) dispatch(message Message) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.buffer) == h.size {
+		h.buffer = h.buffer[1:]
+	}
+	h.buffer = append(h.buffer, message)
+
+	for subscription := range h.subscribers {
+		select {
+		case subscription.c <- message:
+		default:
+			delete(h.subscribers, subscription)
+			close(subscription.c)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package events
+
+import (
+	"app/pkg/webhook"
+	"encoding/json"
+	"time"
+)
+
+// Notification is a change the services publish, the same events webhooks
+// receive.
+type Notification struct {
+	Event      webhook.Event
+	ProjectIDs []int64
+	// OwnerID is the user who sees the event without being a member of one
+	// of the projects, the creator of a file.
+	OwnerID *int64
+	Data    any
+}
+
+// Message is a published notification as it's passed between instances and
+// kept in the replay buffer.
+type Message struct {
+	ID         string          `json:"id"`
+	Event      webhook.Event   `json:"event"`
+	OccurredAt time.Time       `json:"occurredAt"`
+	ProjectIDs []int64         `json:"projectIds"`
+	OwnerID    *int64          `json:"ownerId,omitempty"`
+	Data       json.RawMessage `json:"data,omitempty"`
+}
@@ -0,0 +1,28 @@
+package events
+
+import (
+	"app/pkg/database"
+	"context"
+)
+
+type Repository interface {
+	// Notify sends the payload to the listeners of the channel on every
+	// instance. Inside a transaction it's only sent once the transaction
+	// commits.
+	Notify(ctx context.Context, channel, payload string) error
+}
+
+type repository struct {
+	queries *database.Queries
+}
+
+func NewRepository(queries *database.Queries) Repository {
+	return &repository{queries: queries}
+}
+
+func (r *repository) Notify(ctx context.Context, channel, payload string) error {
+	return r.queries.NotifyEvent(ctx, &database.NotifyEventParams{
+		Channel: channel,
+		Payload: payload,
+	})
+}
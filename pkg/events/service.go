@@ -0,0 +1,106 @@
+package events
+
+import (
+	"app/pkg/membership"
+	"app/pkg/platform/auth"
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// maxPayloadSize keeps messages below the 8000 byte limit of Postgres
+// notifications.
+const maxPayloadSize = 7900
+
+type Service interface {
+	// Publish sends the notification to the streams of every instance. Inside
+	// a transaction it's only sent once the transaction commits.
+	Publish(ctx context.Context, notification Notification) error
+	// Subscribe opens a stream for the caller and returns the buffered
+	// messages after lastEventId for replay.
+	Subscribe(ctx context.Context, lastEventId string) (*Subscription, []Message, error)
+	// Visible reports whether the caller may see the message: instance
+	// admins see every message, members the ones of their projects and
+	// owners their own.
+	Visible(ctx context.Context, message Message) (bool, error)
+}
+
+type service struct {
+	repository        Repository
+	hub               *Hub
+	membershipService membership.Service
+}
+
+func NewService(repository Repository, hub *Hub, membershipService membership.Service) Service {
+	return &service{
+		repository:        repository,
+		hub:               hub,
+		membershipService: membershipService,
+	}
+}
+
+func (s *service) Publish(ctx context.Context, notification Notification) error {
+	data, err := json.Marshal(notification.Data)
+	if err != nil {
+		return err
+	}
+
+	projectIds := notification.ProjectIDs
+	if projectIds == nil {
+		projectIds = []int64{}
+	}
+
+	message := Message{
+		ID:         uuid.NewString(),
+		Event:      notification.Event,
+		OccurredAt: time.Now().UTC(),
+		ProjectIDs: projectIds,
+		OwnerID:    notification.OwnerID,
+		Data:       data,
+	}
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	// The data is left out of messages that don't fit, clients fetch the
+	// resource instead.
+	if len(payload) > maxPayloadSize {
+		message.Data = nil
+		if payload, err = json.Marshal(message); err != nil {
+			return err
+		}
+	}
+
+	return s.repository.Notify(ctx, channel, string(payload))
+}
+
+func (s *service) Subscribe(ctx context.Context, lastEventId string) (*Subscription, []Message, error) {
+	if _, ok := auth.GetPrincipal(ctx); !ok {
+		return nil, nil, auth.ErrForbidden
+	}
+	return s.hub.Subscribe(lastEventId)
+}
+
+func (s *service) Visible(ctx context.Context, message Message) (bool, error) {
+	principal, ok := auth.GetPrincipal(ctx)
+	if !ok {
+		return false, nil
+	}
+	if principal.IsAdmin || (message.OwnerID != nil && *message.OwnerID == principal.UserID) {
+		return true, nil
+	}
+
+	for _, projectId := range message.ProjectIDs {
+		err := s.membershipService.Authorize(ctx, projectId, membership.RoleViewer)
+		if err == nil {
+			return true, nil
+		}
+		if !errors.Is(err, auth.ErrForbidden) {
+			return false, err
+		}
+	}
+	return false, nil
+}
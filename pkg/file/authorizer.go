@@ -0,0 +1,68 @@
+package file
+
+import (
+	"app/pkg/membership"
+	"app/pkg/platform/auth"
+	"app/pkg/version"
+	"context"
+	"errors"
+)
+
+// Authorizer checks the caller's access to files. Versions rely on it when
+// files are attached, they can't depend on the file service.
+type Authorizer interface {
+	// Authorized loads the file and checks that the caller created it or
+	// holds the role on one of the projects whose versions include the file.
+	Authorized(ctx context.Context, id int64, role membership.Role) (File, error)
+	// AuthorizeFile is Authorized for callers that don't need the file, an
+	// unknown file fails with version.ErrFileNotFound.
+	AuthorizeFile(ctx context.Context, id int64, role membership.Role) error
+}
+
+type authorizer struct {
+	repository        Repository
+	membershipService membership.Service
+}
+
+func NewAuthorizer(repository Repository, membershipService membership.Service) Authorizer {
+	return &authorizer{repository: repository, membershipService: membershipService}
+}
+
+func (a *authorizer) Authorized(ctx context.Context, id int64, role membership.Role) (File, error) {
+	file, err := a.repository.GetById(ctx, id)
+	if err != nil {
+		return File{}, err
+	}
+
+	principal, ok := auth.GetPrincipal(ctx)
+	if !ok {
+		return File{}, auth.ErrForbidden
+	}
+	if principal.IsAdmin || (file.CreatedBy != nil && *file.CreatedBy == principal.UserID) {
+		return file, nil
+	}
+
+	projectIds, err := a.repository.ListProjectIds(ctx, id)
+	if err != nil {
+		return File{}, err
+	}
+	for _, projectId := range projectIds {
+		err := a.membershipService.Authorize(ctx, projectId, role)
+		if err == nil {
+			return file, nil
+		}
+		if !errors.Is(err, auth.ErrForbidden) {
+			return File{}, err
+		}
+	}
+
+	return File{}, auth.ErrForbidden
+}
+
+func (a *authorizer) AuthorizeFile(ctx context.Context, id int64, role membership.Role) error {
+	_, err := a.Authorized(ctx, id, role)
+	if errors.Is(err, ErrFileNotFound) {
+		return version.ErrFileNotFound
+	}
+	return err
+}
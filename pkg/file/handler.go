@@ -2,7 +2,12 @@ package file
 
 import (
 	"app/pkg/api"
+	"app/pkg/platform/auth"
 	"app/pkg/platform/handler"
+	"app/pkg/platform/links"
+	"app/pkg/qrcode"
+	"app/pkg/version"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -13,14 +18,22 @@ import (
 
 	"github.com/dustin/go-humanize"
 	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	openapitypes "github.com/oapi-codegen/runtime/types"
+)
+
+const (
+	uploadOffsetHeader = "Upload-Offset"
+	maxUploadChunkSize = 64 * humanize.MiByte
 )
 
 type Handler struct {
-	service Service
+	service    Service
+	qrRenderer *qrcode.Renderer
 }
 
-func NewHandler(service Service) *Handler {
-	return &Handler{service: service}
+func NewHandler(service Service, qrRenderer *qrcode.Renderer) *Handler {
+	return &Handler{service: service, qrRenderer: qrRenderer}
 }
 
 func (h *Handler) RegisterRoutes(r chi.Router) {
@@ -30,11 +43,35 @@ func (h *Handler) RegisterRoutes(r chi.Router) {
 
 		r.Route("/{fileId}", func(r chi.Router) {
 			r.Get("/", h.GetById)
+			r.Get("/qr", h.QRCode)
 			r.Post("/upload", h.Upload)
 			r.Get("/download", h.Download)
 			r.Delete("/", h.Delete)
+
+			r.Route("/revisions", func(r chi.Router) {
+				r.Get("/", h.ListRevisions)
+				r.Get("/{revisionId}/download", h.DownloadRevision)
+				r.Post("/{revisionId}/restore", h.RestoreRevision)
+			})
+
+			r.Route("/uploads", func(r chi.Router) {
+				r.Post("/", h.CreateUploadSession)
+
+				r.Route("/{uploadId}", func(r chi.Router) {
+					r.Get("/", h.GetUploadSession)
+					r.Patch("/", h.UploadChunk)
+					r.Delete("/", h.CancelUploadSession)
+				})
+			})
 		})
 	})
+
+	r.Get("/v1/projects/{projectId}/files/latest", h.GetLatestByName)
+
+	r.Route("/v1/admin/files", func(r chi.Router) {
+		r.Post("/verify", h.VerifyFiles)
+		r.Post("/{fileId}/verify", h.VerifyFile)
+	})
 }
 
 func (h *Handler) GetById(w http.ResponseWriter, r *http.Request) {
@@ -45,6 +82,44 @@ func (h *Handler) GetById(w http.ResponseWriter, r *http.Request) {
 	}
 
 	file, err := h.service.GetById(r.Context(), id)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrFileNotFound) {
+		writeFileNotFoundError(w)
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	handler.WriteJson(w, http.StatusOK, toFileResponse(file))
+}
+
+func (h *Handler) GetLatestByName(w http.ResponseWriter, r *http.Request) {
+	projectId, err := strconv.ParseInt(chi.URLParam(r, "projectId"), 10, 64)
+	if err != nil {
+		handler.WriteError(w, http.StatusBadRequest, "invalid project id")
+		return
+	}
+
+	name := r.URL.Query().Get("name")
+	if name == "" {
+		handler.WriteError(w, http.StatusBadRequest, "missing file name")
+		return
+	}
+
+	file, err := h.service.GetLatestByName(r.Context(), projectId, name)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, version.ErrProjectNotFound) {
+		handler.WriteError(w, http.StatusNotFound, err.Error())
+		return
+	}
 	if errors.Is(err, ErrFileNotFound) {
 		writeFileNotFoundError(w)
 		return
@@ -67,6 +142,10 @@ func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
 	}
 
 	files, err := h.service.List(r.Context(), versionId, limit, offset)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
 	if err != nil {
 		handler.WriteInternalServerError(w)
 		return
@@ -85,6 +164,10 @@ func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 	file, err := h.service.Create(r.Context(), CreateFileRequest{
 		Name: req.Name,
 	})
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
 	if errors.Is(err, ErrFileAlreadyExist) {
 		writeFileAlreadyExistsError(w)
 		return
@@ -112,15 +195,25 @@ func (h *Handler) Upload(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	req := UploadFileRequest{File: multipartFile, FileHeader: multipartFileHeader}
+	expectedChecksum, err := handler.ParseSHA256Digest(r, handler.ReprDigestHeader)
+	if err != nil {
+		writeInvalidDigestError(w)
+		return
+	}
+
+	req := UploadFileRequest{File: multipartFile, FileHeader: multipartFileHeader, ExpectedChecksum: expectedChecksum}
 
 	file, err := h.service.UploadFile(r.Context(), id, req)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
 	if errors.Is(err, ErrFileNotFound) {
 		writeFileNotFoundError(w)
 		return
 	}
-	if errors.Is(err, ErrFileAlreadyComplete) {
-		writeFileAlreadyCompleteError(w)
+	if errors.Is(err, ErrChecksumMismatch) {
+		writeChecksumMismatchError(w)
 		return
 	}
 	if err != nil {
@@ -139,6 +232,10 @@ func (h *Handler) Download(w http.ResponseWriter, r *http.Request) {
 	}
 
 	file, reader, err := h.service.Download(r.Context(), id)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
 	if errors.Is(err, ErrFileNotFound) {
 		writeFileNotFoundError(w)
 		return
@@ -151,28 +248,100 @@ func (h *Handler) Download(w http.ResponseWriter, r *http.Request) {
 		handler.WriteInternalServerError(w)
 		return
 	}
-	defer func(reader io.ReadSeekCloser) {
-		err := reader.Close()
-		if err != nil {
-			log.Printf("error closing file reader: %v", err)
-		}
-	}(reader)
+	WriteContent(w, r, file, reader)
+}
 
-	contentType := "application/octet-stream"
-	if file.MimeType != nil {
-		contentType = *file.MimeType
+func (h *Handler) ListRevisions(w http.ResponseWriter, r *http.Request) {
+	limit, offset := handler.ParsePagination(r)
+
+	id, err := parseFileId(r)
+	if err != nil {
+		writeInvalidFileIdError(w)
+		return
 	}
 
-	contentLength := int64(0)
-	if file.Size != nil {
-		contentLength = *file.Size
+	revisions, err := h.service.ListRevisions(r.Context(), id, limit, offset)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrFileNotFound) {
+		writeFileNotFoundError(w)
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
 	}
 
-	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", file.Name))
-	w.Header().Set("Content-Type", contentType)
-	w.Header().Set("Content-Length", fmt.Sprintf("%d", contentLength))
+	handler.WriteJson(w, http.StatusOK, toListFileRevisionsResponse(revisions, limit, offset))
+}
 
-	http.ServeContent(w, r, file.Name, file.UpdatedAt, reader)
+func (h *Handler) DownloadRevision(w http.ResponseWriter, r *http.Request) {
+	id, err := parseFileId(r)
+	if err != nil {
+		writeInvalidFileIdError(w)
+		return
+	}
+
+	revisionId, err := parseRevisionId(r)
+	if err != nil {
+		writeInvalidRevisionIdError(w)
+		return
+	}
+
+	file, reader, err := h.service.DownloadRevision(r.Context(), id, revisionId)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrFileNotFound) {
+		writeFileNotFoundError(w)
+		return
+	}
+	if errors.Is(err, ErrRevisionNotFound) {
+		writeRevisionNotFoundError(w)
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+	WriteContent(w, r, file, reader)
+}
+
+func (h *Handler) RestoreRevision(w http.ResponseWriter, r *http.Request) {
+	id, err := parseFileId(r)
+	if err != nil {
+		writeInvalidFileIdError(w)
+		return
+	}
+
+	revisionId, err := parseRevisionId(r)
+	if err != nil {
+		writeInvalidRevisionIdError(w)
+		return
+	}
+
+	file, err := h.service.RestoreRevision(r.Context(), id, revisionId)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrFileNotFound) {
+		writeFileNotFoundError(w)
+		return
+	}
+	if errors.Is(err, ErrRevisionNotFound) {
+		writeRevisionNotFoundError(w)
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	handler.WriteJson(w, http.StatusOK, toFileResponse(file))
 }
 
 func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
@@ -183,10 +352,18 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	err = h.service.Delete(r.Context(), id)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
 	if errors.Is(err, ErrFileNotFound) {
 		writeFileNotFoundError(w)
 		return
 	}
+	if errors.Is(err, ErrFileReleased) {
+		handler.WriteError(w, http.StatusConflict, err.Error())
+		return
+	}
 	if err != nil {
 		handler.WriteInternalServerError(w)
 		return
@@ -195,6 +372,287 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func (h *Handler) CreateUploadSession(w http.ResponseWriter, r *http.Request) {
+	id, err := parseFileId(r)
+	if err != nil {
+		writeInvalidFileIdError(w)
+		return
+	}
+
+	var req api.CreateUploadSessionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handler.WriteInvalidRequestPayloadError(w)
+		return
+	}
+
+	expectedChecksum, err := handler.ParseSHA256Digest(r, handler.ReprDigestHeader)
+	if err != nil {
+		writeInvalidDigestError(w)
+		return
+	}
+
+	session, err := h.service.CreateUploadSession(r.Context(), id, CreateUploadSessionRequest{
+		Size:             req.Size,
+		ExpectedChecksum: expectedChecksum,
+	})
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrFileNotFound) {
+		writeFileNotFoundError(w)
+		return
+	}
+	if errors.Is(err, ErrUploadSessionInvalidSize) {
+		handler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	writeUploadSession(w, http.StatusCreated, session)
+}
+
+func (h *Handler) GetUploadSession(w http.ResponseWriter, r *http.Request) {
+	id, err := parseFileId(r)
+	if err != nil {
+		writeInvalidFileIdError(w)
+		return
+	}
+
+	uploadId, err := parseUploadId(r)
+	if err != nil {
+		writeInvalidUploadIdError(w)
+		return
+	}
+
+	session, err := h.service.GetUploadSession(r.Context(), id, uploadId)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrUploadSessionNotFound) {
+		writeUploadSessionNotFoundError(w)
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	writeUploadSession(w, http.StatusOK, session)
+}
+
+func (h *Handler) UploadChunk(w http.ResponseWriter, r *http.Request) {
+	id, err := parseFileId(r)
+	if err != nil {
+		writeInvalidFileIdError(w)
+		return
+	}
+
+	uploadId, err := parseUploadId(r)
+	if err != nil {
+		writeInvalidUploadIdError(w)
+		return
+	}
+
+	offset, err := strconv.ParseInt(r.Header.Get(uploadOffsetHeader), 10, 64)
+	if err != nil || offset < 0 {
+		handler.WriteError(w, http.StatusBadRequest, "invalid upload offset")
+		return
+	}
+
+	expectedChecksum, err := handler.ParseSHA256Digest(r, handler.ContentDigestHeader)
+	if err != nil {
+		writeInvalidDigestError(w)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadChunkSize)
+
+	session, err := h.service.UploadChunk(r.Context(), id, uploadId, UploadChunkRequest{
+		Offset:           offset,
+		Data:             r.Body,
+		ExpectedChecksum: expectedChecksum,
+	})
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrUploadSessionNotFound) {
+		writeUploadSessionNotFoundError(w)
+		return
+	}
+	if errors.Is(err, ErrUploadSessionExpired) {
+		handler.WriteError(w, http.StatusGone, "upload session expired")
+		return
+	}
+	if errors.Is(err, ErrUploadOffsetMismatch) {
+		handler.WriteError(w, http.StatusConflict, "upload offset mismatch")
+		return
+	}
+	if errors.Is(err, ErrUploadSessionComplete) {
+		handler.WriteError(w, http.StatusConflict, "upload session already complete")
+		return
+	}
+	if errors.Is(err, ErrChecksumMismatch) {
+		writeChecksumMismatchError(w)
+		return
+	}
+	if errors.Is(err, ErrUploadChunkEmpty) {
+		handler.WriteError(w, http.StatusBadRequest, "upload chunk is empty")
+		return
+	}
+	if _, ok := errors.AsType[*http.MaxBytesError](err); ok || errors.Is(err, ErrUploadChunkTooLarge) {
+		handler.WriteError(w, http.StatusRequestEntityTooLarge, "upload chunk too large")
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	writeUploadSession(w, http.StatusOK, session)
+}
+
+func (h *Handler) CancelUploadSession(w http.ResponseWriter, r *http.Request) {
+	id, err := parseFileId(r)
+	if err != nil {
+		writeInvalidFileIdError(w)
+		return
+	}
+
+	uploadId, err := parseUploadId(r)
+	if err != nil {
+		writeInvalidUploadIdError(w)
+		return
+	}
+
+	err = h.service.CancelUploadSession(r.Context(), id, uploadId)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrUploadSessionNotFound) {
+		writeUploadSessionNotFoundError(w)
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) VerifyFile(w http.ResponseWriter, r *http.Request) {
+	id, err := parseFileId(r)
+	if err != nil {
+		writeInvalidFileIdError(w)
+		return
+	}
+
+	verification, err := h.service.VerifyFile(r.Context(), id)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrFileNotFound) {
+		writeFileNotFoundError(w)
+		return
+	}
+	if errors.Is(err, ErrFileNotComplete) {
+		writeFileNotCompleteError(w)
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	handler.WriteJson(w, http.StatusOK, toFileVerificationResponse(verification))
+}
+
+func (h *Handler) VerifyFiles(w http.ResponseWriter, r *http.Request) {
+	limit, offset := handler.ParsePagination(r)
+
+	verifications, err := h.service.VerifyFiles(r.Context(), limit, offset)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	handler.WriteJson(w, http.StatusOK, toVerifyFilesResponse(verifications, limit, offset))
+}
+
+func (h *Handler) QRCode(w http.ResponseWriter, r *http.Request) {
+	id, err := parseFileId(r)
+	if err != nil {
+		writeInvalidFileIdError(w)
+		return
+	}
+
+	_, err = h.service.GetById(r.Context(), id)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrFileNotFound) {
+		writeFileNotFoundError(w)
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	h.qrRenderer.Write(w, r, links.FilePath(id))
+}
+
+func writeUploadSession(w http.ResponseWriter, status int, session UploadSession) {
+	w.Header().Set(uploadOffsetHeader, strconv.FormatInt(session.Offset, 10))
+	handler.WriteJson(w, status, toUploadSessionResponse(session))
+}
+
+// WriteContent streams the contents of a complete file as an attachment and
+// closes the reader once done.
+func WriteContent(w http.ResponseWriter, r *http.Request, file File, reader io.ReadSeekCloser) {
+	defer func(reader io.ReadSeekCloser) {
+		err := reader.Close()
+		if err != nil {
+			log.Printf("error closing file reader: %v", err)
+		}
+	}(reader)
+
+	contentType := "application/octet-stream"
+	if file.MimeType != nil {
+		contentType = *file.MimeType
+	}
+
+	contentLength := int64(0)
+	if file.Size != nil {
+		contentLength = *file.Size
+	}
+
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", file.Name))
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Length", fmt.Sprintf("%d", contentLength))
+	if file.Checksum != nil {
+		checksum, err := hex.DecodeString(*file.Checksum)
+		if err == nil {
+			w.Header().Set(handler.ReprDigestHeader, handler.FormatSHA256Digest(checksum))
+		}
+	}
+
+	http.ServeContent(w, r, file.Name, file.UpdatedAt, reader)
+}
+
 func writeInvalidFileIdError(w http.ResponseWriter) {
 	handler.WriteError(w, http.StatusBadRequest, "invalid file id")
 }
@@ -211,14 +669,46 @@ func writeFileNotCompleteError(w http.ResponseWriter) {
 	handler.WriteError(w, http.StatusNotFound, "file not complete")
 }
 
-func writeFileAlreadyCompleteError(w http.ResponseWriter) {
-	handler.WriteError(w, http.StatusConflict, "file already complete")
+func writeInvalidRevisionIdError(w http.ResponseWriter) {
+	handler.WriteError(w, http.StatusBadRequest, "invalid revision id")
+}
+
+func writeRevisionNotFoundError(w http.ResponseWriter) {
+	handler.WriteError(w, http.StatusNotFound, "file revision not found")
+}
+
+func writeInvalidDigestError(w http.ResponseWriter) {
+	handler.WriteError(w, http.StatusBadRequest, "invalid digest header")
+}
+
+func writeChecksumMismatchError(w http.ResponseWriter) {
+	handler.WriteError(w, http.StatusUnprocessableEntity, "checksum mismatch")
+}
+
+func writeInvalidUploadIdError(w http.ResponseWriter) {
+	handler.WriteError(w, http.StatusBadRequest, "invalid upload id")
+}
+
+func writeUploadSessionNotFoundError(w http.ResponseWriter) {
+	handler.WriteError(w, http.StatusNotFound, "upload session not found")
 }
 
 func parseFileId(r *http.Request) (int64, error) {
 	return strconv.ParseInt(chi.URLParam(r, "fileId"), 10, 64)
 }
 
+func parseRevisionId(r *http.Request) (int64, error) {
+	return strconv.ParseInt(chi.URLParam(r, "revisionId"), 10, 64)
+}
+
+func parseUploadId(r *http.Request) (string, error) {
+	uploadId, err := uuid.Parse(chi.URLParam(r, "uploadId"))
+	if err != nil {
+		return "", err
+	}
+	return uploadId.String(), nil
+}
+
 func parseVersionId(r *http.Request) (*int64, error) {
 	if !r.URL.Query().Has("versionId") {
 		return nil, nil
@@ -245,6 +735,68 @@ func toFileResponse(f File) api.FileResponse {
 		Size:       f.Size,
 		MimeType:   f.MimeType,
 		IsComplete: f.IsComplete,
+		Checksum:   f.Checksum,
+		RevisionId: f.RevisionID,
+	}
+}
+
+func toFileRevisionResponse(r Revision) api.FileRevisionResponse {
+	return api.FileRevisionResponse{
+		Id:        r.ID,
+		CreatedAt: r.CreatedAt,
+		FileId:    r.FileID,
+		Number:    r.Number,
+		Size:      r.Size,
+		MimeType:  r.MimeType,
+		Checksum:  r.Checksum,
+		CreatedBy: r.CreatedBy,
+	}
+}
+
+func toListFileRevisionsResponse(revisions []Revision, limit, offset int64) api.ListFileRevisionsResponse {
+	items := make([]api.FileRevisionResponse, len(revisions))
+	for i, revision := range revisions {
+		items[i] = toFileRevisionResponse(revision)
+	}
+	return api.ListFileRevisionsResponse{
+		Revisions: items,
+		Limit:     limit,
+		Offset:    offset,
+	}
+}
+
+func toUploadSessionResponse(s UploadSession) api.UploadSessionResponse {
+	return api.UploadSessionResponse{
+		Id:         openapitypes.UUID(uuid.MustParse(s.ID)),
+		CreatedAt:  s.CreatedAt,
+		UpdatedAt:  s.UpdatedAt,
+		ExpiresAt:  s.ExpiresAt,
+		FileId:     s.FileID,
+		Size:       s.Size,
+		Offset:     s.Offset,
+		IsComplete: s.IsComplete,
+	}
+}
+
+func toFileVerificationResponse(v FileVerification) api.FileVerificationResponse {
+	return api.FileVerificationResponse{
+		FileId:           v.FileID,
+		Name:             v.Name,
+		Status:           api.FileVerificationStatus(v.Status),
+		ExpectedChecksum: v.ExpectedChecksum,
+		ActualChecksum:   v.ActualChecksum,
+	}
+}
+
+func toVerifyFilesResponse(verifications []FileVerification, limit, offset int64) api.VerifyFilesResponse {
+	items := make([]api.FileVerificationResponse, len(verifications))
+	for i, verification := range verifications {
+		items[i] = toFileVerificationResponse(verification)
+	}
+	return api.VerifyFilesResponse{
+		Files:  items,
+		Limit:  limit,
+		Offset: offset,
 	}
 }
 
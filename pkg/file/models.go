@@ -1,10 +1,13 @@
 package file
 
 import (
+	"io"
 	"mime/multipart"
 	"time"
 )
 
+// File has the contents of one of its revisions, the current one unless it
+// was read as part of a version that pins another.
 type File struct {
 	ID         int64
 	CreatedAt  time.Time
@@ -14,13 +17,81 @@ type File struct {
 	Path       *string
 	MimeType   *string
 	IsComplete bool
+	Checksum   *string
+	CreatedBy  *int64
+	RevisionID *int64
+}
+
+// Revision is the immutable contents of one upload of a file, numbered from 1
+// per file.
+type Revision struct {
+	ID        int64
+	CreatedAt time.Time
+	FileID    int64
+	Number    int32
+	Path      string
+	Size      int64
+	MimeType  string
+	Checksum  *string
+	CreatedBy *int64
 }
 
 type CreateFileRequest struct {
 	Name string `json:"name" validate:"required" example:"example.pdf"`
 }
 
+// CreateFileWithContentsRequest creates a file and stores its contents in one
+// go, for contents that arrive in bulk rather than through an upload.
+type CreateFileWithContentsRequest struct {
+	Name     string
+	Contents io.Reader
+	Size     int64
+}
+
 type UploadFileRequest struct {
-	File       multipart.File
-	FileHeader *multipart.FileHeader
+	File             multipart.File
+	FileHeader       *multipart.FileHeader
+	ExpectedChecksum []byte
+}
+
+type UploadSession struct {
+	ID         string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	ExpiresAt  time.Time
+	FileID     int64
+	Size       int64
+	Offset     int64
+	ChunkPaths []string
+	IsComplete bool
+	Checksum   *string
+}
+
+type CreateUploadSessionRequest struct {
+	Size             int64
+	ExpectedChecksum []byte
+}
+
+type UploadChunkRequest struct {
+	Offset           int64
+	Data             io.Reader
+	ExpectedChecksum []byte
+}
+
+type VerificationStatus string
+
+const (
+	VerificationStatusOk         VerificationStatus = "ok"
+	VerificationStatusMismatch   VerificationStatus = "mismatch"
+	VerificationStatusMissing    VerificationStatus = "missing"
+	VerificationStatusUnreadable VerificationStatus = "unreadable"
+	VerificationStatusBackfilled VerificationStatus = "backfilled"
+)
+
+type FileVerification struct {
+	FileID           int64
+	Name             string
+	Status           VerificationStatus
+	ExpectedChecksum *string
+	ActualChecksum   *string
 }
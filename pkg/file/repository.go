@@ -5,21 +5,63 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 var (
-	ErrFileNotFound     = errors.New("file not found")
-	ErrFileAlreadyExist = errors.New("file already exist")
+	ErrFileNotFound          = errors.New("file not found")
+	ErrFileAlreadyExist      = errors.New("file already exist")
+	ErrUploadSessionNotFound = errors.New("upload session not found")
+	ErrUploadOffsetMismatch  = errors.New("upload offset mismatch")
+	ErrRevisionNotFound      = errors.New("file revision not found")
 )
 
 type Repository interface {
 	GetById(ctx context.Context, id int64) (File, error)
-	List(ctx context.Context, versionId *int64, limit, offset int64) ([]File, error)
+	List(ctx context.Context, versionId, memberId *int64, limit, offset int64) ([]File, error)
+	// ListCompleteByVersion returns the complete files of a version ordered
+	// by name.
+	ListCompleteByVersion(ctx context.Context, versionId int64) ([]File, error)
+	// GetByNameInVersions returns the file with the name from the first of
+	// the versions that contains one.
+	GetByNameInVersions(ctx context.Context, name string, versionIds []int64) (File, error)
+	// GetInVersion returns the file with the revision the version pins.
+	GetInVersion(ctx context.Context, versionId, id int64) (File, error)
 	Create(ctx context.Context, file File) (File, error)
 	Update(ctx context.Context, file File) (File, error)
 	Delete(ctx context.Context, id int64) error
+	ListComplete(ctx context.Context, limit, offset int64) ([]File, error)
+	// ListProjectIds returns the projects the file is attached to through their versions.
+	ListProjectIds(ctx context.Context, id int64) ([]int64, error)
+	// IsReleased reports whether the file is part of a released version.
+	IsReleased(ctx context.Context, id int64) (bool, error)
+	// Lock holds the file until the transaction ends, so uploads to it take
+	// turns numbering their revisions.
+	Lock(ctx context.Context, id int64) error
+	// CreateRevision adds the revision with the next number of the file.
+	CreateRevision(ctx context.Context, revision Revision) (Revision, error)
+	GetRevision(ctx context.Context, id int64) (Revision, error)
+	// ListRevisions returns the revisions of the file, newest first.
+	ListRevisions(ctx context.Context, fileId int64, limit, offset int64) ([]Revision, error)
+	ListRevisionPaths(ctx context.Context, fileId int64) ([]string, error)
+	// SetCurrentRevision makes the revision current and returns its file with
+	// the revision's contents.
+	SetCurrentRevision(ctx context.Context, revisionId int64) (File, error)
+	SetRevisionChecksum(ctx context.Context, id int64, checksum string) error
+	GetUploadSession(ctx context.Context, fileId int64, id string) (UploadSession, error)
+	CreateUploadSession(ctx context.Context, session UploadSession) (UploadSession, error)
+	AppendUploadSessionChunk(ctx context.Context, id string, expectedOffset int64, chunkSize int64, chunkPath string) (UploadSession, error)
+	CompleteUploadSession(ctx context.Context, id string) (UploadSession, error)
+	DeleteUploadSession(ctx context.Context, id string) error
+	// ListExpiredUploadSessions returns the oldest sessions that expired
+	// before the time, complete or not.
+	ListExpiredUploadSessions(ctx context.Context, expiredBefore time.Time, limit int64) ([]UploadSession, error)
+	// ListUploadSessionChunkPaths returns the chunks the sessions of the file
+	// haven't assembled yet.
+	ListUploadSessionChunkPaths(ctx context.Context, fileId int64) ([]string, error)
 }
 
 type repository struct {
@@ -41,9 +83,51 @@ func (r *repository) GetById(ctx context.Context, id int64) (File, error) {
 	return toFile(row), nil
 }
 
-func (r *repository) List(ctx context.Context, versionId *int64, limit, offset int64) ([]File, error) {
+func (r *repository) ListCompleteByVersion(ctx context.Context, versionId int64) ([]File, error) {
+	rows, err := r.queries.ListCompleteFilesByVersionId(ctx, versionId)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]File, len(rows))
+	for i, row := range rows {
+		files[i] = toRevisionFile((*database.ListFilesRow)(row))
+	}
+	return files, nil
+}
+
+func (r *repository) GetByNameInVersions(ctx context.Context, name string, versionIds []int64) (File, error) {
+	row, err := r.queries.GetFileByNameInVersions(ctx, &database.GetFileByNameInVersionsParams{
+		Name:       name,
+		VersionIds: versionIds,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return File{}, ErrFileNotFound
+	}
+	if err != nil {
+		return File{}, err
+	}
+	return toRevisionFile((*database.ListFilesRow)(row)), nil
+}
+
+func (r *repository) GetInVersion(ctx context.Context, versionId, id int64) (File, error) {
+	row, err := r.queries.GetFileInVersion(ctx, &database.GetFileInVersionParams{
+		VersionId: versionId,
+		FileId:    id,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return File{}, ErrFileNotFound
+	}
+	if err != nil {
+		return File{}, err
+	}
+	return toRevisionFile((*database.ListFilesRow)(row)), nil
+}
+
+func (r *repository) List(ctx context.Context, versionId, memberId *int64, limit, offset int64) ([]File, error) {
 	rows, err := r.queries.ListFiles(ctx, &database.ListFilesParams{
 		VersionId: versionId,
+		MemberId:  memberId,
 		Limit:     limit,
 		Offset:    offset,
 	})
@@ -52,7 +136,7 @@ func (r *repository) List(ctx context.Context, versionId *int64, limit, offset i
 	}
 	files := make([]File, len(rows))
 	for i, row := range rows {
-		files[i] = toFile(row)
+		files[i] = toRevisionFile(row)
 	}
 	return files, nil
 }
@@ -64,6 +148,8 @@ func (r *repository) Create(ctx context.Context, file File) (File, error) {
 		Path:       file.Path,
 		MimeType:   file.MimeType,
 		IsComplete: file.IsComplete,
+		Checksum:   file.Checksum,
+		CreatedBy:  file.CreatedBy,
 	})
 	if err != nil {
 		if isPgUniqueViolation(err) {
@@ -82,6 +168,7 @@ func (r *repository) Update(ctx context.Context, file File) (File, error) {
 		Path:       file.Path,
 		MimeType:   file.MimeType,
 		IsComplete: file.IsComplete,
+		Checksum:   file.Checksum,
 	})
 	if errors.Is(err, pgx.ErrNoRows) {
 		return File{}, ErrFileNotFound
@@ -103,6 +190,194 @@ func (r *repository) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
+func (r *repository) ListComplete(ctx context.Context, limit, offset int64) ([]File, error) {
+	rows, err := r.queries.ListCompleteFiles(ctx, &database.ListCompleteFilesParams{
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+	files := make([]File, len(rows))
+	for i, row := range rows {
+		files[i] = toFile(row)
+	}
+	return files, nil
+}
+
+func (r *repository) ListProjectIds(ctx context.Context, id int64) ([]int64, error) {
+	return r.queries.ListProjectIdsByFileId(ctx, id)
+}
+
+func (r *repository) IsReleased(ctx context.Context, id int64) (bool, error) {
+	return r.queries.IsFileReleased(ctx, id)
+}
+
+func (r *repository) Lock(ctx context.Context, id int64) error {
+	return r.queries.LockFile(ctx, id)
+}
+
+func (r *repository) CreateRevision(ctx context.Context, revision Revision) (Revision, error) {
+	row, err := r.queries.CreateFileRevision(ctx, &database.CreateFileRevisionParams{
+		FileId:    revision.FileID,
+		Path:      revision.Path,
+		Size:      revision.Size,
+		MimeType:  revision.MimeType,
+		Checksum:  revision.Checksum,
+		CreatedBy: revision.CreatedBy,
+	})
+	if err != nil {
+		return Revision{}, err
+	}
+	return toRevision(row), nil
+}
+
+func (r *repository) GetRevision(ctx context.Context, id int64) (Revision, error) {
+	row, err := r.queries.GetFileRevision(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Revision{}, ErrRevisionNotFound
+	}
+	if err != nil {
+		return Revision{}, err
+	}
+	return toRevision(row), nil
+}
+
+func (r *repository) ListRevisions(ctx context.Context, fileId int64, limit, offset int64) ([]Revision, error) {
+	rows, err := r.queries.ListFileRevisions(ctx, &database.ListFileRevisionsParams{
+		FileId: fileId,
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+	revisions := make([]Revision, len(rows))
+	for i, row := range rows {
+		revisions[i] = toRevision(row)
+	}
+	return revisions, nil
+}
+
+func (r *repository) ListRevisionPaths(ctx context.Context, fileId int64) ([]string, error) {
+	return r.queries.ListFileRevisionPaths(ctx, fileId)
+}
+
+func (r *repository) SetCurrentRevision(ctx context.Context, revisionId int64) (File, error) {
+	row, err := r.queries.SetCurrentFileRevision(ctx, revisionId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return File{}, ErrRevisionNotFound
+	}
+	if err != nil {
+		return File{}, err
+	}
+	return toFile(row), nil
+}
+
+func (r *repository) SetRevisionChecksum(ctx context.Context, id int64, checksum string) error {
+	return r.queries.SetFileRevisionChecksum(ctx, &database.SetFileRevisionChecksumParams{
+		ID:       id,
+		Checksum: &checksum,
+	})
+}
+
+func (r *repository) GetUploadSession(ctx context.Context, fileId int64, id string) (UploadSession, error) {
+	sessionId, err := toUUID(id)
+	if err != nil {
+		return UploadSession{}, ErrUploadSessionNotFound
+	}
+
+	row, err := r.queries.GetUploadSession(ctx, &database.GetUploadSessionParams{
+		ID:     sessionId,
+		FileID: fileId,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return UploadSession{}, ErrUploadSessionNotFound
+	}
+	if err != nil {
+		return UploadSession{}, err
+	}
+	return toUploadSession(row), nil
+}
+
+func (r *repository) CreateUploadSession(ctx context.Context, session UploadSession) (UploadSession, error) {
+	row, err := r.queries.CreateUploadSession(ctx, &database.CreateUploadSessionParams{
+		FileID:    session.FileID,
+		Size:      session.Size,
+		ExpiresAt: pgtype.Timestamp{Time: session.ExpiresAt, Valid: true},
+		Checksum:  session.Checksum,
+	})
+	if err != nil {
+		return UploadSession{}, err
+	}
+	return toUploadSession(row), nil
+}
+
+func (r *repository) ListExpiredUploadSessions(ctx context.Context, expiredBefore time.Time, limit int64) ([]UploadSession, error) {
+	rows, err := r.queries.ListExpiredUploadSessions(ctx, &database.ListExpiredUploadSessionsParams{
+		ExpiredBefore: pgtype.Timestamp{Time: expiredBefore, Valid: true},
+		Limit:         limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	sessions := make([]UploadSession, len(rows))
+	for i, row := range rows {
+		sessions[i] = toUploadSession(row)
+	}
+	return sessions, nil
+}
+
+func (r *repository) ListUploadSessionChunkPaths(ctx context.Context, fileId int64) ([]string, error) {
+	return r.queries.ListUploadSessionChunkPaths(ctx, fileId)
+}
+
+func (r *repository) AppendUploadSessionChunk(ctx context.Context, id string, expectedOffset int64, chunkSize int64, chunkPath string) (UploadSession, error) {
+	sessionId, err := toUUID(id)
+	if err != nil {
+		return UploadSession{}, ErrUploadSessionNotFound
+	}
+
+	row, err := r.queries.AppendUploadSessionChunk(ctx, &database.AppendUploadSessionChunkParams{
+		ID:             sessionId,
+		ExpectedOffset: expectedOffset,
+		ChunkSize:      chunkSize,
+		ChunkPath:      chunkPath,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return UploadSession{}, ErrUploadOffsetMismatch
+	}
+	if err != nil {
+		return UploadSession{}, err
+	}
+	return toUploadSession(row), nil
+}
+
+func (r *repository) CompleteUploadSession(ctx context.Context, id string) (UploadSession, error) {
+	sessionId, err := toUUID(id)
+	if err != nil {
+		return UploadSession{}, ErrUploadSessionNotFound
+	}
+
+	row, err := r.queries.CompleteUploadSession(ctx, sessionId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return UploadSession{}, ErrUploadSessionComplete
+	}
+	if err != nil {
+		return UploadSession{}, err
+	}
+	return toUploadSession(row), nil
+}
+
+func (r *repository) DeleteUploadSession(ctx context.Context, id string) error {
+	sessionId, err := toUUID(id)
+	if err != nil {
+		return ErrUploadSessionNotFound
+	}
+
+	return r.queries.DeleteUploadSession(ctx, sessionId)
+}
+
 func toFile(row *database.File) File {
 	return File{
 		ID:         row.ID,
@@ -113,9 +388,65 @@ func toFile(row *database.File) File {
 		Path:       row.Path,
 		MimeType:   row.MimeType,
 		IsComplete: row.IsComplete,
+		Checksum:   row.Checksum,
+		CreatedBy:  row.CreatedBy,
+		RevisionID: row.CurrentRevisionID,
+	}
+}
+
+// toRevisionFile converts the rows of the queries that read a file with the
+// revision a version pins, they all have the shape of ListFilesRow.
+func toRevisionFile(row *database.ListFilesRow) File {
+	return File{
+		ID:         row.ID,
+		CreatedAt:  row.CreatedAt.Time,
+		UpdatedAt:  row.UpdatedAt.Time,
+		Name:       row.Name,
+		Size:       row.Size,
+		Path:       row.Path,
+		MimeType:   row.MimeType,
+		IsComplete: row.IsComplete,
+		Checksum:   row.Checksum,
+		CreatedBy:  row.CreatedBy,
+		RevisionID: row.RevisionID,
+	}
+}
+
+func toRevision(row *database.FileRevision) Revision {
+	return Revision{
+		ID:        row.ID,
+		CreatedAt: row.CreatedAt.Time,
+		FileID:    row.FileID,
+		Number:    row.Number,
+		Path:      row.Path,
+		Size:      row.Size,
+		MimeType:  row.MimeType,
+		Checksum:  row.Checksum,
+		CreatedBy: row.CreatedBy,
 	}
 }
 
+func toUploadSession(row *database.UploadSession) UploadSession {
+	return UploadSession{
+		ID:         row.ID.String(),
+		CreatedAt:  row.CreatedAt.Time,
+		UpdatedAt:  row.UpdatedAt.Time,
+		ExpiresAt:  row.ExpiresAt.Time,
+		FileID:     row.FileID,
+		Size:       row.Size,
+		Offset:     row.ReceivedBytes,
+		ChunkPaths: row.ChunkPaths,
+		IsComplete: row.IsComplete,
+		Checksum:   row.Checksum,
+	}
+}
+
+func toUUID(id string) (pgtype.UUID, error) {
+	var uuid pgtype.UUID
+	err := uuid.Scan(id)
+	return uuid, err
+}
+
 func isPgUniqueViolation(err error) bool {
 	return err != nil && (strings.Contains(err.Error(), "23505") || strings.Contains(err.Error(), "unique"))
 }
@@ -1,54 +1,195 @@
 package file
 
 import (
+	"app/pkg/audit"
+	"app/pkg/content"
+	"app/pkg/events"
+	"app/pkg/jobs"
+	"app/pkg/membership"
+	"app/pkg/platform/auth"
+	"app/pkg/platform/transaction"
 	"app/pkg/storage"
+	"app/pkg/version"
+	"app/pkg/webhook"
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"io"
+	"io/fs"
 	"log"
 	"mime/multipart"
 	"path"
+	"time"
 
 	"github.com/gabriel-vasile/mimetype"
 	"github.com/google/uuid"
 )
 
+const (
+	JobKindDeleteExpiredUploads = "files.delete-expired-uploads"
+
+	mimeTypeDetectionLimit = 3072
+	uploadSessionTTL       = 24 * time.Hour
+	// uploadSessionRetention is how long an expired session is kept before
+	// its chunks are deleted, so a chunk that was accepted just before the
+	// expiry can still be assembled.
+	uploadSessionRetention = time.Hour
+	uploadSessionBatchSize = 100
+)
+
 var (
-	ErrFileNotComplete     = errors.New("file not complete")
-	ErrFileAlreadyComplete = errors.New("file already complete")
+	ErrFileNotComplete          = errors.New("file not complete")
+	ErrUploadSessionExpired     = errors.New("upload session expired")
+	ErrUploadSessionComplete    = errors.New("upload session already complete")
+	ErrUploadSessionInvalidSize = errors.New("upload session size must be positive")
+	ErrUploadChunkTooLarge      = errors.New("upload chunk exceeds remaining size")
+	ErrUploadChunkEmpty         = errors.New("upload chunk is empty")
+	ErrChecksumMismatch         = errors.New("checksum mismatch")
+	ErrFileReleased             = errors.New("file is part of a released version")
 )
 
 type Service interface {
 	GetById(ctx context.Context, id int64) (File, error)
 	List(ctx context.Context, versionId *int64, limit, offset int64) ([]File, error)
+	// ListCompleteByVersion returns all complete files of a version ordered
+	// by name, it isn't paginated.
+	ListCompleteByVersion(ctx context.Context, versionId int64) ([]File, error)
+	// GetLatestByName returns the copy of the named file in the most current
+	// version of the project that contains it, following the project's
+	// latest version strategy.
+	GetLatestByName(ctx context.Context, projectId int64, name string) (File, error)
 	Create(ctx context.Context, req CreateFileRequest) (File, error)
+	CreateWithContents(ctx context.Context, req CreateFileWithContentsRequest) (File, error)
+	// UploadFile stores the contents as a new revision and makes it current.
 	UploadFile(ctx context.Context, id int64, req UploadFileRequest) (File, error)
 	Download(ctx context.Context, id int64) (File, io.ReadSeekCloser, error)
+	// DownloadInVersion returns the file with the contents of the revision
+	// the version pins, or of the current one when it pins none.
+	DownloadInVersion(ctx context.Context, versionId, id int64) (File, io.ReadSeekCloser, error)
+	// ListRevisions returns the revisions of the file, newest first.
+	ListRevisions(ctx context.Context, id int64, limit, offset int64) ([]Revision, error)
+	// DownloadRevision returns the file with the contents of the revision.
+	DownloadRevision(ctx context.Context, id, revisionId int64) (File, io.ReadSeekCloser, error)
+	// RestoreRevision makes an earlier revision current again. It doesn't
+	// copy the contents, the revision is simply pointed at once more.
+	RestoreRevision(ctx context.Context, id, revisionId int64) (File, error)
 	Delete(ctx context.Context, id int64) error
+	CreateUploadSession(ctx context.Context, id int64, req CreateUploadSessionRequest) (UploadSession, error)
+	GetUploadSession(ctx context.Context, id int64, sessionId string) (UploadSession, error)
+	UploadChunk(ctx context.Context, id int64, sessionId string, req UploadChunkRequest) (UploadSession, error)
+	CancelUploadSession(ctx context.Context, id int64, sessionId string) error
+	// DeleteExpiredUploads is the processor of JobKindDeleteExpiredUploads
+	// jobs, it deletes expired upload sessions with their chunks.
+	DeleteExpiredUploads(ctx context.Context, job jobs.Job) error
+	VerifyFile(ctx context.Context, id int64) (FileVerification, error)
+	VerifyFiles(ctx context.Context, limit, offset int64) ([]FileVerification, error)
 }
 
 type service struct {
-	repository  Repository
-	fileStorage storage.FileStorage
+	repository        Repository
+	fileStorage       storage.FileStorage
+	membershipService membership.Service
+	authorizer        Authorizer
+	versionService    version.Service
+	contentService    content.Service
+	auditService      audit.Service
+	webhookService    webhook.Service
+	eventService      events.Service
+	transactions      transaction.Manager
 }
 
-func NewFileService(repository Repository, fileStorage storage.FileStorage) Service {
-	return &service{repository: repository, fileStorage: fileStorage}
+func NewFileService(repository Repository, fileStorage storage.FileStorage, membershipService membership.Service, authorizer Authorizer, versionService version.Service, contentService content.Service, auditService audit.Service, webhookService webhook.Service, eventService events.Service, transactions transaction.Manager) Service {
+	return &service{repository: repository, fileStorage: fileStorage, membershipService: membershipService, authorizer: authorizer, versionService: versionService, contentService: contentService, auditService: auditService, webhookService: webhookService, eventService: eventService, transactions: transactions}
 }
 
 func (s *service) GetById(ctx context.Context, id int64) (File, error) {
-	return s.repository.GetById(ctx, id)
+	return s.authorizer.Authorized(ctx, id, membership.RoleViewer)
 }
 
 func (s *service) List(ctx context.Context, versionId *int64, limit, offset int64) ([]File, error) {
-	return s.repository.List(ctx, versionId, limit, offset)
+	memberId, err := s.membershipService.MemberFilter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.repository.List(ctx, versionId, memberId, limit, offset)
+}
+
+func (s *service) ListCompleteByVersion(ctx context.Context, versionId int64) ([]File, error) {
+	if _, err := s.versionService.GetById(ctx, versionId); err != nil {
+		return nil, err
+	}
+	return s.repository.ListCompleteByVersion(ctx, versionId)
+}
+
+func (s *service) GetLatestByName(ctx context.Context, projectId int64, name string) (File, error) {
+	versions, err := s.versionService.ListLatest(ctx, projectId)
+	if err != nil {
+		return File{}, err
+	}
+	if len(versions) == 0 {
+		return File{}, ErrFileNotFound
+	}
+
+	versionIds := make([]int64, len(versions))
+	for i, v := range versions {
+		versionIds[i] = v.ID
+	}
+	return s.repository.GetByNameInVersions(ctx, name, versionIds)
 }
 
 func (s *service) Create(ctx context.Context, req CreateFileRequest) (File, error) {
+	principal, ok := auth.GetPrincipal(ctx)
+	if !ok {
+		return File{}, auth.ErrForbidden
+	}
+
 	file := File{
 		Name: req.Name,
 	}
-	return s.repository.Create(ctx, file)
+	if principal.UserID != 0 {
+		file.CreatedBy = &principal.UserID
+	}
+
+	err := s.transactions.Run(ctx, func(ctx context.Context) error {
+		created, err := s.repository.Create(ctx, file)
+		if err != nil {
+			return err
+		}
+		file = created
+
+		return s.auditService.Record(ctx, audit.Entry{
+			Action:       audit.ActionCreate,
+			ResourceType: audit.ResourceTypeFile,
+			ResourceID:   file.ID,
+			After:        auditState(file),
+		})
+	})
+	if err != nil {
+		return File{}, err
+	}
+	return file, nil
+}
+
+func (s *service) CreateWithContents(ctx context.Context, req CreateFileWithContentsRequest) (File, error) {
+	file, err := s.Create(ctx, CreateFileRequest{Name: req.Name})
+	if err != nil {
+		return File{}, err
+	}
+
+	stored, err := s.storeContents(ctx, file, req.Contents, req.Size, nil)
+	if err != nil {
+		// Deleting through the service keeps the audit log and subscribers
+		// in line with the file that was already created.
+		deleteErr := s.Delete(ctx, file.ID)
+		if deleteErr != nil {
+			log.Printf("error deleting file after failed store: %v", deleteErr)
+		}
+		return File{}, err
+	}
+
+	return stored, nil
 }
 
 func (s *service) UploadFile(ctx context.Context, id int64, req UploadFileRequest) (File, error) {
@@ -59,38 +200,504 @@ func (s *service) UploadFile(ctx context.Context, id int64, req UploadFileReques
 		}
 	}(req.File)
 
-	file, err := s.repository.GetById(ctx, id)
+	file, err := s.authorizer.Authorized(ctx, id, membership.RoleEditor)
 	if err != nil {
 		return File{}, err
 	}
 
-	if file.IsComplete {
-		return File{}, ErrFileAlreadyComplete
+	return s.storeContents(ctx, file, req.File, req.FileHeader.Size, req.ExpectedChecksum)
+}
+
+func (s *service) Download(ctx context.Context, id int64) (File, io.ReadSeekCloser, error) {
+	file, err := s.authorizer.Authorized(ctx, id, membership.RoleViewer)
+	if err != nil {
+		return File{}, nil, err
 	}
 
-	assetPath := buildFileAssetPath(uuid.NewString())
+	if !file.IsComplete {
+		return File{}, nil, ErrFileNotComplete
+	}
+
+	reader, err := s.fileStorage.Retrieve(ctx, *file.Path)
+	if err != nil {
+		return File{}, nil, err
+	}
+
+	return file, reader, nil
+}
+
+func (s *service) DownloadInVersion(ctx context.Context, versionId, id int64) (File, io.ReadSeekCloser, error) {
+	if _, err := s.authorizer.Authorized(ctx, id, membership.RoleViewer); err != nil {
+		return File{}, nil, err
+	}
+
+	file, err := s.repository.GetInVersion(ctx, versionId, id)
+	if err != nil {
+		return File{}, nil, err
+	}
+
+	if !file.IsComplete || file.Path == nil {
+		return File{}, nil, ErrFileNotComplete
+	}
+
+	reader, err := s.fileStorage.Retrieve(ctx, *file.Path)
+	if err != nil {
+		return File{}, nil, err
+	}
+
+	return file, reader, nil
+}
+
+func (s *service) ListRevisions(ctx context.Context, id int64, limit, offset int64) ([]Revision, error) {
+	if _, err := s.authorizer.Authorized(ctx, id, membership.RoleViewer); err != nil {
+		return nil, err
+	}
+	return s.repository.ListRevisions(ctx, id, limit, offset)
+}
+
+func (s *service) DownloadRevision(ctx context.Context, id, revisionId int64) (File, io.ReadSeekCloser, error) {
+	file, err := s.authorizer.Authorized(ctx, id, membership.RoleViewer)
+	if err != nil {
+		return File{}, nil, err
+	}
+
+	revision, err := s.revision(ctx, id, revisionId)
+	if err != nil {
+		return File{}, nil, err
+	}
+
+	reader, err := s.fileStorage.Retrieve(ctx, revision.Path)
+	if err != nil {
+		return File{}, nil, err
+	}
+
+	file.UpdatedAt = revision.CreatedAt
+	file.Size = &revision.Size
+	file.Path = &revision.Path
+	file.MimeType = &revision.MimeType
+	file.Checksum = revision.Checksum
+	file.RevisionID = &revision.ID
+	return file, reader, nil
+}
+
+func (s *service) RestoreRevision(ctx context.Context, id, revisionId int64) (File, error) {
+	var file File
+	err := s.transactions.Run(ctx, func(ctx context.Context) error {
+		before, err := s.authorizer.Authorized(ctx, id, membership.RoleEditor)
+		if err != nil {
+			return err
+		}
+
+		revision, err := s.revision(ctx, id, revisionId)
+		if err != nil {
+			return err
+		}
+
+		if err := s.repository.Lock(ctx, id); err != nil {
+			return err
+		}
+		restored, err := s.repository.SetCurrentRevision(ctx, revision.ID)
+		if err != nil {
+			return err
+		}
+		file = restored
+
+		err = s.auditService.Record(ctx, audit.Entry{
+			Action:       audit.ActionRestore,
+			ResourceType: audit.ResourceTypeFile,
+			ResourceID:   id,
+			Before:       auditState(before),
+			After:        auditState(file),
+		})
+		if err != nil {
+			return err
+		}
 
-	mimeType, err := mimetype.DetectReader(req.File)
+		projectIds, err := s.repository.ListProjectIds(ctx, id)
+		if err != nil {
+			return err
+		}
+		return s.publish(ctx, webhook.EventFileRestored, file, projectIds)
+	})
 	if err != nil {
 		return File{}, err
 	}
 
-	_, err = req.File.Seek(0, io.SeekStart)
+	err = s.contentService.Enqueue(ctx, file.ID)
+	if err != nil {
+		log.Printf("error scheduling content extraction of file %d: %v", file.ID, err)
+	}
+
+	return file, nil
+}
+
+// revision loads a revision of the file, the revisions of other files aren't
+// found.
+func (s *service) revision(ctx context.Context, id, revisionId int64) (Revision, error) {
+	revision, err := s.repository.GetRevision(ctx, revisionId)
+	if err != nil {
+		return Revision{}, err
+	}
+	if revision.FileID != id {
+		return Revision{}, ErrRevisionNotFound
+	}
+	return revision, nil
+}
+
+func (s *service) Delete(ctx context.Context, id int64) error {
+	return s.transactions.Run(ctx, func(ctx context.Context) error {
+		file, err := s.authorizer.Authorized(ctx, id, membership.RoleEditor)
+		if err != nil {
+			return err
+		}
+
+		released, err := s.repository.IsReleased(ctx, id)
+		if err != nil {
+			return err
+		}
+		if released {
+			return ErrFileReleased
+		}
+
+		// Deleting the file detaches it from its versions, so the projects
+		// it was part of are looked up first, and drops its revisions.
+		projectIds, err := s.repository.ListProjectIds(ctx, id)
+		if err != nil {
+			return err
+		}
+		paths, err := s.repository.ListRevisionPaths(ctx, id)
+		if err != nil {
+			return err
+		}
+		// Its upload sessions go with it, their chunks are deleted as well.
+		chunkPaths, err := s.repository.ListUploadSessionChunkPaths(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		err = s.repository.Delete(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		err = s.auditService.Record(ctx, audit.Entry{
+			Action:       audit.ActionDelete,
+			ResourceType: audit.ResourceTypeFile,
+			ResourceID:   id,
+			Before:       auditState(file),
+		})
+		if err != nil {
+			return err
+		}
+
+		err = s.publish(ctx, webhook.EventFileDeleted, file, projectIds)
+		if err != nil {
+			return err
+		}
+
+		// The assets go once the rows are gone for good, when that fails the
+		// storage reconciliation reports them as orphans.
+		s.transactions.AfterCommit(ctx, func(ctx context.Context) {
+			for _, assetPath := range paths {
+				if err := s.fileStorage.Delete(ctx, assetPath); err != nil {
+					log.Printf("error deleting asset of deleted file %d: %v", id, err)
+				}
+			}
+			s.deleteChunks(ctx, chunkPaths)
+		})
+
+		return nil
+	})
+}
+
+func (s *service) CreateUploadSession(ctx context.Context, id int64, req CreateUploadSessionRequest) (UploadSession, error) {
+	if req.Size <= 0 {
+		return UploadSession{}, ErrUploadSessionInvalidSize
+	}
+
+	file, err := s.authorizer.Authorized(ctx, id, membership.RoleEditor)
+	if err != nil {
+		return UploadSession{}, err
+	}
+
+	var checksum *string
+	if req.ExpectedChecksum != nil {
+		checksum = new(hex.EncodeToString(req.ExpectedChecksum))
+	}
+
+	return s.repository.CreateUploadSession(ctx, UploadSession{
+		FileID:    file.ID,
+		Size:      req.Size,
+		ExpiresAt: time.Now().Add(uploadSessionTTL),
+		Checksum:  checksum,
+	})
+}
+
+func (s *service) GetUploadSession(ctx context.Context, id int64, sessionId string) (UploadSession, error) {
+	if _, err := s.authorizer.Authorized(ctx, id, membership.RoleEditor); err != nil {
+		return UploadSession{}, err
+	}
+	return s.repository.GetUploadSession(ctx, id, sessionId)
+}
+
+// UploadChunk appends a chunk at the given offset. Chunks are stored as
+// separate objects until the last one arrives, at which point they are
+// concatenated into a new revision of the file, which becomes current. A
+// session whose chunks are all received but which failed to assemble can be
+// finalized by sending an empty chunk at the final offset.
+func (s *service) UploadChunk(ctx context.Context, id int64, sessionId string, req UploadChunkRequest) (UploadSession, error) {
+	if _, err := s.authorizer.Authorized(ctx, id, membership.RoleEditor); err != nil {
+		return UploadSession{}, err
+	}
+
+	session, err := s.repository.GetUploadSession(ctx, id, sessionId)
+	if err != nil {
+		return UploadSession{}, err
+	}
+
+	if session.IsComplete {
+		return UploadSession{}, ErrUploadSessionComplete
+	}
+
+	if time.Now().After(session.ExpiresAt) {
+		return UploadSession{}, ErrUploadSessionExpired
+	}
+
+	if req.Offset != session.Offset {
+		return UploadSession{}, ErrUploadOffsetMismatch
+	}
+
+	remaining := session.Size - session.Offset
+	if remaining > 0 {
+		session, err = s.appendChunk(ctx, session, req.Data, remaining, req.ExpectedChecksum)
+		if err != nil {
+			return UploadSession{}, err
+		}
+	}
+
+	if session.Offset < session.Size {
+		return session, nil
+	}
+
+	return s.completeUploadSession(ctx, session)
+}
+
+func (s *service) CancelUploadSession(ctx context.Context, id int64, sessionId string) error {
+	if _, err := s.authorizer.Authorized(ctx, id, membership.RoleEditor); err != nil {
+		return err
+	}
+
+	session, err := s.repository.GetUploadSession(ctx, id, sessionId)
+	if err != nil {
+		return err
+	}
+
+	err = s.repository.DeleteUploadSession(ctx, session.ID)
+	if err != nil {
+		return err
+	}
+
+	s.deleteChunks(ctx, session.ChunkPaths)
+
+	return nil
+}
+
+func (s *service) DeleteExpiredUploads(ctx context.Context, _ jobs.Job) error {
+	expiredBefore := time.Now().Add(-uploadSessionRetention)
+	for {
+		sessions, err := s.repository.ListExpiredUploadSessions(ctx, expiredBefore, uploadSessionBatchSize)
+		if err != nil {
+			return err
+		}
+
+		deleted := 0
+		for _, session := range sessions {
+			// A session whose chunks can't all be deleted is kept, the next
+			// run tries again.
+			if !s.deleteExpiredChunks(ctx, session.ChunkPaths) {
+				continue
+			}
+			if err := s.repository.DeleteUploadSession(ctx, session.ID); err != nil {
+				return err
+			}
+			deleted++
+		}
+
+		if len(sessions) < uploadSessionBatchSize || deleted == 0 {
+			return nil
+		}
+	}
+}
+
+// deleteExpiredChunks deletes the chunks and reports whether none are left,
+// chunks that are already gone don't count as failures.
+func (s *service) deleteExpiredChunks(ctx context.Context, chunkPaths []string) bool {
+	ok := true
+	for _, chunkPath := range chunkPaths {
+		err := s.fileStorage.Delete(ctx, chunkPath)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			log.Printf("error deleting chunk of expired upload: %v", err)
+			ok = false
+		}
+	}
+	return ok
+}
+
+func (s *service) appendChunk(ctx context.Context, session UploadSession, data io.Reader, remaining int64, expectedChecksum []byte) (UploadSession, error) {
+	chunkPath := buildUploadChunkPath(session.ID, uuid.NewString())
+
+	// Read one byte past the remaining size so oversized chunks can be told
+	// apart from chunks that exactly fill the session.
+	counter := &countingReader{reader: io.LimitReader(data, remaining+1)}
+	hash := sha256.New()
+
+	err := s.fileStorage.Save(ctx, chunkPath, io.TeeReader(counter, hash))
+	if err != nil {
+		return UploadSession{}, err
+	}
+
+	if counter.count == 0 || counter.count > remaining {
+		s.deleteChunks(ctx, []string{chunkPath})
+		if counter.count == 0 {
+			return UploadSession{}, ErrUploadChunkEmpty
+		}
+		return UploadSession{}, ErrUploadChunkTooLarge
+	}
+
+	if expectedChecksum != nil && !bytes.Equal(hash.Sum(nil), expectedChecksum) {
+		s.deleteChunks(ctx, []string{chunkPath})
+		return UploadSession{}, ErrChecksumMismatch
+	}
+
+	updated, err := s.repository.AppendUploadSessionChunk(ctx, session.ID, session.Offset, counter.count, chunkPath)
+	if err != nil {
+		s.deleteChunks(ctx, []string{chunkPath})
+		return UploadSession{}, err
+	}
+
+	return updated, nil
+}
+
+// completeUploadSession claims the session before assembling it, so only one
+// of concurrent requests reaching the final offset stores the contents. The
+// claim is undone when storing fails and the session can be finalized again.
+func (s *service) completeUploadSession(ctx context.Context, session UploadSession) (UploadSession, error) {
+	file, err := s.repository.GetById(ctx, session.FileID)
+	if err != nil {
+		return UploadSession{}, err
+	}
+
+	var expectedChecksum []byte
+	if session.Checksum != nil {
+		expectedChecksum, err = hex.DecodeString(*session.Checksum)
+		if err != nil {
+			return UploadSession{}, err
+		}
+	}
+
+	var completed UploadSession
+	err = s.transactions.Run(ctx, func(ctx context.Context) error {
+		claimed, err := s.repository.CompleteUploadSession(ctx, session.ID)
+		if err != nil {
+			return err
+		}
+		completed = claimed
+
+		contents := &chunkReader{ctx: ctx, fileStorage: s.fileStorage, paths: session.ChunkPaths}
+		defer func(contents *chunkReader) {
+			err := contents.Close()
+			if err != nil {
+				log.Printf("error closing upload chunk: %v", err)
+			}
+		}(contents)
+
+		_, err = s.storeContents(ctx, file, contents, session.Size, expectedChecksum)
+		return err
+	})
+	if err != nil {
+		return UploadSession{}, err
+	}
+
+	s.deleteChunks(ctx, session.ChunkPaths)
+
+	return completed, nil
+}
+
+// storeContents saves the contents as the asset of a new revision of the file
+// and makes it current, which also marks the file as complete. The SHA-256
+// checksum is computed while the contents are streamed to storage, the asset
+// is removed again when it doesn't match the expected checksum or when the
+// revision can't be created. The text of the file is extracted in the
+// background, failing to schedule that doesn't fail the upload.
+func (s *service) storeContents(ctx context.Context, file File, contents io.Reader, size int64, expectedChecksum []byte) (File, error) {
+	assetPath := buildFileAssetPath(uuid.NewString())
+
+	mimeType, contents, err := detectMimeType(contents)
 	if err != nil {
 		return File{}, err
 	}
 
-	err = s.fileStorage.Save(ctx, assetPath, req.File)
+	hash := sha256.New()
+
+	err = s.fileStorage.Save(ctx, assetPath, io.TeeReader(contents, hash))
 	if err != nil {
 		return File{}, err
 	}
 
-	file.Size = &req.FileHeader.Size
-	file.Path = &assetPath
-	file.MimeType = new(mimeType.String())
-	file.IsComplete = true
+	checksum := hash.Sum(nil)
+	if expectedChecksum != nil && !bytes.Equal(checksum, expectedChecksum) {
+		fileDeleteErr := s.fileStorage.Delete(ctx, assetPath)
+		if fileDeleteErr != nil {
+			log.Printf("error deleting file during upload: %v", fileDeleteErr)
+		}
+		return File{}, ErrChecksumMismatch
+	}
 
-	file, err = s.repository.Update(ctx, file)
+	revision := Revision{
+		FileID:   file.ID,
+		Path:     assetPath,
+		Size:     size,
+		MimeType: mimeType.String(),
+		Checksum: new(hex.EncodeToString(checksum)),
+	}
+	if principal, ok := auth.GetPrincipal(ctx); ok && principal.UserID != 0 {
+		revision.CreatedBy = &principal.UserID
+	}
+
+	before := file
+	err = s.transactions.Run(ctx, func(ctx context.Context) error {
+		// Concurrent uploads to the file take turns, so each revision gets
+		// the next number.
+		if err := s.repository.Lock(ctx, file.ID); err != nil {
+			return err
+		}
+		created, err := s.repository.CreateRevision(ctx, revision)
+		if err != nil {
+			return err
+		}
+		updated, err := s.repository.SetCurrentRevision(ctx, created.ID)
+		if err != nil {
+			return err
+		}
+		file = updated
+
+		err = s.auditService.Record(ctx, audit.Entry{
+			Action:       audit.ActionUpload,
+			ResourceType: audit.ResourceTypeFile,
+			ResourceID:   file.ID,
+			Before:       auditState(before),
+			After:        auditState(file),
+		})
+		if err != nil {
+			return err
+		}
+
+		projectIds, err := s.repository.ListProjectIds(ctx, file.ID)
+		if err != nil {
+			return err
+		}
+		return s.publish(ctx, webhook.EventFileUploaded, file, projectIds)
+	})
 	if err != nil {
 		fileDeleteErr := s.fileStorage.Delete(ctx, assetPath)
 		if fileDeleteErr != nil {
@@ -99,48 +706,237 @@ func (s *service) UploadFile(ctx context.Context, id int64, req UploadFileReques
 		return File{}, err
 	}
 
+	err = s.contentService.Enqueue(ctx, file.ID)
+	if err != nil {
+		log.Printf("error scheduling content extraction of file %d: %v", file.ID, err)
+	}
+
 	return file, nil
 }
 
-func (s *service) Download(ctx context.Context, id int64) (File, io.ReadSeekCloser, error) {
+func (s *service) VerifyFile(ctx context.Context, id int64) (FileVerification, error) {
+	if err := authorizeAdmin(ctx); err != nil {
+		return FileVerification{}, err
+	}
+
 	file, err := s.repository.GetById(ctx, id)
 	if err != nil {
-		return File{}, nil, err
+		return FileVerification{}, err
 	}
 
 	if !file.IsComplete {
-		return File{}, nil, ErrFileNotComplete
+		return FileVerification{}, ErrFileNotComplete
 	}
 
-	reader, err := s.fileStorage.Retrieve(ctx, *file.Path)
+	return s.verify(ctx, file)
+}
+
+func (s *service) VerifyFiles(ctx context.Context, limit, offset int64) ([]FileVerification, error) {
+	if err := authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	files, err := s.repository.ListComplete(ctx, limit, offset)
 	if err != nil {
-		return File{}, nil, err
+		return nil, err
 	}
 
-	return file, reader, nil
+	verifications := make([]FileVerification, len(files))
+	for i, file := range files {
+		verifications[i], err = s.verify(ctx, file)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return verifications, nil
 }
 
-func (s *service) Delete(ctx context.Context, id int64) error {
-	file, err := s.repository.GetById(ctx, id)
-	if err != nil {
-		return err
+// verify re-hashes the stored asset of a complete file and compares it to the
+// recorded checksum. Files uploaded before checksums were recorded get the
+// computed checksum stored, since there is nothing to compare it against.
+func (s *service) verify(ctx context.Context, file File) (FileVerification, error) {
+	verification := FileVerification{
+		FileID:           file.ID,
+		Name:             file.Name,
+		ExpectedChecksum: file.Checksum,
 	}
 
-	err = s.repository.Delete(ctx, id)
+	reader, err := s.fileStorage.Retrieve(ctx, *file.Path)
 	if err != nil {
-		return err
+		log.Printf("error retrieving file %d for verification: %v", file.ID, err)
+		verification.Status = VerificationStatusMissing
+		return verification, nil
 	}
-
-	if file.Path != nil {
-		err = s.fileStorage.Delete(ctx, *file.Path)
+	defer func(reader io.ReadSeekCloser) {
+		err := reader.Close()
 		if err != nil {
-			return err
+			log.Printf("error closing file reader: %v", err)
+		}
+	}(reader)
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, reader); err != nil {
+		log.Printf("error reading file %d for verification: %v", file.ID, err)
+		verification.Status = VerificationStatusUnreadable
+		return verification, nil
+	}
+	verification.ActualChecksum = new(hex.EncodeToString(hash.Sum(nil)))
+
+	switch {
+	case file.Checksum == nil:
+		file.Checksum = verification.ActualChecksum
+		if _, err := s.repository.Update(ctx, file); err != nil {
+			return FileVerification{}, err
+		}
+		if file.RevisionID != nil {
+			err := s.repository.SetRevisionChecksum(ctx, *file.RevisionID, *file.Checksum)
+			if err != nil {
+				return FileVerification{}, err
+			}
 		}
+		verification.Status = VerificationStatusBackfilled
+	case *file.Checksum == *verification.ActualChecksum:
+		verification.Status = VerificationStatusOk
+	default:
+		verification.Status = VerificationStatusMismatch
 	}
 
+	return verification, nil
+}
+
+// authorizeAdmin restricts verification to instance admins, it reads every
+// stored asset and backfills checksums.
+func authorizeAdmin(ctx context.Context) error {
+	principal, ok := auth.GetPrincipal(ctx)
+	if !ok || !principal.IsAdmin {
+		return auth.ErrForbidden
+	}
 	return nil
 }
 
+func (s *service) deleteChunks(ctx context.Context, chunkPaths []string) {
+	for _, chunkPath := range chunkPaths {
+		err := s.fileStorage.Delete(ctx, chunkPath)
+		if err != nil {
+			log.Printf("error deleting upload chunk: %v", err)
+		}
+	}
+}
+
+// detectMimeType sniffs the MIME type from the head of the contents and
+// returns a reader that still yields the full contents, so streams that can't
+// seek back can be inspected before they are stored.
+func detectMimeType(contents io.Reader) (*mimetype.MIME, io.Reader, error) {
+	header := make([]byte, mimeTypeDetectionLimit)
+	n, err := io.ReadFull(contents, header)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, nil, err
+	}
+	header = header[:n]
+
+	return mimetype.Detect(header), io.MultiReader(bytes.NewReader(header), contents), nil
+}
+
+type countingReader struct {
+	reader io.Reader
+	count  int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.count += int64(n)
+	return n, err
+}
+
+// chunkReader reads the chunks of an upload session back to back, opening
+// each chunk only once the previous one has been fully consumed.
+type chunkReader struct {
+	ctx         context.Context
+	fileStorage storage.FileStorage
+	paths       []string
+	current     io.ReadCloser
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for {
+		if r.current == nil {
+			if len(r.paths) == 0 {
+				return 0, io.EOF
+			}
+
+			current, err := r.fileStorage.Retrieve(r.ctx, r.paths[0])
+			if err != nil {
+				return 0, err
+			}
+			r.current = current
+			r.paths = r.paths[1:]
+		}
+
+		n, err := r.current.Read(p)
+		if errors.Is(err, io.EOF) {
+			closeErr := r.Close()
+			if closeErr != nil {
+				return n, closeErr
+			}
+			if n > 0 {
+				return n, nil
+			}
+			continue
+		}
+
+		return n, err
+	}
+}
+
+func (r *chunkReader) Close() error {
+	if r.current == nil {
+		return nil
+	}
+
+	err := r.current.Close()
+	r.current = nil
+	return err
+}
+
+func buildUploadChunkPath(sessionUuid string, chunkUuid string) string {
+	return path.Join("uploads", sessionUuid, chunkUuid)
+}
+
 func buildFileAssetPath(fileUuid string) string {
 	return path.Join("files", fileUuid)
 }
+
+// publish notifies the subscribers of the projects the file is part of, a
+// file that isn't attached to any version yet only reaches global webhooks
+// and the streams of its creator.
+func (s *service) publish(ctx context.Context, event webhook.Event, file File, projectIds []int64) error {
+	data := toFileResponse(file)
+	err := s.webhookService.Publish(ctx, webhook.Notification{
+		Event:      event,
+		ProjectIDs: projectIds,
+		Data:       data,
+	})
+	if err != nil {
+		return err
+	}
+	return s.eventService.Publish(ctx, events.Notification{
+		Event:      event,
+		ProjectIDs: projectIds,
+		OwnerID:    file.CreatedBy,
+		Data:       data,
+	})
+}
+
+// auditState is what the audit log keeps of a file, the asset path is left
+// out as it's an internal detail.
+func auditState(file File) map[string]any {
+	return map[string]any{
+		"name":       file.Name,
+		"size":       file.Size,
+		"mimeType":   file.MimeType,
+		"checksum":   file.Checksum,
+		"isComplete": file.IsComplete,
+		"revisionId": file.RevisionID,
+	}
+}
@@ -0,0 +1,152 @@
+package jobs
+
+import (
+	"app/pkg/api"
+	"app/pkg/platform/auth"
+	"app/pkg/platform/handler"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Route("/v1/admin/jobs", func(r chi.Router) {
+		r.Get("/", h.List)
+
+		r.Route("/{jobId}", func(r chi.Router) {
+			r.Get("/", h.GetById)
+			r.Post("/retry", h.Retry)
+		})
+	})
+}
+
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	limit, offset := handler.ParsePagination(r)
+
+	var filter ListJobsFilter
+	if r.URL.Query().Has("status") {
+		filter.Status = new(Status(r.URL.Query().Get("status")))
+	}
+	if r.URL.Query().Has("kind") {
+		filter.Kind = new(r.URL.Query().Get("kind"))
+	}
+
+	jobs, err := h.service.List(r.Context(), filter, limit, offset)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrJobInvalidStatus) {
+		handler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	handler.WriteJson(w, http.StatusOK, toListJobsResponse(jobs, limit, offset))
+}
+
+func (h *Handler) GetById(w http.ResponseWriter, r *http.Request) {
+	id, err := parseJobId(r)
+	if err != nil {
+		writeInvalidJobIdError(w)
+		return
+	}
+
+	job, err := h.service.GetById(r.Context(), id)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrJobNotFound) {
+		writeJobNotFoundError(w)
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	handler.WriteJson(w, http.StatusOK, toJobResponse(job))
+}
+
+func (h *Handler) Retry(w http.ResponseWriter, r *http.Request) {
+	id, err := parseJobId(r)
+	if err != nil {
+		writeInvalidJobIdError(w)
+		return
+	}
+
+	job, err := h.service.Retry(r.Context(), id)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrJobNotFound) {
+		writeJobNotFoundError(w)
+		return
+	}
+	if errors.Is(err, ErrJobNotFailed) {
+		handler.WriteError(w, http.StatusConflict, "only failed jobs can be retried")
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	handler.WriteJson(w, http.StatusOK, toJobResponse(job))
+}
+
+func writeInvalidJobIdError(w http.ResponseWriter) {
+	handler.WriteError(w, http.StatusBadRequest, "invalid job id")
+}
+
+func writeJobNotFoundError(w http.ResponseWriter) {
+	handler.WriteError(w, http.StatusNotFound, "job not found")
+}
+
+func parseJobId(r *http.Request) (int64, error) {
+	return strconv.ParseInt(chi.URLParam(r, "jobId"), 10, 64)
+}
+
+func toJobResponse(j Job) api.JobResponse {
+	return api.JobResponse{
+		Id:          j.ID,
+		CreatedAt:   j.CreatedAt,
+		UpdatedAt:   j.UpdatedAt,
+		Kind:        j.Kind,
+		Payload:     j.Payload,
+		Status:      api.JobStatus(j.Status),
+		Attempts:    j.Attempts,
+		MaxAttempts: j.MaxAttempts,
+		RunAt:       j.RunAt,
+		LockedAt:    j.LockedAt,
+		LastError:   j.LastError,
+		CompletedAt: j.CompletedAt,
+	}
+}
+
+func toListJobsResponse(jobs []Job, limit, offset int64) api.ListJobsResponse {
+	items := make([]api.JobResponse, len(jobs))
+	for i, job := range jobs {
+		items[i] = toJobResponse(job)
+	}
+	return api.ListJobsResponse{
+		Limit:  limit,
+		Offset: offset,
+		Jobs:   items,
+	}
+}
@@ -0,0 +1,61 @@
+package jobs
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	// StatusFailed marks dead letters, jobs that ran out of attempts.
+	StatusFailed Status = "failed"
+)
+
+func (s Status) IsValid() bool {
+	switch s {
+	case StatusPending, StatusRunning, StatusCompleted, StatusFailed:
+		return true
+	}
+	return false
+}
+
+type Job struct {
+	ID          int64
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Kind        string
+	Payload     json.RawMessage
+	Status      Status
+	Attempts    int32
+	MaxAttempts int32
+	RunAt       time.Time
+	LockedAt    *time.Time
+	LastError   *string
+	CompletedAt *time.Time
+	UniqueKey   *string
+}
+
+// Decode unmarshals the JSON payload of the job into v.
+func (j Job) Decode(v any) error {
+	return json.Unmarshal(j.Payload, v)
+}
+
+// EnqueueRequest describes a job to run. The payload is stored as JSON, a job
+// with a unique key is only queued once and MaxAttempts defaults to
+// DefaultMaxAttempts.
+type EnqueueRequest struct {
+	Kind        string
+	Payload     any
+	Delay       time.Duration
+	MaxAttempts int32
+	UniqueKey   *string
+}
+
+type ListJobsFilter struct {
+	Status *Status
+	Kind   *string
+}
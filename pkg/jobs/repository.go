@@ -0,0 +1,177 @@
+package jobs
+
+import (
+	"app/pkg/database"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrJobNotFound      = errors.New("job not found")
+	ErrJobAlreadyQueued = errors.New("job already queued")
+	ErrJobNotFailed     = errors.New("job has not failed")
+	ErrNoJobDue         = errors.New("no job due")
+)
+
+type Repository interface {
+	Enqueue(ctx context.Context, job Job, delay time.Duration) (Job, error)
+	// Claim marks the next due job of one of the kinds as running, it fails
+	// with ErrNoJobDue when there is none.
+	Claim(ctx context.Context, kinds []string) (Job, error)
+	Complete(ctx context.Context, id int64) error
+	Reschedule(ctx context.Context, id int64, delay time.Duration, lastError string) error
+	Fail(ctx context.Context, id int64, lastError string) error
+	// Heartbeat renews the claim of a running job.
+	Heartbeat(ctx context.Context, id int64) error
+	// ReleaseStale hands back the running jobs whose claim wasn't renewed
+	// within the timeout, or fails them when they have no attempts left.
+	ReleaseStale(ctx context.Context, timeout time.Duration) (int64, error)
+	DeleteCompleted(ctx context.Context, age time.Duration) (int64, error)
+	GetById(ctx context.Context, id int64) (Job, error)
+	List(ctx context.Context, filter ListJobsFilter, limit, offset int64) ([]Job, error)
+	Retry(ctx context.Context, id int64) (Job, error)
+}
+
+type repository struct {
+	queries *database.Queries
+}
+
+func NewRepository(queries *database.Queries) Repository {
+	return &repository{queries: queries}
+}
+
+func (r *repository) Enqueue(ctx context.Context, job Job, delay time.Duration) (Job, error) {
+	row, err := r.queries.EnqueueJob(ctx, &database.EnqueueJobParams{
+		Kind:        job.Kind,
+		Payload:     job.Payload,
+		MaxAttempts: job.MaxAttempts,
+		Delay:       toInterval(delay),
+		UniqueKey:   job.UniqueKey,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Job{}, ErrJobAlreadyQueued
+	}
+	if err != nil {
+		return Job{}, err
+	}
+	return toJob(row), nil
+}
+
+func (r *repository) Claim(ctx context.Context, kinds []string) (Job, error) {
+	row, err := r.queries.ClaimJob(ctx, kinds)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Job{}, ErrNoJobDue
+	}
+	if err != nil {
+		return Job{}, err
+	}
+	return toJob(row), nil
+}
+
+func (r *repository) Complete(ctx context.Context, id int64) error {
+	return r.queries.CompleteJob(ctx, id)
+}
+
+func (r *repository) Reschedule(ctx context.Context, id int64, delay time.Duration, lastError string) error {
+	return r.queries.RescheduleJob(ctx, &database.RescheduleJobParams{
+		ID:        id,
+		Delay:     toInterval(delay),
+		LastError: &lastError,
+	})
+}
+
+func (r *repository) Fail(ctx context.Context, id int64, lastError string) error {
+	return r.queries.FailJob(ctx, &database.FailJobParams{
+		ID:        id,
+		LastError: &lastError,
+	})
+}
+
+func (r *repository) Heartbeat(ctx context.Context, id int64) error {
+	return r.queries.HeartbeatJob(ctx, id)
+}
+
+func (r *repository) ReleaseStale(ctx context.Context, timeout time.Duration) (int64, error) {
+	return r.queries.ReleaseStaleJobs(ctx, toInterval(timeout))
+}
+
+func (r *repository) DeleteCompleted(ctx context.Context, age time.Duration) (int64, error) {
+	return r.queries.DeleteCompletedJobs(ctx, toInterval(age))
+}
+
+func (r *repository) GetById(ctx context.Context, id int64) (Job, error) {
+	row, err := r.queries.GetJob(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Job{}, ErrJobNotFound
+	}
+	if err != nil {
+		return Job{}, err
+	}
+	return toJob(row), nil
+}
+
+func (r *repository) List(ctx context.Context, filter ListJobsFilter, limit, offset int64) ([]Job, error) {
+	rows, err := r.queries.ListJobs(ctx, &database.ListJobsParams{
+		Status: (*string)(filter.Status),
+		Kind:   filter.Kind,
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+	jobs := make([]Job, len(rows))
+	for i, row := range rows {
+		jobs[i] = toJob(row)
+	}
+	return jobs, nil
+}
+
+// Retry only resets failed jobs, for any other job it tells apart a missing
+// job from one that hasn't failed.
+func (r *repository) Retry(ctx context.Context, id int64) (Job, error) {
+	row, err := r.queries.RetryJob(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		if _, err := r.GetById(ctx, id); err != nil {
+			return Job{}, err
+		}
+		return Job{}, ErrJobNotFailed
+	}
+	if err != nil {
+		return Job{}, err
+	}
+	return toJob(row), nil
+}
+
+func toJob(row *database.Job) Job {
+	return Job{
+		ID:          row.ID,
+		CreatedAt:   row.CreatedAt.Time,
+		UpdatedAt:   row.UpdatedAt.Time,
+		Kind:        row.Kind,
+		Payload:     row.Payload,
+		Status:      Status(row.Status),
+		Attempts:    row.Attempts,
+		MaxAttempts: row.MaxAttempts,
+		RunAt:       row.RunAt.Time,
+		LockedAt:    toTime(row.LockedAt),
+		LastError:   row.LastError,
+		CompletedAt: toTime(row.CompletedAt),
+		UniqueKey:   row.UniqueKey,
+	}
+}
+
+func toTime(timestamp pgtype.Timestamp) *time.Time {
+	if !timestamp.Valid {
+		return nil
+	}
+	return &timestamp.Time
+}
+
+func toInterval(d time.Duration) pgtype.Interval {
+	return pgtype.Interval{Microseconds: d.Microseconds(), Valid: true}
+}
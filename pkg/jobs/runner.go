@@ -0,0 +1,248 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	pollInterval = 5 * time.Second
+	// heartbeatInterval is how often a running job's claim is renewed.
+	heartbeatInterval = 30 * time.Second
+	// staleTimeout is how long a claim may go without a heartbeat before it's
+	// assumed that the worker is gone and the job is handed to another one.
+	// It spans several heartbeats, so a slow database doesn't release jobs
+	// that are still running.
+	staleTimeout = 5 * time.Minute
+	// completedRetention is how long completed jobs are kept for inspection.
+	completedRetention = 7 * 24 * time.Hour
+
+	backoffBase = 10 * time.Second
+	backoffMax  = time.Hour
+
+	KindDeleteCompleted = "jobs.delete-completed"
+)
+
+// Processor runs a job. Returning an error schedules another attempt with
+// exponential backoff until the job runs out of attempts and is marked
+// failed. The context is cancelled when the runner stops draining.
+type Processor func(ctx context.Context, job Job) error
+
+type schedule struct {
+	kind     string
+	interval time.Duration
+}
+
+// Runner claims due jobs from the jobs table with a number of workers. Any
+// number of runners may share the table, a job is only ever claimed by one.
+type Runner struct {
+	repository  Repository
+	service     Service
+	concurrency int
+	processors  map[string]Processor
+	schedules   []schedule
+
+	stopClaiming context.CancelFunc
+	cancelJobs   context.CancelFunc
+	wg           sync.WaitGroup
+}
+
+func NewRunner(repository Repository, service Service, concurrency int) *Runner {
+	runner := &Runner{
+		repository:  repository,
+		service:     service,
+		concurrency: concurrency,
+		processors:  make(map[string]Processor),
+	}
+	runner.Register(KindDeleteCompleted, runner.deleteCompleted)
+	runner.Schedule(KindDeleteCompleted, time.Hour)
+	return runner
+}
+
+// Register sets the processor of a kind of job, it must be called before
+// Start.
+func (r *Runner) Register(kind string, processor Processor) {
+	r.processors[kind] = processor
+}
+
+// Schedule queues a job of the kind once per interval of at least a minute,
+// it must be called before Start. Runs are aligned to multiples of the
+// interval, so runners on several instances agree on them and each run is
+// queued once.
+func (r *Runner) Schedule(kind string, interval time.Duration) {
+	r.schedules = append(r.schedules, schedule{kind: kind, interval: interval})
+}
+
+// Start starts the workers and the scheduler, they run until Shutdown.
+func (r *Runner) Start() {
+	claimCtx, stopClaiming := context.WithCancel(context.Background())
+	jobCtx, cancelJobs := context.WithCancel(context.Background())
+	r.stopClaiming = stopClaiming
+	r.cancelJobs = cancelJobs
+
+	kinds := slices.Sorted(maps.Keys(r.processors))
+	for range r.concurrency {
+		r.wg.Go(func() {
+			r.work(claimCtx, jobCtx, kinds)
+		})
+	}
+	r.wg.Go(func() {
+		r.schedule(claimCtx)
+	})
+}
+
+// Shutdown stops claiming jobs and waits for the running ones to finish.
+// When the context expires first, the running jobs are cancelled and
+// rescheduled, and the context's error is returned.
+func (r *Runner) Shutdown(ctx context.Context) error {
+	r.stopClaiming()
+
+	done := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		r.cancelJobs()
+		return nil
+	case <-ctx.Done():
+		r.cancelJobs()
+		<-done
+		return ctx.Err()
+	}
+}
+
+// work claims and runs jobs until claiming stops. The claim itself uses the
+// job context, cancelling it halfway could lose a claimed job until it's
+// released as stale.
+func (r *Runner) work(claimCtx, jobCtx context.Context, kinds []string) {
+	for claimCtx.Err() == nil {
+		job, err := r.repository.Claim(jobCtx, kinds)
+		if err != nil {
+			if !errors.Is(err, ErrNoJobDue) && claimCtx.Err() == nil {
+				log.Printf("error claiming job: %v", err)
+			}
+			select {
+			case <-claimCtx.Done():
+			case <-time.After(pollInterval):
+			}
+			continue
+		}
+
+		r.run(jobCtx, job)
+	}
+}
+
+// run processes a claimed job and records the outcome. The outcome is
+// recorded even when the job was cancelled by a shutdown.
+func (r *Runner) run(ctx context.Context, job Job) {
+	heartbeatCtx, stopHeartbeat := context.WithCancel(ctx)
+	var heartbeat sync.WaitGroup
+	heartbeat.Go(func() {
+		r.heartbeat(heartbeatCtx, job.ID)
+	})
+
+	err := r.process(ctx, job)
+	stopHeartbeat()
+	heartbeat.Wait()
+	recordCtx := context.WithoutCancel(ctx)
+
+	switch {
+	case err == nil:
+		err = r.repository.Complete(recordCtx, job.ID)
+	case ctx.Err() != nil:
+		err = r.repository.Reschedule(recordCtx, job.ID, 0, fmt.Sprintf("interrupted by shutdown: %v", err))
+	case job.Attempts >= job.MaxAttempts:
+		log.Printf("job %d (%s) failed after %d attempts: %v", job.ID, job.Kind, job.Attempts, err)
+		err = r.repository.Fail(recordCtx, job.ID, err.Error())
+	default:
+		err = r.repository.Reschedule(recordCtx, job.ID, backoff(job.Attempts), err.Error())
+	}
+	if err != nil {
+		log.Printf("error recording outcome of job %d: %v", job.ID, err)
+	}
+}
+
+// heartbeat renews the claim of a running job until the context is done.
+func (r *Runner) heartbeat(ctx context.Context, id int64) {
+	ticker := time.NewTicker(heartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := r.repository.Heartbeat(ctx, id); err != nil && ctx.Err() == nil {
+			log.Printf("error renewing claim of job %d: %v", id, err)
+		}
+	}
+}
+
+func (r *Runner) process(ctx context.Context, job Job) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic: %v", p)
+		}
+	}()
+	return r.processors[job.Kind](ctx, job)
+}
+
+// schedule checks every minute that the scheduled jobs of the current period
+// are queued, the unique key turns all but the first check into a no-op. It
+// also hands stale jobs back to the queue.
+func (r *Runner) schedule(ctx context.Context) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		now := time.Now()
+		for _, schedule := range r.schedules {
+			period := now.Truncate(schedule.interval)
+			key := fmt.Sprintf("%s@%s", schedule.kind, period.UTC().Format(time.RFC3339))
+			_, err := r.service.Enqueue(ctx, EnqueueRequest{Kind: schedule.kind, UniqueKey: &key})
+			if err != nil && !errors.Is(err, ErrJobAlreadyQueued) && ctx.Err() == nil {
+				log.Printf("error scheduling job %s: %v", schedule.kind, err)
+			}
+		}
+
+		released, err := r.repository.ReleaseStale(ctx, staleTimeout)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("error releasing stale jobs: %v", err)
+		}
+		if released > 0 {
+			log.Printf("released %d stale jobs", released)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Runner) deleteCompleted(ctx context.Context, _ Job) error {
+	_, err := r.repository.DeleteCompleted(ctx, completedRetention)
+	return err
+}
+
+// backoff doubles the delay with every attempt up to backoffMax, with up to
+// 20% jitter so jobs that failed together don't all retry together.
+func backoff(attempts int32) time.Duration {
+	delay := backoffMax
+	if attempts <= 12 {
+		delay = min(backoffBase<<(attempts-1), backoffMax)
+	}
+	return delay - time.Duration(rand.Int64N(int64(delay)/5+1))
+}
@@ -0,0 +1,92 @@
+package jobs
+
+import (
+	"app/pkg/platform/auth"
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+const DefaultMaxAttempts = 5
+
+var (
+	ErrJobInvalidStatus      = errors.New("invalid job status")
+	ErrJobInvalidMaxAttempts = errors.New("job max attempts must be positive")
+)
+
+type Service interface {
+	// Enqueue queues a job for the runner, it fails with ErrJobAlreadyQueued
+	// when the unique key was used before.
+	Enqueue(ctx context.Context, req EnqueueRequest) (Job, error)
+	GetById(ctx context.Context, id int64) (Job, error)
+	List(ctx context.Context, filter ListJobsFilter, limit, offset int64) ([]Job, error)
+	// Retry runs a failed job again with a fresh set of attempts.
+	Retry(ctx context.Context, id int64) (Job, error)
+}
+
+type service struct {
+	repository Repository
+}
+
+func NewService(repository Repository) Service {
+	return &service{repository: repository}
+}
+
+func (s *service) Enqueue(ctx context.Context, req EnqueueRequest) (Job, error) {
+	if req.MaxAttempts == 0 {
+		req.MaxAttempts = DefaultMaxAttempts
+	}
+	if req.MaxAttempts < 0 {
+		return Job{}, ErrJobInvalidMaxAttempts
+	}
+
+	payload := json.RawMessage("{}")
+	if req.Payload != nil {
+		var err error
+		payload, err = json.Marshal(req.Payload)
+		if err != nil {
+			return Job{}, err
+		}
+	}
+
+	return s.repository.Enqueue(ctx, Job{
+		Kind:        req.Kind,
+		Payload:     payload,
+		MaxAttempts: req.MaxAttempts,
+		UniqueKey:   req.UniqueKey,
+	}, req.Delay)
+}
+
+func (s *service) GetById(ctx context.Context, id int64) (Job, error) {
+	if err := authorizeAdmin(ctx); err != nil {
+		return Job{}, err
+	}
+	return s.repository.GetById(ctx, id)
+}
+
+func (s *service) List(ctx context.Context, filter ListJobsFilter, limit, offset int64) ([]Job, error) {
+	if err := authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if filter.Status != nil && !filter.Status.IsValid() {
+		return nil, ErrJobInvalidStatus
+	}
+	return s.repository.List(ctx, filter, limit, offset)
+}
+
+func (s *service) Retry(ctx context.Context, id int64) (Job, error) {
+	if err := authorizeAdmin(ctx); err != nil {
+		return Job{}, err
+	}
+	return s.repository.Retry(ctx, id)
+}
+
+// authorizeAdmin restricts the job administration to instance admins, jobs
+// aren't bound to a project.
+func authorizeAdmin(ctx context.Context) error {
+	principal, ok := auth.GetPrincipal(ctx)
+	if !ok || !principal.IsAdmin {
+		return auth.ErrForbidden
+	}
+	return nil
+}
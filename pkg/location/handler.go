@@ -0,0 +1,204 @@
+package location
+
+import (
+	"app/pkg/api"
+	"app/pkg/platform/auth"
+	"app/pkg/platform/handler"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Route("/v1/locations", func(r chi.Router) {
+		r.Get("/", h.List)
+		r.Post("/", h.Create)
+
+		r.Route("/{locationId}", func(r chi.Router) {
+			r.Get("/", h.GetById)
+			r.Put("/", h.Update)
+			r.Delete("/", h.Delete)
+		})
+	})
+}
+
+func (h *Handler) GetById(w http.ResponseWriter, r *http.Request) {
+	id, err := parseLocationId(r)
+	if err != nil {
+		writeInvalidLocationIdError(w)
+		return
+	}
+
+	location, err := h.service.GetById(r.Context(), id)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrLocationNotFound) {
+		writeLocationNotFoundError(w)
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	handler.WriteJson(w, http.StatusOK, ToLocationResponse(location))
+}
+
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	limit, offset := handler.ParsePagination(r)
+
+	locations, err := h.service.List(r.Context(), limit, offset)
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	handler.WriteJson(w, http.StatusOK, toListLocationsResponse(locations, limit, offset))
+}
+
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	var req api.CreateLocationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handler.WriteInvalidRequestPayloadError(w)
+		return
+	}
+
+	location, err := h.service.Create(r.Context(), CreateLocationRequest{
+		Name:    req.Name,
+		Address: req.Address,
+		Lat:     req.Lat,
+		Lon:     req.Lon,
+	})
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrLocationIncompleteCoordinates) {
+		handler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	handler.WriteJson(w, http.StatusCreated, ToLocationResponse(location))
+}
+
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := parseLocationId(r)
+	if err != nil {
+		writeInvalidLocationIdError(w)
+		return
+	}
+
+	var req api.UpdateLocationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handler.WriteInvalidRequestPayloadError(w)
+		return
+	}
+
+	location, err := h.service.Update(r.Context(), id, UpdateLocationRequest{
+		Name:    req.Name,
+		Address: req.Address,
+		Lat:     req.Lat,
+		Lon:     req.Lon,
+	})
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrLocationNotFound) {
+		writeLocationNotFoundError(w)
+		return
+	}
+	if errors.Is(err, ErrLocationIncompleteCoordinates) {
+		handler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	handler.WriteJson(w, http.StatusOK, ToLocationResponse(location))
+}
+
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := parseLocationId(r)
+	if err != nil {
+		writeInvalidLocationIdError(w)
+		return
+	}
+
+	err = h.service.Delete(r.Context(), id)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrLocationNotFound) {
+		writeLocationNotFoundError(w)
+		return
+	}
+	if errors.Is(err, ErrLocationInUse) {
+		handler.WriteError(w, http.StatusConflict, "location is assigned to projects")
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeInvalidLocationIdError(w http.ResponseWriter) {
+	handler.WriteError(w, http.StatusBadRequest, "invalid location id")
+}
+
+func writeLocationNotFoundError(w http.ResponseWriter) {
+	handler.WriteError(w, http.StatusNotFound, "location not found")
+}
+
+func parseLocationId(r *http.Request) (int64, error) {
+	return strconv.ParseInt(chi.URLParam(r, "locationId"), 10, 64)
+}
+
+// ToLocationResponse is exported for the project handler, which embeds the
+// location of a project in its response.
+func ToLocationResponse(l Location) api.LocationResponse {
+	return api.LocationResponse{
+		Id:        l.ID,
+		CreatedAt: l.CreatedAt,
+		UpdatedAt: l.UpdatedAt,
+		Name:      l.Name,
+		Address:   l.Address,
+		Lat:       l.Lat,
+		Lon:       l.Lon,
+	}
+}
+
+func toListLocationsResponse(locations []Location, limit, offset int64) api.ListLocationsResponse {
+	items := make([]api.LocationResponse, len(locations))
+	for i, location := range locations {
+		items[i] = ToLocationResponse(location)
+	}
+	return api.ListLocationsResponse{
+		Limit:     limit,
+		Offset:    offset,
+		Locations: items,
+	}
+}
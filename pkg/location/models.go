@@ -0,0 +1,28 @@
+package location
+
+import "time"
+
+type Location struct {
+	ID        int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Name      string
+	Address   *string
+	Lat       *float64
+	Lon       *float64
+	CreatedBy *int64
+}
+
+type CreateLocationRequest struct {
+	Name    string
+	Address *string
+	Lat     *float64
+	Lon     *float64
+}
+
+type UpdateLocationRequest struct {
+	Name    string
+	Address *string
+	Lat     *float64
+	Lon     *float64
+}
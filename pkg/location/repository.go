@@ -0,0 +1,141 @@
+package location
+
+import (
+	"app/pkg/database"
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrLocationNotFound = errors.New("location not found")
+	ErrLocationInUse    = errors.New("location in use")
+)
+
+type Repository interface {
+	GetById(ctx context.Context, id int64) (Location, error)
+	// GetByIds and List return the locations of the projects memberId is a
+	// member of and those they created, every location when it is nil.
+	GetByIds(ctx context.Context, ids []int64, memberId *int64) ([]Location, error)
+	List(ctx context.Context, memberId *int64, limit, offset int64) ([]Location, error)
+	Create(ctx context.Context, location Location) (Location, error)
+	Update(ctx context.Context, location Location) (Location, error)
+	Delete(ctx context.Context, id int64) error
+	// ListProjectIds returns the projects the location is assigned to.
+	ListProjectIds(ctx context.Context, id int64) ([]int64, error)
+}
+
+type repository struct {
+	queries *database.Queries
+}
+
+func NewRepository(queries *database.Queries) Repository {
+	return &repository{queries: queries}
+}
+
+func (r *repository) GetById(ctx context.Context, id int64) (Location, error) {
+	row, err := r.queries.GetLocation(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Location{}, ErrLocationNotFound
+	}
+	if err != nil {
+		return Location{}, err
+	}
+	return toLocation(row), nil
+}
+
+func (r *repository) GetByIds(ctx context.Context, ids []int64, memberId *int64) ([]Location, error) {
+	rows, err := r.queries.ListLocationsByIds(ctx, &database.ListLocationsByIdsParams{
+		Ids:      ids,
+		MemberId: memberId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	locations := make([]Location, len(rows))
+	for i, row := range rows {
+		locations[i] = toLocation(row)
+	}
+	return locations, nil
+}
+
+func (r *repository) List(ctx context.Context, memberId *int64, limit, offset int64) ([]Location, error) {
+	rows, err := r.queries.ListLocations(ctx, &database.ListLocationsParams{
+		MemberId: memberId,
+		Limit:    limit,
+		Offset:   offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+	locations := make([]Location, len(rows))
+	for i, row := range rows {
+		locations[i] = toLocation(row)
+	}
+	return locations, nil
+}
+
+func (r *repository) Create(ctx context.Context, location Location) (Location, error) {
+	row, err := r.queries.CreateLocation(ctx, &database.CreateLocationParams{
+		Name:      location.Name,
+		Address:   location.Address,
+		Lat:       location.Lat,
+		Lon:       location.Lon,
+		CreatedBy: location.CreatedBy,
+	})
+	if err != nil {
+		return Location{}, err
+	}
+	return toLocation(row), nil
+}
+
+func (r *repository) Update(ctx context.Context, location Location) (Location, error) {
+	row, err := r.queries.UpdateLocation(ctx, &database.UpdateLocationParams{
+		ID:      location.ID,
+		Name:    location.Name,
+		Address: location.Address,
+		Lat:     location.Lat,
+		Lon:     location.Lon,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Location{}, ErrLocationNotFound
+	}
+	if err != nil {
+		return Location{}, err
+	}
+	return toLocation(row), nil
+}
+
+func (r *repository) Delete(ctx context.Context, id int64) error {
+	err := r.queries.DeleteLocation(ctx, id)
+	if err != nil {
+		if isPgForeignKeyViolation(err) {
+			return ErrLocationInUse
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *repository) ListProjectIds(ctx context.Context, id int64) ([]int64, error) {
+	return r.queries.ListProjectIdsByLocationId(ctx, &id)
+}
+
+func toLocation(row *database.Location) Location {
+	return Location{
+		ID:        row.ID,
+		CreatedAt: row.CreatedAt.Time,
+		UpdatedAt: row.UpdatedAt.Time,
+		Name:      row.Name,
+		Address:   row.Address,
+		Lat:       row.Lat,
+		Lon:       row.Lon,
+		CreatedBy: row.CreatedBy,
+	}
+}
+
+func isPgForeignKeyViolation(err error) bool {
+	return err != nil && (strings.Contains(err.Error(), "23503") || strings.Contains(err.Error(), "foreign key"))
+}
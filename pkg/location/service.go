@@ -0,0 +1,234 @@
+package location
+
+import (
+	"app/pkg/audit"
+	"app/pkg/membership"
+	"app/pkg/platform/auth"
+	"app/pkg/platform/transaction"
+	"context"
+	"errors"
+)
+
+var ErrLocationIncompleteCoordinates = errors.New("location requires both lat and lon")
+
+// Service manages locations. Reading a location is open to instance admins,
+// its creator and the members of the projects it is assigned to.
+type Service interface {
+	GetById(ctx context.Context, id int64) (Location, error)
+	// GetByIds leaves out the locations the caller may not read.
+	GetByIds(ctx context.Context, ids []int64) ([]Location, error)
+	List(ctx context.Context, limit, offset int64) ([]Location, error)
+	Create(ctx context.Context, req CreateLocationRequest) (Location, error)
+	// Update and Delete are open to instance admins, the admins of every
+	// project the location is assigned to and, while it isn't assigned to
+	// any, its creator.
+	Update(ctx context.Context, id int64, req UpdateLocationRequest) (Location, error)
+	Delete(ctx context.Context, id int64) error
+}
+
+type service struct {
+	repository        Repository
+	membershipService membership.Service
+	auditService      audit.Service
+	transactions      transaction.Manager
+}
+
+func NewService(repository Repository, membershipService membership.Service, auditService audit.Service, transactions transaction.Manager) Service {
+	return &service{repository: repository, membershipService: membershipService, auditService: auditService, transactions: transactions}
+}
+
+func (s *service) GetById(ctx context.Context, id int64) (Location, error) {
+	return s.readable(ctx, id)
+}
+
+func (s *service) GetByIds(ctx context.Context, ids []int64) ([]Location, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	memberId, err := s.membershipService.MemberFilter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.repository.GetByIds(ctx, ids, memberId)
+}
+
+func (s *service) List(ctx context.Context, limit, offset int64) ([]Location, error) {
+	memberId, err := s.membershipService.MemberFilter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.repository.List(ctx, memberId, limit, offset)
+}
+
+func (s *service) Create(ctx context.Context, req CreateLocationRequest) (Location, error) {
+	principal, ok := auth.GetPrincipal(ctx)
+	if !ok {
+		return Location{}, auth.ErrForbidden
+	}
+	if (req.Lat == nil) != (req.Lon == nil) {
+		return Location{}, ErrLocationIncompleteCoordinates
+	}
+
+	location := Location{
+		Name:    req.Name,
+		Address: req.Address,
+		Lat:     req.Lat,
+		Lon:     req.Lon,
+	}
+	if principal.UserID != 0 {
+		location.CreatedBy = &principal.UserID
+	}
+
+	err := s.transactions.Run(ctx, func(ctx context.Context) error {
+		created, err := s.repository.Create(ctx, location)
+		if err != nil {
+			return err
+		}
+		location = created
+
+		return s.auditService.Record(ctx, audit.Entry{
+			Action:       audit.ActionCreate,
+			ResourceType: audit.ResourceTypeLocation,
+			ResourceID:   location.ID,
+			After:        auditState(location),
+		})
+	})
+	if err != nil {
+		return Location{}, err
+	}
+	return location, nil
+}
+
+func (s *service) Update(ctx context.Context, id int64, req UpdateLocationRequest) (Location, error) {
+	if (req.Lat == nil) != (req.Lon == nil) {
+		return Location{}, ErrLocationIncompleteCoordinates
+	}
+
+	var location Location
+	err := s.transactions.Run(ctx, func(ctx context.Context) error {
+		before, err := s.changeable(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		location, err = s.repository.Update(ctx, Location{
+			ID:      id,
+			Name:    req.Name,
+			Address: req.Address,
+			Lat:     req.Lat,
+			Lon:     req.Lon,
+		})
+		if err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, audit.Entry{
+			Action:       audit.ActionUpdate,
+			ResourceType: audit.ResourceTypeLocation,
+			ResourceID:   id,
+			Before:       auditState(before),
+			After:        auditState(location),
+		})
+	})
+	if err != nil {
+		return Location{}, err
+	}
+	return location, nil
+}
+
+func (s *service) Delete(ctx context.Context, id int64) error {
+	return s.transactions.Run(ctx, func(ctx context.Context) error {
+		location, err := s.changeable(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.repository.Delete(ctx, id); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, audit.Entry{
+			Action:       audit.ActionDelete,
+			ResourceType: audit.ResourceTypeLocation,
+			ResourceID:   id,
+			Before:       auditState(location),
+		})
+	})
+}
+
+// readable loads a location the caller may read, they must have created it or
+// be a member of one of the projects it is assigned to.
+func (s *service) readable(ctx context.Context, id int64) (Location, error) {
+	location, err := s.repository.GetById(ctx, id)
+	if err != nil {
+		return Location{}, err
+	}
+
+	principal, ok := auth.GetPrincipal(ctx)
+	if !ok {
+		return Location{}, auth.ErrForbidden
+	}
+	if principal.IsAdmin || (location.CreatedBy != nil && *location.CreatedBy == principal.UserID) {
+		return location, nil
+	}
+
+	projectIds, err := s.repository.ListProjectIds(ctx, id)
+	if err != nil {
+		return Location{}, err
+	}
+	for _, projectId := range projectIds {
+		err := s.membershipService.Authorize(ctx, projectId, membership.RoleViewer)
+		if err == nil {
+			return location, nil
+		}
+		if !errors.Is(err, auth.ErrForbidden) {
+			return Location{}, err
+		}
+	}
+	return Location{}, auth.ErrForbidden
+}
+
+// changeable loads a location the caller may change. Locations are shared
+// between projects, so changing one that is assigned takes the admin role on
+// all of them.
+func (s *service) changeable(ctx context.Context, id int64) (Location, error) {
+	location, err := s.repository.GetById(ctx, id)
+	if err != nil {
+		return Location{}, err
+	}
+
+	principal, ok := auth.GetPrincipal(ctx)
+	if !ok {
+		return Location{}, auth.ErrForbidden
+	}
+	if principal.IsAdmin {
+		return location, nil
+	}
+
+	projectIds, err := s.repository.ListProjectIds(ctx, id)
+	if err != nil {
+		return Location{}, err
+	}
+	if len(projectIds) == 0 {
+		if location.CreatedBy != nil && *location.CreatedBy == principal.UserID {
+			return location, nil
+		}
+		return Location{}, auth.ErrForbidden
+	}
+	for _, projectId := range projectIds {
+		if err := s.membershipService.Authorize(ctx, projectId, membership.RoleAdmin); err != nil {
+			return Location{}, err
+		}
+	}
+	return location, nil
+}
+
+// auditState is what the audit log keeps of a location.
+func auditState(location Location) map[string]any {
+	return map[string]any{
+		"name":    location.Name,
+		"address": location.Address,
+		"lat":     location.Lat,
+		"lon":     location.Lon,
+	}
+}
@@ -0,0 +1,222 @@
+package membership
+
+import (
+	"app/pkg/api"
+	"app/pkg/platform/auth"
+	"app/pkg/platform/handler"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Route("/v1/projects/{projectId}/members", func(r chi.Router) {
+		r.Get("/", h.List)
+		r.Post("/", h.Create)
+
+		r.Route("/{userId}", func(r chi.Router) {
+			r.Put("/", h.Update)
+			r.Delete("/", h.Delete)
+		})
+	})
+}
+
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	projectId, err := parseProjectId(r)
+	if err != nil {
+		writeInvalidProjectIdError(w)
+		return
+	}
+
+	limit, offset := handler.ParsePagination(r)
+
+	memberships, err := h.service.List(r.Context(), projectId, limit, offset)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	handler.WriteJson(w, http.StatusOK, toListMembersResponse(memberships, limit, offset))
+}
+
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	projectId, err := parseProjectId(r)
+	if err != nil {
+		writeInvalidProjectIdError(w)
+		return
+	}
+
+	var req api.CreateMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handler.WriteInvalidRequestPayloadError(w)
+		return
+	}
+
+	membership, err := h.service.Create(r.Context(), projectId, CreateMembershipRequest{
+		UserID: req.UserId,
+		Role:   Role(req.Role),
+	})
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrMembershipInvalidRole) {
+		handler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, ErrMembershipUserNotFound) {
+		handler.WriteError(w, http.StatusBadRequest, "user not found")
+		return
+	}
+	if errors.Is(err, ErrMembershipProjectNotFound) {
+		handler.WriteError(w, http.StatusNotFound, "project not found")
+		return
+	}
+	if errors.Is(err, ErrMembershipAlreadyExists) {
+		handler.WriteError(w, http.StatusConflict, "member already exists")
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	handler.WriteJson(w, http.StatusCreated, toMemberResponse(membership))
+}
+
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	projectId, err := parseProjectId(r)
+	if err != nil {
+		writeInvalidProjectIdError(w)
+		return
+	}
+
+	userId, err := parseUserId(r)
+	if err != nil {
+		writeInvalidUserIdError(w)
+		return
+	}
+
+	var req api.UpdateMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handler.WriteInvalidRequestPayloadError(w)
+		return
+	}
+
+	membership, err := h.service.Update(r.Context(), projectId, userId, UpdateMembershipRequest{
+		Role: Role(req.Role),
+	})
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrMembershipInvalidRole) {
+		handler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, ErrMembershipNotFound) {
+		writeMemberNotFoundError(w)
+		return
+	}
+	if errors.Is(err, ErrMembershipLastAdmin) {
+		handler.WriteError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	handler.WriteJson(w, http.StatusOK, toMemberResponse(membership))
+}
+
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	projectId, err := parseProjectId(r)
+	if err != nil {
+		writeInvalidProjectIdError(w)
+		return
+	}
+
+	userId, err := parseUserId(r)
+	if err != nil {
+		writeInvalidUserIdError(w)
+		return
+	}
+
+	err = h.service.Delete(r.Context(), projectId, userId)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrMembershipNotFound) {
+		writeMemberNotFoundError(w)
+		return
+	}
+	if errors.Is(err, ErrMembershipLastAdmin) {
+		handler.WriteError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeInvalidProjectIdError(w http.ResponseWriter) {
+	handler.WriteError(w, http.StatusBadRequest, "invalid project id")
+}
+
+func writeInvalidUserIdError(w http.ResponseWriter) {
+	handler.WriteError(w, http.StatusBadRequest, "invalid user id")
+}
+
+func writeMemberNotFoundError(w http.ResponseWriter) {
+	handler.WriteError(w, http.StatusNotFound, "member not found")
+}
+
+func parseProjectId(r *http.Request) (int64, error) {
+	return strconv.ParseInt(chi.URLParam(r, "projectId"), 10, 64)
+}
+
+func parseUserId(r *http.Request) (int64, error) {
+	return strconv.ParseInt(chi.URLParam(r, "userId"), 10, 64)
+}
+
+func toMemberResponse(m Membership) api.MemberResponse {
+	return api.MemberResponse{
+		ProjectId: m.ProjectID,
+		UserId:    m.UserID,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+		Role:      api.MemberRole(m.Role),
+	}
+}
+
+func toListMembersResponse(m []Membership, limit, offset int64) api.ListMembersResponse {
+	items := make([]api.MemberResponse, len(m))
+	for i, membership := range m {
+		items[i] = toMemberResponse(membership)
+	}
+	return api.ListMembersResponse{
+		Limit:   limit,
+		Offset:  offset,
+		Members: items,
+	}
+}
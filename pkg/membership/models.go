@@ -0,0 +1,50 @@
+package membership
+
+import "time"
+
+type Role string
+
+const (
+	RoleViewer Role = "viewer"
+	RoleEditor Role = "editor"
+	RoleAdmin  Role = "admin"
+)
+
+// Grants reports whether the role includes the permissions of required, every
+// role includes the permissions of the roles below it.
+func (r Role) Grants(required Role) bool {
+	return r.rank() >= required.rank()
+}
+
+func (r Role) IsValid() bool {
+	return r.rank() > 0
+}
+
+func (r Role) rank() int {
+	switch r {
+	case RoleViewer:
+		return 1
+	case RoleEditor:
+		return 2
+	case RoleAdmin:
+		return 3
+	}
+	return 0
+}
+
+type Membership struct {
+	ProjectID int64
+	UserID    int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Role      Role
+}
+
+type CreateMembershipRequest struct {
+	UserID int64
+	Role   Role
+}
+
+type UpdateMembershipRequest struct {
+	Role Role
+}
@@ -0,0 +1,132 @@
+package membership
+
+import (
+	"app/pkg/database"
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrMembershipNotFound        = errors.New("membership not found")
+	ErrMembershipAlreadyExists   = errors.New("membership already exists")
+	ErrMembershipUserNotFound    = errors.New("membership user not found")
+	ErrMembershipProjectNotFound = errors.New("membership project not found")
+)
+
+type Repository interface {
+	Get(ctx context.Context, projectId, userId int64) (Membership, error)
+	List(ctx context.Context, projectId int64, limit, offset int64) ([]Membership, error)
+	CountByRole(ctx context.Context, projectId int64, role Role) (int64, error)
+	Create(ctx context.Context, membership Membership) (Membership, error)
+	Update(ctx context.Context, membership Membership) (Membership, error)
+	Delete(ctx context.Context, projectId, userId int64) error
+}
+
+type repository struct {
+	queries *database.Queries
+}
+
+func NewRepository(queries *database.Queries) Repository {
+	return &repository{queries: queries}
+}
+
+func (r *repository) Get(ctx context.Context, projectId, userId int64) (Membership, error) {
+	row, err := r.queries.GetProjectMembership(ctx, &database.GetProjectMembershipParams{
+		ProjectID: projectId,
+		UserID:    userId,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Membership{}, ErrMembershipNotFound
+	}
+	if err != nil {
+		return Membership{}, err
+	}
+	return toMembership(row), nil
+}
+
+func (r *repository) List(ctx context.Context, projectId int64, limit, offset int64) ([]Membership, error) {
+	rows, err := r.queries.ListProjectMemberships(ctx, &database.ListProjectMembershipsParams{
+		ProjectID: projectId,
+		Limit:     limit,
+		Offset:    offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+	memberships := make([]Membership, len(rows))
+	for i, row := range rows {
+		memberships[i] = toMembership(row)
+	}
+	return memberships, nil
+}
+
+func (r *repository) CountByRole(ctx context.Context, projectId int64, role Role) (int64, error) {
+	return r.queries.CountProjectMembershipsByRole(ctx, &database.CountProjectMembershipsByRoleParams{
+		ProjectID: projectId,
+		Role:      string(role),
+	})
+}
+
+func (r *repository) Create(ctx context.Context, membership Membership) (Membership, error) {
+	row, err := r.queries.CreateProjectMembership(ctx, &database.CreateProjectMembershipParams{
+		ProjectID: membership.ProjectID,
+		UserID:    membership.UserID,
+		Role:      string(membership.Role),
+	})
+	if err != nil {
+		if isPgUniqueViolation(err) {
+			return Membership{}, ErrMembershipAlreadyExists
+		}
+		if isPgForeignKeyViolation(err) && strings.Contains(err.Error(), "fk_project_memberships_project") {
+			return Membership{}, ErrMembershipProjectNotFound
+		}
+		if isPgForeignKeyViolation(err) {
+			return Membership{}, ErrMembershipUserNotFound
+		}
+		return Membership{}, err
+	}
+	return toMembership(row), nil
+}
+
+func (r *repository) Update(ctx context.Context, membership Membership) (Membership, error) {
+	row, err := r.queries.UpdateProjectMembership(ctx, &database.UpdateProjectMembershipParams{
+		ProjectID: membership.ProjectID,
+		UserID:    membership.UserID,
+		Role:      string(membership.Role),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Membership{}, ErrMembershipNotFound
+	}
+	if err != nil {
+		return Membership{}, err
+	}
+	return toMembership(row), nil
+}
+
+func (r *repository) Delete(ctx context.Context, projectId, userId int64) error {
+	return r.queries.DeleteProjectMembership(ctx, &database.DeleteProjectMembershipParams{
+		ProjectID: projectId,
+		UserID:    userId,
+	})
+}
+
+func toMembership(row *database.ProjectMembership) Membership {
+	return Membership{
+		ProjectID: row.ProjectID,
+		UserID:    row.UserID,
+		CreatedAt: row.CreatedAt.Time,
+		UpdatedAt: row.UpdatedAt.Time,
+		Role:      Role(row.Role),
+	}
+}
+
+func isPgUniqueViolation(err error) bool {
+	return err != nil && (strings.Contains(err.Error(), "23505") || strings.Contains(err.Error(), "unique"))
+}
+
+func isPgForeignKeyViolation(err error) bool {
+	return err != nil && (strings.Contains(err.Error(), "23503") || strings.Contains(err.Error(), "foreign key"))
+}
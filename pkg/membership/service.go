@@ -0,0 +1,232 @@
+package membership
+
+import (
+	"app/pkg/audit"
+	"app/pkg/platform/auth"
+	"app/pkg/platform/transaction"
+	"context"
+	"errors"
+)
+
+var (
+	ErrMembershipInvalidRole = errors.New("invalid membership role")
+	ErrMembershipLastAdmin   = errors.New("project must keep at least one admin")
+)
+
+type Service interface {
+	List(ctx context.Context, projectId int64, limit, offset int64) ([]Membership, error)
+	Create(ctx context.Context, projectId int64, req CreateMembershipRequest) (Membership, error)
+	Update(ctx context.Context, projectId, userId int64, req UpdateMembershipRequest) (Membership, error)
+	Delete(ctx context.Context, projectId, userId int64) error
+	// Authorize fails with auth.ErrForbidden unless the caller holds at least
+	// the given role on the project.
+	Authorize(ctx context.Context, projectId int64, role Role) error
+	// MemberFilter returns the user whose memberships restrict listings, or
+	// nil when the caller may see every project.
+	MemberFilter(ctx context.Context) (*int64, error)
+	// GrantCreator makes the caller admin of the project they just created.
+	GrantCreator(ctx context.Context, projectId int64) error
+}
+
+type service struct {
+	repository   Repository
+	auditService audit.Service
+	transactions transaction.Manager
+}
+
+func NewService(repository Repository, auditService audit.Service, transactions transaction.Manager) Service {
+	return &service{repository: repository, auditService: auditService, transactions: transactions}
+}
+
+func (s *service) List(ctx context.Context, projectId int64, limit, offset int64) ([]Membership, error) {
+	if err := s.Authorize(ctx, projectId, RoleViewer); err != nil {
+		return nil, err
+	}
+	return s.repository.List(ctx, projectId, limit, offset)
+}
+
+func (s *service) Create(ctx context.Context, projectId int64, req CreateMembershipRequest) (Membership, error) {
+	if !req.Role.IsValid() {
+		return Membership{}, ErrMembershipInvalidRole
+	}
+	if err := s.Authorize(ctx, projectId, RoleAdmin); err != nil {
+		return Membership{}, err
+	}
+
+	return s.create(ctx, Membership{
+		ProjectID: projectId,
+		UserID:    req.UserID,
+		Role:      req.Role,
+	})
+}
+
+func (s *service) Update(ctx context.Context, projectId, userId int64, req UpdateMembershipRequest) (Membership, error) {
+	if !req.Role.IsValid() {
+		return Membership{}, ErrMembershipInvalidRole
+	}
+	if err := s.Authorize(ctx, projectId, RoleAdmin); err != nil {
+		return Membership{}, err
+	}
+
+	var membership Membership
+	err := s.transactions.Run(ctx, func(ctx context.Context) error {
+		before, err := s.repository.Get(ctx, projectId, userId)
+		if err != nil {
+			return err
+		}
+		if before.Role == RoleAdmin && req.Role != RoleAdmin {
+			if err := s.ensureOtherAdmin(ctx, projectId); err != nil {
+				return err
+			}
+		}
+
+		updated := before
+		updated.Role = req.Role
+		membership, err = s.repository.Update(ctx, updated)
+		if err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, audit.Entry{
+			Action:       audit.ActionUpdate,
+			ResourceType: audit.ResourceTypeMembership,
+			ResourceID:   projectId,
+			Before:       auditState(before),
+			After:        auditState(membership),
+		})
+	})
+	if err != nil {
+		return Membership{}, err
+	}
+	return membership, nil
+}
+
+// Delete removes a member, admins can remove anyone and every member can
+// leave a project on their own.
+func (s *service) Delete(ctx context.Context, projectId, userId int64) error {
+	principal, ok := auth.GetPrincipal(ctx)
+	if !ok {
+		return auth.ErrForbidden
+	}
+	if principal.UserID != userId {
+		if err := s.Authorize(ctx, projectId, RoleAdmin); err != nil {
+			return err
+		}
+	}
+
+	return s.transactions.Run(ctx, func(ctx context.Context) error {
+		membership, err := s.repository.Get(ctx, projectId, userId)
+		if err != nil {
+			return err
+		}
+		if membership.Role == RoleAdmin {
+			if err := s.ensureOtherAdmin(ctx, projectId); err != nil {
+				return err
+			}
+		}
+
+		if err := s.repository.Delete(ctx, projectId, userId); err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, audit.Entry{
+			Action:       audit.ActionDelete,
+			ResourceType: audit.ResourceTypeMembership,
+			ResourceID:   projectId,
+			Before:       auditState(membership),
+		})
+	})
+}
+
+func (s *service) Authorize(ctx context.Context, projectId int64, role Role) error {
+	principal, ok := auth.GetPrincipal(ctx)
+	if !ok {
+		return auth.ErrForbidden
+	}
+	if principal.IsAdmin {
+		return nil
+	}
+
+	membership, err := s.repository.Get(ctx, projectId, principal.UserID)
+	if errors.Is(err, ErrMembershipNotFound) {
+		return auth.ErrForbidden
+	}
+	if err != nil {
+		return err
+	}
+	if !membership.Role.Grants(role) {
+		return auth.ErrForbidden
+	}
+
+	return nil
+}
+
+func (s *service) MemberFilter(ctx context.Context) (*int64, error) {
+	principal, ok := auth.GetPrincipal(ctx)
+	if !ok {
+		return nil, auth.ErrForbidden
+	}
+	if principal.IsAdmin {
+		return nil, nil
+	}
+	return &principal.UserID, nil
+}
+
+func (s *service) GrantCreator(ctx context.Context, projectId int64) error {
+	principal, ok := auth.GetPrincipal(ctx)
+	if !ok {
+		return auth.ErrForbidden
+	}
+	// The system principal isn't a user and can't become a member.
+	if principal.UserID == 0 {
+		return nil
+	}
+
+	_, err := s.create(ctx, Membership{
+		ProjectID: projectId,
+		UserID:    principal.UserID,
+		Role:      RoleAdmin,
+	})
+	return err
+}
+
+func (s *service) create(ctx context.Context, membership Membership) (Membership, error) {
+	err := s.transactions.Run(ctx, func(ctx context.Context) error {
+		created, err := s.repository.Create(ctx, membership)
+		if err != nil {
+			return err
+		}
+		membership = created
+
+		return s.auditService.Record(ctx, audit.Entry{
+			Action:       audit.ActionCreate,
+			ResourceType: audit.ResourceTypeMembership,
+			ResourceID:   membership.ProjectID,
+			After:        auditState(membership),
+		})
+	})
+	if err != nil {
+		return Membership{}, err
+	}
+	return membership, nil
+}
+
+func (s *service) ensureOtherAdmin(ctx context.Context, projectId int64) error {
+	admins, err := s.repository.CountByRole(ctx, projectId, RoleAdmin)
+	if err != nil {
+		return err
+	}
+	if admins <= 1 {
+		return ErrMembershipLastAdmin
+	}
+	return nil
+}
+
+// auditState is what the audit log keeps of a membership, the project is the
+// resource of the event.
+func auditState(membership Membership) map[string]any {
+	return map[string]any{
+		"userId": membership.UserID,
+		"role":   membership.Role,
+	}
+}
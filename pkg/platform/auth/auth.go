@@ -42,6 +42,16 @@ func NewAuthenticator(config config.AuthConfig) (*Authenticator, error) {
 	return &Authenticator{config: config, cache: cache}, nil
 }
 
+// Middleware prepares the request to carry the claims that Authenticate
+// verifies, so later handlers can read them with GetTokenContext. It must run
+// before the request validator calls Authenticate.
+func (m *Authenticator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), tokenContextKey{}, &tokenContextHolder{})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
 func (m *Authenticator) Authenticate(ctx context.Context, input *openapi3filter.AuthenticationInput) error {
 	if input.SecuritySchemeName == "OpenIdConnect" {
 		err, unverifiedToken := checkToken(input.RequestValidationInput.Request)
@@ -64,112 +74,108 @@ func (m *Authenticator) Authenticate(ctx context.Context, input *openapi3filter.
 			return errors.New("invalid token")
 		}
 
-		var scopeString string
-		if err := token.Get("scope", &scopeString); err != nil {
-			return errors.New("invalid scope")
+		tokenContext, err := toTokenContext(token)
+		if err != nil {
+			return err
 		}
 
-		scopes := strings.Split(scopeString, " ")
-
 		if len(m.config.Scopes) > 0 {
 			for _, scope := range m.config.Scopes {
-				if !slices.Contains(scopes, scope) {
+				if !slices.Contains(tokenContext.Scopes, scope) {
 					return errors.New(fmt.Sprintf("missing scope %s", scope))
 				}
 			}
 		}
+
+		if holder, ok := input.RequestValidationInput.Request.Context().Value(tokenContextKey{}).(*tokenContextHolder); ok {
+			holder.tokenContext = &tokenContext
+		}
 	}
 
 	return nil
 }
 
-// GetUnverifiedToken parses a JWT from the request and extracts claims without
-// verifying the token's signature. This function MUST ONLY be called on
-// requests that have already been authenticated and whose JWT has already
-// been validated (e.g. by Authenticator.Authenticate). Do not use this
-// helper to perform authentication or authorization decisions on
-// unauthenticated requests.
-func GetUnverifiedToken(request *http.Request) (error, TokenContext) {
-	err, unverifiedToken := checkToken(request)
-	if err != nil {
-		return err, TokenContext{}
+// IsAdmin reports whether the token grants one of the configured instance
+// admin realm roles.
+func (m *Authenticator) IsAdmin(tokenContext TokenContext) bool {
+	for _, role := range m.config.AdminRoles {
+		if slices.Contains(tokenContext.Roles, role) {
+			return true
+		}
 	}
+	return false
+}
 
-	token, err := jwt.ParseString(unverifiedToken, jwt.WithVerify(false))
-	if errors.Is(err, jwt.TokenExpiredError()) {
-		return errors.New("token expired"), TokenContext{}
-	}
-	if err != nil {
-		log.Printf("error parsing token: %v", err)
-		return errors.New("invalid token"), TokenContext{}
+// GetTokenContext returns the claims of the token that authenticated the
+// request. It only succeeds for requests that passed Authenticator.Middleware
+// and were authenticated by Authenticate.
+func GetTokenContext(ctx context.Context) (TokenContext, bool) {
+	holder, ok := ctx.Value(tokenContextKey{}).(*tokenContextHolder)
+	if !ok || holder.tokenContext == nil {
+		return TokenContext{}, false
 	}
+	return *holder.tokenContext, true
+}
+
+type tokenContextKey struct{}
+
+// tokenContextHolder is placed in the request context before validation,
+// since the validator doesn't let Authenticate replace the context.
+type tokenContextHolder struct {
+	tokenContext *TokenContext
+}
 
+// toTokenContext extracts the claims of a verified token. Only the subject is
+// required, the profile claims depend on the scopes granted to the client.
+func toTokenContext(token jwt.Token) (TokenContext, error) {
 	var scopeString string
 	if err := token.Get("scope", &scopeString); err != nil {
-		return errors.New("invalid scope"), TokenContext{}
+		return TokenContext{}, errors.New("invalid scope")
 	}
 
-	scopes := strings.Split(scopeString, " ")
-
 	subject, ok := token.Subject()
 	if !ok {
-		return errors.New("invalid token"), TokenContext{}
+		return TokenContext{}, errors.New("invalid token")
 	}
 
-	issuer, ok := token.Issuer()
-	if !ok {
-		return errors.New("invalid token"), TokenContext{}
-	}
+	issuer, _ := token.Issuer()
 
-	var name string
-	err = token.Get("name", &name)
-	if err != nil {
-		return errors.New("invalid token"), TokenContext{}
-	}
-
-	var givenName string
-	err = token.Get("given_name", &givenName)
-	if err != nil {
-		return errors.New("invalid token"), TokenContext{}
-	}
-
-	var familyName string
-	err = token.Get("family_name", &familyName)
-	if err != nil {
-		return errors.New("invalid token"), TokenContext{}
+	tokenContext := TokenContext{
+		Subject:           subject,
+		Issuer:            issuer,
+		Name:              getStringClaim(token, "name"),
+		GivenName:         getStringClaim(token, "given_name"),
+		FamilyName:        getStringClaim(token, "family_name"),
+		Email:             getStringClaim(token, "email"),
+		PreferredUsername: getStringClaim(token, "preferred_username"),
+		Scopes:            strings.Split(scopeString, " "),
 	}
 
-	var email string
-	err = token.Get("email", &email)
-	if err != nil {
-		return errors.New("invalid token"), TokenContext{}
+	if err := token.Get("email_verified", &tokenContext.EmailVerified); err != nil {
+		tokenContext.EmailVerified = false
 	}
 
-	var emailVerified bool
-	err = token.Get("email_verified", &emailVerified)
-	if err != nil {
-		return errors.New("invalid token"), TokenContext{}
+	// Realm roles are optional, tokens without them simply carry no roles.
+	var realmAccess map[string]any
+	if err := token.Get("realm_access", &realmAccess); err == nil {
+		if values, ok := realmAccess["roles"].([]any); ok {
+			for _, value := range values {
+				if role, ok := value.(string); ok {
+					tokenContext.Roles = append(tokenContext.Roles, role)
+				}
+			}
+		}
 	}
 
-	var preferredUsername string
-	err = token.Get("preferred_username", &preferredUsername)
-	if err != nil {
-		return errors.New("invalid token"), TokenContext{}
-	}
+	return tokenContext, nil
+}
 
-	tokenContext := TokenContext{
-		Subject:           subject,
-		Issuer:            issuer,
-		Name:              name,
-		GivenName:         givenName,
-		FamilyName:        familyName,
-		Email:             email,
-		EmailVerified:     emailVerified,
-		PreferredUsername: preferredUsername,
-		Scopes:            scopes,
+func getStringClaim(token jwt.Token, name string) string {
+	var value string
+	if err := token.Get(name, &value); err != nil {
+		return ""
 	}
-
-	return nil, tokenContext
+	return value
 }
 
 type TokenContext struct {
@@ -182,6 +188,7 @@ type TokenContext struct {
 	EmailVerified     bool
 	PreferredUsername string
 	Scopes            []string
+	Roles             []string
 }
 
 func checkToken(r *http.Request) (error, string) {
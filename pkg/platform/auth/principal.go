@@ -0,0 +1,33 @@
+package auth
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrForbidden = errors.New("forbidden")
+
+type principalContextKey struct{}
+
+// Principal is the caller of a request. Instance admins bypass project
+// memberships, everybody else only reaches the projects they're a member of.
+type Principal struct {
+	UserID  int64
+	Subject string
+	IsAdmin bool
+}
+
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+// WithSystemPrincipal marks work the application does on its own behalf,
+// such as maintenance tasks, which isn't bound to any project membership.
+func WithSystemPrincipal(ctx context.Context) context.Context {
+	return WithPrincipal(ctx, Principal{IsAdmin: true})
+}
+
+func GetPrincipal(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalContextKey{}).(Principal)
+	return principal, ok
+}
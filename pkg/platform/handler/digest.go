@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+const (
+	ContentDigestHeader = "Content-Digest"
+	ReprDigestHeader    = "Repr-Digest"
+
+	digestAlgorithmSHA256 = "sha-256"
+)
+
+var ErrInvalidDigestHeader = errors.New("invalid digest header")
+
+// ParseSHA256Digest reads the sha-256 member of an RFC 9530 digest header such
+// as Content-Digest or Repr-Digest. It returns nil when the header is absent
+// or only lists algorithms that aren't supported.
+func ParseSHA256Digest(r *http.Request, header string) ([]byte, error) {
+	value := r.Header.Get(header)
+	if value == "" {
+		return nil, nil
+	}
+
+	for member := range strings.SplitSeq(value, ",") {
+		algorithm, encoded, ok := strings.Cut(strings.TrimSpace(member), "=")
+		if !ok {
+			return nil, ErrInvalidDigestHeader
+		}
+
+		if strings.ToLower(strings.TrimSpace(algorithm)) != digestAlgorithmSHA256 {
+			continue
+		}
+
+		encoded = strings.TrimSpace(encoded)
+		if len(encoded) < 2 || !strings.HasPrefix(encoded, ":") || !strings.HasSuffix(encoded, ":") {
+			return nil, ErrInvalidDigestHeader
+		}
+
+		digest, err := base64.StdEncoding.DecodeString(encoded[1 : len(encoded)-1])
+		if err != nil || len(digest) != 32 {
+			return nil, ErrInvalidDigestHeader
+		}
+
+		return digest, nil
+	}
+
+	return nil, nil
+}
+
+func FormatSHA256Digest(digest []byte) string {
+	return digestAlgorithmSHA256 + "=:" + base64.StdEncoding.EncodeToString(digest) + ":"
+}
@@ -31,6 +31,10 @@ func WriteInternalServerError(w http.ResponseWriter) {
 	WriteError(w, http.StatusInternalServerError, "internal server error")
 }
 
+func WriteForbiddenError(w http.ResponseWriter) {
+	WriteError(w, http.StatusForbidden, "forbidden")
+}
+
 func WriteInvalidRequestPayloadError(w http.ResponseWriter) {
 	WriteError(w, http.StatusBadRequest, "invalid request payload")
 }
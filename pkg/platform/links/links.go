@@ -0,0 +1,55 @@
+package links
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Builder turns paths into absolute URLs below the public base URL, these are
+// the URLs that end up on printed labels.
+type Builder struct {
+	baseURL *url.URL
+}
+
+func NewBuilder(publicURL string) (*Builder, error) {
+	baseURL, err := url.Parse(strings.TrimSuffix(publicURL, "/") + "/")
+	if err != nil {
+		return nil, fmt.Errorf("invalid public url '%s': %w", publicURL, err)
+	}
+	if baseURL.Scheme == "" || baseURL.Host == "" {
+		return nil, fmt.Errorf("public url '%s' must be absolute", publicURL)
+	}
+
+	return &Builder{baseURL: baseURL}, nil
+}
+
+// URL resolves path against the public base URL. Paths are relative to the
+// base URL so deployments below a sub path keep working.
+func (b *Builder) URL(path string) string {
+	return b.baseURL.JoinPath(path).String()
+}
+
+func ProjectPath(id int64) string {
+	return fmt.Sprintf("projects/%d", id)
+}
+
+func VersionPath(id int64) string {
+	return fmt.Sprintf("versions/%d", id)
+}
+
+func FilePath(id int64) string {
+	return fmt.Sprintf("files/%d", id)
+}
+
+func ShortLinkPath(code string) string {
+	return "q/" + url.PathEscape(code)
+}
+
+func ShareLinkPath(token string) string {
+	return "s/" + url.PathEscape(token)
+}
+
+func ShareLinkFilePath(token string, fileId int64) string {
+	return fmt.Sprintf("%s/files/%d", ShareLinkPath(token), fileId)
+}
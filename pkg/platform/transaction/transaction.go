@@ -0,0 +1,110 @@
+package transaction
+
+import (
+	"app/pkg/database"
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type transactionContextKey struct{}
+
+// Manager runs units of work in a database transaction. It's also the
+// database.DBTX the queries are built on, so every repository call made with
+// the context handed to Run takes part in that transaction without knowing
+// about it. A transaction belongs to one goroutine, the context of Run must
+// not be used concurrently.
+type Manager interface {
+	database.DBTX
+	// Run calls fn in a transaction that's committed when fn returns nil and
+	// rolled back otherwise. Inside another Run it opens a savepoint, so an
+	// inner failure only rolls back the inner work.
+	Run(ctx context.Context, fn func(ctx context.Context) error) error
+	// AfterCommit defers fn until the outermost transaction has committed,
+	// it's dropped when the work it belongs to is rolled back. Side effects
+	// outside the database such as deleting stored objects go here. Outside
+	// of a transaction fn runs right away.
+	AfterCommit(ctx context.Context, fn func(ctx context.Context))
+}
+
+type transaction struct {
+	tx    pgx.Tx
+	hooks []func(ctx context.Context)
+}
+
+type manager struct {
+	pool *pgxpool.Pool
+}
+
+func NewManager(pool *pgxpool.Pool) Manager {
+	return &manager{pool: pool}
+}
+
+func (m *manager) Run(ctx context.Context, fn func(ctx context.Context) error) error {
+	parent := current(ctx)
+
+	var tx pgx.Tx
+	var err error
+	if parent != nil {
+		tx, err = parent.tx.Begin(ctx)
+	} else {
+		tx, err = m.pool.Begin(ctx)
+	}
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	t := &transaction{tx: tx}
+	if err := fn(context.WithValue(ctx, transactionContextKey{}, t)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	if parent != nil {
+		parent.hooks = append(parent.hooks, t.hooks...)
+		return nil
+	}
+	for _, hook := range t.hooks {
+		hook(ctx)
+	}
+	return nil
+}
+
+func (m *manager) AfterCommit(ctx context.Context, fn func(ctx context.Context)) {
+	t := current(ctx)
+	if t == nil {
+		fn(ctx)
+		return
+	}
+	t.hooks = append(t.hooks, fn)
+}
+
+func (m *manager) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	return m.conn(ctx).Exec(ctx, sql, args...)
+}
+
+func (m *manager) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	return m.conn(ctx).Query(ctx, sql, args...)
+}
+
+func (m *manager) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	return m.conn(ctx).QueryRow(ctx, sql, args...)
+}
+
+func (m *manager) conn(ctx context.Context) database.DBTX {
+	if t := current(ctx); t != nil {
+		return t.tx
+	}
+	return m.pool
+}
+
+func current(ctx context.Context) *transaction {
+	t, _ := ctx.Value(transactionContextKey{}).(*transaction)
+	return t
+}
@@ -0,0 +1,171 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var errRollback = errors.New("rollback")
+
+// setup connects to the database named by DOCPORT_TEST_DATABASE_DSN and
+// creates a table of its own for the test, the test is skipped without one.
+func setup(t *testing.T) (Manager, string) {
+	t.Helper()
+
+	dsn := os.Getenv("DOCPORT_TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("DOCPORT_TEST_DATABASE_DSN isn't set")
+	}
+
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(pool.Close)
+
+	table := fmt.Sprintf("transaction_test_%d", time.Now().UnixNano())
+	if _, err := pool.Exec(ctx, fmt.Sprintf("CREATE TABLE %s (value INT NOT NULL)", table)); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if _, err := pool.Exec(context.Background(), fmt.Sprintf("DROP TABLE %s", table)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	return NewManager(pool), table
+}
+
+func insert(ctx context.Context, m Manager, table string, value int) error {
+	_, err := m.Exec(ctx, fmt.Sprintf("INSERT INTO %s (value) VALUES ($1)", table), value)
+	return err
+}
+
+func values(t *testing.T, m Manager, table string) []int {
+	t.Helper()
+
+	rows, err := m.Query(context.Background(), fmt.Sprintf("SELECT value FROM %s ORDER BY value", table))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	var values []int
+	for rows.Next() {
+		var value int
+		if err := rows.Scan(&value); err != nil {
+			t.Fatal(err)
+		}
+		values = append(values, value)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return values
+}
+
+func TestInnerRollbackKeepsOuterWork(t *testing.T) {
+	m, table := setup(t)
+
+	err := m.Run(context.Background(), func(ctx context.Context) error {
+		if err := insert(ctx, m, table, 1); err != nil {
+			return err
+		}
+		err := m.Run(ctx, func(ctx context.Context) error {
+			if err := insert(ctx, m, table, 2); err != nil {
+				return err
+			}
+			return errRollback
+		})
+		if !errors.Is(err, errRollback) {
+			t.Errorf("inner Run returned %v, want %v", err, errRollback)
+		}
+		return insert(ctx, m, table, 3)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := values(t, m, table); !slices.Equal(got, []int{1, 3}) {
+		t.Errorf("values = %v, want [1 3]", got)
+	}
+}
+
+func TestOuterRollbackDiscardsInnerWork(t *testing.T) {
+	m, table := setup(t)
+
+	err := m.Run(context.Background(), func(ctx context.Context) error {
+		err := m.Run(ctx, func(ctx context.Context) error {
+			return insert(ctx, m, table, 1)
+		})
+		if err != nil {
+			return err
+		}
+		return errRollback
+	})
+	if !errors.Is(err, errRollback) {
+		t.Fatalf("Run returned %v, want %v", err, errRollback)
+	}
+
+	if got := values(t, m, table); len(got) != 0 {
+		t.Errorf("values = %v, want none", got)
+	}
+}
+
+func TestHooksRunAfterOutermostCommit(t *testing.T) {
+	m, table := setup(t)
+
+	var ran []string
+	err := m.Run(context.Background(), func(ctx context.Context) error {
+		m.AfterCommit(ctx, func(context.Context) { ran = append(ran, "outer") })
+
+		err := m.Run(ctx, func(ctx context.Context) error {
+			m.AfterCommit(ctx, func(context.Context) { ran = append(ran, "inner") })
+			return insert(ctx, m, table, 1)
+		})
+		if err != nil {
+			return err
+		}
+
+		_ = m.Run(ctx, func(ctx context.Context) error {
+			m.AfterCommit(ctx, func(context.Context) { ran = append(ran, "rolled back") })
+			return errRollback
+		})
+
+		if len(ran) != 0 {
+			t.Errorf("hooks %v ran before the outermost commit", ran)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(ran, []string{"outer", "inner"}) {
+		t.Errorf("hooks ran = %v, want [outer inner]", ran)
+	}
+}
+
+func TestHooksAreDroppedOnRollback(t *testing.T) {
+	m, _ := setup(t)
+
+	ran := false
+	err := m.Run(context.Background(), func(ctx context.Context) error {
+		m.AfterCommit(ctx, func(context.Context) { ran = true })
+		return errRollback
+	})
+	if !errors.Is(err, errRollback) {
+		t.Fatalf("Run returned %v, want %v", err, errRollback)
+	}
+	if ran {
+		t.Error("hook ran although the transaction was rolled back")
+	}
+}
@@ -2,21 +2,29 @@ package project
 
 import (
 	"app/pkg/api"
+	"app/pkg/location"
+	"app/pkg/platform/auth"
 	"app/pkg/platform/handler"
+	"app/pkg/platform/links"
+	"app/pkg/qrcode"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"math"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 )
 
 type Handler struct {
-	service Service
+	service    Service
+	qrRenderer *qrcode.Renderer
 }
 
-func NewHandler(service Service) *Handler {
-	return &Handler{service: service}
+func NewHandler(service Service, qrRenderer *qrcode.Renderer) *Handler {
+	return &Handler{service: service, qrRenderer: qrRenderer}
 }
 
 func (h *Handler) RegisterRoutes(r chi.Router) {
@@ -26,6 +34,7 @@ func (h *Handler) RegisterRoutes(r chi.Router) {
 
 		r.Route("/{projectId}", func(r chi.Router) {
 			r.Get("/", h.GetById)
+			r.Get("/qr", h.QRCode)
 			r.Put("/", h.Update)
 			r.Delete("/", h.Delete)
 		})
@@ -40,6 +49,10 @@ func (h *Handler) GetById(w http.ResponseWriter, r *http.Request) {
 	}
 
 	project, err := h.service.GetById(r.Context(), id)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
 	if errors.Is(err, ErrProjectNotFound) {
 		writeProjectNotFoundError(w)
 		return
@@ -55,7 +68,44 @@ func (h *Handler) GetById(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
 	limit, offset := handler.ParsePagination(r)
 
-	projects, err := h.service.List(r.Context(), limit, offset)
+	locationId, err := parseLocationId(r)
+	if err != nil {
+		handler.WriteError(w, http.StatusBadRequest, "invalid location id")
+		return
+	}
+
+	near, err := parseNear(r)
+	if err != nil {
+		handler.WriteError(w, http.StatusBadRequest, "invalid near, expected lat,lon")
+		return
+	}
+
+	radiusKm, err := parseRadiusKm(r)
+	if err != nil {
+		handler.WriteError(w, http.StatusBadRequest, "invalid radius")
+		return
+	}
+
+	boundingBox, err := parseBoundingBox(r)
+	if err != nil {
+		handler.WriteError(w, http.StatusBadRequest, "invalid bbox, expected minLat,minLon,maxLat,maxLon")
+		return
+	}
+
+	projects, err := h.service.List(r.Context(), ListProjectsFilter{
+		LocationID:  locationId,
+		Near:        near,
+		RadiusKm:    radiusKm,
+		BoundingBox: boundingBox,
+	}, limit, offset)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrProjectRadiusWithoutNear) {
+		handler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 	if err != nil {
 		handler.WriteInternalServerError(w)
 		return
@@ -72,13 +122,22 @@ func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	project, err := h.service.Create(r.Context(), CreateProjectRequest{
-		Slug: req.Slug,
-		Name: req.Name,
+		Slug:       req.Slug,
+		Name:       req.Name,
+		LocationID: req.LocationId,
 	})
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
 	if errors.Is(err, ErrProjectAlreadyExists) {
 		writeProjectAlreadyExistsError(w)
 		return
 	}
+	if errors.Is(err, ErrProjectLocationNotFound) {
+		writeProjectLocationNotFoundError(w)
+		return
+	}
 	if err != nil {
 		handler.WriteInternalServerError(w)
 		return
@@ -101,13 +160,26 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 	}
 
 	project, err := h.service.Update(r.Context(), id, UpdateProjectRequest{
-		Slug: req.Slug,
-		Name: req.Name,
+		Slug:       req.Slug,
+		Name:       req.Name,
+		LocationID: req.LocationId,
 	})
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
 	if errors.Is(err, ErrProjectNotFound) {
 		writeProjectNotFoundError(w)
 		return
 	}
+	if errors.Is(err, ErrProjectAlreadyExists) {
+		writeProjectAlreadyExistsError(w)
+		return
+	}
+	if errors.Is(err, ErrProjectLocationNotFound) {
+		writeProjectLocationNotFoundError(w)
+		return
+	}
 	if err != nil {
 		handler.WriteInternalServerError(w)
 		return
@@ -124,6 +196,10 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	err = h.service.Delete(r.Context(), id)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
 	if errors.Is(err, ErrProjectNotFound) {
 		writeProjectNotFoundError(w)
 		return
@@ -136,6 +212,30 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func (h *Handler) QRCode(w http.ResponseWriter, r *http.Request) {
+	id, err := parseProjectId(r)
+	if err != nil {
+		writeInvalidProjectIdError(w)
+		return
+	}
+
+	_, err = h.service.GetById(r.Context(), id)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrProjectNotFound) {
+		writeProjectNotFoundError(w)
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	h.qrRenderer.Write(w, r, links.ProjectPath(id))
+}
+
 func writeInvalidProjectIdError(w http.ResponseWriter) {
 	handler.WriteError(w, http.StatusBadRequest, "invalid project id")
 }
@@ -148,17 +248,129 @@ func writeProjectAlreadyExistsError(w http.ResponseWriter) {
 	handler.WriteError(w, http.StatusConflict, "project already exists")
 }
 
+func writeProjectLocationNotFoundError(w http.ResponseWriter) {
+	handler.WriteError(w, http.StatusBadRequest, "location not found")
+}
+
 func parseProjectId(r *http.Request) (int64, error) {
 	return strconv.ParseInt(chi.URLParam(r, "projectId"), 10, 64)
 }
 
+func parseLocationId(r *http.Request) (*int64, error) {
+	if !r.URL.Query().Has("locationId") {
+		return nil, nil
+	}
+
+	locationId, err := strconv.ParseInt(r.URL.Query().Get("locationId"), 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &locationId, nil
+}
+
+func parseNear(r *http.Request) (*Point, error) {
+	if !r.URL.Query().Has("near") {
+		return nil, nil
+	}
+
+	coordinates, err := parseCoordinates(r.URL.Query().Get("near"), 2)
+	if err != nil {
+		return nil, err
+	}
+
+	point := Point{Lat: coordinates[0], Lon: coordinates[1]}
+	if !isValidLat(point.Lat) || !isValidLon(point.Lon) {
+		return nil, errors.New("coordinates out of range")
+	}
+
+	return &point, nil
+}
+
+func parseRadiusKm(r *http.Request) (*float64, error) {
+	if !r.URL.Query().Has("radiusKm") {
+		return nil, nil
+	}
+
+	radiusKm, err := strconv.ParseFloat(r.URL.Query().Get("radiusKm"), 64)
+	if err != nil {
+		return nil, err
+	}
+	if math.IsNaN(radiusKm) || math.IsInf(radiusKm, 0) || radiusKm <= 0 {
+		return nil, errors.New("radius must be positive")
+	}
+
+	return &radiusKm, nil
+}
+
+func parseBoundingBox(r *http.Request) (*BoundingBox, error) {
+	if !r.URL.Query().Has("bbox") {
+		return nil, nil
+	}
+
+	coordinates, err := parseCoordinates(r.URL.Query().Get("bbox"), 4)
+	if err != nil {
+		return nil, err
+	}
+
+	box := BoundingBox{
+		MinLat: coordinates[0],
+		MinLon: coordinates[1],
+		MaxLat: coordinates[2],
+		MaxLon: coordinates[3],
+	}
+	if !isValidLat(box.MinLat) || !isValidLat(box.MaxLat) || !isValidLon(box.MinLon) || !isValidLon(box.MaxLon) {
+		return nil, errors.New("coordinates out of range")
+	}
+	if box.MinLat > box.MaxLat {
+		return nil, errors.New("minimum latitude exceeds maximum latitude")
+	}
+
+	return &box, nil
+}
+
+// parseCoordinates parses a comma separated list of exactly count numbers.
+func parseCoordinates(value string, count int) ([]float64, error) {
+	parts := strings.Split(value, ",")
+	if len(parts) != count {
+		return nil, fmt.Errorf("expected %d coordinates, got %d", count, len(parts))
+	}
+
+	coordinates := make([]float64, count)
+	for i, part := range parts {
+		coordinate, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, err
+		}
+		coordinates[i] = coordinate
+	}
+
+	return coordinates, nil
+}
+
+func isValidLat(lat float64) bool {
+	return lat >= -90 && lat <= 90
+}
+
+func isValidLon(lon float64) bool {
+	return lon >= -180 && lon <= 180
+}
+
 func toProjectResponse(p Project) api.ProjectResponse {
+	var projectLocation *api.LocationResponse
+	if p.Location != nil {
+		projectLocation = new(location.ToLocationResponse(*p.Location))
+	}
+
 	return api.ProjectResponse{
-		Id:        p.ID,
-		CreatedAt: p.CreatedAt,
-		UpdatedAt: p.UpdatedAt,
-		Slug:      p.Slug,
-		Name:      p.Name,
+		Id:         p.ID,
+		CreatedAt:  p.CreatedAt,
+		UpdatedAt:  p.UpdatedAt,
+		Slug:       p.Slug,
+		Name:       p.Name,
+		LocationId: p.LocationID,
+		Location:   projectLocation,
+		DistanceKm: p.DistanceKm,
 	}
 }
 
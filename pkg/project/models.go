@@ -1,21 +1,53 @@
 package project
 
-import "time"
+import (
+	"app/pkg/location"
+	"time"
+)
 
 type Project struct {
-	ID        int64
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	Slug      string
-	Name      string
+	ID         int64
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	Slug       string
+	Name       string
+	LocationID *int64
+	Location   *location.Location
+	// DistanceKm is only set when projects are searched near a point.
+	DistanceKm *float64
 }
 
 type CreateProjectRequest struct {
-	Slug string
-	Name string
+	Slug       string
+	Name       string
+	LocationID *int64
 }
 
 type UpdateProjectRequest struct {
-	Slug string
-	Name string
+	Slug       string
+	Name       string
+	LocationID *int64
+}
+
+type ListProjectsFilter struct {
+	LocationID  *int64
+	Near        *Point
+	RadiusKm    *float64
+	BoundingBox *BoundingBox
+	// MemberID restricts the listing to projects the user is a member of.
+	MemberID *int64
+}
+
+type Point struct {
+	Lat float64
+	Lon float64
+}
+
+// BoundingBox is bounded by its south-west and north-east corners. A box with
+// MinLon greater than MaxLon crosses the antimeridian.
+type BoundingBox struct {
+	MinLat float64
+	MinLon float64
+	MaxLat float64
+	MaxLon float64
 }
@@ -10,13 +10,14 @@ import (
 )
 
 var (
-	ErrProjectNotFound      = errors.New("project not found")
-	ErrProjectAlreadyExists = errors.New("project already exists")
+	ErrProjectNotFound         = errors.New("project not found")
+	ErrProjectAlreadyExists    = errors.New("project already exists")
+	ErrProjectLocationNotFound = errors.New("project location not found")
 )
 
 type Repository interface {
 	GetById(ctx context.Context, id int64) (Project, error)
-	List(ctx context.Context, limit, offset int64) ([]Project, error)
+	List(ctx context.Context, filter ListProjectsFilter, limit, offset int64) ([]Project, error)
 	Create(ctx context.Context, project Project) (Project, error)
 	Update(ctx context.Context, project Project) (Project, error)
 	Delete(ctx context.Context, id int64) error
@@ -41,30 +42,59 @@ func (r *repository) GetById(ctx context.Context, id int64) (Project, error) {
 	return toProject(row), nil
 }
 
-func (r *repository) List(ctx context.Context, limit, offset int64) ([]Project, error) {
-	rows, err := r.queries.ListProjects(ctx, &database.ListProjectsParams{
-		Offset: offset,
-		Limit:  limit,
-	})
+func (r *repository) List(ctx context.Context, filter ListProjectsFilter, limit, offset int64) ([]Project, error) {
+	params := &database.ListProjectsParams{
+		LocationId: filter.LocationID,
+		RadiusKm:   filter.RadiusKm,
+		MemberId:   filter.MemberID,
+		Offset:     offset,
+		Limit:      limit,
+	}
+	if filter.Near != nil {
+		params.NearLat = &filter.Near.Lat
+		params.NearLon = &filter.Near.Lon
+	}
+	if filter.BoundingBox != nil {
+		params.MinLat = &filter.BoundingBox.MinLat
+		params.MinLon = &filter.BoundingBox.MinLon
+		params.MaxLat = &filter.BoundingBox.MaxLat
+		params.MaxLon = &filter.BoundingBox.MaxLon
+	}
+
+	rows, err := r.queries.ListProjects(ctx, params)
 	if err != nil {
 		return nil, err
 	}
 	projects := make([]Project, len(rows))
 	for i, row := range rows {
-		projects[i] = toProject(row)
+		projects[i] = Project{
+			ID:         row.ID,
+			CreatedAt:  row.CreatedAt.Time,
+			UpdatedAt:  row.UpdatedAt.Time,
+			Slug:       row.Slug,
+			Name:       row.Name,
+			LocationID: row.LocationID,
+		}
+		if filter.Near != nil {
+			projects[i].DistanceKm = &row.DistanceKm
+		}
 	}
 	return projects, nil
 }
 
 func (r *repository) Create(ctx context.Context, project Project) (Project, error) {
 	row, err := r.queries.CreateProject(ctx, &database.CreateProjectParams{
-		Slug: project.Slug,
-		Name: project.Name,
+		Slug:       project.Slug,
+		Name:       project.Name,
+		LocationID: project.LocationID,
 	})
 	if err != nil {
 		if isPgUniqueViolation(err) {
 			return Project{}, ErrProjectAlreadyExists
 		}
+		if isPgForeignKeyViolation(err) {
+			return Project{}, ErrProjectLocationNotFound
+		}
 		return Project{}, err
 	}
 	return toProject(row), nil
@@ -72,14 +102,21 @@ func (r *repository) Create(ctx context.Context, project Project) (Project, erro
 
 func (r *repository) Update(ctx context.Context, project Project) (Project, error) {
 	row, err := r.queries.UpdateProject(ctx, &database.UpdateProjectParams{
-		ID:   project.ID,
-		Slug: project.Slug,
-		Name: project.Name,
+		ID:         project.ID,
+		Slug:       project.Slug,
+		Name:       project.Name,
+		LocationID: project.LocationID,
 	})
 	if errors.Is(err, pgx.ErrNoRows) {
 		return Project{}, ErrProjectNotFound
 	}
 	if err != nil {
+		if isPgUniqueViolation(err) {
+			return Project{}, ErrProjectAlreadyExists
+		}
+		if isPgForeignKeyViolation(err) {
+			return Project{}, ErrProjectLocationNotFound
+		}
 		return Project{}, err
 	}
 	return toProject(row), nil
@@ -98,13 +135,19 @@ func (r *repository) Delete(ctx context.Context, id int64) error {
 
 func toProject(row *database.Project) Project {
 	return Project{
-		ID:        row.ID,
-		CreatedAt: row.CreatedAt.Time,
-		UpdatedAt: row.UpdatedAt.Time,
-		Slug:      row.Slug,
-		Name:      row.Name,
+		ID:         row.ID,
+		CreatedAt:  row.CreatedAt.Time,
+		UpdatedAt:  row.UpdatedAt.Time,
+		Slug:       row.Slug,
+		Name:       row.Name,
+		LocationID: row.LocationID,
 	}
 }
+
 func isPgUniqueViolation(err error) bool {
 	return err != nil && (strings.Contains(err.Error(), "23505") || strings.Contains(err.Error(), "unique"))
 }
+
+func isPgForeignKeyViolation(err error) bool {
+	return err != nil && (strings.Contains(err.Error(), "23503") || strings.Contains(err.Error(), "foreign key"))
+}
@@ -1,50 +1,261 @@
 package project
 
 import (
+	"app/pkg/audit"
+	"app/pkg/events"
+	"app/pkg/location"
+	"app/pkg/membership"
+	"app/pkg/platform/transaction"
+	"app/pkg/webhook"
 	"context"
+	"errors"
 )
 
+var ErrProjectRadiusWithoutNear = errors.New("radius requires a point to search near")
+
 type Service interface {
 	GetById(ctx context.Context, id int64) (Project, error)
-	List(ctx context.Context, limit, offset int64) ([]Project, error)
+	List(ctx context.Context, filter ListProjectsFilter, limit, offset int64) ([]Project, error)
 	Create(ctx context.Context, req CreateProjectRequest) (Project, error)
 	Update(ctx context.Context, id int64, req UpdateProjectRequest) (Project, error)
 	Delete(ctx context.Context, id int64) error
 }
 
 type service struct {
-	repository Repository
+	repository        Repository
+	locationService   location.Service
+	membershipService membership.Service
+	auditService      audit.Service
+	webhookService    webhook.Service
+	eventService      events.Service
+	transactions      transaction.Manager
 }
 
-func NewService(repository Repository) Service {
-	return &service{repository: repository}
+func NewService(repository Repository, locationService location.Service, membershipService membership.Service, auditService audit.Service, webhookService webhook.Service, eventService events.Service, transactions transaction.Manager) Service {
+	return &service{repository: repository, locationService: locationService, membershipService: membershipService, auditService: auditService, webhookService: webhookService, eventService: eventService, transactions: transactions}
 }
 
 func (s *service) GetById(ctx context.Context, id int64) (Project, error) {
-	return s.repository.GetById(ctx, id)
+	if err := s.membershipService.Authorize(ctx, id, membership.RoleViewer); err != nil {
+		return Project{}, err
+	}
+
+	project, err := s.repository.GetById(ctx, id)
+	if err != nil {
+		return Project{}, err
+	}
+	return s.withLocation(ctx, project)
 }
 
-func (s *service) List(ctx context.Context, limit, offset int64) ([]Project, error) {
-	return s.repository.List(ctx, limit, offset)
+func (s *service) List(ctx context.Context, filter ListProjectsFilter, limit, offset int64) ([]Project, error) {
+	if filter.RadiusKm != nil && filter.Near == nil {
+		return nil, ErrProjectRadiusWithoutNear
+	}
+
+	memberId, err := s.membershipService.MemberFilter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	filter.MemberID = memberId
+
+	projects, err := s.repository.List(ctx, filter, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return s.withLocations(ctx, projects)
 }
 
 func (s *service) Create(ctx context.Context, req CreateProjectRequest) (Project, error) {
 	project := Project{
-		Slug: req.Slug,
-		Name: req.Name,
+		Slug:       req.Slug,
+		Name:       req.Name,
+		LocationID: req.LocationID,
+	}
+	if err := s.authorizeLocation(ctx, project.LocationID); err != nil {
+		return Project{}, err
+	}
+
+	err := s.transactions.Run(ctx, func(ctx context.Context) error {
+		created, err := s.repository.Create(ctx, project)
+		if err != nil {
+			return err
+		}
+		project = created
+
+		if err := s.membershipService.GrantCreator(ctx, project.ID); err != nil {
+			return err
+		}
+		err = s.auditService.Record(ctx, audit.Entry{
+			Action:       audit.ActionCreate,
+			ResourceType: audit.ResourceTypeProject,
+			ResourceID:   project.ID,
+			After:        auditState(project),
+		})
+		if err != nil {
+			return err
+		}
+		return s.publish(ctx, webhook.EventProjectCreated, project)
+	})
+	if err != nil {
+		return Project{}, err
 	}
-	return s.repository.Create(ctx, project)
+	return s.withLocation(ctx, project)
 }
 
 func (s *service) Update(ctx context.Context, id int64, req UpdateProjectRequest) (Project, error) {
+	if err := s.membershipService.Authorize(ctx, id, membership.RoleEditor); err != nil {
+		return Project{}, err
+	}
+
 	project := Project{
-		ID:   id,
-		Slug: req.Slug,
-		Name: req.Name,
+		ID:         id,
+		Slug:       req.Slug,
+		Name:       req.Name,
+		LocationID: req.LocationID,
+	}
+
+	err := s.transactions.Run(ctx, func(ctx context.Context) error {
+		before, err := s.repository.GetById(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !equalIds(before.LocationID, project.LocationID) {
+			if err := s.authorizeLocation(ctx, project.LocationID); err != nil {
+				return err
+			}
+		}
+
+		updated, err := s.repository.Update(ctx, project)
+		if err != nil {
+			return err
+		}
+		project = updated
+
+		err = s.auditService.Record(ctx, audit.Entry{
+			Action:       audit.ActionUpdate,
+			ResourceType: audit.ResourceTypeProject,
+			ResourceID:   id,
+			Before:       auditState(before),
+			After:        auditState(project),
+		})
+		if err != nil {
+			return err
+		}
+		return s.publish(ctx, webhook.EventProjectUpdated, project)
+	})
+	if err != nil {
+		return Project{}, err
 	}
-	return s.repository.Update(ctx, project)
+	return s.withLocation(ctx, project)
 }
 
 func (s *service) Delete(ctx context.Context, id int64) error {
-	return s.repository.Delete(ctx, id)
+	if err := s.membershipService.Authorize(ctx, id, membership.RoleAdmin); err != nil {
+		return err
+	}
+
+	return s.transactions.Run(ctx, func(ctx context.Context) error {
+		before, err := s.repository.GetById(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.repository.Delete(ctx, id); err != nil {
+			return err
+		}
+
+		err = s.auditService.Record(ctx, audit.Entry{
+			Action:       audit.ActionDelete,
+			ResourceType: audit.ResourceTypeProject,
+			ResourceID:   id,
+			Before:       auditState(before),
+		})
+		if err != nil {
+			return err
+		}
+		return s.publish(ctx, webhook.EventProjectDeleted, before)
+	})
+}
+
+func (s *service) withLocation(ctx context.Context, project Project) (Project, error) {
+	projects, err := s.withLocations(ctx, []Project{project})
+	if err != nil {
+		return Project{}, err
+	}
+	return projects[0], nil
+}
+
+// authorizeLocation checks that the caller may read a location they assign,
+// the project would reveal it to its members otherwise.
+func (s *service) authorizeLocation(ctx context.Context, locationId *int64) error {
+	if locationId == nil {
+		return nil
+	}
+	_, err := s.locationService.GetById(ctx, *locationId)
+	if errors.Is(err, location.ErrLocationNotFound) {
+		return ErrProjectLocationNotFound
+	}
+	return err
+}
+
+func equalIds(a, b *int64) bool {
+	return (a == nil && b == nil) || (a != nil && b != nil && *a == *b)
+}
+
+// withLocations loads the locations of the projects in a single query.
+func (s *service) withLocations(ctx context.Context, projects []Project) ([]Project, error) {
+	var locationIds []int64
+	for _, project := range projects {
+		if project.LocationID != nil {
+			locationIds = append(locationIds, *project.LocationID)
+		}
+	}
+
+	locations, err := s.locationService.GetByIds(ctx, locationIds)
+	if err != nil {
+		return nil, err
+	}
+
+	locationsById := make(map[int64]location.Location, len(locations))
+	for _, l := range locations {
+		locationsById[l.ID] = l
+	}
+
+	for i, project := range projects {
+		if project.LocationID == nil {
+			continue
+		}
+		if l, ok := locationsById[*project.LocationID]; ok {
+			projects[i].Location = &l
+		}
+	}
+
+	return projects, nil
+}
+
+// publish notifies webhooks and event streams about a change of the project.
+func (s *service) publish(ctx context.Context, event webhook.Event, project Project) error {
+	data := toProjectResponse(project)
+	err := s.webhookService.Publish(ctx, webhook.Notification{
+		Event:      event,
+		ProjectIDs: []int64{project.ID},
+		Data:       data,
+	})
+	if err != nil {
+		return err
+	}
+	return s.eventService.Publish(ctx, events.Notification{
+		Event:      event,
+		ProjectIDs: []int64{project.ID},
+		Data:       data,
+	})
+}
+
+// auditState is what the audit log keeps of a project.
+func auditState(project Project) map[string]any {
+	return map[string]any{
+		"slug":       project.Slug,
+		"name":       project.Name,
+		"locationId": project.LocationID,
+	}
 }
@@ -0,0 +1,244 @@
+package qrcode
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"strings"
+
+	goqrcode "github.com/skip2/go-qrcode"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+type Format string
+
+const (
+	FormatPNG Format = "png"
+	FormatSVG Format = "svg"
+)
+
+type Level string
+
+const (
+	LevelLow      Level = "L"
+	LevelMedium   Level = "M"
+	LevelQuartile Level = "Q"
+	LevelHigh     Level = "H"
+)
+
+const (
+	MinSize        = 64
+	MaxSize        = 2048
+	DefaultSize    = 256
+	MaxLabelLength = 64
+
+	// quietZoneModules is the blank border the QR specification requires
+	// around the symbol.
+	quietZoneModules = 4
+	// labelBaseSize is the output size at which the label is drawn at the
+	// native glyph size, larger codes scale the label up proportionally.
+	labelBaseSize = 256
+	labelPadding  = 4
+)
+
+var (
+	ErrSizeTooSmall = errors.New("size too small for the encoded content")
+	ErrInvalidLevel = errors.New("invalid error correction level")
+)
+
+type Options struct {
+	Format Format
+	// Size is the width of the code in pixels, the label is drawn below it.
+	Size  int
+	Level Level
+	Label string
+}
+
+// Encode renders content as a QR code. The output only depends on content and
+// options, so rendering the same code twice yields identical bytes.
+func Encode(w io.Writer, content string, options Options) error {
+	level, err := toRecoveryLevel(options.Level)
+	if err != nil {
+		return err
+	}
+
+	code, err := goqrcode.New(content, level)
+	if err != nil {
+		return fmt.Errorf("failed to encode qr code: %w", err)
+	}
+	code.DisableBorder = true
+
+	layout, err := newLayout(code.Bitmap(), options.Size, options.Label)
+	if err != nil {
+		return err
+	}
+
+	switch options.Format {
+	case FormatSVG:
+		return writeSVG(w, layout)
+	default:
+		return writePNG(w, layout)
+	}
+}
+
+func ContentType(format Format) string {
+	if format == FormatSVG {
+		return "image/svg+xml"
+	}
+	return "image/png"
+}
+
+// layout positions the modules on a square canvas of the requested size,
+// using whole pixels per module and spreading the remainder over the quiet
+// zone so the modules stay sharp.
+type layout struct {
+	modules     [][]bool
+	size        int
+	moduleSize  int
+	offset      int
+	label       string
+	labelScale  int
+	labelHeight int
+}
+
+func newLayout(modules [][]bool, size int, label string) (layout, error) {
+	count := len(modules) + 2*quietZoneModules
+	moduleSize := size / count
+	if moduleSize == 0 {
+		return layout{}, ErrSizeTooSmall
+	}
+
+	l := layout{
+		modules:    modules,
+		size:       size,
+		moduleSize: moduleSize,
+		offset:     (size - moduleSize*len(modules)) / 2,
+		label:      label,
+		labelScale: max(1, size/labelBaseSize),
+	}
+	if label != "" {
+		l.labelHeight = (basicfont.Face7x13.Height + 2*labelPadding) * l.labelScale
+	}
+
+	return l, nil
+}
+
+func writePNG(w io.Writer, l layout) error {
+	img := image.NewGray(image.Rect(0, 0, l.size, l.size+l.labelHeight))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+
+	for y, row := range l.modules {
+		for x, dark := range row {
+			if !dark {
+				continue
+			}
+			module := image.Rect(0, 0, l.moduleSize, l.moduleSize).
+				Add(image.Pt(l.offset+x*l.moduleSize, l.offset+y*l.moduleSize))
+			draw.Draw(img, module, image.Black, image.Point{}, draw.Src)
+		}
+	}
+
+	if l.label != "" {
+		drawLabel(img, l)
+	}
+
+	encoder := png.Encoder{CompressionLevel: png.BestCompression}
+	return encoder.Encode(w, img)
+}
+
+// drawLabel renders the label with the built-in bitmap font at its native
+// size and scales it up with nearest neighbour sampling, which keeps the
+// output independent of any fonts installed on the host.
+func drawLabel(img *image.Gray, l layout) {
+	face := basicfont.Face7x13
+	text := fitLabel(l.label, l.size/l.labelScale)
+	width := font.MeasureString(face, text).Ceil()
+
+	glyphs := image.NewAlpha(image.Rect(0, 0, width, face.Height))
+	drawer := font.Drawer{
+		Dst:  glyphs,
+		Src:  image.Opaque,
+		Face: face,
+		Dot:  fixed.P(0, face.Ascent),
+	}
+	drawer.DrawString(text)
+
+	left := (l.size - width*l.labelScale) / 2
+	top := l.size + labelPadding*l.labelScale
+	for y := range face.Height {
+		for x := range width {
+			if glyphs.AlphaAt(x, y).A < 0x80 {
+				continue
+			}
+			pixel := image.Rect(0, 0, l.labelScale, l.labelScale).
+				Add(image.Pt(left+x*l.labelScale, top+y*l.labelScale))
+			draw.Draw(img, pixel, image.NewUniform(color.Black), image.Point{}, draw.Src)
+		}
+	}
+}
+
+// fitLabel shortens the label until it fits within width pixels.
+func fitLabel(label string, width int) string {
+	runes := []rune(label)
+	for len(runes) > 0 && font.MeasureString(basicfont.Face7x13, string(runes)).Ceil() > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes)
+}
+
+func writeSVG(w io.Writer, l layout) error {
+	var buf bytes.Buffer
+	height := l.size + l.labelHeight
+
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`,
+		l.size, height, l.size, height)
+	fmt.Fprintf(&buf, `<rect width="%d" height="%d" fill="#fff"/>`, l.size, height)
+
+	var path strings.Builder
+	for y, row := range l.modules {
+		for x, dark := range row {
+			if dark {
+				fmt.Fprintf(&path, "M%d %dh%dv%dh-%dz",
+					l.offset+x*l.moduleSize, l.offset+y*l.moduleSize, l.moduleSize, l.moduleSize, l.moduleSize)
+			}
+		}
+	}
+	fmt.Fprintf(&buf, `<path d="%s" fill="#000"/>`, path.String())
+
+	if l.label != "" {
+		fontSize := basicfont.Face7x13.Height * l.labelScale
+		fmt.Fprintf(&buf, `<text x="%d" y="%d" font-family="monospace" font-size="%d" text-anchor="middle" fill="#000">`,
+			l.size/2, l.size+labelPadding*l.labelScale+basicfont.Face7x13.Ascent*l.labelScale, fontSize)
+		if err := xml.EscapeText(&buf, []byte(l.label)); err != nil {
+			return err
+		}
+		buf.WriteString(`</text>`)
+	}
+
+	buf.WriteString(`</svg>`)
+
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+func toRecoveryLevel(level Level) (goqrcode.RecoveryLevel, error) {
+	switch level {
+	case LevelLow:
+		return goqrcode.Low, nil
+	case LevelMedium, "":
+		return goqrcode.Medium, nil
+	case LevelQuartile:
+		return goqrcode.High, nil
+	case LevelHigh:
+		return goqrcode.Highest, nil
+	}
+	return 0, ErrInvalidLevel
+}
@@ -0,0 +1,85 @@
+package qrcode
+
+import (
+	"app/pkg/platform/handler"
+	"app/pkg/platform/links"
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"unicode/utf8"
+)
+
+// Renderer serves QR codes that point at pages below the public base URL.
+type Renderer struct {
+	links *links.Builder
+}
+
+func NewRenderer(links *links.Builder) *Renderer {
+	return &Renderer{links: links}
+}
+
+// Write renders a QR code for path with the options from the query string.
+func (r *Renderer) Write(w http.ResponseWriter, req *http.Request, path string) {
+	options, err := ParseOptions(req)
+	if err != nil {
+		handler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var buf bytes.Buffer
+	err = Encode(&buf, r.links.URL(path), options)
+	if errors.Is(err, ErrSizeTooSmall) {
+		handler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	w.Header().Set("Content-Type", ContentType(options.Format))
+	w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+	w.WriteHeader(http.StatusOK)
+	_, _ = buf.WriteTo(w)
+}
+
+func ParseOptions(r *http.Request) (Options, error) {
+	query := r.URL.Query()
+
+	options := Options{
+		Format: FormatPNG,
+		Size:   DefaultSize,
+		Level:  LevelMedium,
+		Label:  query.Get("label"),
+	}
+
+	if query.Has("format") {
+		options.Format = Format(query.Get("format"))
+		if options.Format != FormatPNG && options.Format != FormatSVG {
+			return Options{}, errors.New("invalid format")
+		}
+	}
+
+	if query.Has("size") {
+		size, err := strconv.Atoi(query.Get("size"))
+		if err != nil || size < MinSize || size > MaxSize {
+			return Options{}, fmt.Errorf("size must be between %d and %d", MinSize, MaxSize)
+		}
+		options.Size = size
+	}
+
+	if query.Has("level") {
+		options.Level = Level(query.Get("level"))
+		if _, err := toRecoveryLevel(options.Level); err != nil {
+			return Options{}, err
+		}
+	}
+
+	if utf8.RuneCountInString(options.Label) > MaxLabelLength {
+		return Options{}, fmt.Errorf("label must not exceed %d characters", MaxLabelLength)
+	}
+
+	return options, nil
+}
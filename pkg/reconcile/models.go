@@ -0,0 +1,39 @@
+package reconcile
+
+import "time"
+
+// Orphan is a stored object no file revision refers to. Temporary objects are the
+// leftovers of uploads that were interrupted before they were renamed.
+type Orphan struct {
+	Path        string    `json:"path"`
+	Size        int64     `json:"size"`
+	ModifiedAt  time.Time `json:"modifiedAt"`
+	Temporary   bool      `json:"temporary"`
+	Deleted     bool      `json:"deleted"`
+	DeleteError *string   `json:"deleteError,omitempty"`
+}
+
+// MissingBlob is a file revision whose stored asset is gone.
+type MissingBlob struct {
+	FileID     int64  `json:"fileId"`
+	RevisionID int64  `json:"revisionId"`
+	Path       string `json:"path"`
+}
+
+type FilePath struct {
+	RevisionID int64
+	FileID     int64
+	Path       string
+}
+
+// Report is the outcome of comparing the storage against the database. In a
+// dry run nothing is deleted. It's printed as JSON by cmd/reconcile.
+type Report struct {
+	StartedAt  time.Time     `json:"startedAt"`
+	FinishedAt time.Time     `json:"finishedAt"`
+	DryRun     bool          `json:"dryRun"`
+	Scanned    int64         `json:"scanned"`
+	Orphans    []Orphan      `json:"orphans"`
+	OrphanSize int64         `json:"orphanSize"`
+	Missing    []MissingBlob `json:"missing"`
+}
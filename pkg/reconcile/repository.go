@@ -0,0 +1,42 @@
+package reconcile
+
+import (
+	"app/pkg/database"
+	"context"
+)
+
+type Repository interface {
+	// ListFilePaths pages through the stored assets of file revisions by
+	// revision id.
+	ListFilePaths(ctx context.Context, afterId int64, limit int64) ([]FilePath, error)
+	// ListReferenced returns the paths among the given ones that file
+	// revisions refer to.
+	ListReferenced(ctx context.Context, paths []string) ([]string, error)
+}
+
+type repository struct {
+	queries *database.Queries
+}
+
+func NewRepository(queries *database.Queries) Repository {
+	return &repository{queries: queries}
+}
+
+func (r *repository) ListFilePaths(ctx context.Context, afterId int64, limit int64) ([]FilePath, error) {
+	rows, err := r.queries.ListFilePaths(ctx, &database.ListFilePathsParams{
+		AfterId: afterId,
+		Limit:   limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	paths := make([]FilePath, len(rows))
+	for i, row := range rows {
+		paths[i] = FilePath{RevisionID: row.ID, FileID: row.FileID, Path: row.Path}
+	}
+	return paths, nil
+}
+
+func (r *repository) ListReferenced(ctx context.Context, paths []string) ([]string, error) {
+	return r.queries.ListReferencedFilePaths(ctx, paths)
+}
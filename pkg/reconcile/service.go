@@ -0,0 +1,190 @@
+package reconcile
+
+import (
+	"app/pkg/jobs"
+	"app/pkg/storage"
+	"cmp"
+	"context"
+	"errors"
+	"io/fs"
+	"log"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	JobKindReconcile = "storage.reconcile"
+
+	// assetRoot is where the file package stores the assets of files.
+	assetRoot = "files"
+	batchSize = 1000
+)
+
+type Service interface {
+	// Reconcile walks the stored assets and compares them against the paths
+	// file revisions refer to. Unreferenced objects older than the grace
+	// period are reported as orphans and deleted unless dryRun is set, younger
+	// ones may belong to uploads in progress and are left alone. Revisions
+	// whose asset is missing are only reported.
+	Reconcile(ctx context.Context, dryRun bool) (Report, error)
+	// ReconcileJob is the processor of JobKindReconcile jobs, it only deletes
+	// orphans when deleteOrphans is configured.
+	ReconcileJob(ctx context.Context, job jobs.Job) error
+}
+
+type service struct {
+	repository    Repository
+	fileStorage   storage.FileStorage
+	gracePeriod   time.Duration
+	deleteOrphans bool
+}
+
+func NewService(repository Repository, fileStorage storage.FileStorage, gracePeriod time.Duration, deleteOrphans bool) Service {
+	return &service{repository: repository, fileStorage: fileStorage, gracePeriod: gracePeriod, deleteOrphans: deleteOrphans}
+}
+
+func (s *service) Reconcile(ctx context.Context, dryRun bool) (Report, error) {
+	report := Report{StartedAt: time.Now(), DryRun: dryRun}
+
+	referenced, err := s.referencedPaths(ctx)
+	if err != nil {
+		return Report{}, err
+	}
+
+	// What is left of referenced after the walk was never seen in storage.
+	cutoff := report.StartedAt.Add(-s.gracePeriod)
+	var candidates []Orphan
+	err = s.fileStorage.Walk(ctx, assetRoot, func(info storage.ObjectInfo) error {
+		report.Scanned++
+		if _, ok := referenced[info.Path]; ok {
+			delete(referenced, info.Path)
+			return nil
+		}
+		if info.ModifiedAt.After(cutoff) {
+			return nil
+		}
+		candidates = append(candidates, Orphan{
+			Path:       info.Path,
+			Size:       info.Size,
+			ModifiedAt: info.ModifiedAt,
+			Temporary:  strings.HasSuffix(info.Path, ".tmp"),
+		})
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return Report{}, err
+	}
+
+	report.Orphans, err = s.unreferenced(ctx, candidates)
+	if err != nil {
+		return Report{}, err
+	}
+	for i := range report.Orphans {
+		orphan := &report.Orphans[i]
+		report.OrphanSize += orphan.Size
+		if dryRun {
+			continue
+		}
+		if err := s.fileStorage.Delete(ctx, orphan.Path); err != nil {
+			orphan.DeleteError = new(err.Error())
+			continue
+		}
+		orphan.Deleted = true
+	}
+
+	report.Missing, err = s.missing(ctx, referenced)
+	if err != nil {
+		return Report{}, err
+	}
+
+	report.FinishedAt = time.Now()
+	return report, nil
+}
+
+func (s *service) ReconcileJob(ctx context.Context, _ jobs.Job) error {
+	report, err := s.Reconcile(ctx, !s.deleteOrphans)
+	if err != nil {
+		return err
+	}
+
+	deleted := 0
+	for _, orphan := range report.Orphans {
+		if orphan.Deleted {
+			deleted++
+		} else if orphan.DeleteError != nil {
+			log.Printf("error deleting orphaned object %s: %s", orphan.Path, *orphan.DeleteError)
+		}
+	}
+	for _, missing := range report.Missing {
+		log.Printf("revision %d of file %d is missing its stored asset %s", missing.RevisionID, missing.FileID, missing.Path)
+	}
+	log.Printf("storage reconciled: %d objects scanned, %d orphans of %d bytes, %d deleted, %d revisions missing their asset",
+		report.Scanned, len(report.Orphans), report.OrphanSize, deleted, len(report.Missing))
+	return nil
+}
+
+// referencedPaths maps the paths file revisions refer to to the revisions.
+func (s *service) referencedPaths(ctx context.Context) (map[string]FilePath, error) {
+	referenced := make(map[string]FilePath)
+	var afterId int64
+	for {
+		paths, err := s.repository.ListFilePaths(ctx, afterId, batchSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range paths {
+			referenced[path.Path] = path
+		}
+		if len(paths) < batchSize {
+			return referenced, nil
+		}
+		afterId = paths[len(paths)-1].RevisionID
+	}
+}
+
+// unreferenced checks the candidates against the database again, a file may
+// have come to refer to one of them during the walk.
+func (s *service) unreferenced(ctx context.Context, candidates []Orphan) ([]Orphan, error) {
+	orphans := make([]Orphan, 0, len(candidates))
+	for batch := range slices.Chunk(candidates, batchSize) {
+		paths := make([]string, len(batch))
+		for i, candidate := range batch {
+			paths[i] = candidate.Path
+		}
+
+		referenced, err := s.repository.ListReferenced(ctx, paths)
+		if err != nil {
+			return nil, err
+		}
+		for _, candidate := range batch {
+			if !slices.Contains(referenced, candidate.Path) {
+				orphans = append(orphans, candidate)
+			}
+		}
+	}
+	return orphans, nil
+}
+
+// missing checks the revisions whose asset wasn't seen against the database
+// again, revisions of files deleted during the walk aren't missing anything.
+func (s *service) missing(ctx context.Context, unseen map[string]FilePath) ([]MissingBlob, error) {
+	paths := slices.Collect(maps.Keys(unseen))
+
+	var missing []MissingBlob
+	for batch := range slices.Chunk(paths, batchSize) {
+		referenced, err := s.repository.ListReferenced(ctx, batch)
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range referenced {
+			revision := unseen[path]
+			missing = append(missing, MissingBlob{FileID: revision.FileID, RevisionID: revision.RevisionID, Path: path})
+		}
+	}
+	slices.SortFunc(missing, func(a, b MissingBlob) int {
+		return cmp.Compare(a.RevisionID, b.RevisionID)
+	})
+	return missing, nil
+}
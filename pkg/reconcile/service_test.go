@@ -0,0 +1,135 @@
+package reconcile
+
+import (
+	"app/pkg/jobs"
+	"app/pkg/storage"
+	"context"
+	"errors"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+const gracePeriod = time.Hour
+
+// fakeRepository holds the asset paths of file revisions by revision id.
+type fakeRepository struct {
+	paths map[int64]string
+}
+
+func (r *fakeRepository) ListFilePaths(_ context.Context, afterId int64, limit int64) ([]FilePath, error) {
+	var paths []FilePath
+	for _, id := range slices.Sorted(maps.Keys(r.paths)) {
+		if id > afterId && int64(len(paths)) < limit {
+			paths = append(paths, FilePath{RevisionID: id, FileID: id, Path: r.paths[id]})
+		}
+	}
+	return paths, nil
+}
+
+func (r *fakeRepository) ListReferenced(_ context.Context, paths []string) ([]string, error) {
+	var referenced []string
+	for _, path := range r.paths {
+		if slices.Contains(paths, path) {
+			referenced = append(referenced, path)
+		}
+	}
+	return referenced, nil
+}
+
+// setup stores a referenced asset, an orphan past the grace period and a
+// fresh unreferenced object, and refers to an asset that was never stored.
+func setup(t *testing.T) (string, storage.FileStorage, Repository) {
+	t.Helper()
+
+	root := t.TempDir()
+	fileStorage, err := storage.NewFilesystemStorage(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{"files/1/referenced", "files/2/orphan", "files/3/fresh"} {
+		if err := fileStorage.Save(context.Background(), path, strings.NewReader(path)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	old := time.Now().Add(-2 * gracePeriod)
+	if err := os.Chtimes(filepath.Join(root, "files", "2", "orphan"), old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	repository := &fakeRepository{paths: map[int64]string{1: "files/1/referenced", 4: "files/4/missing"}}
+	return root, fileStorage, repository
+}
+
+func assertExists(t *testing.T, root, path string, exists bool) {
+	t.Helper()
+
+	_, err := os.Stat(filepath.Join(root, filepath.FromSlash(path)))
+	if exists && err != nil {
+		t.Errorf("%s should exist: %v", path, err)
+	}
+	if !exists && !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("%s should be deleted: %v", path, err)
+	}
+}
+
+func TestReconcileDryRunReportsOrphans(t *testing.T) {
+	root, fileStorage, repository := setup(t)
+	service := NewService(repository, fileStorage, gracePeriod, false)
+
+	report, err := service.Reconcile(context.Background(), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Scanned != 3 {
+		t.Errorf("scanned %d objects, want 3", report.Scanned)
+	}
+	if len(report.Orphans) != 1 || report.Orphans[0].Path != "files/2/orphan" {
+		t.Fatalf("orphans = %+v, want files/2/orphan only", report.Orphans)
+	}
+	if report.Orphans[0].Deleted {
+		t.Error("dry run deleted the orphan")
+	}
+	if len(report.Missing) != 1 || report.Missing[0].RevisionID != 4 {
+		t.Errorf("missing = %+v, want revision 4 only", report.Missing)
+	}
+	assertExists(t, root, "files/2/orphan", true)
+}
+
+func TestReconcileDeletesOrphans(t *testing.T) {
+	root, fileStorage, repository := setup(t)
+	service := NewService(repository, fileStorage, gracePeriod, false)
+
+	report, err := service.Reconcile(context.Background(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(report.Orphans) != 1 || !report.Orphans[0].Deleted {
+		t.Fatalf("orphans = %+v, want files/2/orphan deleted", report.Orphans)
+	}
+	assertExists(t, root, "files/2/orphan", false)
+	assertExists(t, root, "files/1/referenced", true)
+	assertExists(t, root, "files/3/fresh", true)
+}
+
+func TestReconcileJobDeletesOnlyWhenConfigured(t *testing.T) {
+	for _, deleteOrphans := range []bool{false, true} {
+		root, fileStorage, repository := setup(t)
+		service := NewService(repository, fileStorage, gracePeriod, deleteOrphans)
+
+		if err := service.ReconcileJob(context.Background(), jobs.Job{Kind: JobKindReconcile}); err != nil {
+			t.Fatal(err)
+		}
+
+		assertExists(t, root, "files/2/orphan", !deleteOrphans)
+		assertExists(t, root, "files/1/referenced", true)
+	}
+}
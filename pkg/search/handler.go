@@ -0,0 +1,70 @@
+package search
+
+import (
+	"app/pkg/api"
+	"app/pkg/platform/auth"
+	"app/pkg/platform/handler"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Get("/v1/search", h.Search)
+}
+
+func (h *Handler) Search(w http.ResponseWriter, r *http.Request) {
+	limit, offset := handler.ParsePagination(r)
+
+	query := Query{Term: r.URL.Query().Get("q"), Mode: Mode(r.URL.Query().Get("mode"))}
+	if types := r.URL.Query().Get("types"); types != "" {
+		for t := range strings.SplitSeq(types, ",") {
+			query.Types = append(query.Types, HitType(strings.TrimSpace(t)))
+		}
+	}
+
+	hits, err := h.service.Search(r.Context(), query, limit, offset)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrSearchQueryEmpty) || errors.Is(err, ErrSearchInvalidType) || errors.Is(err, ErrSearchInvalidMode) {
+		handler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	handler.WriteJson(w, http.StatusOK, toSearchResponse(hits, limit, offset))
+}
+
+func toSearchResponse(hits []Hit, limit, offset int64) api.SearchResponse {
+	items := make([]api.SearchHitResponse, len(hits))
+	for i, hit := range hits {
+		items[i] = api.SearchHitResponse{
+			Type:      api.SearchHitType(hit.Type),
+			Id:        hit.ID,
+			ProjectId: hit.ProjectID,
+			Title:     hit.Title,
+			Highlight: hit.Highlight,
+			Rank:      hit.Rank,
+		}
+	}
+	return api.SearchResponse{
+		Limit:  limit,
+		Offset: offset,
+		Hits:   items,
+	}
+}
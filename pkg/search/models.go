@@ -0,0 +1,48 @@
+package search
+
+type HitType string
+
+const (
+	HitTypeProject HitType = "project"
+	HitTypeVersion HitType = "version"
+	HitTypeFile    HitType = "file"
+)
+
+func (t HitType) IsValid() bool {
+	return t == HitTypeProject || t == HitTypeVersion || t == HitTypeFile
+}
+
+// Mode selects what is searched. Metadata searches the names of projects,
+// versions and files and the descriptions of versions, content searches the
+// text extracted from files.
+type Mode string
+
+const (
+	ModeMetadata Mode = "metadata"
+	ModeContent  Mode = "content"
+)
+
+func (m Mode) IsValid() bool {
+	return m == ModeMetadata || m == ModeContent
+}
+
+// Query is a search term in web search syntax, quoted phrases, "or" and a
+// leading "-" to exclude a word are understood. An empty Types searches
+// every type, content searches only ever find files.
+type Query struct {
+	Term  string
+	Mode  Mode
+	Types []HitType
+}
+
+// Hit is a single match. ProjectID is the project the hit belongs to, files
+// can be attached to versions of several projects and have none. For content
+// searches the highlight is a snippet of the matching text.
+type Hit struct {
+	Type      HitType
+	ID        int64
+	ProjectID *int64
+	Title     string
+	Highlight string
+	Rank      float32
+}
@@ -0,0 +1,72 @@
+package search
+
+import (
+	"app/pkg/database"
+	"context"
+)
+
+type Repository interface {
+	Search(ctx context.Context, query Query, memberId *int64, limit, offset int64) ([]Hit, error)
+	SearchContents(ctx context.Context, term string, memberId *int64, limit, offset int64) ([]Hit, error)
+}
+
+type repository struct {
+	queries *database.Queries
+}
+
+func NewRepository(queries *database.Queries) Repository {
+	return &repository{queries: queries}
+}
+
+func (r *repository) Search(ctx context.Context, query Query, memberId *int64, limit, offset int64) ([]Hit, error) {
+	var types []string
+	for _, t := range query.Types {
+		types = append(types, string(t))
+	}
+
+	rows, err := r.queries.Search(ctx, &database.SearchParams{
+		Query:    query.Term,
+		Types:    types,
+		MemberId: memberId,
+		Limit:    limit,
+		Offset:   offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+	hits := make([]Hit, len(rows))
+	for i, row := range rows {
+		hits[i] = Hit{
+			Type:      HitType(row.Type),
+			ID:        row.ID,
+			ProjectID: row.ProjectID,
+			Title:     row.Title,
+			Highlight: row.Highlight,
+			Rank:      row.Rank,
+		}
+	}
+	return hits, nil
+}
+
+func (r *repository) SearchContents(ctx context.Context, term string, memberId *int64, limit, offset int64) ([]Hit, error) {
+	rows, err := r.queries.SearchFileContents(ctx, &database.SearchFileContentsParams{
+		Query:    term,
+		MemberId: memberId,
+		Limit:    limit,
+		Offset:   offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+	hits := make([]Hit, len(rows))
+	for i, row := range rows {
+		hits[i] = Hit{
+			Type:      HitTypeFile,
+			ID:        row.ID,
+			Title:     row.Name,
+			Highlight: row.Snippet,
+			Rank:      row.Rank,
+		}
+	}
+	return hits, nil
+}
@@ -0,0 +1,61 @@
+package search
+
+import (
+	"app/pkg/membership"
+	"context"
+	"errors"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrSearchQueryEmpty  = errors.New("search query must not be empty")
+	ErrSearchInvalidType = errors.New("invalid search type")
+	ErrSearchInvalidMode = errors.New("invalid search mode")
+)
+
+type Service interface {
+	// Search only returns hits the caller may see, which are the projects
+	// they're a member of, their versions and the files attached to them or
+	// uploaded by the caller.
+	Search(ctx context.Context, query Query, limit, offset int64) ([]Hit, error)
+}
+
+type service struct {
+	repository        Repository
+	membershipService membership.Service
+}
+
+func NewService(repository Repository, membershipService membership.Service) Service {
+	return &service{repository: repository, membershipService: membershipService}
+}
+
+func (s *service) Search(ctx context.Context, query Query, limit, offset int64) ([]Hit, error) {
+	query.Term = strings.TrimSpace(query.Term)
+	if query.Term == "" {
+		return nil, ErrSearchQueryEmpty
+	}
+	if query.Mode == "" {
+		query.Mode = ModeMetadata
+	}
+	if !query.Mode.IsValid() {
+		return nil, ErrSearchInvalidMode
+	}
+	for _, t := range query.Types {
+		if !t.IsValid() {
+			return nil, ErrSearchInvalidType
+		}
+	}
+
+	memberId, err := s.membershipService.MemberFilter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if query.Mode == ModeContent {
+		if len(query.Types) > 0 && !slices.Contains(query.Types, HitTypeFile) {
+			return []Hit{}, nil
+		}
+		return s.repository.SearchContents(ctx, query.Term, memberId, limit, offset)
+	}
+	return s.repository.Search(ctx, query, memberId, limit, offset)
+}
@@ -0,0 +1,316 @@
+package sharelink
+
+import (
+	"app/pkg/api"
+	"app/pkg/file"
+	"app/pkg/platform/auth"
+	"app/pkg/platform/handler"
+	"app/pkg/platform/links"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler struct {
+	service Service
+	signer  *Signer
+	links   *links.Builder
+}
+
+func NewHandler(service Service, signer *Signer, links *links.Builder) *Handler {
+	return &Handler{service: service, signer: signer, links: links}
+}
+
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Route("/v1/share-links", func(r chi.Router) {
+		r.Get("/", h.List)
+		r.Post("/", h.Create)
+
+		r.Route("/{shareLinkId}", func(r chi.Router) {
+			r.Get("/", h.GetById)
+			r.Post("/revoke", h.Revoke)
+		})
+	})
+}
+
+// RegisterPublicRoutes registers the routes share links point at, they are
+// served without a bearer token.
+func (h *Handler) RegisterPublicRoutes(r chi.Router) {
+	r.Route("/s/{token}", func(r chi.Router) {
+		r.Get("/", h.Open)
+		r.Head("/", h.Open)
+		r.Get("/files/{fileId}", h.DownloadFile)
+		r.Head("/files/{fileId}", h.DownloadFile)
+	})
+}
+
+func (h *Handler) GetById(w http.ResponseWriter, r *http.Request) {
+	id, err := parseShareLinkId(r)
+	if err != nil {
+		writeInvalidShareLinkIdError(w)
+		return
+	}
+
+	shareLink, err := h.service.GetById(r.Context(), id)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrShareLinkNotFound) {
+		writeShareLinkNotFoundError(w)
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	handler.WriteJson(w, http.StatusOK, h.toShareLinkResponse(shareLink))
+}
+
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	limit, offset := handler.ParsePagination(r)
+
+	fileId, err := parseOptionalQueryId(r, "fileId")
+	if err != nil {
+		handler.WriteError(w, http.StatusBadRequest, "invalid file id")
+		return
+	}
+
+	versionId, err := parseOptionalQueryId(r, "versionId")
+	if err != nil {
+		handler.WriteError(w, http.StatusBadRequest, "invalid version id")
+		return
+	}
+
+	shareLinks, err := h.service.List(r.Context(), fileId, versionId, limit, offset)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	handler.WriteJson(w, http.StatusOK, h.toListShareLinksResponse(shareLinks, limit, offset))
+}
+
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	var req api.CreateShareLinkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handler.WriteInvalidRequestPayloadError(w)
+		return
+	}
+
+	shareLink, err := h.service.Create(r.Context(), CreateShareLinkRequest{
+		FileID:       req.FileId,
+		VersionID:    req.VersionId,
+		ExpiresAt:    req.ExpiresAt,
+		MaxDownloads: req.MaxDownloads,
+		Password:     req.Password,
+	})
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrShareLinkInvalidTarget) ||
+		errors.Is(err, ErrShareLinkInvalidExpiry) ||
+		errors.Is(err, ErrShareLinkInvalidMaxDownloads) ||
+		errors.Is(err, ErrShareLinkTargetNotFound) {
+		handler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	handler.WriteJson(w, http.StatusCreated, h.toShareLinkResponse(shareLink))
+}
+
+func (h *Handler) Revoke(w http.ResponseWriter, r *http.Request) {
+	id, err := parseShareLinkId(r)
+	if err != nil {
+		writeInvalidShareLinkIdError(w)
+		return
+	}
+
+	shareLink, err := h.service.Revoke(r.Context(), id)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrShareLinkNotFound) {
+		writeShareLinkNotFoundError(w)
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	handler.WriteJson(w, http.StatusOK, h.toShareLinkResponse(shareLink))
+}
+
+func (h *Handler) Open(w http.ResponseWriter, r *http.Request) {
+	access := parseAccess(r)
+
+	shareLink, err := h.service.Open(r.Context(), access)
+	if err != nil {
+		writeAccessError(w, err)
+		return
+	}
+
+	if shareLink.FileID != nil {
+		h.download(w, r, access, nil)
+		return
+	}
+
+	limit, offset := handler.ParsePagination(r)
+
+	files, err := h.service.ListFiles(r.Context(), access, limit, offset)
+	if err != nil {
+		writeAccessError(w, err)
+		return
+	}
+
+	handler.WriteJson(w, http.StatusOK, h.toSharedVersionResponse(access.Token, shareLink, files, limit, offset))
+}
+
+func (h *Handler) DownloadFile(w http.ResponseWriter, r *http.Request) {
+	fileId, err := strconv.ParseInt(chi.URLParam(r, "fileId"), 10, 64)
+	if err != nil {
+		handler.WriteError(w, http.StatusBadRequest, "invalid file id")
+		return
+	}
+
+	h.download(w, r, parseAccess(r), &fileId)
+}
+
+func (h *Handler) download(w http.ResponseWriter, r *http.Request, access Access, fileId *int64) {
+	sharedFile, reader, err := h.service.Download(r.Context(), access, fileId, countsAsDownload(r))
+	if err != nil {
+		writeAccessError(w, err)
+		return
+	}
+
+	// Downloads are counted, so responses must not be cached.
+	w.Header().Set("Cache-Control", "no-store")
+	file.WriteContent(w, r, sharedFile, reader)
+}
+
+// countsAsDownload tells whether the request uses up the link. Every GET
+// returns content and counts, ranges included, otherwise any range covering
+// the file would be an unlimited download. HEAD requests are free, they still
+// fail once the link is used up though.
+func countsAsDownload(r *http.Request) bool {
+	return r.Method == http.MethodGet
+}
+
+// writeAccessError maps the errors of the public routes. Unknown and forged
+// tokens look the same, so holders can't probe for valid link ids.
+func writeAccessError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrShareLinkInvalidSignature), errors.Is(err, ErrShareLinkNotFound):
+		writeShareLinkNotFoundError(w)
+	case errors.Is(err, ErrShareLinkRevoked), errors.Is(err, ErrShareLinkExpired), errors.Is(err, ErrShareLinkExhausted):
+		handler.WriteError(w, http.StatusGone, err.Error())
+	case errors.Is(err, ErrShareLinkPasswordRequired):
+		w.Header().Set("WWW-Authenticate", `Basic realm="share link", charset="UTF-8"`)
+		handler.WriteError(w, http.StatusUnauthorized, err.Error())
+	case errors.Is(err, file.ErrFileNotFound), errors.Is(err, ErrShareLinkNotAVersion):
+		handler.WriteError(w, http.StatusNotFound, "file not found")
+	case errors.Is(err, file.ErrFileNotComplete):
+		handler.WriteError(w, http.StatusNotFound, "file not complete")
+	default:
+		handler.WriteInternalServerError(w)
+	}
+}
+
+func writeInvalidShareLinkIdError(w http.ResponseWriter) {
+	handler.WriteError(w, http.StatusBadRequest, "invalid share link id")
+}
+
+func writeShareLinkNotFoundError(w http.ResponseWriter) {
+	handler.WriteError(w, http.StatusNotFound, "share link not found")
+}
+
+func parseShareLinkId(r *http.Request) (int64, error) {
+	return strconv.ParseInt(chi.URLParam(r, "shareLinkId"), 10, 64)
+}
+
+// parseAccess reads the token from the path and the password from HTTP basic
+// authentication, which browsers prompt for on their own.
+func parseAccess(r *http.Request) Access {
+	access := Access{Token: chi.URLParam(r, "token")}
+	if _, password, ok := r.BasicAuth(); ok {
+		access.Password = &password
+	}
+	return access
+}
+
+func parseOptionalQueryId(r *http.Request, name string) (*int64, error) {
+	if !r.URL.Query().Has(name) {
+		return nil, nil
+	}
+
+	id, err := strconv.ParseInt(r.URL.Query().Get(name), 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &id, nil
+}
+
+func (h *Handler) toShareLinkResponse(s ShareLink) api.ShareLinkResponse {
+	return api.ShareLinkResponse{
+		Id:            s.ID,
+		CreatedAt:     s.CreatedAt,
+		UpdatedAt:     s.UpdatedAt,
+		Url:           h.links.URL(links.ShareLinkPath(h.signer.Sign(s))),
+		FileId:        s.FileID,
+		VersionId:     s.VersionID,
+		ExpiresAt:     s.ExpiresAt,
+		MaxDownloads:  s.MaxDownloads,
+		DownloadCount: s.DownloadCount,
+		HasPassword:   s.PasswordHash != nil,
+		RevokedAt:     s.RevokedAt,
+	}
+}
+
+func (h *Handler) toListShareLinksResponse(s []ShareLink, limit, offset int64) api.ListShareLinksResponse {
+	items := make([]api.ShareLinkResponse, len(s))
+	for i, shareLink := range s {
+		items[i] = h.toShareLinkResponse(shareLink)
+	}
+	return api.ListShareLinksResponse{
+		Limit:      limit,
+		Offset:     offset,
+		ShareLinks: items,
+	}
+}
+
+func (h *Handler) toSharedVersionResponse(token string, s ShareLink, f []file.File, limit, offset int64) api.SharedVersionResponse {
+	items := make([]api.SharedFileResponse, len(f))
+	for i, sharedFile := range f {
+		items[i] = api.SharedFileResponse{
+			Id:       sharedFile.ID,
+			Name:     sharedFile.Name,
+			Size:     sharedFile.Size,
+			MimeType: sharedFile.MimeType,
+			Checksum: sharedFile.Checksum,
+			Url:      h.links.URL(links.ShareLinkFilePath(token, sharedFile.ID)),
+		}
+	}
+	return api.SharedVersionResponse{
+		VersionId: *s.VersionID,
+		ExpiresAt: s.ExpiresAt,
+		Limit:     limit,
+		Offset:    offset,
+		Files:     items,
+	}
+}
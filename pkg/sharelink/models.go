@@ -0,0 +1,32 @@
+package sharelink
+
+import "time"
+
+type ShareLink struct {
+	ID            int64
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	FileID        *int64
+	VersionID     *int64
+	ExpiresAt     time.Time
+	MaxDownloads  *int64
+	DownloadCount int64
+	PasswordHash  *string
+	RevokedAt     *time.Time
+	CreatedBy     *int64
+}
+
+type CreateShareLinkRequest struct {
+	FileID       *int64
+	VersionID    *int64
+	ExpiresAt    time.Time
+	MaxDownloads *int64
+	Password     *string
+}
+
+// Access is what the holder of a share link presents, the password is only
+// checked for links that were created with one.
+type Access struct {
+	Token    string
+	Password *string
+}
@@ -0,0 +1,140 @@
+package sharelink
+
+import (
+	"app/pkg/database"
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrShareLinkNotFound       = errors.New("share link not found")
+	ErrShareLinkTargetNotFound = errors.New("share link target not found")
+	ErrShareLinkExhausted      = errors.New("share link download limit reached")
+)
+
+type Repository interface {
+	GetById(ctx context.Context, id int64) (ShareLink, error)
+	List(ctx context.Context, fileId, versionId, createdBy *int64, limit, offset int64) ([]ShareLink, error)
+	Create(ctx context.Context, shareLink ShareLink) (ShareLink, error)
+	Revoke(ctx context.Context, id int64) (ShareLink, error)
+	// ConsumeDownload counts a download and fails with ErrShareLinkExhausted
+	// when the link has no downloads left.
+	ConsumeDownload(ctx context.Context, id int64) (ShareLink, error)
+	IsFileInVersion(ctx context.Context, versionId, fileId int64) (bool, error)
+}
+
+type repository struct {
+	queries *database.Queries
+}
+
+func NewRepository(queries *database.Queries) Repository {
+	return &repository{queries: queries}
+}
+
+func (r *repository) GetById(ctx context.Context, id int64) (ShareLink, error) {
+	row, err := r.queries.GetShareLink(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ShareLink{}, ErrShareLinkNotFound
+	}
+	if err != nil {
+		return ShareLink{}, err
+	}
+	return toShareLink(row), nil
+}
+
+func (r *repository) List(ctx context.Context, fileId, versionId, createdBy *int64, limit, offset int64) ([]ShareLink, error) {
+	rows, err := r.queries.ListShareLinks(ctx, &database.ListShareLinksParams{
+		FileId:    fileId,
+		VersionId: versionId,
+		CreatedBy: createdBy,
+		Limit:     limit,
+		Offset:    offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+	shareLinks := make([]ShareLink, len(rows))
+	for i, row := range rows {
+		shareLinks[i] = toShareLink(row)
+	}
+	return shareLinks, nil
+}
+
+func (r *repository) Create(ctx context.Context, shareLink ShareLink) (ShareLink, error) {
+	row, err := r.queries.CreateShareLink(ctx, &database.CreateShareLinkParams{
+		FileID:       shareLink.FileID,
+		VersionID:    shareLink.VersionID,
+		ExpiresAt:    pgtype.Timestamp{Time: shareLink.ExpiresAt, Valid: true},
+		MaxDownloads: shareLink.MaxDownloads,
+		PasswordHash: shareLink.PasswordHash,
+		CreatedBy:    shareLink.CreatedBy,
+	})
+	if err != nil {
+		if isPgForeignKeyViolation(err) {
+			return ShareLink{}, ErrShareLinkTargetNotFound
+		}
+		return ShareLink{}, err
+	}
+	return toShareLink(row), nil
+}
+
+func (r *repository) Revoke(ctx context.Context, id int64) (ShareLink, error) {
+	row, err := r.queries.RevokeShareLink(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ShareLink{}, ErrShareLinkNotFound
+	}
+	if err != nil {
+		return ShareLink{}, err
+	}
+	return toShareLink(row), nil
+}
+
+func (r *repository) ConsumeDownload(ctx context.Context, id int64) (ShareLink, error) {
+	row, err := r.queries.ConsumeShareLinkDownload(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ShareLink{}, ErrShareLinkExhausted
+	}
+	if err != nil {
+		return ShareLink{}, err
+	}
+	return toShareLink(row), nil
+}
+
+func (r *repository) IsFileInVersion(ctx context.Context, versionId, fileId int64) (bool, error) {
+	return r.queries.IsFileInVersion(ctx, &database.IsFileInVersionParams{
+		VersionID: versionId,
+		FileID:    fileId,
+	})
+}
+
+func toShareLink(row *database.ShareLink) ShareLink {
+	return ShareLink{
+		ID:            row.ID,
+		CreatedAt:     row.CreatedAt.Time,
+		UpdatedAt:     row.UpdatedAt.Time,
+		FileID:        row.FileID,
+		VersionID:     row.VersionID,
+		ExpiresAt:     row.ExpiresAt.Time,
+		MaxDownloads:  row.MaxDownloads,
+		DownloadCount: row.DownloadCount,
+		PasswordHash:  row.PasswordHash,
+		RevokedAt:     toTime(row.RevokedAt),
+		CreatedBy:     row.CreatedBy,
+	}
+}
+
+func toTime(timestamp pgtype.Timestamp) *time.Time {
+	if !timestamp.Valid {
+		return nil
+	}
+	return &timestamp.Time
+}
+
+func isPgForeignKeyViolation(err error) bool {
+	return err != nil && (strings.Contains(err.Error(), "23503") || strings.Contains(err.Error(), "foreign key"))
+}
@@ -0,0 +1,326 @@
+package sharelink
+
+import (
+	"app/pkg/audit"
+	"app/pkg/file"
+	"app/pkg/membership"
+	"app/pkg/platform/auth"
+	"app/pkg/platform/transaction"
+	"app/pkg/version"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrShareLinkInvalidTarget       = errors.New("share link must target either a file or a version")
+	ErrShareLinkInvalidExpiry       = errors.New("share link expiry must be in the future")
+	ErrShareLinkInvalidMaxDownloads = errors.New("share link max downloads must be positive")
+	ErrShareLinkInvalidSignature    = errors.New("share link signature is invalid")
+	ErrShareLinkRevoked             = errors.New("share link revoked")
+	ErrShareLinkExpired             = errors.New("share link expired")
+	ErrShareLinkPasswordRequired    = errors.New("share link password required")
+	ErrShareLinkNotAVersion         = errors.New("share link does not target a version")
+)
+
+type Service interface {
+	GetById(ctx context.Context, id int64) (ShareLink, error)
+	List(ctx context.Context, fileId, versionId *int64, limit, offset int64) ([]ShareLink, error)
+	Create(ctx context.Context, req CreateShareLinkRequest) (ShareLink, error)
+	// Revoke is up to instance admins, the creator of the link and the
+	// editors of what it shares.
+	Revoke(ctx context.Context, id int64) (ShareLink, error)
+	// Open validates the token and password of a public request and returns
+	// the link when it may still be used.
+	Open(ctx context.Context, access Access) (ShareLink, error)
+	// ListFiles lists the files of the version a link was issued for.
+	ListFiles(ctx context.Context, access Access, limit, offset int64) ([]file.File, error)
+	// Download opens the shared file, or the given file of a shared version,
+	// and counts the download against the link's limit when count is set.
+	Download(ctx context.Context, access Access, fileId *int64, count bool) (file.File, io.ReadSeekCloser, error)
+}
+
+type service struct {
+	repository        Repository
+	signer            *Signer
+	fileService       file.Service
+	fileAuthorizer    file.Authorizer
+	versionService    version.Service
+	membershipService membership.Service
+	auditService      audit.Service
+	transactions      transaction.Manager
+}
+
+func NewService(repository Repository, signer *Signer, fileService file.Service, fileAuthorizer file.Authorizer, versionService version.Service, membershipService membership.Service, auditService audit.Service, transactions transaction.Manager) Service {
+	return &service{repository: repository, signer: signer, fileService: fileService, fileAuthorizer: fileAuthorizer, versionService: versionService, membershipService: membershipService, auditService: auditService, transactions: transactions}
+}
+
+func (s *service) GetById(ctx context.Context, id int64) (ShareLink, error) {
+	return s.authorized(ctx, id, membership.RoleViewer)
+}
+
+// List shows instance admins every link, everybody else only the links they
+// created.
+func (s *service) List(ctx context.Context, fileId, versionId *int64, limit, offset int64) ([]ShareLink, error) {
+	principal, ok := auth.GetPrincipal(ctx)
+	if !ok {
+		return nil, auth.ErrForbidden
+	}
+
+	var createdBy *int64
+	if !principal.IsAdmin {
+		createdBy = &principal.UserID
+	}
+
+	return s.repository.List(ctx, fileId, versionId, createdBy, limit, offset)
+}
+
+func (s *service) Create(ctx context.Context, req CreateShareLinkRequest) (ShareLink, error) {
+	if (req.FileID == nil) == (req.VersionID == nil) {
+		return ShareLink{}, ErrShareLinkInvalidTarget
+	}
+	if !req.ExpiresAt.After(time.Now()) {
+		return ShareLink{}, ErrShareLinkInvalidExpiry
+	}
+	if req.MaxDownloads != nil && *req.MaxDownloads <= 0 {
+		return ShareLink{}, ErrShareLinkInvalidMaxDownloads
+	}
+
+	principal, ok := auth.GetPrincipal(ctx)
+	if !ok {
+		return ShareLink{}, auth.ErrForbidden
+	}
+
+	shareLink := ShareLink{
+		FileID:       req.FileID,
+		VersionID:    req.VersionID,
+		ExpiresAt:    req.ExpiresAt.UTC().Truncate(time.Second),
+		MaxDownloads: req.MaxDownloads,
+	}
+	if principal.UserID != 0 {
+		shareLink.CreatedBy = &principal.UserID
+	}
+	if err := s.authorizeTarget(ctx, shareLink, membership.RoleViewer); err != nil {
+		return ShareLink{}, err
+	}
+
+	if req.Password != nil && *req.Password != "" {
+		hash, err := bcrypt.GenerateFromPassword([]byte(*req.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return ShareLink{}, err
+		}
+		shareLink.PasswordHash = new(string(hash))
+	}
+
+	err := s.transactions.Run(ctx, func(ctx context.Context) error {
+		created, err := s.repository.Create(ctx, shareLink)
+		if err != nil {
+			return err
+		}
+		shareLink = created
+
+		return s.auditService.Record(ctx, audit.Entry{
+			Action:       audit.ActionCreate,
+			ResourceType: audit.ResourceTypeShareLink,
+			ResourceID:   shareLink.ID,
+			After:        auditState(shareLink),
+		})
+	})
+	if err != nil {
+		return ShareLink{}, err
+	}
+	return shareLink, nil
+}
+
+func (s *service) Revoke(ctx context.Context, id int64) (ShareLink, error) {
+	var shareLink ShareLink
+	err := s.transactions.Run(ctx, func(ctx context.Context) error {
+		before, err := s.authorized(ctx, id, membership.RoleEditor)
+		if err != nil {
+			return err
+		}
+
+		shareLink, err = s.repository.Revoke(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, audit.Entry{
+			Action:       audit.ActionRevoke,
+			ResourceType: audit.ResourceTypeShareLink,
+			ResourceID:   id,
+			Before:       auditState(before),
+			After:        auditState(shareLink),
+		})
+	})
+	if err != nil {
+		return ShareLink{}, err
+	}
+	return shareLink, nil
+}
+
+func (s *service) Open(ctx context.Context, access Access) (ShareLink, error) {
+	id, expiresAt, err := s.signer.Verify(access.Token)
+	if err != nil {
+		return ShareLink{}, err
+	}
+	if time.Now().After(expiresAt) {
+		return ShareLink{}, ErrShareLinkExpired
+	}
+
+	shareLink, err := s.repository.GetById(ctx, id)
+	if err != nil {
+		return ShareLink{}, err
+	}
+	if !shareLink.ExpiresAt.Equal(expiresAt) {
+		return ShareLink{}, ErrShareLinkInvalidSignature
+	}
+	if shareLink.RevokedAt != nil {
+		return ShareLink{}, ErrShareLinkRevoked
+	}
+	if shareLink.MaxDownloads != nil && shareLink.DownloadCount >= *shareLink.MaxDownloads {
+		return ShareLink{}, ErrShareLinkExhausted
+	}
+
+	if shareLink.PasswordHash != nil {
+		if access.Password == nil {
+			return ShareLink{}, ErrShareLinkPasswordRequired
+		}
+		err := bcrypt.CompareHashAndPassword([]byte(*shareLink.PasswordHash), []byte(*access.Password))
+		if err != nil {
+			return ShareLink{}, ErrShareLinkPasswordRequired
+		}
+	}
+
+	return shareLink, nil
+}
+
+func (s *service) ListFiles(ctx context.Context, access Access, limit, offset int64) ([]file.File, error) {
+	shareLink, err := s.Open(ctx, access)
+	if err != nil {
+		return nil, err
+	}
+	if shareLink.VersionID == nil {
+		return nil, ErrShareLinkNotAVersion
+	}
+
+	// The link itself grants access, the holder isn't a member of the project.
+	return s.fileService.List(auth.WithSystemPrincipal(ctx), shareLink.VersionID, limit, offset)
+}
+
+func (s *service) Download(ctx context.Context, access Access, fileId *int64, count bool) (file.File, io.ReadSeekCloser, error) {
+	shareLink, err := s.Open(ctx, access)
+	if err != nil {
+		return file.File{}, nil, err
+	}
+
+	id, err := s.sharedFileId(ctx, shareLink, fileId)
+	if err != nil {
+		return file.File{}, nil, err
+	}
+
+	// A shared version hands out the revisions it pins.
+	var sharedFile file.File
+	var reader io.ReadSeekCloser
+	if shareLink.VersionID != nil {
+		sharedFile, reader, err = s.fileService.DownloadInVersion(auth.WithSystemPrincipal(ctx), *shareLink.VersionID, id)
+	} else {
+		sharedFile, reader, err = s.fileService.Download(auth.WithSystemPrincipal(ctx), id)
+	}
+	if err != nil {
+		return file.File{}, nil, err
+	}
+
+	if !count {
+		return sharedFile, reader, nil
+	}
+	if _, err := s.repository.ConsumeDownload(ctx, shareLink.ID); err != nil {
+		if closeErr := reader.Close(); closeErr != nil {
+			log.Printf("error closing file reader: %v", closeErr)
+		}
+		return file.File{}, nil, err
+	}
+
+	return sharedFile, reader, nil
+}
+
+// sharedFileId resolves which file a download refers to, files of a shared
+// version must be named explicitly.
+func (s *service) sharedFileId(ctx context.Context, shareLink ShareLink, fileId *int64) (int64, error) {
+	if shareLink.FileID != nil {
+		if fileId != nil && *fileId != *shareLink.FileID {
+			return 0, file.ErrFileNotFound
+		}
+		return *shareLink.FileID, nil
+	}
+
+	if fileId == nil {
+		return 0, file.ErrFileNotFound
+	}
+
+	included, err := s.repository.IsFileInVersion(ctx, *shareLink.VersionID, *fileId)
+	if err != nil {
+		return 0, err
+	}
+	if !included {
+		return 0, file.ErrFileNotFound
+	}
+
+	return *fileId, nil
+}
+
+// authorized loads the link and lets instance admins and its creator manage
+// it, as well as anybody with the role on what it shares.
+func (s *service) authorized(ctx context.Context, id int64, role membership.Role) (ShareLink, error) {
+	shareLink, err := s.repository.GetById(ctx, id)
+	if err != nil {
+		return ShareLink{}, err
+	}
+
+	principal, ok := auth.GetPrincipal(ctx)
+	if !ok {
+		return ShareLink{}, auth.ErrForbidden
+	}
+	if principal.IsAdmin || (shareLink.CreatedBy != nil && *shareLink.CreatedBy == principal.UserID) {
+		return shareLink, nil
+	}
+	if err := s.authorizeTarget(ctx, shareLink, role); err != nil {
+		return ShareLink{}, err
+	}
+	return shareLink, nil
+}
+
+func (s *service) authorizeTarget(ctx context.Context, shareLink ShareLink, role membership.Role) error {
+	var err error
+	if shareLink.FileID != nil {
+		_, err = s.fileAuthorizer.Authorized(ctx, *shareLink.FileID, role)
+	} else {
+		var v version.Version
+		v, err = s.versionService.GetById(ctx, *shareLink.VersionID)
+		if err == nil {
+			err = s.membershipService.Authorize(ctx, v.ProjectID, role)
+		}
+	}
+	if errors.Is(err, file.ErrFileNotFound) || errors.Is(err, version.ErrVersionNotFound) {
+		return ErrShareLinkTargetNotFound
+	}
+	return err
+}
+
+// auditState is what the audit log keeps of a share link, the password hash
+// is left out.
+func auditState(shareLink ShareLink) map[string]any {
+	return map[string]any{
+		"fileId":        shareLink.FileID,
+		"versionId":     shareLink.VersionID,
+		"expiresAt":     shareLink.ExpiresAt,
+		"maxDownloads":  shareLink.MaxDownloads,
+		"downloadCount": shareLink.DownloadCount,
+		"hasPassword":   shareLink.PasswordHash != nil,
+		"revokedAt":     shareLink.RevokedAt,
+	}
+}
@@ -0,0 +1,294 @@
+package shortlink
+
+import (
+	"app/pkg/api"
+	"app/pkg/platform/auth"
+	"app/pkg/platform/handler"
+	"app/pkg/platform/links"
+	"app/pkg/qrcode"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler struct {
+	service    Service
+	links      *links.Builder
+	qrRenderer *qrcode.Renderer
+}
+
+func NewHandler(service Service, links *links.Builder, qrRenderer *qrcode.Renderer) *Handler {
+	return &Handler{service: service, links: links, qrRenderer: qrRenderer}
+}
+
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Route("/v1/short-links", func(r chi.Router) {
+		r.Get("/", h.List)
+		r.Post("/", h.Create)
+
+		r.Route("/{shortLinkId}", func(r chi.Router) {
+			r.Get("/", h.GetById)
+			r.Put("/", h.Update)
+			r.Post("/revoke", h.Revoke)
+			r.Get("/qr", h.QRCode)
+		})
+	})
+}
+
+// RegisterPublicRoutes registers the routes printed on labels, these live
+// outside of the API so they stay short.
+func (h *Handler) RegisterPublicRoutes(r chi.Router) {
+	r.Get("/q/{code}", h.Resolve)
+}
+
+func (h *Handler) GetById(w http.ResponseWriter, r *http.Request) {
+	id, err := parseShortLinkId(r)
+	if err != nil {
+		writeInvalidShortLinkIdError(w)
+		return
+	}
+
+	shortLink, err := h.service.GetById(r.Context(), id)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrShortLinkNotFound) {
+		writeShortLinkNotFoundError(w)
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	handler.WriteJson(w, http.StatusOK, h.toShortLinkResponse(shortLink))
+}
+
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	limit, offset := handler.ParsePagination(r)
+
+	projectId, err := parseOptionalQueryId(r, "projectId")
+	if err != nil {
+		handler.WriteError(w, http.StatusBadRequest, "invalid project id")
+		return
+	}
+
+	versionId, err := parseOptionalQueryId(r, "versionId")
+	if err != nil {
+		handler.WriteError(w, http.StatusBadRequest, "invalid version id")
+		return
+	}
+
+	shortLinks, err := h.service.List(r.Context(), projectId, versionId, limit, offset)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	handler.WriteJson(w, http.StatusOK, h.toListShortLinksResponse(shortLinks, limit, offset))
+}
+
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	var req api.CreateShortLinkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handler.WriteInvalidRequestPayloadError(w)
+		return
+	}
+
+	shortLink, err := h.service.Create(r.Context(), CreateShortLinkRequest{
+		TargetType: TargetType(req.TargetType),
+		ProjectID:  req.ProjectId,
+		VersionID:  req.VersionId,
+	})
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrShortLinkInvalidTarget) || errors.Is(err, ErrShortLinkTargetNotFound) {
+		handler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	handler.WriteJson(w, http.StatusCreated, h.toShortLinkResponse(shortLink))
+}
+
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := parseShortLinkId(r)
+	if err != nil {
+		writeInvalidShortLinkIdError(w)
+		return
+	}
+
+	var req api.UpdateShortLinkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handler.WriteInvalidRequestPayloadError(w)
+		return
+	}
+
+	shortLink, err := h.service.Update(r.Context(), id, UpdateShortLinkRequest{
+		TargetType: TargetType(req.TargetType),
+		ProjectID:  req.ProjectId,
+		VersionID:  req.VersionId,
+	})
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrShortLinkNotFound) {
+		writeShortLinkNotFoundError(w)
+		return
+	}
+	if errors.Is(err, ErrShortLinkInvalidTarget) || errors.Is(err, ErrShortLinkTargetNotFound) {
+		handler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	handler.WriteJson(w, http.StatusOK, h.toShortLinkResponse(shortLink))
+}
+
+func (h *Handler) Revoke(w http.ResponseWriter, r *http.Request) {
+	id, err := parseShortLinkId(r)
+	if err != nil {
+		writeInvalidShortLinkIdError(w)
+		return
+	}
+
+	shortLink, err := h.service.Revoke(r.Context(), id)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrShortLinkNotFound) {
+		writeShortLinkNotFoundError(w)
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	handler.WriteJson(w, http.StatusOK, h.toShortLinkResponse(shortLink))
+}
+
+func (h *Handler) QRCode(w http.ResponseWriter, r *http.Request) {
+	id, err := parseShortLinkId(r)
+	if err != nil {
+		writeInvalidShortLinkIdError(w)
+		return
+	}
+
+	shortLink, err := h.service.GetById(r.Context(), id)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrShortLinkNotFound) {
+		writeShortLinkNotFoundError(w)
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	h.qrRenderer.Write(w, r, links.ShortLinkPath(shortLink.Code))
+}
+
+func (h *Handler) Resolve(w http.ResponseWriter, r *http.Request) {
+	target, err := h.service.Resolve(r.Context(), chi.URLParam(r, "code"))
+	if errors.Is(err, ErrShortLinkNotFound) {
+		writeShortLinkNotFoundError(w)
+		return
+	}
+	if errors.Is(err, ErrShortLinkRevoked) {
+		handler.WriteError(w, http.StatusGone, "short link revoked")
+		return
+	}
+	if errors.Is(err, ErrShortLinkTargetNotFound) {
+		handler.WriteError(w, http.StatusNotFound, "short link target not found")
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	path := links.ProjectPath(target.ID)
+	if target.Type == TargetTypeVersion {
+		path = links.VersionPath(target.ID)
+	}
+
+	// Targets can be re-pointed at any time, so the redirect must not be
+	// cached by browsers or proxies.
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, r, h.links.URL(path), http.StatusFound)
+}
+
+func writeInvalidShortLinkIdError(w http.ResponseWriter) {
+	handler.WriteError(w, http.StatusBadRequest, "invalid short link id")
+}
+
+func writeShortLinkNotFoundError(w http.ResponseWriter) {
+	handler.WriteError(w, http.StatusNotFound, "short link not found")
+}
+
+func parseShortLinkId(r *http.Request) (int64, error) {
+	return strconv.ParseInt(chi.URLParam(r, "shortLinkId"), 10, 64)
+}
+
+func parseOptionalQueryId(r *http.Request, name string) (*int64, error) {
+	if !r.URL.Query().Has(name) {
+		return nil, nil
+	}
+
+	id, err := strconv.ParseInt(r.URL.Query().Get(name), 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &id, nil
+}
+
+func (h *Handler) toShortLinkResponse(s ShortLink) api.ShortLinkResponse {
+	return api.ShortLinkResponse{
+		Id:         s.ID,
+		CreatedAt:  s.CreatedAt,
+		UpdatedAt:  s.UpdatedAt,
+		Code:       s.Code,
+		Url:        h.links.URL(links.ShortLinkPath(s.Code)),
+		TargetType: api.ShortLinkTargetType(s.TargetType),
+		ProjectId:  s.ProjectID,
+		VersionId:  s.VersionID,
+		RevokedAt:  s.RevokedAt,
+		HitCount:   s.HitCount,
+		LastHitAt:  s.LastHitAt,
+	}
+}
+
+func (h *Handler) toListShortLinksResponse(s []ShortLink, limit, offset int64) api.ListShortLinksResponse {
+	items := make([]api.ShortLinkResponse, len(s))
+	for i, shortLink := range s {
+		items[i] = h.toShortLinkResponse(shortLink)
+	}
+	return api.ListShortLinksResponse{
+		Limit:      limit,
+		Offset:     offset,
+		ShortLinks: items,
+	}
+}
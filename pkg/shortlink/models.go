@@ -0,0 +1,43 @@
+package shortlink
+
+import "time"
+
+type TargetType string
+
+const (
+	TargetTypeProject       TargetType = "project"
+	TargetTypeVersion       TargetType = "version"
+	TargetTypeLatestVersion TargetType = "latest_version"
+)
+
+type ShortLink struct {
+	ID         int64
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	Code       string
+	TargetType TargetType
+	ProjectID  *int64
+	VersionID  *int64
+	RevokedAt  *time.Time
+	HitCount   int64
+	LastHitAt  *time.Time
+}
+
+type CreateShortLinkRequest struct {
+	TargetType TargetType
+	ProjectID  *int64
+	VersionID  *int64
+}
+
+type UpdateShortLinkRequest struct {
+	TargetType TargetType
+	ProjectID  *int64
+	VersionID  *int64
+}
+
+// Target is what a short link currently resolves to, a latest version link
+// resolves to a concrete version.
+type Target struct {
+	Type TargetType
+	ID   int64
+}
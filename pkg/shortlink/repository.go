@@ -0,0 +1,161 @@
+package shortlink
+
+import (
+	"app/pkg/database"
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrShortLinkNotFound       = errors.New("short link not found")
+	ErrShortLinkAlreadyExists  = errors.New("short link already exists")
+	ErrShortLinkTargetNotFound = errors.New("short link target not found")
+)
+
+type Repository interface {
+	GetById(ctx context.Context, id int64) (ShortLink, error)
+	GetByCode(ctx context.Context, code string) (ShortLink, error)
+	// List returns the short links, only the ones pointing into projects of
+	// the member unless memberId is nil.
+	List(ctx context.Context, projectId, versionId, memberId *int64, limit, offset int64) ([]ShortLink, error)
+	Create(ctx context.Context, shortLink ShortLink) (ShortLink, error)
+	UpdateTarget(ctx context.Context, shortLink ShortLink) (ShortLink, error)
+	Revoke(ctx context.Context, id int64) (ShortLink, error)
+	RecordHit(ctx context.Context, id int64) error
+}
+
+type repository struct {
+	queries *database.Queries
+}
+
+func NewRepository(queries *database.Queries) Repository {
+	return &repository{queries: queries}
+}
+
+func (r *repository) GetById(ctx context.Context, id int64) (ShortLink, error) {
+	row, err := r.queries.GetShortLink(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ShortLink{}, ErrShortLinkNotFound
+	}
+	if err != nil {
+		return ShortLink{}, err
+	}
+	return toShortLink(row), nil
+}
+
+func (r *repository) GetByCode(ctx context.Context, code string) (ShortLink, error) {
+	row, err := r.queries.GetShortLinkByCode(ctx, code)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ShortLink{}, ErrShortLinkNotFound
+	}
+	if err != nil {
+		return ShortLink{}, err
+	}
+	return toShortLink(row), nil
+}
+
+func (r *repository) List(ctx context.Context, projectId, versionId, memberId *int64, limit, offset int64) ([]ShortLink, error) {
+	rows, err := r.queries.ListShortLinks(ctx, &database.ListShortLinksParams{
+		ProjectId: projectId,
+		VersionId: versionId,
+		MemberId:  memberId,
+		Limit:     limit,
+		Offset:    offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+	shortLinks := make([]ShortLink, len(rows))
+	for i, row := range rows {
+		shortLinks[i] = toShortLink(row)
+	}
+	return shortLinks, nil
+}
+
+func (r *repository) Create(ctx context.Context, shortLink ShortLink) (ShortLink, error) {
+	row, err := r.queries.CreateShortLink(ctx, &database.CreateShortLinkParams{
+		Code:       shortLink.Code,
+		TargetType: string(shortLink.TargetType),
+		ProjectID:  shortLink.ProjectID,
+		VersionID:  shortLink.VersionID,
+	})
+	if err != nil {
+		if isPgUniqueViolation(err) {
+			return ShortLink{}, ErrShortLinkAlreadyExists
+		}
+		if isPgForeignKeyViolation(err) {
+			return ShortLink{}, ErrShortLinkTargetNotFound
+		}
+		return ShortLink{}, err
+	}
+	return toShortLink(row), nil
+}
+
+func (r *repository) UpdateTarget(ctx context.Context, shortLink ShortLink) (ShortLink, error) {
+	row, err := r.queries.UpdateShortLinkTarget(ctx, &database.UpdateShortLinkTargetParams{
+		ID:         shortLink.ID,
+		TargetType: string(shortLink.TargetType),
+		ProjectID:  shortLink.ProjectID,
+		VersionID:  shortLink.VersionID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ShortLink{}, ErrShortLinkNotFound
+	}
+	if err != nil {
+		if isPgForeignKeyViolation(err) {
+			return ShortLink{}, ErrShortLinkTargetNotFound
+		}
+		return ShortLink{}, err
+	}
+	return toShortLink(row), nil
+}
+
+func (r *repository) Revoke(ctx context.Context, id int64) (ShortLink, error) {
+	row, err := r.queries.RevokeShortLink(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ShortLink{}, ErrShortLinkNotFound
+	}
+	if err != nil {
+		return ShortLink{}, err
+	}
+	return toShortLink(row), nil
+}
+
+func (r *repository) RecordHit(ctx context.Context, id int64) error {
+	return r.queries.RecordShortLinkHit(ctx, id)
+}
+
+func toShortLink(row *database.ShortLink) ShortLink {
+	return ShortLink{
+		ID:         row.ID,
+		CreatedAt:  row.CreatedAt.Time,
+		UpdatedAt:  row.UpdatedAt.Time,
+		Code:       row.Code,
+		TargetType: TargetType(row.TargetType),
+		ProjectID:  row.ProjectID,
+		VersionID:  row.VersionID,
+		RevokedAt:  toTime(row.RevokedAt),
+		HitCount:   row.HitCount,
+		LastHitAt:  toTime(row.LastHitAt),
+	}
+}
+
+func toTime(timestamp pgtype.Timestamp) *time.Time {
+	if !timestamp.Valid {
+		return nil
+	}
+	return &timestamp.Time
+}
+
+func isPgUniqueViolation(err error) bool {
+	return err != nil && (strings.Contains(err.Error(), "23505") || strings.Contains(err.Error(), "unique"))
+}
+
+func isPgForeignKeyViolation(err error) bool {
+	return err != nil && (strings.Contains(err.Error(), "23503") || strings.Contains(err.Error(), "foreign key"))
+}
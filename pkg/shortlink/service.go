@@ -0,0 +1,297 @@
+package shortlink
+
+import (
+	"app/pkg/audit"
+	"app/pkg/membership"
+	"app/pkg/platform/auth"
+	"app/pkg/platform/transaction"
+	"app/pkg/version"
+	"context"
+	"crypto/rand"
+	"errors"
+)
+
+const (
+	// codeLength codes carry 50 random bits, enough to make guessing a
+	// valid code impractical while keeping printed labels short.
+	codeLength = 10
+	// codeAlphabet is Crockford's base32 alphabet in lower case, it leaves
+	// out letters that are easily confused with digits on a worn label.
+	codeAlphabet = "0123456789abcdefghjkmnpqrstvwxyz"
+	// maxCodeAttempts bounds the retries when a generated code collides
+	// with an existing one.
+	maxCodeAttempts = 5
+)
+
+var (
+	ErrShortLinkInvalidTarget = errors.New("short link target is invalid for its type")
+	ErrShortLinkRevoked       = errors.New("short link revoked")
+)
+
+// Service manages short links. Reading a link takes the viewer role on the
+// project it points to, directly or through a version, changing it takes the
+// editor role.
+type Service interface {
+	GetById(ctx context.Context, id int64) (ShortLink, error)
+	List(ctx context.Context, projectId, versionId *int64, limit, offset int64) ([]ShortLink, error)
+	Create(ctx context.Context, req CreateShortLinkRequest) (ShortLink, error)
+	Update(ctx context.Context, id int64, req UpdateShortLinkRequest) (ShortLink, error)
+	Revoke(ctx context.Context, id int64) (ShortLink, error)
+	// Resolve looks up the current target of an active code and counts the
+	// visit.
+	Resolve(ctx context.Context, code string) (Target, error)
+}
+
+type service struct {
+	repository        Repository
+	versionService    version.Service
+	membershipService membership.Service
+	auditService      audit.Service
+	transactions      transaction.Manager
+}
+
+func NewService(repository Repository, versionService version.Service, membershipService membership.Service, auditService audit.Service, transactions transaction.Manager) Service {
+	return &service{repository: repository, versionService: versionService, membershipService: membershipService, auditService: auditService, transactions: transactions}
+}
+
+func (s *service) GetById(ctx context.Context, id int64) (ShortLink, error) {
+	return s.authorized(ctx, id, membership.RoleViewer)
+}
+
+func (s *service) List(ctx context.Context, projectId, versionId *int64, limit, offset int64) ([]ShortLink, error) {
+	memberId, err := s.membershipService.MemberFilter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.repository.List(ctx, projectId, versionId, memberId, limit, offset)
+}
+
+func (s *service) Create(ctx context.Context, req CreateShortLinkRequest) (ShortLink, error) {
+	if err := validateTarget(req.TargetType, req.ProjectID, req.VersionID); err != nil {
+		return ShortLink{}, err
+	}
+	if err := s.authorizeTarget(ctx, req.ProjectID, req.VersionID, membership.RoleEditor); err != nil {
+		return ShortLink{}, err
+	}
+
+	for range maxCodeAttempts {
+		var shortLink ShortLink
+		err := s.transactions.Run(ctx, func(ctx context.Context) error {
+			created, err := s.repository.Create(ctx, ShortLink{
+				Code:       generateCode(),
+				TargetType: req.TargetType,
+				ProjectID:  req.ProjectID,
+				VersionID:  req.VersionID,
+			})
+			if err != nil {
+				return err
+			}
+			shortLink = created
+
+			return s.auditService.Record(ctx, audit.Entry{
+				Action:       audit.ActionCreate,
+				ResourceType: audit.ResourceTypeShortLink,
+				ResourceID:   shortLink.ID,
+				After:        auditState(shortLink),
+			})
+		})
+		if errors.Is(err, ErrShortLinkAlreadyExists) {
+			continue
+		}
+		if err != nil {
+			return ShortLink{}, err
+		}
+		return shortLink, nil
+	}
+
+	return ShortLink{}, ErrShortLinkAlreadyExists
+}
+
+func (s *service) Update(ctx context.Context, id int64, req UpdateShortLinkRequest) (ShortLink, error) {
+	if err := validateTarget(req.TargetType, req.ProjectID, req.VersionID); err != nil {
+		return ShortLink{}, err
+	}
+	var shortLink ShortLink
+	err := s.transactions.Run(ctx, func(ctx context.Context) error {
+		before, err := s.authorized(ctx, id, membership.RoleEditor)
+		if err != nil {
+			return err
+		}
+		if err := s.authorizeTarget(ctx, req.ProjectID, req.VersionID, membership.RoleEditor); err != nil {
+			return err
+		}
+
+		shortLink, err = s.repository.UpdateTarget(ctx, ShortLink{
+			ID:         id,
+			TargetType: req.TargetType,
+			ProjectID:  req.ProjectID,
+			VersionID:  req.VersionID,
+		})
+		if err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, audit.Entry{
+			Action:       audit.ActionUpdate,
+			ResourceType: audit.ResourceTypeShortLink,
+			ResourceID:   id,
+			Before:       auditState(before),
+			After:        auditState(shortLink),
+		})
+	})
+	if err != nil {
+		return ShortLink{}, err
+	}
+	return shortLink, nil
+}
+
+func (s *service) Revoke(ctx context.Context, id int64) (ShortLink, error) {
+	var shortLink ShortLink
+	err := s.transactions.Run(ctx, func(ctx context.Context) error {
+		before, err := s.authorized(ctx, id, membership.RoleEditor)
+		if err != nil {
+			return err
+		}
+
+		shortLink, err = s.repository.Revoke(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return s.auditService.Record(ctx, audit.Entry{
+			Action:       audit.ActionRevoke,
+			ResourceType: audit.ResourceTypeShortLink,
+			ResourceID:   id,
+			Before:       auditState(before),
+			After:        auditState(shortLink),
+		})
+	})
+	if err != nil {
+		return ShortLink{}, err
+	}
+	return shortLink, nil
+}
+
+func (s *service) Resolve(ctx context.Context, code string) (Target, error) {
+	shortLink, err := s.repository.GetByCode(ctx, code)
+	if err != nil {
+		return Target{}, err
+	}
+	if shortLink.RevokedAt != nil {
+		return Target{}, ErrShortLinkRevoked
+	}
+
+	if err := s.repository.RecordHit(ctx, shortLink.ID); err != nil {
+		return Target{}, err
+	}
+
+	return s.resolveTarget(ctx, shortLink)
+}
+
+// resolveTarget fails with ErrShortLinkTargetNotFound when the target was
+// deleted after the link was issued, the link then waits to be re-pointed.
+func (s *service) resolveTarget(ctx context.Context, shortLink ShortLink) (Target, error) {
+	switch shortLink.TargetType {
+	case TargetTypeProject:
+		if shortLink.ProjectID != nil {
+			return Target{Type: TargetTypeProject, ID: *shortLink.ProjectID}, nil
+		}
+	case TargetTypeVersion:
+		if shortLink.VersionID != nil {
+			return Target{Type: TargetTypeVersion, ID: *shortLink.VersionID}, nil
+		}
+	case TargetTypeLatestVersion:
+		if shortLink.ProjectID == nil {
+			break
+		}
+
+		latest, err := s.versionService.GetLatestByProjectId(ctx, *shortLink.ProjectID)
+		if errors.Is(err, version.ErrNoReleasedVersion) || errors.Is(err, version.ErrProjectNotFound) {
+			break
+		}
+		if err != nil {
+			return Target{}, err
+		}
+
+		return Target{Type: TargetTypeVersion, ID: latest.ID}, nil
+	}
+
+	return Target{}, ErrShortLinkTargetNotFound
+}
+
+// authorized loads the short link and checks the caller's role on its target.
+func (s *service) authorized(ctx context.Context, id int64, role membership.Role) (ShortLink, error) {
+	shortLink, err := s.repository.GetById(ctx, id)
+	if err != nil {
+		return ShortLink{}, err
+	}
+	err = s.authorizeTarget(ctx, shortLink.ProjectID, shortLink.VersionID, role)
+	if errors.Is(err, ErrShortLinkTargetNotFound) {
+		return ShortLink{}, auth.ErrForbidden
+	}
+	if err != nil {
+		return ShortLink{}, err
+	}
+	return shortLink, nil
+}
+
+// authorizeTarget checks the caller's role on the project of a target. Links
+// whose target was deleted belong to no project, only admins may touch them.
+func (s *service) authorizeTarget(ctx context.Context, projectId, versionId *int64, role membership.Role) error {
+	switch {
+	case projectId != nil:
+		return s.membershipService.Authorize(ctx, *projectId, role)
+	case versionId != nil:
+		v, err := s.versionService.GetById(ctx, *versionId)
+		if errors.Is(err, version.ErrVersionNotFound) {
+			return ErrShortLinkTargetNotFound
+		}
+		if err != nil {
+			return err
+		}
+		return s.membershipService.Authorize(ctx, v.ProjectID, role)
+	}
+
+	principal, ok := auth.GetPrincipal(ctx)
+	if !ok || !principal.IsAdmin {
+		return auth.ErrForbidden
+	}
+	return nil
+}
+
+func validateTarget(targetType TargetType, projectId, versionId *int64) error {
+	switch targetType {
+	case TargetTypeProject, TargetTypeLatestVersion:
+		if projectId == nil || versionId != nil {
+			return ErrShortLinkInvalidTarget
+		}
+	case TargetTypeVersion:
+		if versionId == nil || projectId != nil {
+			return ErrShortLinkInvalidTarget
+		}
+	default:
+		return ErrShortLinkInvalidTarget
+	}
+	return nil
+}
+
+func generateCode() string {
+	code := make([]byte, codeLength)
+	_, _ = rand.Read(code)
+	for i, b := range code {
+		code[i] = codeAlphabet[b&31]
+	}
+	return string(code)
+}
+
+// auditState is what the audit log keeps of a short link, visits aren't
+// changes and are left out.
+func auditState(shortLink ShortLink) map[string]any {
+	return map[string]any{
+		"code":       shortLink.Code,
+		"targetType": shortLink.TargetType,
+		"projectId":  shortLink.ProjectID,
+		"versionId":  shortLink.VersionID,
+		"revokedAt":  shortLink.RevokedAt,
+	}
+}
@@ -54,9 +54,9 @@ func (s *filesystemStorage) Save(_ context.Context, relativePath string, data io
 
 	_, err = io.Copy(tmpFile, data)
 	if err != nil {
-		err := tmpFile.Close()
-		if err != nil {
-			log.Printf("failed to close file stream: %v", err)
+		closeErr := tmpFile.Close()
+		if closeErr != nil {
+			log.Printf("failed to close file stream: %v", closeErr)
 		}
 		_ = s.root.Remove(tmpName)
 		return fmt.Errorf("failed to write data to temporary file '%s': %w", tmpName, err)
@@ -119,8 +119,9 @@ func (s *filesystemStorage) List(ctx context.Context, root string) ([]ObjectInfo
 
 		relativePath := filepath.Join(root, entry.Name())
 		objects = append(objects, ObjectInfo{
-			Path: filepath.ToSlash(relativePath),
-			Size: info.Size(),
+			Path:       filepath.ToSlash(relativePath),
+			Size:       info.Size(),
+			ModifiedAt: info.ModTime(),
 		})
 	}
 
@@ -146,8 +147,9 @@ func (s *filesystemStorage) Walk(ctx context.Context, root string, walkFunc Walk
 		}
 
 		return walkFunc(ObjectInfo{
-			Path: filepath.ToSlash(path),
-			Size: info.Size(),
+			Path:       filepath.ToSlash(path),
+			Size:       info.Size(),
+			ModifiedAt: info.ModTime(),
 		})
 	})
 }
@@ -0,0 +1,287 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+const (
+	s3UploadPartSize = 16 * humanize.MiByte
+	s3ListPageSize   = 1000
+)
+
+type S3Options struct {
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	UseSSL          bool
+	UsePathStyle    bool
+}
+
+type s3Storage struct {
+	client *minio.Client
+	bucket string
+}
+
+func NewS3Storage(ctx context.Context, options S3Options) (FileStorage, error) {
+	if options.Endpoint == "" {
+		return nil, errors.New("s3 endpoint is required")
+	}
+	if options.Bucket == "" {
+		return nil, errors.New("s3 bucket is required")
+	}
+
+	bucketLookup := minio.BucketLookupAuto
+	if options.UsePathStyle {
+		bucketLookup = minio.BucketLookupPath
+	}
+
+	client, err := minio.New(options.Endpoint, &minio.Options{
+		Creds:        buildS3Credentials(options),
+		Secure:       options.UseSSL,
+		Region:       options.Region,
+		BucketLookup: bucketLookup,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create s3 client for endpoint '%s': %w", options.Endpoint, err)
+	}
+
+	exists, err := client.BucketExists(ctx, options.Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check s3 bucket '%s': %w", options.Bucket, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("s3 bucket '%s' does not exist", options.Bucket)
+	}
+
+	return &s3Storage{
+		client: client,
+		bucket: options.Bucket,
+	}, nil
+}
+
+func (s *s3Storage) Save(ctx context.Context, relativePath string, data io.Reader) error {
+	key := toObjectKey(relativePath)
+
+	_, err := s.client.PutObject(ctx, s.bucket, key, data, -1, minio.PutObjectOptions{
+		PartSize: s3UploadPartSize,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload object '%s': %w", key, err)
+	}
+
+	return nil
+}
+
+func (s *s3Storage) Retrieve(ctx context.Context, relativePath string) (io.ReadSeekCloser, error) {
+	key := toObjectKey(relativePath)
+
+	info, err := s.client.StatObject(ctx, s.bucket, key, minio.StatObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat object '%s': %w", key, err)
+	}
+
+	return &s3ObjectReader{
+		ctx:    ctx,
+		client: s.client,
+		bucket: s.bucket,
+		key:    key,
+		etag:   info.ETag,
+		size:   info.Size,
+	}, nil
+}
+
+func (s *s3Storage) Delete(ctx context.Context, relativePath string) error {
+	key := toObjectKey(relativePath)
+
+	err := s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to delete object '%s': %w", key, err)
+	}
+
+	return nil
+}
+
+func (s *s3Storage) List(ctx context.Context, root string) ([]ObjectInfo, error) {
+	var objects []ObjectInfo
+	err := s.listObjects(ctx, root, false, func(info ObjectInfo) error {
+		objects = append(objects, info)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list objects under '%s': %w", root, err)
+	}
+
+	return objects, nil
+}
+
+func (s *s3Storage) Walk(ctx context.Context, root string, walkFunc WalkFunc) error {
+	return s.listObjects(ctx, root, true, walkFunc)
+}
+
+// listObjects pages through the objects below root. The minio client follows
+// the continuation tokens itself; cancelling the context stops its background
+// listing when walkFunc bails out early.
+func (s *s3Storage) listObjects(ctx context.Context, root string, recursive bool, walkFunc WalkFunc) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	objects := s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{
+		Prefix:    toObjectPrefix(root),
+		Recursive: recursive,
+		MaxKeys:   s3ListPageSize,
+	})
+
+	for object := range objects {
+		if object.Err != nil {
+			return object.Err
+		}
+
+		// Common prefixes are reported as keys ending in a slash when not
+		// listing recursively, these are the S3 equivalent of directories.
+		if strings.HasSuffix(object.Key, "/") {
+			continue
+		}
+
+		err := walkFunc(ObjectInfo{
+			Path:       object.Key,
+			Size:       object.Size,
+			ModifiedAt: object.LastModified,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func buildS3Credentials(options S3Options) *credentials.Credentials {
+	if options.AccessKeyID != "" {
+		return credentials.NewStaticV4(options.AccessKeyID, options.SecretAccessKey, options.SessionToken)
+	}
+
+	return credentials.NewChainCredentials([]credentials.Provider{
+		&credentials.EnvAWS{},
+		&credentials.IAM{},
+	})
+}
+
+func toObjectKey(relativePath string) string {
+	return strings.TrimPrefix(path.Clean("/"+relativePath), "/")
+}
+
+func toObjectPrefix(root string) string {
+	prefix := toObjectKey(root)
+	if prefix == "" {
+		return ""
+	}
+	return prefix + "/"
+}
+
+// s3ObjectReader exposes an object as an io.ReadSeekCloser. Every read after a
+// seek opens a new ranged GET from the current offset, so serving a single
+// range out of a large object never downloads the bytes before it.
+type s3ObjectReader struct {
+	ctx    context.Context
+	client *minio.Client
+	bucket string
+	key    string
+	etag   string
+	size   int64
+	offset int64
+	body   io.ReadCloser
+}
+
+func (r *s3ObjectReader) Read(p []byte) (int, error) {
+	if r.offset >= r.size {
+		return 0, io.EOF
+	}
+
+	if r.body == nil {
+		body, err := r.openRange(r.offset)
+		if err != nil {
+			return 0, err
+		}
+		r.body = body
+	}
+
+	n, err := r.body.Read(p)
+	r.offset += int64(n)
+	if errors.Is(err, io.EOF) && r.offset < r.size {
+		return n, io.ErrUnexpectedEOF
+	}
+
+	return n, err
+}
+
+func (r *s3ObjectReader) Seek(offset int64, whence int) (int64, error) {
+	var target int64
+	switch whence {
+	case io.SeekStart:
+		target = offset
+	case io.SeekCurrent:
+		target = r.offset + offset
+	case io.SeekEnd:
+		target = r.size + offset
+	default:
+		return 0, fmt.Errorf("invalid whence %d", whence)
+	}
+
+	if target < 0 {
+		return 0, fmt.Errorf("negative position %d", target)
+	}
+
+	if target != r.offset {
+		if err := r.closeBody(); err != nil {
+			return 0, err
+		}
+		r.offset = target
+	}
+
+	return r.offset, nil
+}
+
+func (r *s3ObjectReader) Close() error {
+	return r.closeBody()
+}
+
+func (r *s3ObjectReader) openRange(start int64) (io.ReadCloser, error) {
+	options := minio.GetObjectOptions{}
+	if err := options.SetRange(start, r.size-1); err != nil {
+		return nil, err
+	}
+	// Pin the object to the version that was stat'ed so a concurrent
+	// overwrite cannot mix bytes of two different objects.
+	if err := options.SetMatchETag(r.etag); err != nil {
+		return nil, err
+	}
+
+	object, err := r.client.GetObject(r.ctx, r.bucket, r.key, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get range %d- of object '%s': %w", start, r.key, err)
+	}
+
+	return object, nil
+}
+
+func (r *s3ObjectReader) closeBody() error {
+	if r.body == nil {
+		return nil
+	}
+
+	err := r.body.Close()
+	r.body = nil
+	return err
+}
@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"io"
+	"time"
 )
 
 type Type string
@@ -13,8 +14,9 @@ const (
 )
 
 type ObjectInfo struct {
-	Path string
-	Size int64
+	Path       string
+	Size       int64
+	ModifiedAt time.Time
 }
 
 type WalkFunc func(info ObjectInfo) error
@@ -39,29 +39,19 @@ func (h *Handler) RegisterRoutes(r chi.Router) {
 }
 
 func (h *Handler) GetMe(w http.ResponseWriter, r *http.Request) {
-	err, tokenContext := auth.GetUnverifiedToken(r)
-	if err != nil {
-		handler.WriteInvalidRequestPayloadError(w)
-		return
-	}
-
-	user, err := h.service.GetByKeycloakReference(r.Context(), tokenContext.Subject)
-	if errors.Is(err, ErrUserNotFound) {
+	user, ok := GetCurrentUser(r.Context())
+	if !ok {
 		writeUserNotFoundError(w)
 		return
 	}
-	if err != nil {
-		handler.WriteInternalServerError(w)
-		return
-	}
 
 	handler.WriteJson(w, http.StatusOK, toUserResponse(user))
 }
 
 func (h *Handler) GetMyTokenInfo(w http.ResponseWriter, r *http.Request) {
-	err, tokenContext := auth.GetUnverifiedToken(r)
-	if err != nil {
-		handler.WriteInvalidRequestPayloadError(w)
+	tokenContext, ok := auth.GetTokenContext(r.Context())
+	if !ok {
+		handler.WriteError(w, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
@@ -134,7 +124,7 @@ func toUserResponse(u User) api.UserResponse {
 		CreatedAt:     u.CreatedAt,
 		UpdatedAt:     u.UpdatedAt,
 		Name:          u.Name,
-		Email:         openapitypes.Email(u.Email),
+		Email:         (*openapitypes.Email)(u.Email),
 		EmailVerified: u.EmailVerified,
 	}
 }
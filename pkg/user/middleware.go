@@ -0,0 +1,57 @@
+package user
+
+import (
+	"app/pkg/platform/auth"
+	"app/pkg/platform/handler"
+	"context"
+	"log"
+	"net/http"
+)
+
+type userContextKey struct{}
+
+// NewPrincipalMiddleware provisions the user from the verified token claims
+// and stores it, together with the request principal, in the request
+// context. It must run after the request has been authenticated.
+func NewPrincipalMiddleware(service Service, authenticator *auth.Authenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tokenContext, ok := auth.GetTokenContext(r.Context())
+			if !ok {
+				handler.WriteError(w, http.StatusUnauthorized, "unauthorized")
+				return
+			}
+
+			user, err := service.Provision(r.Context(), ProvisionUserRequest{
+				KeycloakReference: tokenContext.Subject,
+				Name:              tokenContext.Name,
+				Email:             tokenContext.Email,
+				EmailVerified:     tokenContext.EmailVerified,
+			})
+			if err != nil {
+				log.Printf("error provisioning user: %v", err)
+				handler.WriteInternalServerError(w)
+				return
+			}
+
+			ctx := WithCurrentUser(r.Context(), user)
+			ctx = auth.WithPrincipal(ctx, auth.Principal{
+				UserID:  user.ID,
+				Subject: tokenContext.Subject,
+				IsAdmin: authenticator.IsAdmin(tokenContext),
+			})
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func WithCurrentUser(ctx context.Context, user User) context.Context {
+	return context.WithValue(ctx, userContextKey{}, user)
+}
+
+// GetCurrentUser returns the user provisioned for the authenticated caller.
+func GetCurrentUser(ctx context.Context) (User, bool) {
+	user, ok := ctx.Value(userContextKey{}).(User)
+	return user, ok
+}
@@ -5,12 +5,15 @@ import (
 )
 
 type User struct {
-	ID            int64
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
-	Name          string
-	Email         string
-	EmailVerified bool
+	ID        int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Name      string
+	// Email is nil for accounts that came without an email claim or with one
+	// that already belongs to another user.
+	Email             *string
+	EmailVerified     bool
+	KeycloakReference *string
 }
 
 type CreateUserRequest struct {
@@ -18,3 +21,10 @@ type CreateUserRequest struct {
 	Email         string
 	EmailVerified bool
 }
+
+type ProvisionUserRequest struct {
+	KeycloakReference string
+	Name              string
+	Email             string
+	EmailVerified     bool
+}
@@ -12,12 +12,21 @@ import (
 var (
 	ErrUserNotFound      = errors.New("user not found")
 	ErrUserAlreadyExists = errors.New("user already exists")
+	ErrUserAlreadyLinked = errors.New("user already linked to an account")
 )
 
 type Repository interface {
 	GetById(ctx context.Context, id int64) (User, error)
 	GetByKeycloakReference(ctx context.Context, keycloakReference string) (User, error)
 	Create(ctx context.Context, user User) (User, error)
+	GetByEmail(ctx context.Context, email string) (User, error)
+	// Link connects a user created without an account, e.g. an invited one,
+	// to the account. It fails with ErrUserAlreadyLinked when the user is
+	// linked to an account already.
+	Link(ctx context.Context, user User) (User, error)
+	// Upsert creates the user linked to its keycloak reference or refreshes
+	// the stored claims of an existing one.
+	Upsert(ctx context.Context, user User) (User, error)
 }
 
 type repository struct {
@@ -50,12 +59,63 @@ func (r *repository) GetByKeycloakReference(ctx context.Context, keycloakReferen
 	return toUser(row), nil
 }
 
+func (r *repository) GetByEmail(ctx context.Context, email string) (User, error) {
+	row, err := r.queries.GetUserByEmail(ctx, &email)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return User{}, ErrUserNotFound
+	}
+	if err != nil {
+		return User{}, err
+	}
+	return toUser(row), nil
+}
+
+func (r *repository) Link(ctx context.Context, user User) (User, error) {
+	row, err := r.queries.LinkUserToKeycloakReference(ctx, &database.LinkUserToKeycloakReferenceParams{
+		ID:                user.ID,
+		Name:              user.Name,
+		EmailVerified:     user.EmailVerified,
+		KeycloakReference: user.KeycloakReference,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return User{}, ErrUserAlreadyLinked
+	}
+	if err != nil {
+		if isPgUniqueViolation(err) {
+			return User{}, ErrUserAlreadyLinked
+		}
+		return User{}, err
+	}
+	return toUser(row), nil
+}
+
 func (r *repository) Create(ctx context.Context, user User) (User, error) {
 	row, err := r.queries.CreateUser(ctx, &database.CreateUserParams{
-		Name:          user.Name,
-		Email:         user.Email,
-		EmailVerified: user.EmailVerified,
+		Name:              user.Name,
+		Email:             user.Email,
+		EmailVerified:     user.EmailVerified,
+		KeycloakReference: user.KeycloakReference,
+	})
+	if err != nil {
+		if isPgUniqueViolation(err) {
+			return User{}, ErrUserAlreadyExists
+		}
+		return User{}, err
+	}
+	return toUser(row), nil
+}
+
+func (r *repository) Upsert(ctx context.Context, user User) (User, error) {
+	row, err := r.queries.UpsertUserByKeycloakReference(ctx, &database.UpsertUserByKeycloakReferenceParams{
+		Name:              user.Name,
+		Email:             user.Email,
+		EmailVerified:     user.EmailVerified,
+		KeycloakReference: user.KeycloakReference,
 	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		// The claims were unchanged, so the existing row wasn't touched.
+		return r.GetByKeycloakReference(ctx, *user.KeycloakReference)
+	}
 	if err != nil {
 		if isPgUniqueViolation(err) {
 			return User{}, ErrUserAlreadyExists
@@ -67,12 +127,13 @@ func (r *repository) Create(ctx context.Context, user User) (User, error) {
 
 func toUser(row *database.User) User {
 	return User{
-		ID:            row.ID,
-		CreatedAt:     row.CreatedAt.Time,
-		UpdatedAt:     row.UpdatedAt.Time,
-		Name:          row.Name,
-		Email:         row.Email,
-		EmailVerified: row.EmailVerified,
+		ID:                row.ID,
+		CreatedAt:         row.CreatedAt.Time,
+		UpdatedAt:         row.UpdatedAt.Time,
+		Name:              row.Name,
+		Email:             row.Email,
+		EmailVerified:     row.EmailVerified,
+		KeycloakReference: row.KeycloakReference,
 	}
 }
 
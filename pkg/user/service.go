@@ -1,20 +1,35 @@
 package user
 
-import "context"
+import (
+	"app/pkg/audit"
+	"app/pkg/platform/transaction"
+	"context"
+	"errors"
+)
 
 type Service interface {
 	GetById(ctx context.Context, id int64) (User, error)
 	GetByKeycloakReference(ctx context.Context, keycloakReference string) (User, error)
 	CreateUser(ctx context.Context, req CreateUserRequest) (User, error)
+	// Provision returns the user linked to the identity provider account,
+	// creating it on first sight and refreshing it when the claims changed.
+	// A new account with a verified email is linked to the user created with
+	// that email beforehand. Emails that are missing or belong to another
+	// user aren't stored, they never keep the account from signing in.
+	Provision(ctx context.Context, req ProvisionUserRequest) (User, error)
 }
 
 type service struct {
-	repository Repository
+	repository   Repository
+	auditService audit.Service
+	transactions transaction.Manager
 }
 
-func NewService(repository Repository) Service {
+func NewService(repository Repository, auditService audit.Service, transactions transaction.Manager) Service {
 	return &service{
-		repository: repository,
+		repository:   repository,
+		auditService: auditService,
+		transactions: transactions,
 	}
 }
 
@@ -29,8 +44,186 @@ func (s *service) GetByKeycloakReference(ctx context.Context, keycloakReference
 func (s *service) CreateUser(ctx context.Context, req CreateUserRequest) (User, error) {
 	user := User{
 		Name:          req.Name,
-		Email:         req.Email,
+		Email:         &req.Email,
 		EmailVerified: req.EmailVerified,
 	}
-	return s.repository.Create(ctx, user)
+
+	err := s.transactions.Run(ctx, func(ctx context.Context) error {
+		created, err := s.repository.Create(ctx, user)
+		if err != nil {
+			return err
+		}
+		user = created
+
+		return s.auditService.Record(ctx, audit.Entry{
+			Action:       audit.ActionCreate,
+			ResourceType: audit.ResourceTypeUser,
+			ResourceID:   user.ID,
+			After:        auditState(user),
+		})
+	})
+	if err != nil {
+		return User{}, err
+	}
+	return user, nil
+}
+
+func (s *service) Provision(ctx context.Context, req ProvisionUserRequest) (User, error) {
+	user, err := s.repository.GetByKeycloakReference(ctx, req.KeycloakReference)
+	if err != nil && !errors.Is(err, ErrUserNotFound) {
+		return User{}, err
+	}
+	exists := err == nil
+	if exists && user.Name == req.Name && equalEmail(user.Email, req.Email) && user.EmailVerified == req.EmailVerified {
+		return user, nil
+	}
+	// Claims that can't be stored as they are, e.g. an email of another user,
+	// are compared with what would be stored, so every request of the account
+	// doesn't write it again.
+	if exists {
+		email, err := s.availableEmail(ctx, req, user, exists)
+		if err != nil {
+			return User{}, err
+		}
+		if isProvisioned(user, req.Name, email, email != nil && req.EmailVerified) {
+			return user, nil
+		}
+	}
+
+	err = s.transactions.Run(ctx, func(ctx context.Context) error {
+		if !exists && req.Email != "" && req.EmailVerified {
+			linked, err := s.link(ctx, req)
+			if err == nil {
+				user = linked
+				return nil
+			}
+			if !errors.Is(err, ErrUserNotFound) && !errors.Is(err, ErrUserAlreadyLinked) {
+				return err
+			}
+		}
+
+		email, err := s.availableEmail(ctx, req, user, exists)
+		if err != nil {
+			return err
+		}
+		emailVerified := email != nil && req.EmailVerified
+
+		entry := audit.Entry{Action: audit.ActionCreate, ResourceType: audit.ResourceTypeUser}
+		if exists {
+			entry.Action = audit.ActionUpdate
+			entry.Before = auditState(user)
+		}
+
+		upserted, err := s.repository.Upsert(ctx, User{
+			Name:              req.Name,
+			Email:             email,
+			EmailVerified:     emailVerified,
+			KeycloakReference: &req.KeycloakReference,
+		})
+		if err != nil {
+			return err
+		}
+		// The row may already hold the values, then there's nothing to
+		// record.
+		unchanged := exists && isProvisioned(user, upserted.Name, upserted.Email, upserted.EmailVerified)
+		user = upserted
+		if unchanged {
+			return nil
+		}
+
+		entry.ResourceID = user.ID
+		entry.After = auditState(user)
+		return s.auditService.Record(ctx, entry)
+	})
+	if err != nil {
+		return User{}, err
+	}
+	return user, nil
+}
+
+// link connects the account to the user that was created with its verified
+// email before the account signed in, e.g. to be added to projects.
+func (s *service) link(ctx context.Context, req ProvisionUserRequest) (User, error) {
+	user, err := s.repository.GetByEmail(ctx, req.Email)
+	if err != nil {
+		return User{}, err
+	}
+	if user.KeycloakReference != nil {
+		return User{}, ErrUserAlreadyLinked
+	}
+
+	linked, err := s.repository.Link(ctx, User{
+		ID:                user.ID,
+		Name:              req.Name,
+		EmailVerified:     req.EmailVerified,
+		KeycloakReference: &req.KeycloakReference,
+	})
+	if err != nil {
+		return User{}, err
+	}
+
+	err = s.auditService.Record(ctx, audit.Entry{
+		Action:       audit.ActionUpdate,
+		ResourceType: audit.ResourceTypeUser,
+		ResourceID:   linked.ID,
+		Before:       auditState(user),
+		After:        auditState(linked),
+	})
+	if err != nil {
+		return User{}, err
+	}
+	return linked, nil
+}
+
+// availableEmail is the email to store for the account. A missing claim or
+// an email that already belongs to another user keeps the one stored before,
+// if any, so the account can sign in either way.
+func (s *service) availableEmail(ctx context.Context, req ProvisionUserRequest, user User, exists bool) (*string, error) {
+	var current *string
+	if exists {
+		current = user.Email
+	}
+	if req.Email == "" {
+		return current, nil
+	}
+
+	owner, err := s.repository.GetByEmail(ctx, req.Email)
+	if errors.Is(err, ErrUserNotFound) {
+		return &req.Email, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if exists && owner.ID == user.ID {
+		return &req.Email, nil
+	}
+	return current, nil
+}
+
+// isProvisioned tells whether the user already holds the values that would
+// be stored.
+func isProvisioned(user User, name string, email *string, emailVerified bool) bool {
+	if user.Name != name || user.EmailVerified != emailVerified {
+		return false
+	}
+	if email == nil || user.Email == nil {
+		return email == user.Email
+	}
+	return *email == *user.Email
+}
+
+func equalEmail(email *string, claim string) bool {
+	if email == nil {
+		return claim == ""
+	}
+	return *email == claim
+}
+
+// auditState is what the audit log keeps of a user.
+func auditState(user User) map[string]any {
+	return map[string]any{
+		"name":          user.Name,
+		"email":         user.Email,
+		"emailVerified": user.EmailVerified,
+	}
 }
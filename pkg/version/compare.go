@@ -0,0 +1,136 @@
+package version
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+)
+
+// compareFiles diffs the files of two versions. A file attached to both is
+// modified when the versions resolve it to different revisions whose content
+// differs, files with the same name are the same document and modified
+// unless their content matches, and a removed file whose checksum shows up
+// again under another name was renamed.
+func compareFiles(base, target []VersionFile) Comparison {
+	var comparison Comparison
+
+	target = slices.Clone(target)
+	remaining := base[:0:0]
+	for _, b := range base {
+		i := slices.IndexFunc(target, func(t VersionFile) bool { return t.ID == b.ID })
+		if i < 0 {
+			remaining = append(remaining, b)
+			continue
+		}
+		if sameRevision(b, target[i]) {
+			comparison.Unchanged++
+		} else {
+			comparison.Modified = append(comparison.Modified, FileChange{From: b, To: target[i]})
+		}
+		target = slices.Delete(target, i, i+1)
+	}
+	base = remaining
+
+	remaining = base[:0:0]
+	for _, b := range base {
+		i := slices.IndexFunc(target, func(t VersionFile) bool { return t.Name == b.Name })
+		if i < 0 {
+			remaining = append(remaining, b)
+			continue
+		}
+		if sameContent(b, target[i]) {
+			comparison.Unchanged++
+		} else {
+			comparison.Modified = append(comparison.Modified, FileChange{From: b, To: target[i]})
+		}
+		target = slices.Delete(target, i, i+1)
+	}
+	base = remaining
+
+	for _, b := range base {
+		i := slices.IndexFunc(target, func(t VersionFile) bool {
+			return b.Checksum != nil && t.Checksum != nil && *b.Checksum == *t.Checksum
+		})
+		if i < 0 {
+			comparison.Removed = append(comparison.Removed, b)
+			continue
+		}
+		comparison.Renamed = append(comparison.Renamed, FileChange{From: b, To: target[i]})
+		target = slices.Delete(target, i, i+1)
+	}
+	comparison.Added = target
+
+	return comparison
+}
+
+// sameRevision reports whether both versions resolve a file to the same
+// contents. Different revisions only count when their checksums differ or
+// are missing, a revision re-uploaded with the same contents isn't a change.
+func sameRevision(a, b VersionFile) bool {
+	if equalPtr(a.RevisionID, b.RevisionID) {
+		return true
+	}
+	return a.Checksum != nil && b.Checksum != nil && *a.Checksum == *b.Checksum
+}
+
+// sameContent compares checksums when both files have one, files uploaded
+// without a checksum fall back to their size and MIME type.
+func sameContent(a, b VersionFile) bool {
+	if a.Checksum != nil && b.Checksum != nil {
+		return *a.Checksum == *b.Checksum
+	}
+	return equalPtr(a.Size, b.Size) && equalPtr(a.MimeType, b.MimeType)
+}
+
+func equalPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// Changelog renders the comparison as plain text for release notes.
+func (c Comparison) Changelog() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Changes from %s to %s\n", c.Base.Name, c.Target.Name)
+
+	if len(c.Added) > 0 {
+		fmt.Fprintf(&b, "\nAdded (%d):\n", len(c.Added))
+		for _, f := range c.Added {
+			fmt.Fprintf(&b, "  + %s\n", f.Name)
+		}
+	}
+	if len(c.Removed) > 0 {
+		fmt.Fprintf(&b, "\nRemoved (%d):\n", len(c.Removed))
+		for _, f := range c.Removed {
+			fmt.Fprintf(&b, "  - %s\n", f.Name)
+		}
+	}
+	if len(c.Renamed) > 0 {
+		fmt.Fprintf(&b, "\nRenamed (%d):\n", len(c.Renamed))
+		for _, change := range c.Renamed {
+			fmt.Fprintf(&b, "  ~ %s -> %s\n", change.From.Name, change.To.Name)
+		}
+	}
+	if len(c.Modified) > 0 {
+		fmt.Fprintf(&b, "\nModified (%d):\n", len(c.Modified))
+		for _, change := range c.Modified {
+			fmt.Fprintf(&b, "  * %s (%s -> %s)\n", change.To.Name, formatSize(change.From.Size), formatSize(change.To.Size))
+		}
+	}
+
+	fmt.Fprintf(&b, "\n%d added, %d removed, %d renamed, %d modified, %d unchanged\n",
+		len(c.Added), len(c.Removed), len(c.Renamed), len(c.Modified), c.Unchanged)
+
+	return b.String()
+}
+
+func formatSize(size *int64) string {
+	if size == nil {
+		return "unknown size"
+	}
+	return humanize.IBytes(uint64(*size))
+}
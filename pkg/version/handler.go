@@ -2,9 +2,13 @@ package version
 
 import (
 	"app/pkg/api"
+	"app/pkg/platform/auth"
 	"app/pkg/platform/handler"
+	"app/pkg/platform/links"
+	"app/pkg/qrcode"
 	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
 	"strconv"
 
@@ -12,11 +16,12 @@ import (
 )
 
 type Handler struct {
-	service Service
+	service    Service
+	qrRenderer *qrcode.Renderer
 }
 
-func NewHandler(service Service) *Handler {
-	return &Handler{service: service}
+func NewHandler(service Service, qrRenderer *qrcode.Renderer) *Handler {
+	return &Handler{service: service, qrRenderer: qrRenderer}
 }
 
 func (h *Handler) RegisterRoutes(r chi.Router) {
@@ -26,12 +31,22 @@ func (h *Handler) RegisterRoutes(r chi.Router) {
 
 		r.Route("/{versionId}", func(r chi.Router) {
 			r.Get("/", h.GetById)
+			r.Get("/qr", h.QRCode)
 			r.Put("/", h.Update)
 			r.Delete("/", h.Delete)
+			r.Put("/status", h.UpdateStatus)
+			r.Post("/clone", h.Clone)
+			r.Get("/compare/{otherVersionId}", h.Compare)
 			r.Patch("/attach-file", h.AttachFile)
 			r.Patch("/detach-file", h.DetachFile)
 		})
 	})
+
+	r.Get("/v1/projects/{projectId}/versions/latest", h.GetLatest)
+	r.Route("/v1/projects/{projectId}/latest-version-policy", func(r chi.Router) {
+		r.Get("/", h.GetLatestPolicy)
+		r.Put("/", h.UpdateLatestPolicy)
+	})
 }
 
 func (h *Handler) GetById(w http.ResponseWriter, r *http.Request) {
@@ -42,6 +57,10 @@ func (h *Handler) GetById(w http.ResponseWriter, r *http.Request) {
 	}
 
 	version, err := h.service.GetById(r.Context(), id)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
 	if errors.Is(err, ErrVersionNotFound) {
 		writeVersionNotFoundError(w)
 		return
@@ -63,7 +82,20 @@ func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	versions, err := h.service.List(r.Context(), projectId, limit, offset)
+	filter := ListVersionsFilter{ProjectID: projectId}
+	if r.URL.Query().Has("status") {
+		filter.Status = new(Status(r.URL.Query().Get("status")))
+	}
+
+	versions, err := h.service.List(r.Context(), filter, limit, offset)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrVersionInvalidStatus) {
+		handler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 	if err != nil {
 		handler.WriteInternalServerError(w)
 		return
@@ -84,6 +116,48 @@ func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 		Description: req.Description,
 		ProjectId:   req.ProjectId,
 	})
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrVersionAlreadyExists) {
+		writeVersionAlreadyExistsError(w)
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	handler.WriteJson(w, http.StatusCreated, toVersionResponse(version))
+}
+
+func (h *Handler) Clone(w http.ResponseWriter, r *http.Request) {
+	id, err := parseVersionId(r)
+	if err != nil {
+		writeInvalidVersionIdError(w)
+		return
+	}
+
+	var req api.CloneVersionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handler.WriteInvalidRequestPayloadError(w)
+		return
+	}
+
+	version, err := h.service.Clone(r.Context(), id, CloneVersionRequest{
+		Name:            req.Name,
+		Description:     req.Description,
+		CopyDescription: req.CopyDescription == nil || *req.CopyDescription,
+	})
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrVersionNotFound) {
+		writeVersionNotFoundError(w)
+		return
+	}
 	if errors.Is(err, ErrVersionAlreadyExists) {
 		writeVersionAlreadyExistsError(w)
 		return
@@ -113,10 +187,18 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 		Name:        req.Name,
 		Description: req.Description,
 	})
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
 	if errors.Is(err, ErrVersionNotFound) {
 		writeVersionNotFoundError(w)
 		return
 	}
+	if errors.Is(err, ErrVersionNotEditable) {
+		writeVersionNotEditableError(w)
+		return
+	}
 	if err != nil {
 		handler.WriteInternalServerError(w)
 		return
@@ -133,10 +215,18 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	err = h.service.Delete(r.Context(), id)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
 	if errors.Is(err, ErrVersionNotFound) {
 		writeVersionNotFoundError(w)
 		return
 	}
+	if errors.Is(err, ErrVersionReleased) {
+		handler.WriteError(w, http.StatusConflict, err.Error())
+		return
+	}
 	if err != nil {
 		handler.WriteInternalServerError(w)
 		return
@@ -159,16 +249,33 @@ func (h *Handler) AttachFile(w http.ResponseWriter, r *http.Request) {
 	}
 
 	err = h.service.AttachFile(r.Context(), id, AttachFileRequest{
-		FileID: req.FileId,
+		FileID:     req.FileId,
+		RevisionID: req.RevisionId,
 	})
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
 	if errors.Is(err, ErrVersionNotFound) {
 		writeVersionNotFoundError(w)
 		return
 	}
+	if errors.Is(err, ErrVersionNotEditable) {
+		writeVersionNotEditableError(w)
+		return
+	}
 	if errors.Is(err, ErrVersionFileAlreadyAttached) {
 		writeVersionFileAlreadyAttachedError(w)
 		return
 	}
+	if errors.Is(err, ErrFileNotFound) {
+		handler.WriteError(w, http.StatusNotFound, "file not found")
+		return
+	}
+	if errors.Is(err, ErrFileRevisionNotFound) {
+		handler.WriteError(w, http.StatusBadRequest, "file revision not found")
+		return
+	}
 	if err != nil {
 		handler.WriteInternalServerError(w)
 		return
@@ -193,10 +300,18 @@ func (h *Handler) DetachFile(w http.ResponseWriter, r *http.Request) {
 	err = h.service.DetachFile(r.Context(), id, DetachFileRequest{
 		FileID: req.FileId,
 	})
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
 	if errors.Is(err, ErrVersionNotFound) {
 		writeVersionNotFoundError(w)
 		return
 	}
+	if errors.Is(err, ErrVersionNotEditable) {
+		writeVersionNotEditableError(w)
+		return
+	}
 	if err != nil {
 		handler.WriteInternalServerError(w)
 		return
@@ -205,6 +320,201 @@ func (h *Handler) DetachFile(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func (h *Handler) UpdateStatus(w http.ResponseWriter, r *http.Request) {
+	id, err := parseVersionId(r)
+	if err != nil {
+		writeInvalidVersionIdError(w)
+		return
+	}
+
+	var req api.UpdateVersionStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handler.WriteInvalidRequestPayloadError(w)
+		return
+	}
+
+	version, err := h.service.UpdateStatus(r.Context(), id, UpdateVersionStatusRequest{
+		Status: Status(req.Status),
+	})
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrVersionNotFound) {
+		writeVersionNotFoundError(w)
+		return
+	}
+	if errors.Is(err, ErrVersionInvalidStatus) {
+		handler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, ErrVersionInvalidTransition) || errors.Is(err, ErrVersionIncompleteFiles) || errors.Is(err, ErrVersionStatusChanged) {
+		handler.WriteError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	handler.WriteJson(w, http.StatusOK, toVersionResponse(version))
+}
+
+func (h *Handler) Compare(w http.ResponseWriter, r *http.Request) {
+	baseId, err := parseVersionId(r)
+	if err != nil {
+		writeInvalidVersionIdError(w)
+		return
+	}
+
+	targetId, err := strconv.ParseInt(chi.URLParam(r, "otherVersionId"), 10, 64)
+	if err != nil {
+		writeInvalidVersionIdError(w)
+		return
+	}
+
+	comparison, err := h.service.Compare(r.Context(), baseId, targetId)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrVersionNotFound) {
+		writeVersionNotFoundError(w)
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	if r.URL.Query().Get("format") == "text" {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, comparison.Changelog())
+		return
+	}
+
+	handler.WriteJson(w, http.StatusOK, toVersionComparisonResponse(comparison))
+}
+
+func (h *Handler) GetLatest(w http.ResponseWriter, r *http.Request) {
+	projectId, err := parsePathProjectId(r)
+	if err != nil {
+		writeInvalidProjectIdError(w)
+		return
+	}
+
+	var strategy *LatestStrategy
+	if r.URL.Query().Has("strategy") {
+		strategy = new(LatestStrategy(r.URL.Query().Get("strategy")))
+	}
+
+	version, err := h.service.GetLatest(r.Context(), projectId, strategy)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrInvalidLatestStrategy) {
+		handler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, ErrProjectNotFound) || errors.Is(err, ErrNoReleasedVersion) {
+		handler.WriteError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	handler.WriteJson(w, http.StatusOK, toVersionResponse(version))
+}
+
+func (h *Handler) GetLatestPolicy(w http.ResponseWriter, r *http.Request) {
+	projectId, err := parsePathProjectId(r)
+	if err != nil {
+		writeInvalidProjectIdError(w)
+		return
+	}
+
+	policy, err := h.service.GetLatestPolicy(r.Context(), projectId)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrProjectNotFound) {
+		handler.WriteError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	handler.WriteJson(w, http.StatusOK, toLatestVersionPolicyResponse(policy))
+}
+
+func (h *Handler) UpdateLatestPolicy(w http.ResponseWriter, r *http.Request) {
+	projectId, err := parsePathProjectId(r)
+	if err != nil {
+		writeInvalidProjectIdError(w)
+		return
+	}
+
+	var req api.UpdateLatestVersionPolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handler.WriteInvalidRequestPayloadError(w)
+		return
+	}
+
+	policy, err := h.service.UpdateLatestPolicy(r.Context(), projectId, UpdateLatestPolicyRequest{
+		Strategy:        LatestStrategy(req.Strategy),
+		PinnedVersionID: req.PinnedVersionId,
+	})
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrInvalidLatestStrategy) || errors.Is(err, ErrInvalidPinnedVersion) {
+		handler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, ErrProjectNotFound) {
+		handler.WriteError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	handler.WriteJson(w, http.StatusOK, toLatestVersionPolicyResponse(policy))
+}
+
+func (h *Handler) QRCode(w http.ResponseWriter, r *http.Request) {
+	id, err := parseVersionId(r)
+	if err != nil {
+		writeInvalidVersionIdError(w)
+		return
+	}
+
+	_, err = h.service.GetById(r.Context(), id)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrVersionNotFound) {
+		writeVersionNotFoundError(w)
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	h.qrRenderer.Write(w, r, links.VersionPath(id))
+}
+
 func writeInvalidVersionIdError(w http.ResponseWriter) {
 	handler.WriteError(w, http.StatusBadRequest, "invalid version id")
 }
@@ -234,10 +544,18 @@ func parseProjectId(r *http.Request) (*int64, error) {
 	return &projectId, nil
 }
 
+func parsePathProjectId(r *http.Request) (int64, error) {
+	return strconv.ParseInt(chi.URLParam(r, "projectId"), 10, 64)
+}
+
 func writeInvalidProjectIdError(w http.ResponseWriter) {
 	handler.WriteError(w, http.StatusBadRequest, "invalid project id")
 }
 
+func writeVersionNotEditableError(w http.ResponseWriter) {
+	handler.WriteError(w, http.StatusConflict, ErrVersionNotEditable.Error())
+}
+
 func writeVersionFileAlreadyAttachedError(w http.ResponseWriter) {
 	handler.WriteError(w, http.StatusConflict, "version file already attached")
 }
@@ -250,6 +568,63 @@ func toVersionResponse(v Version) api.VersionResponse {
 		Name:        v.Name,
 		Description: v.Description,
 		ProjectId:   v.ProjectID,
+		Status:      api.VersionStatus(v.Status),
+		ReleasedAt:  v.ReleasedAt,
+	}
+}
+
+func toVersionComparisonResponse(c Comparison) api.VersionComparisonResponse {
+	return api.VersionComparisonResponse{
+		BaseVersion:   toVersionResponse(c.Base),
+		TargetVersion: toVersionResponse(c.Target),
+		Summary: api.VersionComparisonSummary{
+			Added:     len(c.Added),
+			Removed:   len(c.Removed),
+			Renamed:   len(c.Renamed),
+			Modified:  len(c.Modified),
+			Unchanged: c.Unchanged,
+		},
+		Added:    toComparedFileResponses(c.Added),
+		Removed:  toComparedFileResponses(c.Removed),
+		Renamed:  toFileChangeResponses(c.Renamed),
+		Modified: toFileChangeResponses(c.Modified),
+	}
+}
+
+func toComparedFileResponses(files []VersionFile) []api.ComparedFileResponse {
+	items := make([]api.ComparedFileResponse, len(files))
+	for i, f := range files {
+		items[i] = toComparedFileResponse(f)
+	}
+	return items
+}
+
+func toComparedFileResponse(f VersionFile) api.ComparedFileResponse {
+	return api.ComparedFileResponse{
+		Id:         f.ID,
+		Name:       f.Name,
+		RevisionId: f.RevisionID,
+		Size:       f.Size,
+		MimeType:   f.MimeType,
+		Checksum:   f.Checksum,
+	}
+}
+
+func toFileChangeResponses(changes []FileChange) []api.FileChangeResponse {
+	items := make([]api.FileChangeResponse, len(changes))
+	for i, change := range changes {
+		items[i] = api.FileChangeResponse{
+			From: toComparedFileResponse(change.From),
+			To:   toComparedFileResponse(change.To),
+		}
+	}
+	return items
+}
+
+func toLatestVersionPolicyResponse(p LatestPolicy) api.LatestVersionPolicyResponse {
+	return api.LatestVersionPolicyResponse{
+		Strategy:        api.LatestVersionStrategy(p.Strategy),
+		PinnedVersionId: p.PinnedVersionID,
 	}
 }
 
@@ -1,6 +1,39 @@
 package version
 
-import "time"
+import (
+	"slices"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusDraft      Status = "draft"
+	StatusInReview   Status = "in_review"
+	StatusReleased   Status = "released"
+	StatusSuperseded Status = "superseded"
+	StatusWithdrawn  Status = "withdrawn"
+)
+
+// transitions lists the statuses a version can move to from each status.
+// Released contents never change again, a released version can only be
+// superseded by a newer one or withdrawn.
+var transitions = map[Status][]Status{
+	StatusDraft:      {StatusInReview, StatusWithdrawn},
+	StatusInReview:   {StatusDraft, StatusReleased, StatusWithdrawn},
+	StatusReleased:   {StatusSuperseded, StatusWithdrawn},
+	StatusSuperseded: {StatusWithdrawn},
+	StatusWithdrawn:  {},
+}
+
+func (s Status) IsValid() bool {
+	_, ok := transitions[s]
+	return ok
+}
+
+func (s Status) CanTransitionTo(target Status) bool {
+	return slices.Contains(transitions[s], target)
+}
 
 type Version struct {
 	ID          int64
@@ -9,6 +42,84 @@ type Version struct {
 	Name        string
 	Description *string
 	ProjectID   int64
+	Status      Status
+	ReleasedAt  *time.Time
+}
+
+// IsEditable reports whether the name, description and files of the version
+// may still change, which is only the case for drafts.
+func (v Version) IsEditable() bool {
+	return v.Status == StatusDraft
+}
+
+// LatestStrategy decides which released version of a project is its current
+// documentation. Drafts and versions under review are never considered.
+type LatestStrategy string
+
+const (
+	// LatestStrategyReleased picks the most recently released version.
+	LatestStrategyReleased LatestStrategy = "released"
+	// LatestStrategySemver picks the released version with the highest
+	// semantic version as its name, names that aren't one are skipped.
+	LatestStrategySemver LatestStrategy = "semver"
+	// LatestStrategyPinned picks the version pinned on the project as long
+	// as it stays released.
+	LatestStrategyPinned LatestStrategy = "pinned"
+)
+
+func (s LatestStrategy) IsValid() bool {
+	switch s {
+	case LatestStrategyReleased, LatestStrategySemver, LatestStrategyPinned:
+		return true
+	}
+	return false
+}
+
+type LatestPolicy struct {
+	Strategy        LatestStrategy
+	PinnedVersionID *int64
+}
+
+type UpdateLatestPolicyRequest struct {
+	Strategy        LatestStrategy
+	PinnedVersionID *int64
+}
+
+// VersionFile is the metadata of a file attached to a version that is looked
+// at when versions are compared.
+type VersionFile struct {
+	ID   int64
+	Name string
+	// RevisionID is the revision the version pins or, when it pins none, the
+	// current one of the file.
+	RevisionID *int64
+	Size       *int64
+	MimeType   *string
+	Checksum   *string
+}
+
+// FileChange pairs the copy of a file in the base version with the one that
+// replaced it in the target version.
+type FileChange struct {
+	From VersionFile
+	To   VersionFile
+}
+
+// Comparison lists what changed in the files between the base and the target
+// version.
+type Comparison struct {
+	Base      Version
+	Target    Version
+	Added     []VersionFile
+	Removed   []VersionFile
+	Renamed   []FileChange
+	Modified  []FileChange
+	Unchanged int
+}
+
+type ListVersionsFilter struct {
+	ProjectID *int64
+	Status    *Status
 }
 
 type CreateVersionRequest struct {
@@ -22,8 +133,24 @@ type UpdateVersionRequest struct {
 	Description *string
 }
 
+// CloneVersionRequest creates a draft in the project of the source version
+// with the same files attached. The description of the source version is
+// copied unless one is given or CopyDescription is false.
+type CloneVersionRequest struct {
+	Name            string
+	Description     *string
+	CopyDescription bool
+}
+
+type UpdateVersionStatusRequest struct {
+	Status Status
+}
+
 type AttachFileRequest struct {
 	FileID int64
+	// RevisionID pins the version to a revision of the file, it follows the
+	// current revision until the version is released otherwise.
+	RevisionID *int64
 }
 
 type DetachFileRequest struct {
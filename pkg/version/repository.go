@@ -5,24 +5,51 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 var (
 	ErrVersionNotFound            = errors.New("version not found")
 	ErrVersionAlreadyExists       = errors.New("version already exists")
 	ErrVersionFileAlreadyAttached = errors.New("version file already attached")
+	ErrFileNotFound               = errors.New("file not found")
+	ErrFileRevisionNotFound       = errors.New("file revision not found")
+	ErrVersionStatusChanged       = errors.New("version status changed concurrently")
+	ErrProjectNotFound            = errors.New("project not found")
 )
 
 type Repository interface {
 	GetById(ctx context.Context, id int64) (Version, error)
-	List(ctx context.Context, projectId *int64, limit, offset int64) ([]Version, error)
+	// Lock loads the version and holds its row until the transaction ends.
+	Lock(ctx context.Context, id int64) (Version, error)
+	// ListReleased returns the released versions of a project, the most
+	// recently released first.
+	ListReleased(ctx context.Context, projectId int64) ([]Version, error)
+	GetLatestPolicy(ctx context.Context, projectId int64) (LatestPolicy, error)
+	UpdateLatestPolicy(ctx context.Context, projectId int64, policy LatestPolicy) (LatestPolicy, error)
+	List(ctx context.Context, filter ListVersionsFilter, memberId *int64, limit, offset int64) ([]Version, error)
 	Create(ctx context.Context, version Version) (Version, error)
+	// CopyFiles attaches the files of the source version to the target
+	// version, the files themselves are shared and not copied.
+	CopyFiles(ctx context.Context, sourceId, targetId int64) error
 	Update(ctx context.Context, version Version) (Version, error)
 	Delete(ctx context.Context, id int64) error
-	AttachFile(ctx context.Context, id int64, fileId int64) error
+	// UpdateStatus moves the version to status and fails with
+	// ErrVersionStatusChanged unless it still has the expected status.
+	UpdateStatus(ctx context.Context, id int64, expected, status Status) (Version, error)
+	CountIncompleteFiles(ctx context.Context, id int64) (int64, error)
+	ListFiles(ctx context.Context, id int64) ([]VersionFile, error)
+	// AttachFile attaches the file, pinned to the revision unless it's nil.
+	// A revision of another file fails with ErrFileRevisionNotFound.
+	AttachFile(ctx context.Context, id int64, fileId int64, revisionId *int64) error
+	AttachFiles(ctx context.Context, id int64, fileIds []int64) error
 	DetachFile(ctx context.Context, id int64, fileId int64) error
+	// PinFiles pins the current revision of the files that aren't pinned yet,
+	// so later uploads don't change the version.
+	PinFiles(ctx context.Context, id int64) error
 }
 
 type repository struct {
@@ -44,9 +71,72 @@ func (r *repository) GetById(ctx context.Context, id int64) (Version, error) {
 	return toVersion(row), nil
 }
 
-func (r *repository) List(ctx context.Context, projectId *int64, limit, offset int64) ([]Version, error) {
+func (r *repository) Lock(ctx context.Context, id int64) (Version, error) {
+	row, err := r.queries.LockVersion(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Version{}, ErrVersionNotFound
+	}
+	if err != nil {
+		return Version{}, err
+	}
+	return toVersion(row), nil
+}
+
+func (r *repository) ListReleased(ctx context.Context, projectId int64) ([]Version, error) {
+	rows, err := r.queries.ListReleasedVersionsByProjectId(ctx, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]Version, len(rows))
+	for i, row := range rows {
+		versions[i] = toVersion(row)
+	}
+	return versions, nil
+}
+
+func (r *repository) GetLatestPolicy(ctx context.Context, projectId int64) (LatestPolicy, error) {
+	row, err := r.queries.GetLatestVersionPolicy(ctx, projectId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return LatestPolicy{}, ErrProjectNotFound
+	}
+	if err != nil {
+		return LatestPolicy{}, err
+	}
+	return LatestPolicy{
+		Strategy:        LatestStrategy(row.LatestVersionStrategy),
+		PinnedVersionID: row.PinnedVersionID,
+	}, nil
+}
+
+func (r *repository) UpdateLatestPolicy(ctx context.Context, projectId int64, policy LatestPolicy) (LatestPolicy, error) {
+	row, err := r.queries.UpdateLatestVersionPolicy(ctx, &database.UpdateLatestVersionPolicyParams{
+		ID:                    projectId,
+		LatestVersionStrategy: string(policy.Strategy),
+		PinnedVersionID:       policy.PinnedVersionID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return LatestPolicy{}, ErrProjectNotFound
+	}
+	if err != nil {
+		return LatestPolicy{}, err
+	}
+	return LatestPolicy{
+		Strategy:        LatestStrategy(row.LatestVersionStrategy),
+		PinnedVersionID: row.PinnedVersionID,
+	}, nil
+}
+
+func (r *repository) List(ctx context.Context, filter ListVersionsFilter, memberId *int64, limit, offset int64) ([]Version, error) {
+	var status *string
+	if filter.Status != nil {
+		status = new(string(*filter.Status))
+	}
+
 	rows, err := r.queries.ListVersions(ctx, &database.ListVersionsParams{
-		ProjectId: projectId,
+		ProjectId: filter.ProjectID,
+		Status:    status,
+		MemberId:  memberId,
 		Offset:    offset,
 		Limit:     limit,
 	})
@@ -75,6 +165,14 @@ func (r *repository) Create(ctx context.Context, version Version) (Version, erro
 	return toVersion(row), nil
 }
 
+func (r *repository) CopyFiles(ctx context.Context, sourceId, targetId int64) error {
+	_, err := r.queries.CopyVersionFiles(ctx, &database.CopyVersionFilesParams{
+		TargetVersionId: targetId,
+		SourceVersionId: sourceId,
+	})
+	return err
+}
+
 func (r *repository) Update(ctx context.Context, version Version) (Version, error) {
 	row, err := r.queries.UpdateVersion(ctx, &database.UpdateVersionParams{
 		ID:          version.ID,
@@ -101,14 +199,72 @@ func (r *repository) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
-func (r *repository) AttachFile(ctx context.Context, id int64, fileId int64) error {
-	err := r.queries.AttachFileToVersion(ctx, &database.AttachFileToVersionParams{
-		VersionID: id,
-		FileID:    fileId,
+func (r *repository) UpdateStatus(ctx context.Context, id int64, expected, status Status) (Version, error) {
+	row, err := r.queries.UpdateVersionStatus(ctx, &database.UpdateVersionStatusParams{
+		ID:             id,
+		ExpectedStatus: string(expected),
+		Status:         string(status),
 	})
 	if errors.Is(err, pgx.ErrNoRows) {
-		return ErrVersionNotFound
+		return Version{}, ErrVersionStatusChanged
+	}
+	if err != nil {
+		return Version{}, err
 	}
+	return toVersion(row), nil
+}
+
+func (r *repository) ListFiles(ctx context.Context, id int64) ([]VersionFile, error) {
+	rows, err := r.queries.ListVersionFiles(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]VersionFile, len(rows))
+	for i, row := range rows {
+		files[i] = VersionFile{
+			ID:         row.ID,
+			Name:       row.Name,
+			RevisionID: row.RevisionID,
+			Size:       row.Size,
+			MimeType:   row.MimeType,
+			Checksum:   row.Checksum,
+		}
+	}
+	return files, nil
+}
+
+func (r *repository) CountIncompleteFiles(ctx context.Context, id int64) (int64, error) {
+	return r.queries.CountIncompleteFilesByVersionId(ctx, id)
+}
+
+func (r *repository) AttachFile(ctx context.Context, id int64, fileId int64, revisionId *int64) error {
+	attached, err := r.queries.AttachFileToVersion(ctx, &database.AttachFileToVersionParams{
+		VersionId:  id,
+		FileId:     fileId,
+		RevisionId: revisionId,
+	})
+	if err != nil {
+		if isPgUniqueViolation(err) {
+			return ErrVersionFileAlreadyAttached
+		}
+		return err
+	}
+	if attached == 0 {
+		return ErrFileRevisionNotFound
+	}
+	return nil
+}
+
+func (r *repository) PinFiles(ctx context.Context, id int64) error {
+	return r.queries.PinVersionFiles(ctx, id)
+}
+
+func (r *repository) AttachFiles(ctx context.Context, id int64, fileIds []int64) error {
+	err := r.queries.AttachFilesToVersion(ctx, &database.AttachFilesToVersionParams{
+		VersionId: id,
+		FileIds:   fileIds,
+	})
 	if err != nil {
 		if isPgUniqueViolation(err) {
 			return ErrVersionFileAlreadyAttached
@@ -140,7 +296,16 @@ func toVersion(row *database.Version) Version {
 		Name:        row.Name,
 		Description: row.Description,
 		ProjectID:   row.ProjectID,
+		Status:      Status(row.Status),
+		ReleasedAt:  toTime(row.ReleasedAt),
+	}
+}
+
+func toTime(timestamp pgtype.Timestamp) *time.Time {
+	if !timestamp.Valid {
+		return nil
 	}
+	return &timestamp.Time
 }
 
 func isPgUniqueViolation(err error) bool {
@@ -1,61 +1,661 @@
 package version
 
 import (
+	"app/pkg/audit"
+	"app/pkg/events"
+	"app/pkg/membership"
+	"app/pkg/platform/transaction"
+	"app/pkg/webhook"
 	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+var (
+	ErrVersionInvalidStatus     = errors.New("invalid version status")
+	ErrVersionInvalidTransition = errors.New("version status transition not allowed")
+	ErrVersionNotEditable       = errors.New("only draft versions can be changed")
+	ErrVersionReleased          = errors.New("released versions can't be deleted")
+	ErrVersionIncompleteFiles   = errors.New("version has files that aren't completely uploaded")
+	ErrNoReleasedVersion        = errors.New("project has no released version")
+	ErrInvalidLatestStrategy    = errors.New("invalid latest version strategy")
+	ErrInvalidPinnedVersion     = errors.New("pinned version must be a released version of the project")
 )
 
 type Service interface {
 	GetById(ctx context.Context, id int64) (Version, error)
-	List(ctx context.Context, projectId *int64, limit, offset int64) ([]Version, error)
+	// GetLatestByProjectId resolves the current version of a project with the
+	// project's strategy without checking memberships, it backs public
+	// resolvers such as short links.
+	GetLatestByProjectId(ctx context.Context, projectId int64) (Version, error)
+	// GetLatest resolves the current version of a project, strategy
+	// overrides the one configured on the project when set.
+	GetLatest(ctx context.Context, projectId int64, strategy *LatestStrategy) (Version, error)
+	// ListLatest returns the versions GetLatest considers, the current one
+	// first.
+	ListLatest(ctx context.Context, projectId int64) ([]Version, error)
+	GetLatestPolicy(ctx context.Context, projectId int64) (LatestPolicy, error)
+	UpdateLatestPolicy(ctx context.Context, projectId int64, req UpdateLatestPolicyRequest) (LatestPolicy, error)
+	List(ctx context.Context, filter ListVersionsFilter, limit, offset int64) ([]Version, error)
 	Create(ctx context.Context, req CreateVersionRequest) (Version, error)
 	Update(ctx context.Context, id int64, req UpdateVersionRequest) (Version, error)
+	// Clone starts the next revision from an existing version.
+	Clone(ctx context.Context, id int64, req CloneVersionRequest) (Version, error)
 	Delete(ctx context.Context, id int64) error
+	// UpdateStatus moves the version through its lifecycle, releasing,
+	// superseding and withdrawing require the admin role on the project.
+	UpdateStatus(ctx context.Context, id int64, req UpdateVersionStatusRequest) (Version, error)
+	// Compare reports how the files of the target version differ from the
+	// ones of the base version.
+	Compare(ctx context.Context, baseId, targetId int64) (Comparison, error)
+	// AttachFile attaches a file the caller may at least view.
 	AttachFile(ctx context.Context, id int64, req AttachFileRequest) error
+	// AttachFiles attaches all files or, when one of them fails, none. Like
+	// AttachFile it requires the caller to be able to view each file.
+	AttachFiles(ctx context.Context, id int64, fileIds []int64) error
 	DetachFile(ctx context.Context, id int64, req DetachFileRequest) error
 }
 
+// FileAuthorizer checks the caller's access to files, so versions only take
+// in files the caller may see.
+type FileAuthorizer interface {
+	// AuthorizeFile fails with ErrFileNotFound for unknown files and with
+	// auth.ErrForbidden when the caller lacks the role on the file.
+	AuthorizeFile(ctx context.Context, id int64, role membership.Role) error
+}
+
 type service struct {
-	repository Repository
+	repository        Repository
+	membershipService membership.Service
+	fileAuthorizer    FileAuthorizer
+	auditService      audit.Service
+	webhookService    webhook.Service
+	eventService      events.Service
+	transactions      transaction.Manager
 }
 
-func NewVersionService(repository Repository) Service {
-	return &service{repository: repository}
+func NewVersionService(repository Repository, membershipService membership.Service, fileAuthorizer FileAuthorizer, auditService audit.Service, webhookService webhook.Service, eventService events.Service, transactions transaction.Manager) Service {
+	return &service{repository: repository, membershipService: membershipService, fileAuthorizer: fileAuthorizer, auditService: auditService, webhookService: webhookService, eventService: eventService, transactions: transactions}
 }
 
 func (s *service) GetById(ctx context.Context, id int64) (Version, error) {
-	return s.repository.GetById(ctx, id)
+	return s.authorized(ctx, id, membership.RoleViewer)
 }
 
-func (s *service) List(ctx context.Context, projectId *int64, limit, offset int64) ([]Version, error) {
-	return s.repository.List(ctx, projectId, limit, offset)
+func (s *service) GetLatestByProjectId(ctx context.Context, projectId int64) (Version, error) {
+	return s.latest(ctx, projectId, nil)
+}
+
+func (s *service) GetLatest(ctx context.Context, projectId int64, strategy *LatestStrategy) (Version, error) {
+	if strategy != nil && !strategy.IsValid() {
+		return Version{}, ErrInvalidLatestStrategy
+	}
+	if err := s.membershipService.Authorize(ctx, projectId, membership.RoleViewer); err != nil {
+		return Version{}, err
+	}
+	return s.latest(ctx, projectId, strategy)
+}
+
+func (s *service) ListLatest(ctx context.Context, projectId int64) ([]Version, error) {
+	if err := s.membershipService.Authorize(ctx, projectId, membership.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	policy, err := s.repository.GetLatestPolicy(ctx, projectId)
+	if err != nil {
+		return nil, err
+	}
+	return s.listLatest(ctx, projectId, policy)
+}
+
+func (s *service) GetLatestPolicy(ctx context.Context, projectId int64) (LatestPolicy, error) {
+	if err := s.membershipService.Authorize(ctx, projectId, membership.RoleViewer); err != nil {
+		return LatestPolicy{}, err
+	}
+	return s.repository.GetLatestPolicy(ctx, projectId)
+}
+
+func (s *service) UpdateLatestPolicy(ctx context.Context, projectId int64, req UpdateLatestPolicyRequest) (LatestPolicy, error) {
+	if !req.Strategy.IsValid() {
+		return LatestPolicy{}, ErrInvalidLatestStrategy
+	}
+	if err := s.membershipService.Authorize(ctx, projectId, membership.RoleAdmin); err != nil {
+		return LatestPolicy{}, err
+	}
+
+	policy := LatestPolicy{Strategy: req.Strategy}
+	if req.Strategy == LatestStrategyPinned {
+		if req.PinnedVersionID == nil {
+			return LatestPolicy{}, ErrInvalidPinnedVersion
+		}
+
+		pinned, err := s.repository.GetById(ctx, *req.PinnedVersionID)
+		if errors.Is(err, ErrVersionNotFound) {
+			return LatestPolicy{}, ErrInvalidPinnedVersion
+		}
+		if err != nil {
+			return LatestPolicy{}, err
+		}
+		if pinned.ProjectID != projectId || pinned.Status != StatusReleased {
+			return LatestPolicy{}, ErrInvalidPinnedVersion
+		}
+
+		policy.PinnedVersionID = req.PinnedVersionID
+	}
+
+	err := s.transactions.Run(ctx, func(ctx context.Context) error {
+		before, err := s.repository.GetLatestPolicy(ctx, projectId)
+		if err != nil {
+			return err
+		}
+
+		updated, err := s.repository.UpdateLatestPolicy(ctx, projectId, policy)
+		if err != nil {
+			return err
+		}
+		policy = updated
+
+		return s.auditService.Record(ctx, audit.Entry{
+			Action:       audit.ActionUpdateLatestPolicy,
+			ResourceType: audit.ResourceTypeProject,
+			ResourceID:   projectId,
+			Before:       policyAuditState(before),
+			After:        policyAuditState(policy),
+		})
+	})
+	if err != nil {
+		return LatestPolicy{}, err
+	}
+	return policy, nil
+}
+
+func (s *service) List(ctx context.Context, filter ListVersionsFilter, limit, offset int64) ([]Version, error) {
+	if filter.Status != nil && !filter.Status.IsValid() {
+		return nil, ErrVersionInvalidStatus
+	}
+	if filter.ProjectID != nil {
+		if err := s.membershipService.Authorize(ctx, *filter.ProjectID, membership.RoleViewer); err != nil {
+			return nil, err
+		}
+	}
+
+	memberId, err := s.membershipService.MemberFilter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.repository.List(ctx, filter, memberId, limit, offset)
 }
 
 func (s *service) Create(ctx context.Context, req CreateVersionRequest) (Version, error) {
+	if err := s.membershipService.Authorize(ctx, req.ProjectId, membership.RoleEditor); err != nil {
+		return Version{}, err
+	}
+
 	version := Version{
 		Name:        req.Name,
 		Description: req.Description,
 		ProjectID:   req.ProjectId,
 	}
-	return s.repository.Create(ctx, version)
+
+	err := s.transactions.Run(ctx, func(ctx context.Context) error {
+		created, err := s.repository.Create(ctx, version)
+		if err != nil {
+			return err
+		}
+		version = created
+
+		err = s.auditService.Record(ctx, audit.Entry{
+			Action:       audit.ActionCreate,
+			ResourceType: audit.ResourceTypeVersion,
+			ResourceID:   version.ID,
+			After:        auditState(version),
+		})
+		if err != nil {
+			return err
+		}
+		return s.publish(ctx, webhook.EventVersionCreated, version)
+	})
+	if err != nil {
+		return Version{}, err
+	}
+	return version, nil
 }
 
-func (s *service) Update(ctx context.Context, id int64, req UpdateVersionRequest) (Version, error) {
+func (s *service) Clone(ctx context.Context, id int64, req CloneVersionRequest) (Version, error) {
+	source, err := s.authorized(ctx, id, membership.RoleEditor)
+	if err != nil {
+		return Version{}, err
+	}
+
+	description := req.Description
+	if description == nil && req.CopyDescription {
+		description = source.Description
+	}
+
 	version := Version{
-		ID:          id,
 		Name:        req.Name,
-		Description: req.Description,
+		Description: description,
+		ProjectID:   source.ProjectID,
+	}
+
+	err = s.transactions.Run(ctx, func(ctx context.Context) error {
+		created, err := s.repository.Create(ctx, version)
+		if err != nil {
+			return err
+		}
+		version = created
+
+		if err := s.repository.CopyFiles(ctx, id, version.ID); err != nil {
+			return err
+		}
+
+		after := auditState(version)
+		after["sourceVersionId"] = id
+		err = s.auditService.Record(ctx, audit.Entry{
+			Action:       audit.ActionClone,
+			ResourceType: audit.ResourceTypeVersion,
+			ResourceID:   version.ID,
+			After:        after,
+		})
+		if err != nil {
+			return err
+		}
+		return s.publish(ctx, webhook.EventVersionCreated, version)
+	})
+	if err != nil {
+		return Version{}, err
+	}
+	return version, nil
+}
+
+func (s *service) Update(ctx context.Context, id int64, req UpdateVersionRequest) (Version, error) {
+	var version Version
+	err := s.transactions.Run(ctx, func(ctx context.Context) error {
+		before, err := s.editable(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		version, err = s.repository.Update(ctx, Version{
+			ID:          id,
+			Name:        req.Name,
+			Description: req.Description,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = s.auditService.Record(ctx, audit.Entry{
+			Action:       audit.ActionUpdate,
+			ResourceType: audit.ResourceTypeVersion,
+			ResourceID:   id,
+			Before:       auditState(before),
+			After:        auditState(version),
+		})
+		if err != nil {
+			return err
+		}
+		return s.publish(ctx, webhook.EventVersionUpdated, version)
+	})
+	if err != nil {
+		return Version{}, err
 	}
-	return s.repository.Update(ctx, version)
+	return version, nil
 }
 
 func (s *service) Delete(ctx context.Context, id int64) error {
-	return s.repository.Delete(ctx, id)
+	return s.transactions.Run(ctx, func(ctx context.Context) error {
+		version, err := s.authorized(ctx, id, membership.RoleEditor)
+		if err != nil {
+			return err
+		}
+		if version.ReleasedAt != nil {
+			return ErrVersionReleased
+		}
+
+		if err := s.repository.Delete(ctx, id); err != nil {
+			return err
+		}
+
+		err = s.auditService.Record(ctx, audit.Entry{
+			Action:       audit.ActionDelete,
+			ResourceType: audit.ResourceTypeVersion,
+			ResourceID:   id,
+			Before:       auditState(version),
+		})
+		if err != nil {
+			return err
+		}
+		return s.publish(ctx, webhook.EventVersionDeleted, version)
+	})
+}
+
+func (s *service) Compare(ctx context.Context, baseId, targetId int64) (Comparison, error) {
+	base, err := s.authorized(ctx, baseId, membership.RoleViewer)
+	if err != nil {
+		return Comparison{}, err
+	}
+	target, err := s.authorized(ctx, targetId, membership.RoleViewer)
+	if err != nil {
+		return Comparison{}, err
+	}
+
+	baseFiles, err := s.repository.ListFiles(ctx, baseId)
+	if err != nil {
+		return Comparison{}, err
+	}
+	targetFiles, err := s.repository.ListFiles(ctx, targetId)
+	if err != nil {
+		return Comparison{}, err
+	}
+
+	comparison := compareFiles(baseFiles, targetFiles)
+	comparison.Base = base
+	comparison.Target = target
+	return comparison, nil
 }
 
 func (s *service) AttachFile(ctx context.Context, id int64, req AttachFileRequest) error {
-	return s.repository.AttachFile(ctx, id, req.FileID)
+	return s.transactions.Run(ctx, func(ctx context.Context) error {
+		version, err := s.editable(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.fileAuthorizer.AuthorizeFile(ctx, req.FileID, membership.RoleViewer); err != nil {
+			return err
+		}
+		if err := s.repository.AttachFile(ctx, id, req.FileID, req.RevisionID); err != nil {
+			return err
+		}
+
+		err = s.auditService.Record(ctx, audit.Entry{
+			Action:       audit.ActionAttach,
+			ResourceType: audit.ResourceTypeFile,
+			ResourceID:   req.FileID,
+			After:        map[string]any{"versionId": id, "revisionId": req.RevisionID},
+		})
+		if err != nil {
+			return err
+		}
+		return s.publishFile(ctx, webhook.EventFileAttached, req.FileID, version)
+	})
+}
+
+func (s *service) AttachFiles(ctx context.Context, id int64, fileIds []int64) error {
+	return s.transactions.Run(ctx, func(ctx context.Context) error {
+		version, err := s.editable(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		for _, fileId := range fileIds {
+			if err := s.fileAuthorizer.AuthorizeFile(ctx, fileId, membership.RoleViewer); err != nil {
+				return err
+			}
+		}
+		if err := s.repository.AttachFiles(ctx, id, fileIds); err != nil {
+			return err
+		}
+
+		for _, fileId := range fileIds {
+			err := s.auditService.Record(ctx, audit.Entry{
+				Action:       audit.ActionAttach,
+				ResourceType: audit.ResourceTypeFile,
+				ResourceID:   fileId,
+				After:        map[string]any{"versionId": id},
+			})
+			if err != nil {
+				return err
+			}
+			if err := s.publishFile(ctx, webhook.EventFileAttached, fileId, version); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
 func (s *service) DetachFile(ctx context.Context, id int64, req DetachFileRequest) error {
-	return s.repository.DetachFile(ctx, id, req.FileID)
+	return s.transactions.Run(ctx, func(ctx context.Context) error {
+		version, err := s.editable(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.repository.DetachFile(ctx, id, req.FileID); err != nil {
+			return err
+		}
+
+		err = s.auditService.Record(ctx, audit.Entry{
+			Action:       audit.ActionDetach,
+			ResourceType: audit.ResourceTypeFile,
+			ResourceID:   req.FileID,
+			Before:       map[string]any{"versionId": id},
+		})
+		if err != nil {
+			return err
+		}
+		return s.publishFile(ctx, webhook.EventFileDetached, req.FileID, version)
+	})
+}
+
+func (s *service) UpdateStatus(ctx context.Context, id int64, req UpdateVersionStatusRequest) (Version, error) {
+	if !req.Status.IsValid() {
+		return Version{}, ErrVersionInvalidStatus
+	}
+
+	role := membership.RoleEditor
+	if req.Status == StatusReleased || req.Status == StatusSuperseded || req.Status == StatusWithdrawn {
+		role = membership.RoleAdmin
+	}
+
+	var updated Version
+	err := s.transactions.Run(ctx, func(ctx context.Context) error {
+		// The version stays locked until the status is changed, so no file
+		// is attached or detached between the checks and pinning its files.
+		version, err := s.locked(ctx, id, role)
+		if err != nil {
+			return err
+		}
+		if !version.Status.CanTransitionTo(req.Status) {
+			return ErrVersionInvalidTransition
+		}
+
+		if req.Status == StatusReleased {
+			incomplete, err := s.repository.CountIncompleteFiles(ctx, id)
+			if err != nil {
+				return err
+			}
+			if incomplete > 0 {
+				return ErrVersionIncompleteFiles
+			}
+		}
+
+		updated, err = s.repository.UpdateStatus(ctx, id, version.Status, req.Status)
+		if err != nil {
+			return err
+		}
+
+		if updated.Status == StatusReleased {
+			if err := s.repository.PinFiles(ctx, id); err != nil {
+				return err
+			}
+		}
+
+		err = s.auditService.Record(ctx, audit.Entry{
+			Action:       audit.ActionStatusChange,
+			ResourceType: audit.ResourceTypeVersion,
+			ResourceID:   id,
+			Before:       auditState(version),
+			After:        auditState(updated),
+		})
+		if err != nil {
+			return err
+		}
+		return s.publish(ctx, statusEvent(updated.Status), updated)
+	})
+	if err != nil {
+		return Version{}, err
+	}
+	return updated, nil
+}
+
+func (s *service) latest(ctx context.Context, projectId int64, strategy *LatestStrategy) (Version, error) {
+	policy, err := s.repository.GetLatestPolicy(ctx, projectId)
+	if err != nil {
+		return Version{}, err
+	}
+	if strategy != nil {
+		policy.Strategy = *strategy
+	}
+
+	versions, err := s.listLatest(ctx, projectId, policy)
+	if err != nil {
+		return Version{}, err
+	}
+	if len(versions) == 0 {
+		return Version{}, ErrNoReleasedVersion
+	}
+	return versions[0], nil
+}
+
+// listLatest orders the released versions of a project by the precedence the
+// policy gives them and leaves out those the policy never picks.
+func (s *service) listLatest(ctx context.Context, projectId int64, policy LatestPolicy) ([]Version, error) {
+	versions, err := s.repository.ListReleased(ctx, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	switch policy.Strategy {
+	case LatestStrategySemver:
+		versions = slices.DeleteFunc(versions, func(v Version) bool {
+			return !semver.IsValid(canonicalSemver(v.Name))
+		})
+		// Stable, so equal versions such as 1.0 and 1.0.0 keep the most
+		// recently released one first.
+		slices.SortStableFunc(versions, func(a, b Version) int {
+			return semver.Compare(canonicalSemver(b.Name), canonicalSemver(a.Name))
+		})
+	case LatestStrategyPinned:
+		versions = slices.DeleteFunc(versions, func(v Version) bool {
+			return policy.PinnedVersionID == nil || v.ID != *policy.PinnedVersionID
+		})
+	}
+
+	return versions, nil
+}
+
+// canonicalSemver accepts version names with or without the leading v.
+func canonicalSemver(name string) string {
+	name = strings.TrimSpace(name)
+	if !strings.HasPrefix(name, "v") {
+		name = "v" + name
+	}
+	return name
+}
+
+// editable locks a version the caller may edit and fails unless it is still
+// a draft. Call it in the transaction of the change, so the version can't be
+// released meanwhile.
+func (s *service) editable(ctx context.Context, id int64) (Version, error) {
+	version, err := s.locked(ctx, id, membership.RoleEditor)
+	if err != nil {
+		return Version{}, err
+	}
+	if !version.IsEditable() {
+		return Version{}, ErrVersionNotEditable
+	}
+	return version, nil
+}
+
+// authorized loads the version and checks the caller's role on its project.
+func (s *service) authorized(ctx context.Context, id int64, role membership.Role) (Version, error) {
+	version, err := s.repository.GetById(ctx, id)
+	if err != nil {
+		return Version{}, err
+	}
+	if err := s.membershipService.Authorize(ctx, version.ProjectID, role); err != nil {
+		return Version{}, err
+	}
+	return version, nil
+}
+
+// locked is authorized for changes, it holds the version until the
+// transaction ends.
+func (s *service) locked(ctx context.Context, id int64, role membership.Role) (Version, error) {
+	version, err := s.repository.Lock(ctx, id)
+	if err != nil {
+		return Version{}, err
+	}
+	if err := s.membershipService.Authorize(ctx, version.ProjectID, role); err != nil {
+		return Version{}, err
+	}
+	return version, nil
+}
+
+// publish notifies webhooks and event streams about a change of the version.
+func (s *service) publish(ctx context.Context, event webhook.Event, version Version) error {
+	return s.notify(ctx, event, version.ProjectID, toVersionResponse(version))
+}
+
+// publishFile notifies about a file joining or leaving a version, the data
+// names the file and holds the version.
+func (s *service) publishFile(ctx context.Context, event webhook.Event, fileId int64, version Version) error {
+	return s.notify(ctx, event, version.ProjectID, map[string]any{
+		"fileId":  fileId,
+		"version": toVersionResponse(version),
+	})
+}
+
+// notify publishes an event of the project to webhooks and event streams.
+func (s *service) notify(ctx context.Context, event webhook.Event, projectId int64, data any) error {
+	err := s.webhookService.Publish(ctx, webhook.Notification{
+		Event:      event,
+		ProjectIDs: []int64{projectId},
+		Data:       data,
+	})
+	if err != nil {
+		return err
+	}
+	return s.eventService.Publish(ctx, events.Notification{
+		Event:      event,
+		ProjectIDs: []int64{projectId},
+		Data:       data,
+	})
+}
+
+// statusEvent is the webhook event for a version that moved to status.
+func statusEvent(status Status) webhook.Event {
+	switch status {
+	case StatusReleased:
+		return webhook.EventVersionReleased
+	case StatusSuperseded:
+		return webhook.EventVersionSuperseded
+	case StatusWithdrawn:
+		return webhook.EventVersionWithdrawn
+	}
+	return webhook.EventVersionStatusChanged
+}
+
+// auditState is what the audit log keeps of a version.
+func auditState(version Version) map[string]any {
+	return map[string]any{
+		"name":        version.Name,
+		"description": version.Description,
+		"projectId":   version.ProjectID,
+		"status":      version.Status,
+		"releasedAt":  version.ReleasedAt,
+	}
+}
+
+func policyAuditState(policy LatestPolicy) map[string]any {
+	return map[string]any{
+		"strategy":        policy.Strategy,
+		"pinnedVersionId": policy.PinnedVersionID,
+	}
 }
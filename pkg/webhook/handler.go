@@ -0,0 +1,322 @@
+package webhook
+
+import (
+	"app/pkg/api"
+	"app/pkg/platform/auth"
+	"app/pkg/platform/handler"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Route("/v1/webhooks", func(r chi.Router) {
+		r.Get("/", h.List)
+		r.Post("/", h.Create)
+
+		r.Route("/{webhookId}", func(r chi.Router) {
+			r.Get("/", h.GetById)
+			r.Put("/", h.Update)
+			r.Delete("/", h.Delete)
+			r.Get("/deliveries", h.ListDeliveries)
+			r.Post("/deliveries/{deliveryId}/redeliver", h.Redeliver)
+		})
+	})
+}
+
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	limit, offset := handler.ParsePagination(r)
+
+	var filter ListSubscriptionsFilter
+	if r.URL.Query().Has("projectId") {
+		projectId, err := strconv.ParseInt(r.URL.Query().Get("projectId"), 10, 64)
+		if err != nil {
+			handler.WriteError(w, http.StatusBadRequest, "invalid project id")
+			return
+		}
+		filter.ProjectID = &projectId
+	}
+
+	subscriptions, err := h.service.ListSubscriptions(r.Context(), filter, limit, offset)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	handler.WriteJson(w, http.StatusOK, toListWebhooksResponse(subscriptions, limit, offset))
+}
+
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	var req api.CreateWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handler.WriteInvalidRequestPayloadError(w)
+		return
+	}
+
+	subscription, err := h.service.CreateSubscription(r.Context(), CreateSubscriptionRequest{
+		ProjectID: req.ProjectId,
+		URL:       req.Url,
+		Events:    toEvents(req.Events),
+	})
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if isValidationError(err) {
+		handler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	// The secret is only handed out once, on creation.
+	response := toWebhookResponse(subscription)
+	response.Secret = &subscription.Secret
+	handler.WriteJson(w, http.StatusCreated, response)
+}
+
+func (h *Handler) GetById(w http.ResponseWriter, r *http.Request) {
+	id, err := parseWebhookId(r)
+	if err != nil {
+		writeInvalidWebhookIdError(w)
+		return
+	}
+
+	subscription, err := h.service.GetSubscription(r.Context(), id)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrSubscriptionNotFound) {
+		writeWebhookNotFoundError(w)
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	handler.WriteJson(w, http.StatusOK, toWebhookResponse(subscription))
+}
+
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := parseWebhookId(r)
+	if err != nil {
+		writeInvalidWebhookIdError(w)
+		return
+	}
+
+	var req api.UpdateWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handler.WriteInvalidRequestPayloadError(w)
+		return
+	}
+
+	subscription, err := h.service.UpdateSubscription(r.Context(), id, UpdateSubscriptionRequest{
+		URL:      req.Url,
+		Events:   toEvents(req.Events),
+		IsActive: req.IsActive,
+	})
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrSubscriptionNotFound) {
+		writeWebhookNotFoundError(w)
+		return
+	}
+	if isValidationError(err) {
+		handler.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	handler.WriteJson(w, http.StatusOK, toWebhookResponse(subscription))
+}
+
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := parseWebhookId(r)
+	if err != nil {
+		writeInvalidWebhookIdError(w)
+		return
+	}
+
+	err = h.service.DeleteSubscription(r.Context(), id)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrSubscriptionNotFound) {
+		writeWebhookNotFoundError(w)
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	id, err := parseWebhookId(r)
+	if err != nil {
+		writeInvalidWebhookIdError(w)
+		return
+	}
+
+	limit, offset := handler.ParsePagination(r)
+
+	deliveries, err := h.service.ListDeliveries(r.Context(), id, limit, offset)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrSubscriptionNotFound) {
+		writeWebhookNotFoundError(w)
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	handler.WriteJson(w, http.StatusOK, toListWebhookDeliveriesResponse(deliveries, limit, offset))
+}
+
+func (h *Handler) Redeliver(w http.ResponseWriter, r *http.Request) {
+	id, err := parseWebhookId(r)
+	if err != nil {
+		writeInvalidWebhookIdError(w)
+		return
+	}
+
+	deliveryId, err := strconv.ParseInt(chi.URLParam(r, "deliveryId"), 10, 64)
+	if err != nil {
+		handler.WriteError(w, http.StatusBadRequest, "invalid delivery id")
+		return
+	}
+
+	delivery, err := h.service.Redeliver(r.Context(), id, deliveryId)
+	if errors.Is(err, auth.ErrForbidden) {
+		handler.WriteForbiddenError(w)
+		return
+	}
+	if errors.Is(err, ErrSubscriptionNotFound) {
+		writeWebhookNotFoundError(w)
+		return
+	}
+	if errors.Is(err, ErrDeliveryNotFound) || errors.Is(err, ErrDeliveryOtherSubscription) {
+		handler.WriteError(w, http.StatusNotFound, "webhook delivery not found")
+		return
+	}
+	if err != nil {
+		handler.WriteInternalServerError(w)
+		return
+	}
+
+	handler.WriteJson(w, http.StatusAccepted, toWebhookDeliveryResponse(delivery))
+}
+
+func isValidationError(err error) bool {
+	return errors.Is(err, ErrSubscriptionInvalidURL) || errors.Is(err, ErrSubscriptionNoEvents) || errors.Is(err, ErrSubscriptionInvalidEvent)
+}
+
+func writeInvalidWebhookIdError(w http.ResponseWriter) {
+	handler.WriteError(w, http.StatusBadRequest, "invalid webhook id")
+}
+
+func writeWebhookNotFoundError(w http.ResponseWriter) {
+	handler.WriteError(w, http.StatusNotFound, "webhook not found")
+}
+
+func parseWebhookId(r *http.Request) (int64, error) {
+	return strconv.ParseInt(chi.URLParam(r, "webhookId"), 10, 64)
+}
+
+func toEvents(events []api.WebhookEvent) []Event {
+	values := make([]Event, len(events))
+	for i, event := range events {
+		values[i] = Event(event)
+	}
+	return values
+}
+
+func toWebhookResponse(s Subscription) api.WebhookResponse {
+	events := make([]api.WebhookEvent, len(s.Events))
+	for i, event := range s.Events {
+		events[i] = api.WebhookEvent(event)
+	}
+	return api.WebhookResponse{
+		Id:        s.ID,
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+		ProjectId: s.ProjectID,
+		Url:       s.URL,
+		Events:    events,
+		IsActive:  s.IsActive,
+	}
+}
+
+func toListWebhooksResponse(subscriptions []Subscription, limit, offset int64) api.ListWebhooksResponse {
+	items := make([]api.WebhookResponse, len(subscriptions))
+	for i, subscription := range subscriptions {
+		items[i] = toWebhookResponse(subscription)
+	}
+	return api.ListWebhooksResponse{
+		Limit:    limit,
+		Offset:   offset,
+		Webhooks: items,
+	}
+}
+
+func toWebhookDeliveryResponse(d Delivery) api.WebhookDeliveryResponse {
+	return api.WebhookDeliveryResponse{
+		Id:             d.ID,
+		CreatedAt:      d.CreatedAt,
+		UpdatedAt:      d.UpdatedAt,
+		WebhookId:      d.SubscriptionID,
+		Event:          api.WebhookEvent(d.Event),
+		EventId:        d.EventID,
+		Payload:        d.Payload,
+		Status:         api.WebhookDeliveryStatus(d.Status),
+		Attempts:       d.Attempts,
+		ResponseStatus: d.ResponseStatus,
+		ResponseBody:   d.ResponseBody,
+		LastError:      d.LastError,
+		DeliveredAt:    d.DeliveredAt,
+		RedeliveryOf:   d.RedeliveryOf,
+	}
+}
+
+func toListWebhookDeliveriesResponse(deliveries []Delivery, limit, offset int64) api.ListWebhookDeliveriesResponse {
+	items := make([]api.WebhookDeliveryResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		items[i] = toWebhookDeliveryResponse(delivery)
+	}
+	return api.ListWebhookDeliveriesResponse{
+		Limit:      limit,
+		Offset:     offset,
+		Deliveries: items,
+	}
+}
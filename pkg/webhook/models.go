@@ -0,0 +1,122 @@
+package webhook
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type Event string
+
+const (
+	EventProjectCreated Event = "project.created"
+	EventProjectUpdated Event = "project.updated"
+	// EventProjectDeleted only reaches global subscriptions, the ones of the
+	// project are deleted with it.
+	EventProjectDeleted    Event = "project.deleted"
+	EventVersionCreated    Event = "version.created"
+	EventVersionUpdated    Event = "version.updated"
+	EventVersionDeleted    Event = "version.deleted"
+	EventVersionReleased   Event = "version.released"
+	EventVersionSuperseded Event = "version.superseded"
+	EventVersionWithdrawn  Event = "version.withdrawn"
+	// EventVersionStatusChanged covers the remaining transitions, submitting
+	// a draft for review and sending it back.
+	EventVersionStatusChanged Event = "version.status_changed"
+	EventFileUploaded         Event = "file.uploaded"
+	EventFileDeleted          Event = "file.deleted"
+	EventFileRestored         Event = "file.restored"
+	EventFileAttached         Event = "file.attached"
+	EventFileDetached         Event = "file.detached"
+)
+
+func (e Event) IsValid() bool {
+	switch e {
+	case EventProjectCreated, EventProjectUpdated, EventProjectDeleted,
+		EventVersionCreated, EventVersionUpdated, EventVersionDeleted,
+		EventVersionReleased, EventVersionSuperseded, EventVersionWithdrawn, EventVersionStatusChanged,
+		EventFileUploaded, EventFileDeleted, EventFileRestored, EventFileAttached, EventFileDetached:
+		return true
+	}
+	return false
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryStatusPending   DeliveryStatus = "pending"
+	DeliveryStatusSucceeded DeliveryStatus = "succeeded"
+	// DeliveryStatusFailed marks deliveries that ran out of attempts.
+	DeliveryStatusFailed DeliveryStatus = "failed"
+)
+
+// Subscription sends the events it subscribes to to its URL. Without a
+// project it's global and receives the events of every project.
+type Subscription struct {
+	ID        int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	ProjectID *int64
+	URL       string
+	// Secret is the key deliveries are signed with.
+	Secret    string
+	Events    []Event
+	IsActive  bool
+	CreatedBy *int64
+}
+
+type Delivery struct {
+	ID             int64
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	SubscriptionID int64
+	Event          Event
+	EventID        string
+	Payload        json.RawMessage
+	Status         DeliveryStatus
+	Attempts       int32
+	ResponseStatus *int32
+	ResponseBody   *string
+	LastError      *string
+	DeliveredAt    *time.Time
+	RedeliveryOf   *int64
+}
+
+// Notification is an event to publish. ProjectIDs are the projects it
+// belongs to, whose subscriptions receive it next to the global ones. Data
+// is the API representation of the resource.
+type Notification struct {
+	Event      Event
+	ProjectIDs []int64
+	Data       any
+}
+
+// Payload is the JSON body of a delivery.
+type Payload struct {
+	ID         string    `json:"id"`
+	Event      Event     `json:"event"`
+	OccurredAt time.Time `json:"occurredAt"`
+	Data       any       `json:"data"`
+}
+
+type CreateSubscriptionRequest struct {
+	ProjectID *int64
+	URL       string
+	Events    []Event
+}
+
+type UpdateSubscriptionRequest struct {
+	URL      string
+	Events   []Event
+	IsActive bool
+}
+
+type ListSubscriptionsFilter struct {
+	ProjectID *int64
+}
+
+// Attempt is the outcome of sending a delivery once.
+type Attempt struct {
+	ResponseStatus *int32
+	ResponseBody   *string
+	Err            error
+}
@@ -0,0 +1,230 @@
+package webhook
+
+import (
+	"app/pkg/database"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrSubscriptionNotFound = errors.New("webhook subscription not found")
+	ErrDeliveryNotFound     = errors.New("webhook delivery not found")
+)
+
+type Repository interface {
+	CreateSubscription(ctx context.Context, subscription Subscription) (Subscription, error)
+	GetSubscription(ctx context.Context, id int64) (Subscription, error)
+	ListSubscriptions(ctx context.Context, filter ListSubscriptionsFilter, limit, offset int64) ([]Subscription, error)
+	UpdateSubscription(ctx context.Context, subscription Subscription) (Subscription, error)
+	DeleteSubscription(ctx context.Context, id int64) error
+	// CreateDeliveries queues the payload for the active subscriptions to
+	// the event, the global ones and those of the projects.
+	CreateDeliveries(ctx context.Context, event Event, eventId string, payload []byte, projectIds []int64) ([]Delivery, error)
+	// CreateRedelivery queues the payload of a delivery once more.
+	CreateRedelivery(ctx context.Context, id int64) (Delivery, error)
+	GetDelivery(ctx context.Context, id int64) (Delivery, error)
+	ListDeliveries(ctx context.Context, subscriptionId int64, limit, offset int64) ([]Delivery, error)
+	RecordAttempt(ctx context.Context, id int64, attempt Attempt, status DeliveryStatus) (Delivery, error)
+}
+
+type repository struct {
+	queries *database.Queries
+}
+
+func NewRepository(queries *database.Queries) Repository {
+	return &repository{queries: queries}
+}
+
+func (r *repository) CreateSubscription(ctx context.Context, subscription Subscription) (Subscription, error) {
+	row, err := r.queries.CreateWebhookSubscription(ctx, &database.CreateWebhookSubscriptionParams{
+		ProjectId: subscription.ProjectID,
+		Url:       subscription.URL,
+		Secret:    subscription.Secret,
+		Events:    fromEvents(subscription.Events),
+		CreatedBy: subscription.CreatedBy,
+	})
+	if err != nil {
+		return Subscription{}, err
+	}
+	return toSubscription(row), nil
+}
+
+func (r *repository) GetSubscription(ctx context.Context, id int64) (Subscription, error) {
+	row, err := r.queries.GetWebhookSubscription(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Subscription{}, ErrSubscriptionNotFound
+	}
+	if err != nil {
+		return Subscription{}, err
+	}
+	return toSubscription(row), nil
+}
+
+func (r *repository) ListSubscriptions(ctx context.Context, filter ListSubscriptionsFilter, limit, offset int64) ([]Subscription, error) {
+	rows, err := r.queries.ListWebhookSubscriptions(ctx, &database.ListWebhookSubscriptionsParams{
+		ProjectId: filter.ProjectID,
+		Limit:     limit,
+		Offset:    offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+	subscriptions := make([]Subscription, len(rows))
+	for i, row := range rows {
+		subscriptions[i] = toSubscription(row)
+	}
+	return subscriptions, nil
+}
+
+func (r *repository) UpdateSubscription(ctx context.Context, subscription Subscription) (Subscription, error) {
+	row, err := r.queries.UpdateWebhookSubscription(ctx, &database.UpdateWebhookSubscriptionParams{
+		ID:       subscription.ID,
+		Url:      subscription.URL,
+		Events:   fromEvents(subscription.Events),
+		IsActive: subscription.IsActive,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Subscription{}, ErrSubscriptionNotFound
+	}
+	if err != nil {
+		return Subscription{}, err
+	}
+	return toSubscription(row), nil
+}
+
+func (r *repository) DeleteSubscription(ctx context.Context, id int64) error {
+	return r.queries.DeleteWebhookSubscription(ctx, id)
+}
+
+func (r *repository) CreateDeliveries(ctx context.Context, event Event, eventId string, payload []byte, projectIds []int64) ([]Delivery, error) {
+	rows, err := r.queries.CreateWebhookDeliveries(ctx, &database.CreateWebhookDeliveriesParams{
+		Event:      string(event),
+		EventId:    eventId,
+		Payload:    payload,
+		ProjectIds: projectIds,
+	})
+	if err != nil {
+		return nil, err
+	}
+	deliveries := make([]Delivery, len(rows))
+	for i, row := range rows {
+		deliveries[i] = toDelivery(row)
+	}
+	return deliveries, nil
+}
+
+func (r *repository) CreateRedelivery(ctx context.Context, id int64) (Delivery, error) {
+	row, err := r.queries.CreateWebhookRedelivery(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Delivery{}, ErrDeliveryNotFound
+	}
+	if err != nil {
+		return Delivery{}, err
+	}
+	return toDelivery(row), nil
+}
+
+func (r *repository) GetDelivery(ctx context.Context, id int64) (Delivery, error) {
+	row, err := r.queries.GetWebhookDelivery(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Delivery{}, ErrDeliveryNotFound
+	}
+	if err != nil {
+		return Delivery{}, err
+	}
+	return toDelivery(row), nil
+}
+
+func (r *repository) ListDeliveries(ctx context.Context, subscriptionId int64, limit, offset int64) ([]Delivery, error) {
+	rows, err := r.queries.ListWebhookDeliveries(ctx, &database.ListWebhookDeliveriesParams{
+		SubscriptionId: subscriptionId,
+		Limit:          limit,
+		Offset:         offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+	deliveries := make([]Delivery, len(rows))
+	for i, row := range rows {
+		deliveries[i] = toDelivery(row)
+	}
+	return deliveries, nil
+}
+
+func (r *repository) RecordAttempt(ctx context.Context, id int64, attempt Attempt, status DeliveryStatus) (Delivery, error) {
+	var lastError *string
+	if attempt.Err != nil {
+		lastError = new(attempt.Err.Error())
+	}
+
+	row, err := r.queries.RecordWebhookDeliveryAttempt(ctx, &database.RecordWebhookDeliveryAttemptParams{
+		ID:             id,
+		Status:         string(status),
+		ResponseStatus: attempt.ResponseStatus,
+		ResponseBody:   attempt.ResponseBody,
+		LastError:      lastError,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Delivery{}, ErrDeliveryNotFound
+	}
+	if err != nil {
+		return Delivery{}, err
+	}
+	return toDelivery(row), nil
+}
+
+func toSubscription(row *database.WebhookSubscription) Subscription {
+	events := make([]Event, len(row.Events))
+	for i, event := range row.Events {
+		events[i] = Event(event)
+	}
+	return Subscription{
+		ID:        row.ID,
+		CreatedAt: row.CreatedAt.Time,
+		UpdatedAt: row.UpdatedAt.Time,
+		ProjectID: row.ProjectID,
+		URL:       row.Url,
+		Secret:    row.Secret,
+		Events:    events,
+		IsActive:  row.IsActive,
+		CreatedBy: row.CreatedBy,
+	}
+}
+
+func toDelivery(row *database.WebhookDelivery) Delivery {
+	return Delivery{
+		ID:             row.ID,
+		CreatedAt:      row.CreatedAt.Time,
+		UpdatedAt:      row.UpdatedAt.Time,
+		SubscriptionID: row.SubscriptionID,
+		Event:          Event(row.Event),
+		EventID:        row.EventID,
+		Payload:        row.Payload,
+		Status:         DeliveryStatus(row.Status),
+		Attempts:       row.Attempts,
+		ResponseStatus: row.ResponseStatus,
+		ResponseBody:   row.ResponseBody,
+		LastError:      row.LastError,
+		DeliveredAt:    toTime(row.DeliveredAt),
+		RedeliveryOf:   row.RedeliveryOf,
+	}
+}
+
+func fromEvents(events []Event) []string {
+	values := make([]string, len(events))
+	for i, event := range events {
+		values[i] = string(event)
+	}
+	return values
+}
+
+func toTime(timestamp pgtype.Timestamp) *time.Time {
+	if !timestamp.Valid {
+		return nil
+	}
+	return &timestamp.Time
+}
@@ -0,0 +1,120 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	maxResponseBodySize = 4096
+
+	HeaderEvent     = "X-DocPort-Event"
+	HeaderDelivery  = "X-DocPort-Delivery"
+	HeaderTimestamp = "X-DocPort-Timestamp"
+	// HeaderSignature holds "sha256=" and the hex encoded HMAC-SHA256 of the
+	// timestamp, a dot and the body, keyed with the subscription secret.
+	HeaderSignature = "X-DocPort-Signature"
+)
+
+var ErrPrivateAddress = errors.New("webhook address is not public")
+
+// Sender posts deliveries to their subscriptions. Unless private networks are
+// allowed it refuses to connect to loopback, private and link-local
+// addresses, so subscriptions can't be used to reach internal services.
+type Sender struct {
+	client *http.Client
+}
+
+func NewSender(timeout time.Duration, allowPrivateNetworks bool) *Sender {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivateNetworks {
+		dialer.Control = refusePrivateAddresses
+	}
+
+	return &Sender{
+		client: &http.Client{
+			Timeout: timeout,
+			Transport: &http.Transport{
+				DialContext:         dialer.DialContext,
+				TLSHandshakeTimeout: timeout,
+				MaxIdleConnsPerHost: 2,
+			},
+			// A redirect counts as a failed delivery, following it would
+			// send the payload somewhere the subscription didn't name.
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// Send posts the delivery once, any response but a 2xx is an error.
+func (s *Sender) Send(ctx context.Context, subscription Subscription, delivery Delivery) Attempt {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return Attempt{Err: err}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "DocPort-Webhooks")
+	req.Header.Set(HeaderEvent, string(delivery.Event))
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, Sign(subscription.Secret, timestamp, delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return Attempt{Err: err}
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBodySize))
+	attempt := Attempt{
+		ResponseStatus: new(int32(resp.StatusCode)),
+		ResponseBody:   new(string(body)),
+	}
+	if err != nil {
+		attempt.Err = err
+		return attempt
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		attempt.Err = fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return attempt
+}
+
+// Sign computes the signature header value receivers check deliveries with.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// refusePrivateAddresses checks the address after name resolution, which
+// also covers host names that resolve to internal addresses.
+func refusePrivateAddresses(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	addr := addrPort.Addr().Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return ErrPrivateAddress
+	}
+	return nil
+}
@@ -0,0 +1,291 @@
+package webhook
+
+import (
+	"app/pkg/jobs"
+	"app/pkg/membership"
+	"app/pkg/platform/auth"
+	"app/pkg/platform/transaction"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	JobKindDeliver = "webhook.deliver"
+	// deliveryMaxAttempts spreads the attempts of a delivery over roughly
+	// twenty minutes with the backoff of the job runner.
+	deliveryMaxAttempts = 8
+	secretSize          = 32
+)
+
+var (
+	ErrSubscriptionInvalidURL    = errors.New("webhook url must be an absolute http or https url")
+	ErrSubscriptionNoEvents      = errors.New("webhook must subscribe to at least one event")
+	ErrSubscriptionInvalidEvent  = errors.New("invalid webhook event")
+	ErrDeliveryOtherSubscription = errors.New("webhook delivery belongs to another subscription")
+)
+
+type deliverPayload struct {
+	DeliveryID int64 `json:"deliveryId"`
+}
+
+type Service interface {
+	// Publish queues the notification for every subscription to it. Call it
+	// in the transaction of the change, so deliveries only go out for
+	// changes that were committed.
+	Publish(ctx context.Context, notification Notification) error
+	// CreateSubscription requires the admin role on the project, global
+	// subscriptions are reserved to instance admins.
+	CreateSubscription(ctx context.Context, req CreateSubscriptionRequest) (Subscription, error)
+	GetSubscription(ctx context.Context, id int64) (Subscription, error)
+	// ListSubscriptions lists the subscriptions of a project, or all of them
+	// for instance admins when no project is given.
+	ListSubscriptions(ctx context.Context, filter ListSubscriptionsFilter, limit, offset int64) ([]Subscription, error)
+	UpdateSubscription(ctx context.Context, id int64, req UpdateSubscriptionRequest) (Subscription, error)
+	DeleteSubscription(ctx context.Context, id int64) error
+	ListDeliveries(ctx context.Context, subscriptionId int64, limit, offset int64) ([]Delivery, error)
+	// Redeliver sends the payload of a delivery again as a new delivery.
+	Redeliver(ctx context.Context, subscriptionId, deliveryId int64) (Delivery, error)
+	// Deliver is the processor of JobKindDeliver jobs. A delivery is
+	// retried with backoff until it gets a 2xx response or runs out of
+	// attempts, every attempt is recorded on the delivery.
+	Deliver(ctx context.Context, job jobs.Job) error
+}
+
+type service struct {
+	repository        Repository
+	membershipService membership.Service
+	jobService        jobs.Service
+	transactions      transaction.Manager
+	sender            *Sender
+}
+
+func NewService(repository Repository, membershipService membership.Service, jobService jobs.Service, transactions transaction.Manager, sender *Sender) Service {
+	return &service{repository: repository, membershipService: membershipService, jobService: jobService, transactions: transactions, sender: sender}
+}
+
+func (s *service) Publish(ctx context.Context, notification Notification) error {
+	payload := Payload{
+		ID:         uuid.NewString(),
+		Event:      notification.Event,
+		OccurredAt: time.Now().UTC(),
+		Data:       notification.Data,
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	projectIds := notification.ProjectIDs
+	if projectIds == nil {
+		projectIds = []int64{}
+	}
+
+	deliveries, err := s.repository.CreateDeliveries(ctx, notification.Event, payload.ID, body, projectIds)
+	if err != nil {
+		return err
+	}
+	for _, delivery := range deliveries {
+		if err := s.enqueue(ctx, delivery); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *service) CreateSubscription(ctx context.Context, req CreateSubscriptionRequest) (Subscription, error) {
+	if err := validate(req.URL, req.Events); err != nil {
+		return Subscription{}, err
+	}
+	if err := s.authorize(ctx, req.ProjectID); err != nil {
+		return Subscription{}, err
+	}
+
+	secret := make([]byte, secretSize)
+	_, _ = rand.Read(secret)
+
+	subscription := Subscription{
+		ProjectID: req.ProjectID,
+		URL:       req.URL,
+		Secret:    hex.EncodeToString(secret),
+		Events:    req.Events,
+	}
+	if principal, ok := auth.GetPrincipal(ctx); ok && principal.UserID != 0 {
+		subscription.CreatedBy = &principal.UserID
+	}
+	return s.repository.CreateSubscription(ctx, subscription)
+}
+
+func (s *service) GetSubscription(ctx context.Context, id int64) (Subscription, error) {
+	return s.authorized(ctx, id)
+}
+
+func (s *service) ListSubscriptions(ctx context.Context, filter ListSubscriptionsFilter, limit, offset int64) ([]Subscription, error) {
+	if err := s.authorize(ctx, filter.ProjectID); err != nil {
+		return nil, err
+	}
+	return s.repository.ListSubscriptions(ctx, filter, limit, offset)
+}
+
+func (s *service) UpdateSubscription(ctx context.Context, id int64, req UpdateSubscriptionRequest) (Subscription, error) {
+	if err := validate(req.URL, req.Events); err != nil {
+		return Subscription{}, err
+	}
+
+	subscription, err := s.authorized(ctx, id)
+	if err != nil {
+		return Subscription{}, err
+	}
+
+	subscription.URL = req.URL
+	subscription.Events = req.Events
+	subscription.IsActive = req.IsActive
+	return s.repository.UpdateSubscription(ctx, subscription)
+}
+
+func (s *service) DeleteSubscription(ctx context.Context, id int64) error {
+	if _, err := s.authorized(ctx, id); err != nil {
+		return err
+	}
+	return s.repository.DeleteSubscription(ctx, id)
+}
+
+func (s *service) ListDeliveries(ctx context.Context, subscriptionId int64, limit, offset int64) ([]Delivery, error) {
+	if _, err := s.authorized(ctx, subscriptionId); err != nil {
+		return nil, err
+	}
+	return s.repository.ListDeliveries(ctx, subscriptionId, limit, offset)
+}
+
+func (s *service) Redeliver(ctx context.Context, subscriptionId, deliveryId int64) (Delivery, error) {
+	if _, err := s.authorized(ctx, subscriptionId); err != nil {
+		return Delivery{}, err
+	}
+
+	delivery, err := s.repository.GetDelivery(ctx, deliveryId)
+	if err != nil {
+		return Delivery{}, err
+	}
+	if delivery.SubscriptionID != subscriptionId {
+		return Delivery{}, ErrDeliveryOtherSubscription
+	}
+
+	var redelivery Delivery
+	err = s.transactions.Run(ctx, func(ctx context.Context) error {
+		created, err := s.repository.CreateRedelivery(ctx, deliveryId)
+		if err != nil {
+			return err
+		}
+		redelivery = created
+		return s.enqueue(ctx, redelivery)
+	})
+	if err != nil {
+		return Delivery{}, err
+	}
+	return redelivery, nil
+}
+
+func (s *service) Deliver(ctx context.Context, job jobs.Job) error {
+	var payload deliverPayload
+	if err := job.Decode(&payload); err != nil {
+		return err
+	}
+
+	delivery, err := s.repository.GetDelivery(ctx, payload.DeliveryID)
+	if errors.Is(err, ErrDeliveryNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if delivery.Status != DeliveryStatusPending {
+		return nil
+	}
+
+	subscription, err := s.repository.GetSubscription(ctx, delivery.SubscriptionID)
+	if errors.Is(err, ErrSubscriptionNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !subscription.IsActive {
+		_, err := s.repository.RecordAttempt(ctx, delivery.ID, Attempt{Err: errors.New("webhook is inactive")}, DeliveryStatusFailed)
+		return err
+	}
+
+	attempt := s.sender.Send(ctx, subscription, delivery)
+
+	status := DeliveryStatusSucceeded
+	if attempt.Err != nil {
+		status = DeliveryStatusPending
+		if job.Attempts >= job.MaxAttempts {
+			status = DeliveryStatusFailed
+		}
+	}
+
+	// Failing to record a successful attempt retries it, receivers may see a
+	// delivery twice and tell by its event id.
+	if _, err := s.repository.RecordAttempt(ctx, delivery.ID, attempt, status); err != nil {
+		return err
+	}
+	return attempt.Err
+}
+
+func (s *service) enqueue(ctx context.Context, delivery Delivery) error {
+	_, err := s.jobService.Enqueue(ctx, jobs.EnqueueRequest{
+		Kind:        JobKindDeliver,
+		Payload:     deliverPayload{DeliveryID: delivery.ID},
+		MaxAttempts: deliveryMaxAttempts,
+	})
+	return err
+}
+
+// authorized loads the subscription and checks that the caller may manage it.
+func (s *service) authorized(ctx context.Context, id int64) (Subscription, error) {
+	subscription, err := s.repository.GetSubscription(ctx, id)
+	if err != nil {
+		return Subscription{}, err
+	}
+	if err := s.authorize(ctx, subscription.ProjectID); err != nil {
+		return Subscription{}, err
+	}
+	return subscription, nil
+}
+
+// authorize lets project admins manage the subscriptions of their project,
+// global subscriptions see every project and are left to instance admins.
+func (s *service) authorize(ctx context.Context, projectId *int64) error {
+	if projectId != nil {
+		return s.membershipService.Authorize(ctx, *projectId, membership.RoleAdmin)
+	}
+
+	principal, ok := auth.GetPrincipal(ctx)
+	if !ok || !principal.IsAdmin {
+		return auth.ErrForbidden
+	}
+	return nil
+}
+
+func validate(rawURL string, events []Event) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrSubscriptionInvalidURL
+	}
+	if len(events) == 0 {
+		return ErrSubscriptionNoEvents
+	}
+	for _, event := range events {
+		if !event.IsValid() {
+			return ErrSubscriptionInvalidEvent
+		}
+	}
+	return nil
+}